@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/endpoint"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/service"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+)
+
+// CardSvc implements service.CardService over HTTP
+type CardSvc struct {
+	Client *Client
+}
+
+// NewCardService creates card service calling the API through c
+func NewCardService(c *Client) service.CardService {
+	return &CardSvc{Client: c}
+}
+
+func (s *CardSvc) GetAllCards(ctx context.Context, sortBy string, sort string, skip int, limit int) ([]repository.Card, error) {
+	var res struct {
+		Cards []repository.Card `json:"cards"`
+	}
+
+	query := pagination{SortBy: sortBy, Sort: sort, Skip: skip, Limit: limit}.query()
+	if err := s.Client.do(ctx, http.MethodGet, "/cards", query, nil, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Cards, nil
+}
+
+// GetCards is not served, the API does not filter cards by wallet
+func (s *CardSvc) GetCards(ctx context.Context, payload *service.GetCardsRequest) ([]repository.Card, error) {
+	return nil, errors.UnsupportedOperation
+}
+
+func (s *CardSvc) GetCard(ctx context.Context, cardID uuid.UUID) (*repository.Card, error) {
+	var card repository.Card
+	if err := s.Client.do(ctx, http.MethodGet, "/cards/"+cardID.String(), nil, nil, &card); err != nil {
+		return nil, err
+	}
+
+	return &card, nil
+}
+
+// GetCardsByWalletIDs is not served, the API only batches cards behind /graphql
+func (s *CardSvc) GetCardsByWalletIDs(ctx context.Context, walletIDs []uuid.UUID) ([]repository.Card, error) {
+	return nil, errors.UnsupportedOperation
+}
+
+func (s *CardSvc) CreateCard(ctx context.Context, payload *service.CreateCardRequest) (*repository.Card, error) {
+	req := endpoint.CreateCardRequest{
+		CardNo:      payload.CardNo,
+		ExpiryMonth: payload.ExpiryMonth,
+		ExpiryYear:  payload.ExpiryYear,
+		CVV:         payload.CVV,
+		Currency:    payload.Currency,
+		Type:        payload.Type,
+		WalletID:    payload.WalletID,
+	}
+
+	var card repository.Card
+	if err := s.Client.do(ctx, http.MethodPost, "/cards", nil, req, &card); err != nil {
+		return nil, err
+	}
+
+	return &card, nil
+}
+
+func (s *CardSvc) UpdateCard(ctx context.Context, cardID uuid.UUID, payload *service.UpdateCardRequest) (*repository.Card, error) {
+	req := endpoint.UpdateCardRequest{
+		ID:           cardID,
+		WalletID:     payload.WalletID,
+		CardNo:       payload.CardNo,
+		ExpiryMonth:  payload.ExpiryMonth,
+		ExpiryYear:   payload.ExpiryYear,
+		CVV:          payload.CVV,
+		DailyLimit:   payload.DailyLimit,
+		MonthlyLimit: payload.MonthlyLimit,
+	}
+
+	var card repository.Card
+	if err := s.Client.do(ctx, http.MethodPut, "/cards/"+cardID.String(), nil, req, &card); err != nil {
+		return nil, err
+	}
+
+	return &card, nil
+}
+
+func (s *CardSvc) DeleteCardByID(ctx context.Context, cardID uuid.UUID) (*repository.Card, error) {
+	var card repository.Card
+	if err := s.Client.do(ctx, http.MethodDelete, "/cards/"+cardID.String(), nil, nil, &card); err != nil {
+		return nil, err
+	}
+
+	return &card, nil
+}
+
+func (s *CardSvc) DeleteCardsByWalletID(ctx context.Context, walletID uuid.UUID) ([]repository.Card, error) {
+	var cards []repository.Card
+	if err := s.Client.do(ctx, http.MethodDelete, "/cards/wallets/"+walletID.String(), nil, nil, &cards); err != nil {
+		return nil, err
+	}
+
+	return cards, nil
+}
+
+func (s *CardSvc) FreezeCard(ctx context.Context, cardID uuid.UUID, reason string) (*repository.Card, error) {
+	req := endpoint.FreezeCardRequest{
+		ID:     cardID,
+		Reason: reason,
+	}
+
+	var card repository.Card
+	if err := s.Client.do(ctx, http.MethodPost, "/cards/"+cardID.String()+"/freeze", nil, req, &card); err != nil {
+		return nil, err
+	}
+
+	return &card, nil
+}
+
+func (s *CardSvc) UnfreezeCard(ctx context.Context, cardID uuid.UUID, reason string) (*repository.Card, error) {
+	req := endpoint.FreezeCardRequest{
+		ID:     cardID,
+		Reason: reason,
+	}
+
+	var card repository.Card
+	if err := s.Client.do(ctx, http.MethodPost, "/cards/"+cardID.String()+"/unfreeze", nil, req, &card); err != nil {
+		return nil, err
+	}
+
+	return &card, nil
+}
+
+func (s *CardSvc) GetCardAudits(ctx context.Context, cardID uuid.UUID, skip int, limit int) ([]repository.CardAudit, error) {
+	var res struct {
+		Audits []repository.CardAudit `json:"audits"`
+	}
+
+	query := pagination{Skip: skip, Limit: limit}.query()
+
+	if err := s.Client.do(ctx, http.MethodGet, "/cards/"+cardID.String()+"/audits", query, nil, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Audits, nil
+}
@@ -0,0 +1,238 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	kitendpoint "github.com/go-kit/kit/endpoint"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	ctxUtil "gitlab.com/renodesper/spenmo-test/util/ctx"
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	resp "gitlab.com/renodesper/spenmo-test/util/response"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+type (
+	// Client calls the HTTP API, the services of this package share it. The
+	// request id and the caller of the context are sent along every request.
+	Client struct {
+		endpoints map[string]kitendpoint.Endpoint
+	}
+
+	// request is sent by the endpoints of Client, Path is relative to the
+	// instance
+	request struct {
+		Path  string
+		Query url.Values
+		Body  interface{}
+	}
+
+	// successResponse keeps the data undecoded until its type is known
+	successResponse struct {
+		Data jsoniter.RawMessage `json:"data"`
+	}
+
+	// pagination is sent as query by the list requests
+	pagination struct {
+		SortBy string
+		Sort   string
+		Skip   int
+		Limit  int
+	}
+)
+
+// RETRY_BACKOFF is the wait before the first retry, it doubles on every retry
+const RETRY_BACKOFF = 100 * time.Millisecond
+
+// New creates a client of the API served at instance, as
+// http://127.0.0.1:8000. Idempotent requests failing on a server error or on
+// the network are retried up to retries times, timeout bounds every attempt.
+func New(instance string, retries int, timeout time.Duration) (*Client, error) {
+	if !strings.HasPrefix(instance, "http") {
+		instance = "http://" + instance
+	}
+
+	tgt, err := url.Parse(instance)
+	if err != nil {
+		return nil, err
+	}
+	tgt.Path = strings.TrimSuffix(tgt.Path, "/")
+
+	httpClient := &http.Client{Timeout: timeout}
+	clientOpts := []httptransport.ClientOption{
+		httptransport.SetClient(httpClient),
+		httptransport.ClientBefore(setRequestID, setUserID, setTraceContext),
+	}
+
+	endpoints := map[string]kitendpoint.Endpoint{}
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete} {
+		var ep kitendpoint.Endpoint
+		ep = httptransport.NewClient(method, tgt, encodeRequest, decodeResponse, clientOpts...).Endpoint()
+
+		// NOTE: Only GET and DELETE are idempotent, a PUT can move a balance
+		if method == http.MethodGet || method == http.MethodDelete {
+			ep = retry(retries, RETRY_BACKOFF)(ep)
+		}
+
+		endpoints[method] = withRequestID(ep)
+	}
+
+	return &Client{endpoints: endpoints}, nil
+}
+
+// do sends the request and decodes the data of its response into out
+func (c *Client) do(ctx context.Context, method string, path string, query url.Values, body interface{}, out interface{}) error {
+	response, err := c.endpoints[method](ctx, request{Path: path, Query: query, Body: body})
+	if err != nil {
+		return err
+	}
+
+	data := response.(jsoniter.RawMessage)
+	if err := json.Unmarshal(data, out); err != nil {
+		return errors.UnreadableResponse.AppendError(err)
+	}
+
+	return nil
+}
+
+func encodeRequest(_ context.Context, r *http.Request, req interface{}) error {
+	rq := req.(request)
+
+	r.URL.Path += rq.Path
+	r.URL.RawQuery = rq.Query.Encode()
+
+	if rq.Body == nil {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(rq.Body); err != nil {
+		return err
+	}
+
+	r.Header.Set("Content-Type", "application/json")
+	r.ContentLength = int64(buf.Len())
+	r.Body = ioutil.NopCloser(&buf)
+
+	return nil
+}
+
+// decodeResponse returns the error of an ErrorResponse as is, so callers
+// can compare its code as they would with the service
+func decodeResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode < 200 || r.StatusCode >= 300 {
+		var errorResponse resp.ErrorResponse
+		if err := json.NewDecoder(r.Body).Decode(&errorResponse); err != nil || len(errorResponse.Errors) == 0 {
+			er := errors.UnreadableResponse.AppendError(fmt.Errorf("%s", r.Status))
+			er.Status = r.StatusCode
+			return nil, er
+		}
+
+		er := errorResponse.Errors[0]
+		if er.Status == 0 {
+			er.Status = r.StatusCode
+		}
+
+		return nil, er
+	}
+
+	var response successResponse
+	if err := json.NewDecoder(r.Body).Decode(&response); err != nil {
+		return nil, errors.UnreadableResponse.AppendError(err)
+	}
+
+	return response.Data, nil
+}
+
+// withRequestID sets the request id once, so the retries of a request share
+// it
+func withRequestID(next kitendpoint.Endpoint) kitendpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		return next(ctxUtil.SetRequestID(ctx, ctxUtil.GetRequestID(ctx)), request)
+	}
+}
+
+func setRequestID(ctx context.Context, r *http.Request) context.Context {
+	r.Header.Set("X-Request-Id", ctxUtil.GetRequestID(ctx))
+	return ctx
+}
+
+func setUserID(ctx context.Context, r *http.Request) context.Context {
+	if userID := ctxUtil.GetUserID(ctx); userID != uuid.Nil {
+		r.Header.Set("X-User-Id", userID.String())
+	}
+
+	return ctx
+}
+
+// setTraceContext sends the span of ctx in traceparent, so the server span
+// continues the trace of the caller
+func setTraceContext(ctx context.Context, r *http.Request) context.Context {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(r.Header))
+	return ctx
+}
+
+// retry calls next again when it fails on the network, on a server error or
+// on the rate limit, waiting backoff and then twice as long on every retry
+func retry(retries int, backoff time.Duration) kitendpoint.Middleware {
+	return func(next kitendpoint.Endpoint) kitendpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			wait := backoff
+
+			for attempt := 0; ; attempt++ {
+				response, err := next(ctx, request)
+				if err == nil || attempt >= retries || !isRetryable(err) {
+					return response, err
+				}
+
+				select {
+				case <-ctx.Done():
+					return nil, err
+				case <-time.After(wait):
+				}
+
+				wait *= 2
+			}
+		}
+	}
+}
+
+func isRetryable(err error) bool {
+	er, ok := err.(e.Error)
+	if !ok {
+		return true
+	}
+
+	return er.Status >= http.StatusInternalServerError || er.Status == http.StatusTooManyRequests
+}
+
+func (p pagination) query() url.Values {
+	query := url.Values{}
+
+	if p.SortBy != "" {
+		query.Set("sortBy", p.SortBy)
+	}
+	if p.Sort != "" {
+		query.Set("sort", p.Sort)
+	}
+	if p.Skip != 0 {
+		query.Set("skip", fmt.Sprintf("%d", p.Skip))
+	}
+	if p.Limit != 0 {
+		query.Set("limit", fmt.Sprintf("%d", p.Limit))
+	}
+
+	return query
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/service"
+	ctxUtil "gitlab.com/renodesper/spenmo-test/util/ctx"
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+	resp "gitlab.com/renodesper/spenmo-test/util/response"
+)
+
+func TestClient(t *testing.T) {
+	userID := uuid.MustParse("8e159833-5078-4b0a-80a0-363d82bafd60")
+	walletID := uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e")
+
+	var (
+		mu         sync.Mutex
+		attempts   int
+		requestIDs []string
+		callers    []string
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users/"+userID.String(), func(w http.ResponseWriter, r *http.Request) {
+		writeSuccess(w, r, repository.User{ID: userID, Email: "admin@spenmo.com"})
+	})
+	mux.HandleFunc("/users/"+walletID.String(), func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, r, http.StatusNotFound, errors.FailedUserNotFound)
+	})
+	mux.HandleFunc("/wallets", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		attempts++
+		requestIDs = append(requestIDs, r.Header.Get("X-Request-Id"))
+		callers = append(callers, r.Header.Get("X-User-Id"))
+
+		if attempts < 3 {
+			writeError(w, r, http.StatusInternalServerError, errors.UnexpectedPanic)
+			return
+		}
+
+		writeSuccess(w, r, map[string]interface{}{
+			"wallets":    []repository.Wallet{{ID: walletID, Balance: money.MustParseDecimal("12.50"), Currency: "SGD"}},
+			"pagination": map[string]interface{}{"limit": r.URL.Query().Get("limit")},
+		})
+	})
+	mux.HandleFunc("/wallets/"+walletID.String(), func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		attempts++
+		writeError(w, r, http.StatusBadGateway, errors.UnexpectedPanic)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c, err := New(server.URL, 2, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := ctxUtil.SetUserID(context.Background(), userID)
+
+	t.Run("SuccessGetUser", func(t *testing.T) {
+		user, err := NewUserService(c).GetUser(ctx, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, userID, user.ID)
+		assert.Equal(t, "admin@spenmo.com", user.Email)
+	})
+
+	t.Run("SuccessRetry", func(t *testing.T) {
+		attempts, requestIDs, callers = 0, nil, nil
+
+		wallets, err := NewWalletService(c).GetAllWallets(ctx, "", "", 0, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+
+		if assert.Len(t, wallets, 1) {
+			assert.Equal(t, walletID, wallets[0].ID)
+			assert.Zero(t, wallets[0].Balance.Cmp(money.MustParseDecimal("12.50")))
+		}
+
+		assert.NotEmpty(t, requestIDs[0])
+		assert.Equal(t, []string{requestIDs[0], requestIDs[0], requestIDs[0]}, requestIDs)
+		assert.Equal(t, []string{userID.String(), userID.String(), userID.String()}, callers)
+	})
+
+	t.Run("SuccessRequestID", func(t *testing.T) {
+		attempts, requestIDs = 2, nil
+
+		_, err := NewWalletService(c).GetAllWallets(ctxUtil.SetRequestID(ctx, "req-1"), "", "", 0, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"req-1"}, requestIDs)
+	})
+
+	t.Run("FailedNotFound", func(t *testing.T) {
+		_, err := NewUserService(c).GetUser(ctx, walletID)
+		assertError(t, err, errors.FailedUserNotFound)
+	})
+
+	t.Run("FailedRetriesExhausted", func(t *testing.T) {
+		attempts = 0
+
+		_, err := NewWalletService(c).GetWallet(ctx, walletID)
+		assertError(t, err, errors.UnexpectedPanic)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("FailedNoRetryOnUpdate", func(t *testing.T) {
+		attempts = 0
+
+		_, err := NewWalletService(c).UpdateWallet(ctx, walletID, &service.UpdateWalletRequest{BalanceIncrease: money.MustParseDecimal("10")})
+		assertError(t, err, errors.UnexpectedPanic)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("FailedUnsupported", func(t *testing.T) {
+		_, err := NewWalletService(c).GetWallets(ctx, &service.GetWalletsRequest{UserID: userID})
+		assertError(t, err, errors.UnsupportedOperation)
+	})
+}
+
+func writeSuccess(w http.ResponseWriter, r *http.Request, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(&resp.SuccessResponse{
+		Data: data,
+		Meta: resp.PopulateMeta(r.Header.Get("X-Request-Id")),
+	})
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, status int, er e.Error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(&resp.ErrorResponse{
+		Errors: []e.Error{er},
+		Meta:   resp.PopulateMeta(r.Header.Get("X-Request-Id")),
+	})
+}
+
+func assertError(t *testing.T, err error, expected e.Error) {
+	er, ok := err.(e.Error)
+	if !assert.True(t, ok, "error %v is not an e.Error", err) {
+		return
+	}
+
+	assert.Equal(t, expected.Code, er.Code)
+	assert.Equal(t, expected.Status, er.Status)
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/endpoint"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/service"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+)
+
+// TeamSvc implements service.TeamService over HTTP
+type TeamSvc struct {
+	Client *Client
+}
+
+// NewTeamService creates team service calling the API through c
+func NewTeamService(c *Client) service.TeamService {
+	return &TeamSvc{Client: c}
+}
+
+func (s *TeamSvc) GetAllTeams(ctx context.Context, sortBy string, sort string, skip int, limit int) ([]repository.Team, error) {
+	var res struct {
+		Teams []repository.Team `json:"teams"`
+	}
+
+	query := pagination{SortBy: sortBy, Sort: sort, Skip: skip, Limit: limit}.query()
+	if err := s.Client.do(ctx, http.MethodGet, "/teams", query, nil, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Teams, nil
+}
+
+func (s *TeamSvc) GetTeam(ctx context.Context, teamID uuid.UUID) (*repository.Team, error) {
+	var team repository.Team
+	if err := s.Client.do(ctx, http.MethodGet, "/teams/"+teamID.String(), nil, nil, &team); err != nil {
+		return nil, err
+	}
+
+	return &team, nil
+}
+
+// GetTeamsByIDs is not served, the API only batches teams behind /graphql
+func (s *TeamSvc) GetTeamsByIDs(ctx context.Context, teamIDs []uuid.UUID) ([]repository.Team, error) {
+	return nil, errors.UnsupportedOperation
+}
+
+func (s *TeamSvc) CreateTeam(ctx context.Context, payload *service.CreateTeamRequest) (*repository.Team, error) {
+	req := endpoint.CreateTeamRequest{
+		Name:     payload.Name,
+		Timezone: payload.Timezone,
+	}
+
+	var team repository.Team
+	if err := s.Client.do(ctx, http.MethodPost, "/teams", nil, req, &team); err != nil {
+		return nil, err
+	}
+
+	return &team, nil
+}
+
+func (s *TeamSvc) UpdateTeam(ctx context.Context, teamID uuid.UUID, payload *service.UpdateTeamRequest) (*repository.Team, error) {
+	req := endpoint.UpdateTeamRequest{
+		ID:       teamID,
+		Name:     payload.Name,
+		Timezone: payload.Timezone,
+	}
+
+	var team repository.Team
+	if err := s.Client.do(ctx, http.MethodPut, "/teams/"+teamID.String(), nil, req, &team); err != nil {
+		return nil, err
+	}
+
+	return &team, nil
+}
+
+func (s *TeamSvc) DeleteTeam(ctx context.Context, teamID uuid.UUID) (*repository.Team, error) {
+	var team repository.Team
+	if err := s.Client.do(ctx, http.MethodDelete, "/teams/"+teamID.String(), nil, nil, &team); err != nil {
+		return nil, err
+	}
+
+	return &team, nil
+}
@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/endpoint"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/service"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+)
+
+// TeamMemberSvc implements service.TeamMemberService over HTTP
+type TeamMemberSvc struct {
+	Client *Client
+}
+
+// TEAM_APPROVERS_BATCH is the page size used to look up the admins of a team
+const TEAM_APPROVERS_BATCH = 100
+
+// NewTeamMemberService creates team member service calling the API through c
+func NewTeamMemberService(c *Client) service.TeamMemberService {
+	return &TeamMemberSvc{Client: c}
+}
+
+func (s *TeamMemberSvc) GetTeamMembers(ctx context.Context, payload *service.GetTeamMembersRequest) ([]repository.TeamMember, error) {
+	var res struct {
+		TeamMembers []repository.TeamMember `json:"teamMembers"`
+	}
+
+	query := pagination{SortBy: payload.SortBy, Sort: payload.Sort, Skip: payload.Skip, Limit: payload.Limit}.query()
+	if payload.TeamID != uuid.Nil {
+		query.Set("teamId", payload.TeamID.String())
+	}
+	if payload.UserID != uuid.Nil {
+		query.Set("userId", payload.UserID.String())
+	}
+
+	if err := s.Client.do(ctx, http.MethodGet, "/team-members", query, nil, &res); err != nil {
+		return nil, err
+	}
+
+	return res.TeamMembers, nil
+}
+
+// GetTeamMembersByTeamIDs is not served, the API only batches team members behind /graphql
+func (s *TeamMemberSvc) GetTeamMembersByTeamIDs(ctx context.Context, teamIDs []uuid.UUID) ([]repository.TeamMember, error) {
+	return nil, errors.UnsupportedOperation
+}
+
+// GetTeamMembersByUserIDs is not served, the API only batches team members behind /graphql
+func (s *TeamMemberSvc) GetTeamMembersByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]repository.TeamMember, error) {
+	return nil, errors.UnsupportedOperation
+}
+
+// GetTeamApprovers pages through the members of the team, the API has no
+// route listing the admins only
+func (s *TeamMemberSvc) GetTeamApprovers(ctx context.Context, teamID uuid.UUID) ([]repository.TeamMember, error) {
+	var approvers []repository.TeamMember
+
+	for skip := 0; ; skip += TEAM_APPROVERS_BATCH {
+		teamMembers, err := s.GetTeamMembers(ctx, &service.GetTeamMembersRequest{
+			TeamID: teamID,
+			SortBy: "created_at",
+			Sort:   "ASC",
+			Skip:   skip,
+			Limit:  TEAM_APPROVERS_BATCH,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, teamMember := range teamMembers {
+			if teamMember.Role == repository.TeamMemberRoleAdmin && !teamMember.IsDeleted {
+				approvers = append(approvers, teamMember)
+			}
+		}
+
+		if len(teamMembers) < TEAM_APPROVERS_BATCH {
+			return approvers, nil
+		}
+	}
+}
+
+func (s *TeamMemberSvc) CreateTeamMember(ctx context.Context, payload *service.CreateTeamMemberRequest) (*repository.TeamMember, error) {
+	req := endpoint.CreateTeamMemberRequest{
+		TeamID: payload.TeamID,
+		UserID: payload.UserID,
+		Role:   payload.Role,
+	}
+
+	var teamMember repository.TeamMember
+	if err := s.Client.do(ctx, http.MethodPost, "/team-members", nil, req, &teamMember); err != nil {
+		return nil, err
+	}
+
+	return &teamMember, nil
+}
+
+func (s *TeamMemberSvc) UpdateTeamMember(ctx context.Context, payload *service.UpdateTeamMemberRequest) (*repository.TeamMember, error) {
+	req := endpoint.UpdateTeamMemberRequest{
+		TeamID: payload.TeamID,
+		UserID: payload.UserID,
+		Role:   payload.Role,
+	}
+
+	var teamMember repository.TeamMember
+	if err := s.Client.do(ctx, http.MethodPut, "/team-members", nil, req, &teamMember); err != nil {
+		return nil, err
+	}
+
+	return &teamMember, nil
+}
+
+func (s *TeamMemberSvc) DeleteTeamMember(ctx context.Context, teamID uuid.UUID, userID uuid.UUID) (*repository.TeamMember, error) {
+	req := endpoint.DeleteTeamMemberRequest{
+		TeamID: teamID,
+		UserID: userID,
+	}
+
+	var teamMember repository.TeamMember
+	if err := s.Client.do(ctx, http.MethodDelete, "/team-members", nil, req, &teamMember); err != nil {
+		return nil, err
+	}
+
+	return &teamMember, nil
+}
+
+func (s *TeamMemberSvc) DeleteTeamMembersByTeamID(ctx context.Context, teamID uuid.UUID) ([]repository.TeamMember, error) {
+	var teamMembers []repository.TeamMember
+	if err := s.Client.do(ctx, http.MethodDelete, "/team-members/teams/"+teamID.String(), nil, nil, &teamMembers); err != nil {
+		return nil, err
+	}
+
+	return teamMembers, nil
+}
+
+func (s *TeamMemberSvc) DeleteTeamMembersByUserID(ctx context.Context, userID uuid.UUID) ([]repository.TeamMember, error) {
+	var teamMembers []repository.TeamMember
+	if err := s.Client.do(ctx, http.MethodDelete, "/team-members/users/"+userID.String(), nil, nil, &teamMembers); err != nil {
+		return nil, err
+	}
+
+	return teamMembers, nil
+}
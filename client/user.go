@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/endpoint"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/service"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+)
+
+// UserSvc implements service.UserService over HTTP
+type UserSvc struct {
+	Client *Client
+}
+
+// NewUserService creates user service calling the API through c
+func NewUserService(c *Client) service.UserService {
+	return &UserSvc{Client: c}
+}
+
+func (s *UserSvc) GetAllUsers(ctx context.Context, sortBy string, sort string, skip int, limit int) ([]repository.User, error) {
+	var res struct {
+		Users []repository.User `json:"users"`
+	}
+
+	query := pagination{SortBy: sortBy, Sort: sort, Skip: skip, Limit: limit}.query()
+	if err := s.Client.do(ctx, http.MethodGet, "/users", query, nil, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Users, nil
+}
+
+func (s *UserSvc) GetUser(ctx context.Context, userID uuid.UUID) (*repository.User, error) {
+	var user repository.User
+	if err := s.Client.do(ctx, http.MethodGet, "/users/"+userID.String(), nil, nil, &user); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// GetUsersByIDs is not served, the API only batches users behind /graphql
+func (s *UserSvc) GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID) ([]repository.User, error) {
+	return nil, errors.UnsupportedOperation
+}
+
+func (s *UserSvc) CreateUser(ctx context.Context, payload *service.CreateUserRequest) (*repository.User, error) {
+	req := endpoint.CreateUserRequest{
+		Email: payload.Email,
+		Name:  payload.Name,
+	}
+
+	var user repository.User
+	if err := s.Client.do(ctx, http.MethodPost, "/users", nil, req, &user); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (s *UserSvc) UpdateUser(ctx context.Context, userID uuid.UUID, payload *service.UpdateUserRequest) (*repository.User, error) {
+	req := endpoint.UpdateUserRequest{
+		ID:    userID,
+		Email: payload.Email,
+		Name:  payload.Name,
+	}
+
+	var user repository.User
+	if err := s.Client.do(ctx, http.MethodPut, "/users/"+userID.String(), nil, req, &user); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (s *UserSvc) DeleteUser(ctx context.Context, userID uuid.UUID) (*repository.User, error) {
+	var user repository.User
+	if err := s.Client.do(ctx, http.MethodDelete, "/users/"+userID.String(), nil, nil, &user); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/endpoint"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/service"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/statement"
+)
+
+// WalletSvc implements service.WalletService over HTTP
+type WalletSvc struct {
+	Client *Client
+}
+
+// NewWalletService creates wallet service calling the API through c
+func NewWalletService(c *Client) service.WalletService {
+	return &WalletSvc{Client: c}
+}
+
+func (s *WalletSvc) GetAllWallets(ctx context.Context, sortBy string, sort string, skip int, limit int) ([]repository.Wallet, error) {
+	var res struct {
+		Wallets []repository.Wallet `json:"wallets"`
+	}
+
+	query := pagination{SortBy: sortBy, Sort: sort, Skip: skip, Limit: limit}.query()
+	if err := s.Client.do(ctx, http.MethodGet, "/wallets", query, nil, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Wallets, nil
+}
+
+// GetWallets is not served, the API does not filter wallets by team or user
+func (s *WalletSvc) GetWallets(ctx context.Context, payload *service.GetWalletsRequest) ([]repository.Wallet, error) {
+	return nil, errors.UnsupportedOperation
+}
+
+func (s *WalletSvc) GetWallet(ctx context.Context, walletID uuid.UUID) (*repository.Wallet, error) {
+	var wallet repository.Wallet
+	if err := s.Client.do(ctx, http.MethodGet, "/wallets/"+walletID.String(), nil, nil, &wallet); err != nil {
+		return nil, err
+	}
+
+	return &wallet, nil
+}
+
+// GetWalletsByIDs is not served, the API only batches wallets behind /graphql
+func (s *WalletSvc) GetWalletsByIDs(ctx context.Context, walletIDs []uuid.UUID) ([]repository.Wallet, error) {
+	return nil, errors.UnsupportedOperation
+}
+
+// GetWalletsByTeamIDs is not served, the API only batches wallets behind /graphql
+func (s *WalletSvc) GetWalletsByTeamIDs(ctx context.Context, teamIDs []uuid.UUID) ([]repository.Wallet, error) {
+	return nil, errors.UnsupportedOperation
+}
+
+// GetWalletsByUserIDs is not served, the API only batches wallets behind /graphql
+func (s *WalletSvc) GetWalletsByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]repository.Wallet, error) {
+	return nil, errors.UnsupportedOperation
+}
+
+func (s *WalletSvc) CreateWallet(ctx context.Context, payload *service.CreateWalletRequest) (*repository.Wallet, error) {
+	req := endpoint.CreateWalletRequest{
+		TeamID:   payload.TeamID,
+		UserID:   payload.UserID,
+		Currency: payload.Currency,
+	}
+
+	var wallet repository.Wallet
+	if err := s.Client.do(ctx, http.MethodPost, "/wallets", nil, req, &wallet); err != nil {
+		return nil, err
+	}
+
+	return &wallet, nil
+}
+
+func (s *WalletSvc) UpdateWallet(ctx context.Context, walletID uuid.UUID, payload *service.UpdateWalletRequest) (*repository.Wallet, error) {
+	req := endpoint.UpdateWalletRequest{
+		ID:              walletID,
+		TeamID:          payload.TeamID,
+		UserID:          payload.UserID,
+		BalanceIncrease: payload.BalanceIncrease,
+		BalanceDecrease: payload.BalanceDecrease,
+		DailyLimit:      payload.DailyLimit,
+		MonthlyLimit:    payload.MonthlyLimit,
+		Currency:        payload.Currency,
+	}
+
+	var wallet repository.Wallet
+	if err := s.Client.do(ctx, http.MethodPut, "/wallets/"+walletID.String(), nil, req, &wallet); err != nil {
+		return nil, err
+	}
+
+	return &wallet, nil
+}
+
+func (s *WalletSvc) DeleteWalletByID(ctx context.Context, walletID uuid.UUID) (*repository.Wallet, error) {
+	var wallet repository.Wallet
+	if err := s.Client.do(ctx, http.MethodDelete, "/wallets/"+walletID.String(), nil, nil, &wallet); err != nil {
+		return nil, err
+	}
+
+	return &wallet, nil
+}
+
+func (s *WalletSvc) DeleteWalletsByUserID(ctx context.Context, userID uuid.UUID) ([]repository.Wallet, error) {
+	var wallets []repository.Wallet
+	if err := s.Client.do(ctx, http.MethodDelete, "/wallets/users/"+userID.String(), nil, nil, &wallets); err != nil {
+		return nil, err
+	}
+
+	return wallets, nil
+}
+
+func (s *WalletSvc) DeleteWalletsByTeamID(ctx context.Context, teamID uuid.UUID) ([]repository.Wallet, error) {
+	var wallets []repository.Wallet
+	if err := s.Client.do(ctx, http.MethodDelete, "/wallets/teams/"+teamID.String(), nil, nil, &wallets); err != nil {
+		return nil, err
+	}
+
+	return wallets, nil
+}
+
+// GetStatement is not served, the API streams statements as CSV or PDF files
+// only
+func (s *WalletSvc) GetStatement(ctx context.Context, payload *service.GetStatementRequest) (*statement.Statement, error) {
+	return nil, errors.UnsupportedOperation
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"gitlab.com/renodesper/spenmo-test/service"
+	"gitlab.com/renodesper/spenmo-test/util/export"
+)
+
+const exportDateLayout = "2006-01-02"
+
+// runExport writes the accounting export of a team to a file or to stdout,
+// the logs go to stderr:
+//
+//	main export -config config/env/production.toml -team <id> -from 2021-01-01 -to 2021-01-31 -format journal -out journal.csv
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	configFile := fs.String("config", "config/env/development.toml", "configuration path")
+	teamIDStr := fs.String("team", "", "team id")
+	walletIDStr := fs.String("wallet", "", "wallet id, required by the qbo and xero formats")
+	fromStr := fs.String("from", "", "first day exported, as 2006-01-02")
+	toStr := fs.String("to", "", "last day exported, as 2006-01-02")
+	format := fs.String("format", export.FormatJournal, "journal, qbo or xero")
+	out := fs.String("out", "", "output file, stdout when empty")
+	_ = fs.Parse(args)
+
+	payload, err := newExportRequest(*teamIDStr, *walletIDStr, *fromStr, *toStr, *format)
+	if err != nil {
+		return err
+	}
+
+	readConfig(*configFile)
+
+	log, err := initLogger(viper.GetString("app.env"), viper.GetString("log.level"))
+	if err != nil {
+		return err
+	}
+
+	db := initDB(log, viper.GetString("db.username"), viper.GetString("db.password"), viper.GetString("db.host"), viper.GetInt("db.port"), viper.GetString("db.name"))
+	defer db.Close()
+
+	teamExport, err := service.NewExporter(log, db).Build(context.Background(), payload)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		return writeExport(teamExport, payload.Format, os.Stdout)
+	}
+
+	file, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+
+	err = writeExport(teamExport, payload.Format, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+func writeExport(teamExport *export.Export, format string, w io.Writer) error {
+	writer, err := export.NewWriter(format, w)
+	if err != nil {
+		return err
+	}
+
+	return teamExport.Write(writer)
+}
+
+// newExportRequest reads from and to as inclusive dates
+func newExportRequest(teamIDStr, walletIDStr, fromStr, toStr, format string) (*service.GetExportRequest, error) {
+	teamID, err := uuid.Parse(teamIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid team %q: %w", teamIDStr, err)
+	}
+
+	var walletID uuid.UUID
+	if walletIDStr != "" {
+		walletID, err = uuid.Parse(walletIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid wallet %q: %w", walletIDStr, err)
+		}
+	}
+
+	from, err := time.Parse(exportDateLayout, fromStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from %q: %w", fromStr, err)
+	}
+
+	to, err := time.Parse(exportDateLayout, toStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid to %q: %w", toStr, err)
+	}
+
+	return &service.GetExportRequest{
+		TeamID:   teamID,
+		WalletID: walletID,
+		From:     from,
+		To:       to.AddDate(0, 0, 1),
+		Format:   format,
+	}, nil
+}
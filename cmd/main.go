@@ -1,32 +1,72 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
+	_ "time/tzdata"
 
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	"github.com/go-pg/pg/v10"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	"github.com/rs/cors"
 	"github.com/spf13/viper"
 	api "gitlab.com/renodesper/spenmo-test/endpoint"
+	"gitlab.com/renodesper/spenmo-test/graphql"
+	"gitlab.com/renodesper/spenmo-test/middleware/metric"
 
 	"gitlab.com/renodesper/spenmo-test/repository/postgre"
 	"gitlab.com/renodesper/spenmo-test/service"
+	grpctransport "gitlab.com/renodesper/spenmo-test/transport/grpc"
 	httptransport "gitlab.com/renodesper/spenmo-test/transport/http"
+	"gitlab.com/renodesper/spenmo-test/util/blob"
+	"gitlab.com/renodesper/spenmo-test/util/blob/local"
+	"gitlab.com/renodesper/spenmo-test/util/fx"
 	"gitlab.com/renodesper/spenmo-test/util/logger"
 	"gitlab.com/renodesper/spenmo-test/util/logger/zap"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+	"gitlab.com/renodesper/spenmo-test/util/notifier"
+	"gitlab.com/renodesper/spenmo-test/util/notifier/logging"
+	notifierNoop "gitlab.com/renodesper/spenmo-test/util/notifier/noop"
+	"gitlab.com/renodesper/spenmo-test/util/ratelimit"
+	ratelimitMemory "gitlab.com/renodesper/spenmo-test/util/ratelimit/memory"
+	"gitlab.com/renodesper/spenmo-test/util/tracing"
+	"gitlab.com/renodesper/spenmo-test/util/velocity"
+	"gitlab.com/renodesper/spenmo-test/util/velocity/memory"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 var (
-	host *string
-	port *int
+	host     *string
+	port     *int
+	grpcPort *int
 )
 
 // Run ...
 func main() {
+	if len(os.Args) > 1 {
+		commands := map[string]func([]string) error{
+			"export":    runExport,
+			"reconcile": runReconcile,
+		}
+
+		if run, ok := commands[os.Args[1]]; ok {
+			if err := run(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", os.Args[1], err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	initConfig()
 
 	env := viper.GetString("app.env")
@@ -41,8 +81,14 @@ func main() {
 
 	log.Infof("Enviroment: %s", env)
 	log.Infof("HTTP url: %s://%s:%d", scheme, *host, *port)
+	log.Infof("gRPC address: %s:%d", *host, *grpcPort)
 	log.Infof("Log level: %s", level)
 
+	provider := initTracing(env)
+	if provider != nil {
+		defer shutdownTracing(log, provider)
+	}
+
 	dbUsername := viper.GetString("db.username")
 	dbPassword := viper.GetString("db.password")
 	dbHost := viper.GetString("db.host")
@@ -55,11 +101,34 @@ func main() {
 	userSvc := service.NewUserService(log, db)
 	teamSvc := service.NewTeamService(log, db)
 	teamMemberSvc := service.NewTeamMemberService(log, db)
-	walletSvc := service.NewWalletService(log, db)
-	cardSvc := service.NewCardService(log, db)
+	business := initBusinessMetrics()
+	walletSvc := service.NewInstrumentedWalletService(service.NewWalletService(log, db), business)
+	cardSvc := service.NewInstrumentedCardService(service.NewCardService(log, db), business)
+
+	converter := initFx()
+	notify := initNotifier(log)
+	fraud := initFraud(log, db)
+	transactionSvc := service.NewInstrumentedTransactionService(service.NewTransactionService(log, db, converter, notify, fraud), business)
+	analyticsSvc := service.NewAnalyticsService(log, db)
+	budgetSvc := service.NewBudgetService(log, db)
+	spendLimitSvc := service.NewSpendLimitService(log, db)
+	spendControlSvc := service.NewSpendControlService(log, db)
+	fundingSvc := service.NewFundingService(log, db)
+	fundRequestSvc := service.NewFundRequestService(log, db, transactionSvc, teamMemberSvc, notify)
+	exportSvc := service.NewExportService(log, db)
+	attachmentSvc := service.NewAttachmentService(log, db, initBlobStore(), viper.GetInt64("attachment.maxSize"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	initFundingScheduler(ctx, log, db, transactionSvc)
+	initReconciler(ctx, log, db)
+
+	schema := initGraphQL(userSvc, teamSvc, teamMemberSvc, walletSvc, cardSvc)
 
-	endpoint := api.New(env, healthSvc, userSvc, teamSvc, teamMemberSvc, walletSvc, cardSvc)
-	handler := httptransport.NewHTTPHandler(endpoint, log)
+	endpoint := api.New(env, healthSvc, userSvc, teamSvc, teamMemberSvc, walletSvc, cardSvc, transactionSvc, analyticsSvc, budgetSvc, spendLimitSvc, spendControlSvc, fundingSvc, fundRequestSvc, attachmentSvc, exportSvc, schema)
+	limiter := initRateLimiter(log, db)
+	metrics := metric.CreateMetrics()
+	handler := httptransport.NewHTTPHandler(endpoint, log, limiter, metrics)
 	handler = cors.New(cors.Options{
 		AllowedOrigins:   []string{"*"},
 		AllowedHeaders:   []string{"*"},
@@ -75,6 +144,19 @@ func main() {
 		errChan <- server.ListenAndServe()
 	}()
 
+	grpcServer := grpctransport.NewGRPCServer(endpoint, log, limiter, metrics)
+
+	go func() {
+		listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", *host, *grpcPort))
+		if err != nil {
+			errChan <- err
+			return
+		}
+
+		log.Info("gRPC service started!")
+		errChan <- grpcServer.Serve(listener)
+	}()
+
 	go func() {
 		c := make(chan os.Signal, 1)
 		signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
@@ -87,16 +169,12 @@ func main() {
 func initConfig() {
 	host = flag.String("host", "0.0.0.0", "host")
 	port = flag.Int("port", 8000, "port")
+	grpcPort = flag.Int("grpc-port", 9000, "gRPC port")
 
 	configFile := flag.String("config", "config/env/development.toml", "configuration path")
 	flag.Parse()
 
-	viper.SetConfigFile(*configFile)
-
-	err := viper.ReadInConfig()
-	if err != nil {
-		panic(err)
-	}
+	readConfig(*configFile)
 
 	if h := viper.GetString("app.host"); h != "" {
 		host = &h
@@ -104,6 +182,9 @@ func initConfig() {
 	if p := viper.GetInt("app.port"); p != 0 {
 		port = &p
 	}
+	if p := viper.GetInt("app.grpcPort"); p != 0 {
+		grpcPort = &p
+	}
 
 	if viper.GetString("app.env") != "production" {
 		fmt.Println("\n> You're not in production mode")
@@ -111,6 +192,15 @@ func initConfig() {
 	}
 }
 
+func readConfig(configFile string) {
+	viper.SetConfigFile(configFile)
+
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(err)
+	}
+}
+
 func initLogger(env, level string) (logger.Logger, error) {
 	z, err := zap.CreateLogger(env, level)
 	if err != nil {
@@ -125,3 +215,208 @@ func initDB(log logger.Logger, username, password, host string, port int, dbName
 	db := postgre.NewPostgreClient(log, username, password, host, port, dbName)
 	return db
 }
+
+func initFx() *fx.Converter {
+	var provider fx.RateProvider
+	var err error
+
+	switch viper.GetString("fx.provider") {
+	case "file":
+		provider, err = fx.NewFileRateProvider(viper.GetString("fx.file"))
+	default:
+		provider, err = fx.NewStaticRateProvider(viper.GetString("fx.static.base"), viper.GetStringMapString("fx.static.rates"))
+	}
+	if err != nil {
+		panic(err)
+	}
+
+	rounding, err := money.ParseRoundingMode(viper.GetString("fx.rounding"))
+	if err != nil {
+		panic(err)
+	}
+
+	return fx.NewConverter(provider, viper.GetUint64("fx.markupBps"), rounding)
+}
+
+func initNotifier(log logger.Logger) notifier.Notifier {
+	switch viper.GetString("notifier.provider") {
+	case "noop":
+		return notifier.New(notifierNoop.CreateNotifier())
+	default:
+		return notifier.New(logging.CreateNotifier(log))
+	}
+}
+
+// initBlobStore only knows the "local" store for now, other stores plug in here
+func initBlobStore() blob.Store {
+	store, err := local.CreateStore(viper.GetString("attachment.dir"))
+	if err != nil {
+		panic(err)
+	}
+
+	return store
+}
+
+func initGraphQL(userSvc service.UserService, teamSvc service.TeamService, teamMemberSvc service.TeamMemberService, walletSvc service.WalletService, cardSvc service.CardService) *graphql.Schema {
+	limits := graphql.Limits{
+		MaxDepth:      viper.GetInt("graphql.maxDepth"),
+		MaxComplexity: viper.GetInt("graphql.maxComplexity"),
+	}
+
+	schema, err := graphql.NewSchema(limits, userSvc, teamSvc, teamMemberSvc, walletSvc, cardSvc)
+	if err != nil {
+		panic(err)
+	}
+
+	return schema
+}
+
+func initFraud(log logger.Logger, db *pg.DB) *service.FraudEngine {
+	var store velocity.Store
+
+	switch viper.GetString("velocity.store") {
+	case "memory":
+		store = memory.CreateStore()
+	default:
+		store = postgre.CreateVelocityRepository(log, db)
+	}
+
+	var rules []service.FraudRule
+	err := viper.UnmarshalKey("fraud.rules", &rules)
+	if err != nil {
+		panic(err)
+	}
+
+	fraud, err := service.NewFraudEngine(log, db, store, rules)
+	if err != nil {
+		panic(err)
+	}
+
+	return fraud
+}
+
+// initTracing installs the provider of the configured exporter, it is nil
+// when the spans are not exported
+func initTracing(env string) *sdktrace.TracerProvider {
+	var exporter sdktrace.SpanExporter
+	var err error
+
+	switch viper.GetString("tracing.exporter") {
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case "otlp":
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(viper.GetString("tracing.otlp.endpoint"))}
+		if viper.GetBool("tracing.otlp.insecure") {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(context.Background(), opts...)
+	default:
+		tracing.Install(nil)
+		return nil
+	}
+	if err != nil {
+		panic(err)
+	}
+
+	provider := tracing.NewProvider(exporter, viper.GetString("tracing.serviceName"), env, viper.GetString("app.version"), viper.GetFloat64("tracing.sampleRatio"))
+	tracing.Install(provider)
+	return provider
+}
+
+// shutdownTracing exports the spans still batched
+func shutdownTracing(log logger.Logger, provider *sdktrace.TracerProvider) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := provider.Shutdown(ctx); err != nil {
+		log.Error(err)
+	}
+}
+
+func initRateLimiter(log logger.Logger, db *pg.DB) *ratelimit.Limiter {
+	var store ratelimit.Store
+
+	switch viper.GetString("ratelimit.store") {
+	case "postgres":
+		store = postgre.CreateRateLimitRepository(log, db)
+	default:
+		store = ratelimitMemory.CreateStore()
+	}
+
+	var limit ratelimit.Limit
+	err := viper.UnmarshalKey("ratelimit.default", &limit)
+	if err != nil {
+		panic(err)
+	}
+
+	var groups []ratelimit.Group
+	err = viper.UnmarshalKey("ratelimit.groups", &groups)
+	if err != nil {
+		panic(err)
+	}
+
+	limiter, err := ratelimit.NewLimiter(store, limit, groups)
+	if err != nil {
+		panic(err)
+	}
+
+	return limiter
+}
+
+func initFundingScheduler(ctx context.Context, log logger.Logger, db *pg.DB, transactionSvc service.TransactionService) {
+	if !viper.GetBool("funding.enabled") {
+		return
+	}
+
+	interval := viper.GetDuration("funding.interval")
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	scheduler := service.NewFundingScheduler(log, db, transactionSvc)
+	go scheduler.Start(ctx, interval)
+}
+
+func initBusinessMetrics() service.BusinessMetrics {
+	return service.BusinessMetrics{
+		WalletsCreated: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "spenmo",
+			Subsystem: "test",
+			Name:      "wallets_created_total",
+			Help:      "Number of wallets created.",
+		}, []string{"currency"}),
+		CardsIssued: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "spenmo",
+			Subsystem: "test",
+			Name:      "cards_issued_total",
+			Help:      "Number of cards issued.",
+		}, []string{}),
+		BalanceVolume: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "spenmo",
+			Subsystem: "test",
+			Name:      "balance_volume_total",
+			Help:      "Amount of money moved on wallet balances.",
+		}, []string{"type", "direction", "currency"}),
+	}
+}
+
+func initReconciler(ctx context.Context, log logger.Logger, db *pg.DB) {
+	if !viper.GetBool("reconciliation.enabled") {
+		return
+	}
+
+	interval := viper.GetDuration("reconciliation.interval")
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	drifting := kitprometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Namespace: "spenmo",
+		Subsystem: "test",
+		Name:      "drifting_wallets",
+		Help:      "Number of wallets whose balance differs from the sum of their transactions.",
+	}, []string{})
+
+	reconciler := service.NewReconciler(log, db, drifting)
+	go reconciler.Start(ctx, interval, viper.GetBool("reconciliation.correct"))
+}
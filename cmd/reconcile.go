@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-kit/kit/metrics/discard"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/spf13/viper"
+	"gitlab.com/renodesper/spenmo-test/service"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// runReconcile compares the balance of every wallet with its ledger and
+// writes the report as JSON to stdout, the logs go to stderr:
+//
+//	main reconcile -config config/env/production.toml -correct
+func runReconcile(args []string) error {
+	fs := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	configFile := fs.String("config", "config/env/development.toml", "configuration path")
+	correct := fs.Bool("correct", false, "record an adjustment for every wallet drifting")
+	_ = fs.Parse(args)
+
+	readConfig(*configFile)
+
+	log, err := initLogger(viper.GetString("app.env"), viper.GetString("log.level"))
+	if err != nil {
+		return err
+	}
+
+	db := initDB(log, viper.GetString("db.username"), viper.GetString("db.password"), viper.GetString("db.host"), viper.GetInt("db.port"), viper.GetString("db.name"))
+	defer db.Close()
+
+	report, err := service.NewReconciler(log, db, discard.NewGauge()).Run(context.Background(), *correct)
+	if err != nil {
+		return err
+	}
+
+	if err := writeReconciliationReport(report, os.Stdout); err != nil {
+		return err
+	}
+
+	// NOTE: A drift left uncorrected fails the command so it can alert from cron
+	if uncorrected := len(report.Drifts) - len(report.Corrections); uncorrected > 0 {
+		return fmt.Errorf("%d of %d wallets drifting", uncorrected, report.Checked)
+	}
+
+	return nil
+}
+
+func writeReconciliationReport(report *service.ReconciliationReport, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(report)
+}
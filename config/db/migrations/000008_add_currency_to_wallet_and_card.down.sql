@@ -0,0 +1,2 @@
+ALTER TABLE "card" DROP COLUMN IF EXISTS currency;
+ALTER TABLE "wallet" DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,2 @@
+ALTER TABLE "wallet" ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'SGD';
+ALTER TABLE "card" ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'SGD';
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS "transaction" (
+  id uuid DEFAULT uuid_generate_v4(),
+  reference_id uuid NOT NULL,
+  wallet_id uuid NOT NULL,
+  card_id uuid,
+  counterparty_wallet_id uuid,
+  type VARCHAR NOT NULL,
+  direction VARCHAR NOT NULL,
+  amount NUMERIC NOT NULL,
+  currency CHAR(3) NOT NULL,
+  original_amount NUMERIC,
+  original_currency CHAR(3),
+  fx_rate NUMERIC,
+  fx_markup_bps INTEGER,
+  fx_rounding VARCHAR,
+  merchant VARCHAR,
+  description VARCHAR,
+  created_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+  updated_at TIMESTAMP DEFAULT NULL,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS transaction_wallet_id_created_at_idx ON "transaction" (wallet_id, created_at);
+CREATE INDEX IF NOT EXISTS transaction_card_id_created_at_idx ON "transaction" (card_id, created_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS transaction_type_created_at_idx;
+
+ALTER TABLE "transaction" DROP COLUMN IF EXISTS mcc;
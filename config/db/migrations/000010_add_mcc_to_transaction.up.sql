@@ -0,0 +1,3 @@
+ALTER TABLE "transaction" ADD COLUMN IF NOT EXISTS mcc CHAR(4);
+
+CREATE INDEX IF NOT EXISTS transaction_type_created_at_idx ON "transaction" (type, created_at);
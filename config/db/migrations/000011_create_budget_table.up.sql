@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS budget (
+  id uuid DEFAULT uuid_generate_v4(),
+  name VARCHAR NOT NULL,
+  wallet_id uuid NOT NULL,
+  card_id uuid,
+  amount NUMERIC NOT NULL,
+  currency CHAR(3) NOT NULL,
+  period VARCHAR NOT NULL,
+  thresholds INTEGER[] NOT NULL,
+  is_deleted BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+  updated_at TIMESTAMP DEFAULT NULL,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS budget_wallet_id_idx ON budget (wallet_id);
+
+CREATE TABLE IF NOT EXISTS budget_alert (
+  id uuid DEFAULT uuid_generate_v4(),
+  budget_id uuid NOT NULL,
+  transaction_id uuid NOT NULL,
+  threshold INTEGER NOT NULL,
+  period_start TIMESTAMP NOT NULL,
+  spent NUMERIC NOT NULL,
+  amount NUMERIC NOT NULL,
+  currency CHAR(3) NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+  PRIMARY KEY (id),
+  UNIQUE (budget_id, period_start, threshold)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS spend_limit;
+
+ALTER TABLE "team" DROP COLUMN IF EXISTS timezone;
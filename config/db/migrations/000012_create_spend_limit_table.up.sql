@@ -0,0 +1,30 @@
+ALTER TABLE "team" ADD COLUMN IF NOT EXISTS timezone VARCHAR NOT NULL DEFAULT 'UTC';
+
+CREATE TABLE IF NOT EXISTS spend_limit (
+  id uuid DEFAULT uuid_generate_v4(),
+  wallet_id uuid NOT NULL,
+  card_id uuid,
+  period VARCHAR NOT NULL,
+  window_type VARCHAR NOT NULL DEFAULT 'calendar',
+  amount NUMERIC NOT NULL,
+  currency CHAR(3) NOT NULL,
+  is_deleted BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+  updated_at TIMESTAMP DEFAULT NULL,
+  PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS spend_limit_owner_period_idx ON spend_limit (wallet_id, COALESCE(card_id, '00000000-0000-0000-0000-000000000000'), period, window_type) WHERE is_deleted = FALSE;
+
+-- NOTE: The fixed daily and monthly limits become calendar spend limits
+INSERT INTO spend_limit (wallet_id, period, window_type, amount, currency)
+SELECT id, 'daily', 'calendar', daily_limit, currency FROM wallet WHERE daily_limit > 0;
+
+INSERT INTO spend_limit (wallet_id, period, window_type, amount, currency)
+SELECT id, 'monthly', 'calendar', monthly_limit, currency FROM wallet WHERE monthly_limit > 0;
+
+INSERT INTO spend_limit (wallet_id, card_id, period, window_type, amount, currency)
+SELECT wallet_id, id, 'daily', 'calendar', daily_limit, currency FROM card WHERE daily_limit > 0;
+
+INSERT INTO spend_limit (wallet_id, card_id, period, window_type, amount, currency)
+SELECT wallet_id, id, 'monthly', 'calendar', monthly_limit, currency FROM card WHERE monthly_limit > 0;
@@ -0,0 +1,5 @@
+ALTER TABLE "transaction" DROP COLUMN IF EXISTS country;
+ALTER TABLE "transaction" DROP COLUMN IF EXISTS merchant_id;
+
+ALTER TABLE "card" DROP COLUMN IF EXISTS controls;
+ALTER TABLE "wallet" DROP COLUMN IF EXISTS controls;
@@ -0,0 +1,5 @@
+ALTER TABLE "wallet" ADD COLUMN IF NOT EXISTS controls JSONB;
+ALTER TABLE "card" ADD COLUMN IF NOT EXISTS controls JSONB;
+
+ALTER TABLE "transaction" ADD COLUMN IF NOT EXISTS merchant_id VARCHAR;
+ALTER TABLE "transaction" ADD COLUMN IF NOT EXISTS country CHAR(2);
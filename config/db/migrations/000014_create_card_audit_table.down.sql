@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS velocity_member;
+DROP TABLE IF EXISTS velocity_event;
+DROP TABLE IF EXISTS card_audit;
+
+ALTER TABLE "card" DROP COLUMN IF EXISTS is_frozen;
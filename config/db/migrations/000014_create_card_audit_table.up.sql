@@ -0,0 +1,32 @@
+ALTER TABLE "card" ADD COLUMN IF NOT EXISTS is_frozen BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS card_audit (
+  id uuid DEFAULT uuid_generate_v4(),
+  card_id uuid NOT NULL,
+  action VARCHAR NOT NULL,
+  rule VARCHAR,
+  reason VARCHAR,
+  user_id uuid,
+  details JSONB,
+  created_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS card_audit_card_id_created_at_idx ON card_audit (card_id, created_at);
+
+CREATE TABLE IF NOT EXISTS velocity_event (
+  id uuid DEFAULT uuid_generate_v4(),
+  key VARCHAR NOT NULL,
+  amount NUMERIC NOT NULL DEFAULT 0,
+  created_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS velocity_event_key_created_at_idx ON velocity_event (key, created_at);
+
+CREATE TABLE IF NOT EXISTS velocity_member (
+  key VARCHAR NOT NULL,
+  member VARCHAR NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+  PRIMARY KEY (key, member)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE "card" DROP COLUMN IF EXISTS is_closed;
+ALTER TABLE "card" DROP COLUMN IF EXISTS locked_merchant;
+ALTER TABLE "card" DROP COLUMN IF EXISTS type;
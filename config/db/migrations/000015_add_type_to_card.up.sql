@@ -0,0 +1,3 @@
+ALTER TABLE "card" ADD COLUMN IF NOT EXISTS type VARCHAR NOT NULL DEFAULT 'standard';
+ALTER TABLE "card" ADD COLUMN IF NOT EXISTS locked_merchant VARCHAR;
+ALTER TABLE "card" ADD COLUMN IF NOT EXISTS is_closed BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS funding_run;
+
+DROP TABLE IF EXISTS funding_schedule;
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS funding_schedule (
+  id uuid DEFAULT uuid_generate_v4(),
+  team_id uuid NOT NULL,
+  source_wallet_id uuid NOT NULL,
+  target_wallet_id uuid NOT NULL,
+  mode VARCHAR NOT NULL DEFAULT 'top_up',
+  amount NUMERIC NOT NULL,
+  currency CHAR(3) NOT NULL,
+  cron VARCHAR,
+  day_of_month INT,
+  next_run_at TIMESTAMP NOT NULL,
+  last_run_at TIMESTAMP DEFAULT NULL,
+  is_deleted BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+  updated_at TIMESTAMP DEFAULT NULL,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS funding_schedule_next_run_at_idx ON funding_schedule (next_run_at) WHERE is_deleted = FALSE;
+
+-- NOTE: A period is funded at most once, the run is inserted in the transaction moving the money
+CREATE TABLE IF NOT EXISTS funding_run (
+  id uuid DEFAULT uuid_generate_v4(),
+  schedule_id uuid NOT NULL,
+  period TIMESTAMP NOT NULL,
+  status VARCHAR NOT NULL,
+  amount NUMERIC,
+  currency CHAR(3),
+  transaction_id uuid,
+  error VARCHAR,
+  created_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+  PRIMARY KEY (id),
+  UNIQUE (schedule_id, period)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS fund_request;
+
+ALTER TABLE "team_member" DROP COLUMN IF EXISTS role;
@@ -0,0 +1,25 @@
+ALTER TABLE "team_member" ADD COLUMN IF NOT EXISTS role VARCHAR NOT NULL DEFAULT 'member';
+
+-- NOTE: The first member of the dummy team approves its fund requests
+UPDATE "team_member" SET role = 'admin' WHERE id = '852da87d-2d16-4173-885a-e84476f2d0ba';
+
+CREATE TABLE IF NOT EXISTS fund_request (
+  id uuid DEFAULT uuid_generate_v4(),
+  team_id uuid NOT NULL,
+  wallet_id uuid NOT NULL,
+  requester_id uuid NOT NULL,
+  amount NUMERIC NOT NULL,
+  currency CHAR(3) NOT NULL,
+  reason VARCHAR NOT NULL,
+  status VARCHAR NOT NULL DEFAULT 'pending',
+  reviewer_id uuid,
+  review_note VARCHAR,
+  source_wallet_id uuid,
+  reference_id uuid,
+  reviewed_at TIMESTAMP DEFAULT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+  updated_at TIMESTAMP DEFAULT NULL,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS fund_request_team_status_idx ON fund_request (team_id, status);
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS attachment;
+
+DROP INDEX IF EXISTS transaction_tags_idx;
+DROP INDEX IF EXISTS transaction_category_idx;
+
+ALTER TABLE "transaction" DROP COLUMN IF EXISTS tags;
+ALTER TABLE "transaction" DROP COLUMN IF EXISTS category;
+ALTER TABLE "transaction" DROP COLUMN IF EXISTS memo;
@@ -0,0 +1,21 @@
+ALTER TABLE "transaction" ADD COLUMN IF NOT EXISTS memo VARCHAR;
+ALTER TABLE "transaction" ADD COLUMN IF NOT EXISTS category VARCHAR;
+ALTER TABLE "transaction" ADD COLUMN IF NOT EXISTS tags TEXT[];
+
+CREATE INDEX IF NOT EXISTS transaction_category_idx ON "transaction" (category);
+CREATE INDEX IF NOT EXISTS transaction_tags_idx ON "transaction" USING GIN (tags);
+
+CREATE TABLE IF NOT EXISTS attachment (
+  id uuid DEFAULT uuid_generate_v4(),
+  transaction_id uuid NOT NULL,
+  kind VARCHAR NOT NULL,
+  file_name VARCHAR NOT NULL,
+  content_type VARCHAR NOT NULL,
+  size BIGINT NOT NULL,
+  storage_key VARCHAR NOT NULL,
+  uploaded_by uuid,
+  created_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS attachment_transaction_id_idx ON attachment (transaction_id);
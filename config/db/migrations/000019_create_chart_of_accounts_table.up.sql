@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS chart_of_accounts (
+  id uuid DEFAULT uuid_generate_v4(),
+  team_id uuid NOT NULL,
+  wallet_account VARCHAR NOT NULL,
+  expense_account VARCHAR NOT NULL,
+  income_account VARCHAR NOT NULL,
+  transfer_account VARCHAR NOT NULL,
+  categories JSONB,
+  created_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+  updated_at TIMESTAMP DEFAULT NULL,
+  PRIMARY KEY (id),
+  UNIQUE (team_id)
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS rate_limit (
+  key VARCHAR NOT NULL,
+  window_start TIMESTAMP NOT NULL,
+  count INTEGER NOT NULL DEFAULT 0,
+  expires_at TIMESTAMP NOT NULL,
+  PRIMARY KEY (key, window_start)
+);
+
+CREATE INDEX IF NOT EXISTS rate_limit_expires_at_idx ON rate_limit (expires_at);
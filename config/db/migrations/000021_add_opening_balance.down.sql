@@ -0,0 +1 @@
+DELETE FROM "transaction" WHERE type = 'adjustment' AND description = 'Opening balance';
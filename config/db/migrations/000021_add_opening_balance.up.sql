@@ -0,0 +1,12 @@
+-- NOTE: Balances set before the ledger existed are recorded as an opening adjustment, so the ledger of every wallet adds up to its balance
+INSERT INTO "transaction" (reference_id, wallet_id, type, direction, amount, currency, description, created_at)
+SELECT uuid_generate_v4(), w.id, 'adjustment', CASE WHEN w.balance - l.ledger_balance > 0 THEN 'credit' ELSE 'debit' END,
+  ABS(w.balance - l.ledger_balance), w.currency, 'Opening balance', LEAST(w.created_at, COALESCE(l.first_transaction_at, w.created_at))
+FROM wallet AS w
+CROSS JOIN LATERAL (
+  SELECT COALESCE(SUM(CASE WHEN t.direction = 'credit' THEN t.amount ELSE -t.amount END), 0) AS ledger_balance,
+    MIN(t.created_at) AS first_transaction_at
+  FROM "transaction" AS t
+  WHERE t.wallet_id = w.id
+) AS l
+WHERE w.balance <> l.ledger_balance;
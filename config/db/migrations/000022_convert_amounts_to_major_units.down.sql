@@ -0,0 +1,17 @@
+CREATE OR REPLACE FUNCTION to_minor_units(amount NUMERIC, currency CHAR(3)) RETURNS NUMERIC AS $$
+  SELECT ROUND(amount * 10::NUMERIC ^ CASE
+    WHEN currency IN ('BHD', 'JOD', 'KWD', 'OMR') THEN 3
+    WHEN currency IN ('CLP', 'ISK', 'JPY', 'KRW', 'VND') THEN 0
+    ELSE 2
+  END)
+$$ LANGUAGE SQL IMMUTABLE;
+
+UPDATE wallet SET balance = to_minor_units(balance, currency), daily_limit = to_minor_units(daily_limit, currency), monthly_limit = to_minor_units(monthly_limit, currency);
+
+UPDATE card SET daily_limit = to_minor_units(daily_limit, currency), monthly_limit = to_minor_units(monthly_limit, currency);
+
+UPDATE spend_limit SET amount = to_minor_units(amount, currency);
+
+UPDATE "transaction" SET amount = to_minor_units(amount, currency), original_amount = to_minor_units(original_amount, original_currency);
+
+DROP FUNCTION to_minor_units(NUMERIC, CHAR(3));
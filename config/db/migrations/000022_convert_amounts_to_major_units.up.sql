@@ -0,0 +1,19 @@
+-- NOTE: Amounts were stored in the minor unit of their currency before they became decimals in the major unit
+CREATE OR REPLACE FUNCTION to_major_units(amount NUMERIC, currency CHAR(3)) RETURNS NUMERIC AS $$
+  SELECT ROUND(amount / 10::NUMERIC ^ u.minor_units, u.minor_units)
+  FROM (SELECT CASE
+    WHEN currency IN ('BHD', 'JOD', 'KWD', 'OMR') THEN 3
+    WHEN currency IN ('CLP', 'ISK', 'JPY', 'KRW', 'VND') THEN 0
+    ELSE 2
+  END AS minor_units) AS u
+$$ LANGUAGE SQL IMMUTABLE;
+
+UPDATE wallet SET balance = to_major_units(balance, currency), daily_limit = to_major_units(daily_limit, currency), monthly_limit = to_major_units(monthly_limit, currency);
+
+UPDATE card SET daily_limit = to_major_units(daily_limit, currency), monthly_limit = to_major_units(monthly_limit, currency);
+
+UPDATE spend_limit SET amount = to_major_units(amount, currency);
+
+UPDATE "transaction" SET amount = to_major_units(amount, currency), original_amount = to_major_units(original_amount, original_currency);
+
+DROP FUNCTION to_major_units(NUMERIC, CHAR(3));
@@ -0,0 +1,37 @@
+package endpoint
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/service"
+)
+
+type (
+	GetTeamSpendRequest struct {
+		ID       uuid.UUID `json:"id" validate:"required"`
+		GroupBy  string    `json:"groupBy" validate:"omitempty,oneof=card user mcc day"`
+		Interval string    `json:"interval" validate:"omitempty,oneof=day week month"`
+		From     time.Time `json:"from" validate:"required"`
+		To       time.Time `json:"to" validate:"required"`
+	}
+)
+
+func MakeGetTeamSpendEndpoint(analyticsSvc service.AnalyticsService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(GetTeamSpendRequest)
+
+		spendReq := service.GetTeamSpendRequest{
+			TeamID:   req.ID,
+			GroupBy:  req.GroupBy,
+			Interval: req.Interval,
+			From:     req.From,
+			To:       req.To,
+		}
+
+		spend, err := analyticsSvc.GetTeamSpend(ctx, &spendReq)
+		return spend, err
+	}
+}
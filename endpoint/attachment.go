@@ -0,0 +1,79 @@
+package endpoint
+
+import (
+	"context"
+	"io"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/service"
+)
+
+type (
+	GetReceiptsRequest struct {
+		TransactionID uuid.UUID `json:"transactionId" validate:"required"`
+	}
+
+	GetReceiptRequest struct {
+		TransactionID uuid.UUID `json:"transactionId" validate:"required"`
+		ID            uuid.UUID `json:"id" validate:"required"`
+	}
+
+	// CreateReceiptRequest carries the uploaded file as a stream, it is only
+	// read by the service
+	CreateReceiptRequest struct {
+		TransactionID uuid.UUID `json:"transactionId" validate:"required"`
+		FileName      string    `json:"fileName"`
+		Content       io.Reader `json:"-"`
+	}
+
+	// GetReceiptResponse is not JSON encoded, the transport streams Content
+	// and closes it
+	GetReceiptResponse struct {
+		Attachment *repository.Attachment
+		Content    io.ReadCloser
+	}
+)
+
+func MakeGetReceiptsEndpoint(attachmentSvc service.AttachmentService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(GetReceiptsRequest)
+
+		receipts, err := attachmentSvc.GetReceipts(ctx, req.TransactionID)
+		return receipts, err
+	}
+}
+
+func MakeGetReceiptEndpoint(attachmentSvc service.AttachmentService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(GetReceiptRequest)
+
+		attachment, content, err := attachmentSvc.GetReceipt(ctx, req.TransactionID, req.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		response = GetReceiptResponse{
+			Attachment: attachment,
+			Content:    content,
+		}
+
+		return response, nil
+	}
+}
+
+func MakeCreateReceiptEndpoint(attachmentSvc service.AttachmentService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(CreateReceiptRequest)
+
+		receiptReq := service.CreateReceiptRequest{
+			TransactionID: req.TransactionID,
+			FileName:      req.FileName,
+			Content:       req.Content,
+		}
+
+		receipt, err := attachmentSvc.CreateReceipt(ctx, &receiptReq)
+		return receipt, err
+	}
+}
@@ -0,0 +1,143 @@
+package endpoint
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/service"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+type (
+	GetBudgetsRequest struct {
+		WalletID uuid.UUID `json:"walletId"`
+		CardID   uuid.UUID `json:"cardId"`
+		SortBy   string    `json:"sortBy"`
+		Sort     string    `json:"sort"`
+		Skip     int       `json:"skip" validate:"min=0"`
+		Limit    int       `json:"limit" validate:"min=1,max=100"`
+	}
+
+	GetBudgetRequest struct {
+		ID uuid.UUID `json:"id" validate:"required"`
+	}
+
+	CreateBudgetRequest struct {
+		Name       string        `json:"name" validate:"required"`
+		WalletID   uuid.UUID     `json:"walletId"`
+		CardID     uuid.UUID     `json:"cardId"`
+		Amount     money.Decimal `json:"amount"`
+		Period     string        `json:"period" validate:"omitempty,oneof=monthly quarterly yearly"`
+		Thresholds []int         `json:"thresholds"`
+	}
+
+	UpdateBudgetRequest struct {
+		ID         uuid.UUID     `json:"id" validate:"required"`
+		Name       string        `json:"name"`
+		Amount     money.Decimal `json:"amount"`
+		Period     string        `json:"period" validate:"omitempty,oneof=monthly quarterly yearly"`
+		Thresholds []int         `json:"thresholds"`
+	}
+
+	DeleteBudgetRequest struct {
+		ID uuid.UUID `json:"id" validate:"required"`
+	}
+
+	GetBudgetAlertsRequest struct {
+		ID uuid.UUID `json:"id" validate:"required"`
+	}
+)
+
+func MakeGetBudgetsEndpoint(budgetSvc service.BudgetService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(GetBudgetsRequest)
+
+		budgetsReq := service.GetBudgetsRequest{
+			WalletID: req.WalletID,
+			CardID:   req.CardID,
+			SortBy:   req.SortBy,
+			Sort:     req.Sort,
+			Skip:     req.Skip,
+			Limit:    req.Limit,
+		}
+
+		budgets, err := budgetSvc.GetBudgets(ctx, &budgetsReq)
+		if err != nil {
+			return nil, err
+		}
+
+		response = map[string]interface{}{
+			"budgets": budgets,
+			"pagination": map[string]interface{}{
+				"sortBy": req.SortBy,
+				"sort":   req.Sort,
+				"skip":   req.Skip,
+				"limit":  req.Limit,
+			},
+		}
+
+		return response, nil
+	}
+}
+
+func MakeGetBudgetEndpoint(budgetSvc service.BudgetService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(GetBudgetRequest)
+
+		budget, err := budgetSvc.GetBudget(ctx, req.ID)
+		return budget, err
+	}
+}
+
+func MakeCreateBudgetEndpoint(budgetSvc service.BudgetService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(CreateBudgetRequest)
+
+		budgetReq := service.CreateBudgetRequest{
+			Name:       req.Name,
+			WalletID:   req.WalletID,
+			CardID:     req.CardID,
+			Amount:     req.Amount,
+			Period:     req.Period,
+			Thresholds: req.Thresholds,
+		}
+
+		budget, err := budgetSvc.CreateBudget(ctx, &budgetReq)
+		return budget, err
+	}
+}
+
+func MakeUpdateBudgetEndpoint(budgetSvc service.BudgetService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(UpdateBudgetRequest)
+
+		budgetReq := service.UpdateBudgetRequest{
+			Name:       req.Name,
+			Amount:     req.Amount,
+			Period:     req.Period,
+			Thresholds: req.Thresholds,
+		}
+
+		budget, err := budgetSvc.UpdateBudget(ctx, req.ID, &budgetReq)
+		return budget, err
+	}
+}
+
+func MakeDeleteBudgetEndpoint(budgetSvc service.BudgetService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(DeleteBudgetRequest)
+
+		budget, err := budgetSvc.DeleteBudget(ctx, req.ID)
+		return budget, err
+	}
+}
+
+func MakeGetBudgetAlertsEndpoint(budgetSvc service.BudgetService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(GetBudgetAlertsRequest)
+
+		alerts, err := budgetSvc.GetBudgetAlerts(ctx, req.ID)
+		return alerts, err
+	}
+}
@@ -6,22 +6,25 @@ import (
 	"github.com/go-kit/kit/endpoint"
 	"github.com/google/uuid"
 	"gitlab.com/renodesper/spenmo-test/service"
+	"gitlab.com/renodesper/spenmo-test/util/money"
 )
 
 type (
 	CreateCardRequest struct {
-		CardNo      string    `json:"cardNo" validate:"required"`
-		ExpiryMonth string    `json:"expiryMonth" validate:"required"`
-		ExpiryYear  string    `json:"expiryYear" validate:"required"`
-		CVV         string    `json:"cvv" validate:"required"`
+		CardNo      string    `json:"cardNo" validate:"required,numeric,min=13,max=19"`
+		ExpiryMonth string    `json:"expiryMonth" validate:"required,expiry_month"`
+		ExpiryYear  string    `json:"expiryYear" validate:"required,expiry_year"`
+		CVV         string    `json:"cvv" validate:"required,numeric,min=3,max=4"`
+		Currency    string    `json:"currency"`
+		Type        string    `json:"type" validate:"omitempty,oneof=standard single_use merchant_locked"`
 		WalletID    uuid.UUID `json:"walletId" validate:"required"`
 	}
 
 	GetAllCardsRequest struct {
 		SortBy string `json:"sortBy"`
 		Sort   string `json:"sort"`
-		Skip   int    `json:"skip"`
-		Limit  int    `json:"limit"`
+		Skip   int    `json:"skip" validate:"min=0"`
+		Limit  int    `json:"limit" validate:"min=1,max=100"`
 	}
 
 	GetCardRequest struct {
@@ -29,14 +32,14 @@ type (
 	}
 
 	UpdateCardRequest struct {
-		ID           uuid.UUID `json:"id" validate:"required"`
-		WalletID     uuid.UUID `json:"walletId"`
-		CardNo       string    `json:"cardNo"`
-		ExpiryMonth  string    `json:"expiryMonth"`
-		ExpiryYear   string    `json:"expiryYear"`
-		CVV          string    `json:"cvv"`
-		DailyLimit   uint64    `json:"dailyLimit"`
-		MonthlyLimit uint64    `json:"monthlyLimit"`
+		ID           uuid.UUID     `json:"id" validate:"required"`
+		WalletID     uuid.UUID     `json:"walletId"`
+		CardNo       string        `json:"cardNo" validate:"omitempty,numeric,min=13,max=19"`
+		ExpiryMonth  string        `json:"expiryMonth" validate:"omitempty,expiry_month"`
+		ExpiryYear   string        `json:"expiryYear" validate:"omitempty,expiry_year"`
+		CVV          string        `json:"cvv" validate:"omitempty,numeric,min=3,max=4"`
+		DailyLimit   money.Decimal `json:"dailyLimit"`
+		MonthlyLimit money.Decimal `json:"monthlyLimit"`
 	}
 
 	DeleteCardByIDRequest struct {
@@ -46,6 +49,17 @@ type (
 	DeleteCardsByWalletIDRequest struct {
 		WalletID uuid.UUID `json:"walletId" validate:"required"`
 	}
+
+	FreezeCardRequest struct {
+		ID     uuid.UUID `json:"id" validate:"required"`
+		Reason string    `json:"reason"`
+	}
+
+	GetCardAuditsRequest struct {
+		ID    uuid.UUID `json:"id" validate:"required"`
+		Skip  int       `json:"skip" validate:"min=0"`
+		Limit int       `json:"limit" validate:"min=1,max=100"`
+	}
 )
 
 func MakeCreateCardEndpoint(CardSvc service.CardService) endpoint.Endpoint {
@@ -57,6 +71,8 @@ func MakeCreateCardEndpoint(CardSvc service.CardService) endpoint.Endpoint {
 			ExpiryMonth: req.ExpiryMonth,
 			ExpiryYear:  req.ExpiryYear,
 			CVV:         req.CVV,
+			Currency:    req.Currency,
+			Type:        req.Type,
 			WalletID:    req.WalletID,
 		}
 
@@ -133,3 +149,42 @@ func MakeDeleteCardsByWalletIDEndpoint(CardSvc service.CardService) endpoint.End
 		return cards, err
 	}
 }
+
+func MakeFreezeCardEndpoint(CardSvc service.CardService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(FreezeCardRequest)
+
+		card, err := CardSvc.FreezeCard(ctx, req.ID, req.Reason)
+		return card, err
+	}
+}
+
+func MakeUnfreezeCardEndpoint(CardSvc service.CardService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(FreezeCardRequest)
+
+		card, err := CardSvc.UnfreezeCard(ctx, req.ID, req.Reason)
+		return card, err
+	}
+}
+
+func MakeGetCardAuditsEndpoint(CardSvc service.CardService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(GetCardAuditsRequest)
+
+		audits, err := CardSvc.GetCardAudits(ctx, req.ID, req.Skip, req.Limit)
+		if err != nil {
+			return nil, err
+		}
+
+		response = map[string]interface{}{
+			"audits": audits,
+			"pagination": map[string]interface{}{
+				"skip":  req.Skip,
+				"limit": req.Limit,
+			},
+		}
+
+		return response, nil
+	}
+}
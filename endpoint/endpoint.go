@@ -2,6 +2,7 @@ package endpoint
 
 import (
 	"github.com/go-kit/kit/endpoint"
+	"gitlab.com/renodesper/spenmo-test/graphql"
 	"gitlab.com/renodesper/spenmo-test/service"
 )
 
@@ -18,6 +19,7 @@ type Set struct {
 	UpdateTeamEndpoint                endpoint.Endpoint
 	DeleteTeamEndpoint                endpoint.Endpoint
 	CreateTeamMemberEndpoint          endpoint.Endpoint
+	UpdateTeamMemberEndpoint          endpoint.Endpoint
 	GetTeamMembersEndpoint            endpoint.Endpoint
 	DeleteTeamMemberEndpoint          endpoint.Endpoint
 	DeleteTeamMembersByTeamIDEndpoint endpoint.Endpoint
@@ -29,12 +31,58 @@ type Set struct {
 	DeleteWalletByIDEndpoint          endpoint.Endpoint
 	DeleteWalletsByTeamIDEndpoint     endpoint.Endpoint
 	DeleteWalletsByUserIDEndpoint     endpoint.Endpoint
+	GetWalletStatementEndpoint        endpoint.Endpoint
 	GetAllCardsEndpoint               endpoint.Endpoint
 	GetCardEndpoint                   endpoint.Endpoint
 	CreateCardEndpoint                endpoint.Endpoint
 	UpdateCardEndpoint                endpoint.Endpoint
 	DeleteCardByIDEndpoint            endpoint.Endpoint
 	DeleteCardsByWalletIDEndpoint     endpoint.Endpoint
+	FreezeCardEndpoint                endpoint.Endpoint
+	UnfreezeCardEndpoint              endpoint.Endpoint
+	GetCardAuditsEndpoint             endpoint.Endpoint
+	GetTransactionsEndpoint           endpoint.Endpoint
+	GetTransactionEndpoint            endpoint.Endpoint
+	UpdateTransactionEndpoint         endpoint.Endpoint
+	CreateTransferEndpoint            endpoint.Endpoint
+	CreateCardTransactionEndpoint     endpoint.Endpoint
+	GetReceiptsEndpoint               endpoint.Endpoint
+	GetReceiptEndpoint                endpoint.Endpoint
+	CreateReceiptEndpoint             endpoint.Endpoint
+	GetTeamExportEndpoint             endpoint.Endpoint
+	GetChartOfAccountsEndpoint        endpoint.Endpoint
+	SetChartOfAccountsEndpoint        endpoint.Endpoint
+	GetTeamSpendEndpoint              endpoint.Endpoint
+	GetBudgetsEndpoint                endpoint.Endpoint
+	GetBudgetEndpoint                 endpoint.Endpoint
+	CreateBudgetEndpoint              endpoint.Endpoint
+	UpdateBudgetEndpoint              endpoint.Endpoint
+	DeleteBudgetEndpoint              endpoint.Endpoint
+	GetBudgetAlertsEndpoint           endpoint.Endpoint
+	GetSpendLimitsEndpoint            endpoint.Endpoint
+	GetSpendLimitEndpoint             endpoint.Endpoint
+	CreateSpendLimitEndpoint          endpoint.Endpoint
+	UpdateSpendLimitEndpoint          endpoint.Endpoint
+	DeleteSpendLimitEndpoint          endpoint.Endpoint
+	GetCardControlsEndpoint           endpoint.Endpoint
+	SetCardControlsEndpoint           endpoint.Endpoint
+	DeleteCardControlsEndpoint        endpoint.Endpoint
+	GetWalletControlsEndpoint         endpoint.Endpoint
+	SetWalletControlsEndpoint         endpoint.Endpoint
+	DeleteWalletControlsEndpoint      endpoint.Endpoint
+	GetFundingSchedulesEndpoint       endpoint.Endpoint
+	GetFundingScheduleEndpoint        endpoint.Endpoint
+	CreateFundingScheduleEndpoint     endpoint.Endpoint
+	UpdateFundingScheduleEndpoint     endpoint.Endpoint
+	DeleteFundingScheduleEndpoint     endpoint.Endpoint
+	GetFundingRunsEndpoint            endpoint.Endpoint
+	GetFundRequestsEndpoint           endpoint.Endpoint
+	GetFundRequestEndpoint            endpoint.Endpoint
+	CreateFundRequestEndpoint         endpoint.Endpoint
+	ApproveFundRequestEndpoint        endpoint.Endpoint
+	RejectFundRequestEndpoint         endpoint.Endpoint
+	CancelFundRequestEndpoint         endpoint.Endpoint
+	GraphQLEndpoint                   endpoint.Endpoint
 }
 
 // New ...
@@ -46,6 +94,16 @@ func New(
 	teamMemberSvc service.TeamMemberService,
 	walletSvc service.WalletService,
 	cardSvc service.CardService,
+	transactionSvc service.TransactionService,
+	analyticsSvc service.AnalyticsService,
+	budgetSvc service.BudgetService,
+	spendLimitSvc service.SpendLimitService,
+	spendControlSvc service.SpendControlService,
+	fundingSvc service.FundingService,
+	fundRequestSvc service.FundRequestService,
+	attachmentSvc service.AttachmentService,
+	exportSvc service.ExportService,
+	schema *graphql.Schema,
 ) Set {
 	return Set{
 		GetHealthCheckEndpoint:            MakeHealthCheckEndpoint(healthSvc),
@@ -60,6 +118,7 @@ func New(
 		UpdateTeamEndpoint:                MakeUpdateTeamEndpoint(teamSvc),
 		DeleteTeamEndpoint:                MakeDeleteTeamEndpoint(teamSvc),
 		CreateTeamMemberEndpoint:          MakeCreateTeamMemberEndpoint(teamMemberSvc),
+		UpdateTeamMemberEndpoint:          MakeUpdateTeamMemberEndpoint(teamMemberSvc),
 		GetTeamMembersEndpoint:            MakeGetTeamMembersEndpoint(teamMemberSvc),
 		DeleteTeamMemberEndpoint:          MakeDeleteTeamMemberEndpoint(teamMemberSvc),
 		DeleteTeamMembersByTeamIDEndpoint: MakeDeleteTeamMembersByTeamIDEndpoint(teamMemberSvc),
@@ -71,11 +130,57 @@ func New(
 		DeleteWalletByIDEndpoint:          MakeDeleteWalletByIDEndpoint(walletSvc),
 		DeleteWalletsByTeamIDEndpoint:     MakeDeleteWalletsByTeamIDEndpoint(walletSvc),
 		DeleteWalletsByUserIDEndpoint:     MakeDeleteWalletsByUserIDEndpoint(walletSvc),
+		GetWalletStatementEndpoint:        MakeGetWalletStatementEndpoint(walletSvc),
 		GetAllCardsEndpoint:               MakeGetAllCardsEndpoint(cardSvc),
 		GetCardEndpoint:                   MakeGetCardEndpoint(cardSvc),
 		CreateCardEndpoint:                MakeCreateCardEndpoint(cardSvc),
 		UpdateCardEndpoint:                MakeUpdateCardEndpoint(cardSvc),
 		DeleteCardByIDEndpoint:            MakeDeleteCardByIDEndpoint(cardSvc),
 		DeleteCardsByWalletIDEndpoint:     MakeDeleteCardsByWalletIDEndpoint(cardSvc),
+		FreezeCardEndpoint:                MakeFreezeCardEndpoint(cardSvc),
+		UnfreezeCardEndpoint:              MakeUnfreezeCardEndpoint(cardSvc),
+		GetCardAuditsEndpoint:             MakeGetCardAuditsEndpoint(cardSvc),
+		GetTransactionsEndpoint:           MakeGetTransactionsEndpoint(transactionSvc),
+		GetTransactionEndpoint:            MakeGetTransactionEndpoint(transactionSvc),
+		UpdateTransactionEndpoint:         MakeUpdateTransactionEndpoint(transactionSvc),
+		CreateTransferEndpoint:            MakeCreateTransferEndpoint(transactionSvc),
+		CreateCardTransactionEndpoint:     MakeCreateCardTransactionEndpoint(transactionSvc),
+		GetReceiptsEndpoint:               MakeGetReceiptsEndpoint(attachmentSvc),
+		GetReceiptEndpoint:                MakeGetReceiptEndpoint(attachmentSvc),
+		CreateReceiptEndpoint:             MakeCreateReceiptEndpoint(attachmentSvc),
+		GetTeamExportEndpoint:             MakeGetTeamExportEndpoint(exportSvc),
+		GetChartOfAccountsEndpoint:        MakeGetChartOfAccountsEndpoint(exportSvc),
+		SetChartOfAccountsEndpoint:        MakeSetChartOfAccountsEndpoint(exportSvc),
+		GetTeamSpendEndpoint:              MakeGetTeamSpendEndpoint(analyticsSvc),
+		GetBudgetsEndpoint:                MakeGetBudgetsEndpoint(budgetSvc),
+		GetBudgetEndpoint:                 MakeGetBudgetEndpoint(budgetSvc),
+		CreateBudgetEndpoint:              MakeCreateBudgetEndpoint(budgetSvc),
+		UpdateBudgetEndpoint:              MakeUpdateBudgetEndpoint(budgetSvc),
+		DeleteBudgetEndpoint:              MakeDeleteBudgetEndpoint(budgetSvc),
+		GetBudgetAlertsEndpoint:           MakeGetBudgetAlertsEndpoint(budgetSvc),
+		GetSpendLimitsEndpoint:            MakeGetSpendLimitsEndpoint(spendLimitSvc),
+		GetSpendLimitEndpoint:             MakeGetSpendLimitEndpoint(spendLimitSvc),
+		CreateSpendLimitEndpoint:          MakeCreateSpendLimitEndpoint(spendLimitSvc),
+		UpdateSpendLimitEndpoint:          MakeUpdateSpendLimitEndpoint(spendLimitSvc),
+		DeleteSpendLimitEndpoint:          MakeDeleteSpendLimitEndpoint(spendLimitSvc),
+		GetCardControlsEndpoint:           MakeGetCardControlsEndpoint(spendControlSvc),
+		SetCardControlsEndpoint:           MakeSetCardControlsEndpoint(spendControlSvc),
+		DeleteCardControlsEndpoint:        MakeDeleteCardControlsEndpoint(spendControlSvc),
+		GetWalletControlsEndpoint:         MakeGetWalletControlsEndpoint(spendControlSvc),
+		SetWalletControlsEndpoint:         MakeSetWalletControlsEndpoint(spendControlSvc),
+		DeleteWalletControlsEndpoint:      MakeDeleteWalletControlsEndpoint(spendControlSvc),
+		GetFundingSchedulesEndpoint:       MakeGetFundingSchedulesEndpoint(fundingSvc),
+		GetFundingScheduleEndpoint:        MakeGetFundingScheduleEndpoint(fundingSvc),
+		CreateFundingScheduleEndpoint:     MakeCreateFundingScheduleEndpoint(fundingSvc),
+		UpdateFundingScheduleEndpoint:     MakeUpdateFundingScheduleEndpoint(fundingSvc),
+		DeleteFundingScheduleEndpoint:     MakeDeleteFundingScheduleEndpoint(fundingSvc),
+		GetFundingRunsEndpoint:            MakeGetFundingRunsEndpoint(fundingSvc),
+		GetFundRequestsEndpoint:           MakeGetFundRequestsEndpoint(fundRequestSvc),
+		GetFundRequestEndpoint:            MakeGetFundRequestEndpoint(fundRequestSvc),
+		CreateFundRequestEndpoint:         MakeCreateFundRequestEndpoint(fundRequestSvc),
+		ApproveFundRequestEndpoint:        MakeApproveFundRequestEndpoint(fundRequestSvc),
+		RejectFundRequestEndpoint:         MakeRejectFundRequestEndpoint(fundRequestSvc),
+		CancelFundRequestEndpoint:         MakeCancelFundRequestEndpoint(fundRequestSvc),
+		GraphQLEndpoint:                   MakeGraphQLEndpoint(schema),
 	}
 }
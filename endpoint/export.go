@@ -0,0 +1,94 @@
+package endpoint
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/service"
+	"gitlab.com/renodesper/spenmo-test/util/export"
+)
+
+type (
+	GetTeamExportRequest struct {
+		ID       uuid.UUID `json:"id" validate:"required"`
+		WalletID uuid.UUID `json:"walletId"`
+		From     time.Time `json:"from" validate:"required"`
+		To       time.Time `json:"to" validate:"required"`
+		Format   string    `json:"format"`
+	}
+
+	// GetTeamExportResponse is not JSON encoded, the transport streams the
+	// export in the requested format
+	GetTeamExportResponse struct {
+		Export *export.Export
+		Format string
+	}
+
+	GetChartOfAccountsRequest struct {
+		TeamID uuid.UUID `json:"teamId" validate:"required"`
+	}
+
+	SetChartOfAccountsRequest struct {
+		TeamID          uuid.UUID         `json:"teamId" validate:"required"`
+		WalletAccount   string            `json:"walletAccount"`
+		ExpenseAccount  string            `json:"expenseAccount"`
+		IncomeAccount   string            `json:"incomeAccount"`
+		TransferAccount string            `json:"transferAccount"`
+		Categories      map[string]string `json:"categories"`
+	}
+)
+
+func MakeGetTeamExportEndpoint(exportSvc service.ExportService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(GetTeamExportRequest)
+
+		exportReq := service.GetExportRequest{
+			TeamID:   req.ID,
+			WalletID: req.WalletID,
+			From:     req.From,
+			To:       req.To,
+			Format:   req.Format,
+		}
+
+		teamExport, err := exportSvc.GetExport(ctx, &exportReq)
+		if err != nil {
+			return nil, err
+		}
+
+		response = GetTeamExportResponse{
+			Export: teamExport,
+			Format: req.Format,
+		}
+
+		return response, nil
+	}
+}
+
+func MakeGetChartOfAccountsEndpoint(exportSvc service.ExportService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(GetChartOfAccountsRequest)
+
+		chart, err := exportSvc.GetChartOfAccounts(ctx, req.TeamID)
+		return chart, err
+	}
+}
+
+func MakeSetChartOfAccountsEndpoint(exportSvc service.ExportService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(SetChartOfAccountsRequest)
+
+		chartReq := service.SetChartOfAccountsRequest{
+			TeamID:          req.TeamID,
+			WalletAccount:   req.WalletAccount,
+			ExpenseAccount:  req.ExpenseAccount,
+			IncomeAccount:   req.IncomeAccount,
+			TransferAccount: req.TransferAccount,
+			Categories:      req.Categories,
+		}
+
+		chart, err := exportSvc.SetChartOfAccounts(ctx, &chartReq)
+		return chart, err
+	}
+}
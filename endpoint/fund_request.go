@@ -0,0 +1,142 @@
+package endpoint
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/service"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+type (
+	GetFundRequestsRequest struct {
+		TeamID      uuid.UUID `json:"teamId"`
+		RequesterID uuid.UUID `json:"requesterId"`
+		Status      string    `json:"status" validate:"omitempty,oneof=pending approved rejected cancelled"`
+		SortBy      string    `json:"sortBy"`
+		Sort        string    `json:"sort"`
+		Skip        int       `json:"skip" validate:"min=0"`
+		Limit       int       `json:"limit" validate:"min=1,max=100"`
+	}
+
+	GetFundRequestRequest struct {
+		ID uuid.UUID `json:"id" validate:"required"`
+	}
+
+	CreateFundRequestRequest struct {
+		TeamID   uuid.UUID     `json:"teamId" validate:"required"`
+		WalletID uuid.UUID     `json:"walletId" validate:"required"`
+		Amount   money.Decimal `json:"amount"`
+		Reason   string        `json:"reason" validate:"required"`
+	}
+
+	ApproveFundRequestRequest struct {
+		ID             uuid.UUID `json:"id" validate:"required"`
+		SourceWalletID uuid.UUID `json:"sourceWalletId" validate:"required"`
+		Note           string    `json:"note"`
+	}
+
+	RejectFundRequestRequest struct {
+		ID   uuid.UUID `json:"id" validate:"required"`
+		Note string    `json:"note"`
+	}
+
+	CancelFundRequestRequest struct {
+		ID uuid.UUID `json:"id" validate:"required"`
+	}
+)
+
+func MakeGetFundRequestsEndpoint(fundRequestSvc service.FundRequestService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(GetFundRequestsRequest)
+
+		fundRequestsReq := service.GetFundRequestsRequest{
+			TeamID:      req.TeamID,
+			RequesterID: req.RequesterID,
+			Status:      req.Status,
+			SortBy:      req.SortBy,
+			Sort:        req.Sort,
+			Skip:        req.Skip,
+			Limit:       req.Limit,
+		}
+
+		fundRequests, err := fundRequestSvc.GetFundRequests(ctx, &fundRequestsReq)
+		if err != nil {
+			return nil, err
+		}
+
+		response = map[string]interface{}{
+			"fundRequests": fundRequests,
+			"pagination": map[string]interface{}{
+				"sortBy": req.SortBy,
+				"sort":   req.Sort,
+				"skip":   req.Skip,
+				"limit":  req.Limit,
+			},
+		}
+
+		return response, nil
+	}
+}
+
+func MakeGetFundRequestEndpoint(fundRequestSvc service.FundRequestService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(GetFundRequestRequest)
+
+		fundRequest, err := fundRequestSvc.GetFundRequest(ctx, req.ID)
+		return fundRequest, err
+	}
+}
+
+func MakeCreateFundRequestEndpoint(fundRequestSvc service.FundRequestService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(CreateFundRequestRequest)
+
+		fundRequestReq := service.CreateFundRequestRequest{
+			TeamID:   req.TeamID,
+			WalletID: req.WalletID,
+			Amount:   req.Amount,
+			Reason:   req.Reason,
+		}
+
+		fundRequest, err := fundRequestSvc.CreateFundRequest(ctx, &fundRequestReq)
+		return fundRequest, err
+	}
+}
+
+func MakeApproveFundRequestEndpoint(fundRequestSvc service.FundRequestService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(ApproveFundRequestRequest)
+
+		fundRequestReq := service.ApproveFundRequestRequest{
+			SourceWalletID: req.SourceWalletID,
+			Note:           req.Note,
+		}
+
+		fundRequest, err := fundRequestSvc.ApproveFundRequest(ctx, req.ID, &fundRequestReq)
+		return fundRequest, err
+	}
+}
+
+func MakeRejectFundRequestEndpoint(fundRequestSvc service.FundRequestService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(RejectFundRequestRequest)
+
+		fundRequestReq := service.RejectFundRequestRequest{
+			Note: req.Note,
+		}
+
+		fundRequest, err := fundRequestSvc.RejectFundRequest(ctx, req.ID, &fundRequestReq)
+		return fundRequest, err
+	}
+}
+
+func MakeCancelFundRequestEndpoint(fundRequestSvc service.FundRequestService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(CancelFundRequestRequest)
+
+		fundRequest, err := fundRequestSvc.CancelFundRequest(ctx, req.ID)
+		return fundRequest, err
+	}
+}
@@ -0,0 +1,155 @@
+package endpoint
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/service"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+type (
+	GetFundingSchedulesRequest struct {
+		TeamID uuid.UUID `json:"teamId"`
+		SortBy string    `json:"sortBy"`
+		Sort   string    `json:"sort"`
+		Skip   int       `json:"skip" validate:"min=0"`
+		Limit  int       `json:"limit" validate:"min=1,max=100"`
+	}
+
+	GetFundingScheduleRequest struct {
+		ID uuid.UUID `json:"id" validate:"required"`
+	}
+
+	CreateFundingScheduleRequest struct {
+		SourceWalletID uuid.UUID     `json:"sourceWalletId" validate:"required"`
+		TargetWalletID uuid.UUID     `json:"targetWalletId" validate:"required"`
+		Mode           string        `json:"mode" validate:"omitempty,oneof=top_up reset"`
+		Amount         money.Decimal `json:"amount"`
+		Cron           string        `json:"cron"`
+		DayOfMonth     int           `json:"dayOfMonth" validate:"omitempty,min=1,max=31"`
+	}
+
+	UpdateFundingScheduleRequest struct {
+		ID         uuid.UUID     `json:"id" validate:"required"`
+		Mode       string        `json:"mode" validate:"omitempty,oneof=top_up reset"`
+		Amount     money.Decimal `json:"amount"`
+		Cron       string        `json:"cron"`
+		DayOfMonth int           `json:"dayOfMonth" validate:"omitempty,min=1,max=31"`
+	}
+
+	DeleteFundingScheduleRequest struct {
+		ID uuid.UUID `json:"id" validate:"required"`
+	}
+
+	GetFundingRunsRequest struct {
+		ID    uuid.UUID `json:"id" validate:"required"`
+		Skip  int       `json:"skip" validate:"min=0"`
+		Limit int       `json:"limit" validate:"min=1,max=100"`
+	}
+)
+
+func MakeGetFundingSchedulesEndpoint(fundingSvc service.FundingService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(GetFundingSchedulesRequest)
+
+		schedulesReq := service.GetFundingSchedulesRequest{
+			TeamID: req.TeamID,
+			SortBy: req.SortBy,
+			Sort:   req.Sort,
+			Skip:   req.Skip,
+			Limit:  req.Limit,
+		}
+
+		schedules, err := fundingSvc.GetFundingSchedules(ctx, &schedulesReq)
+		if err != nil {
+			return nil, err
+		}
+
+		response = map[string]interface{}{
+			"fundingSchedules": schedules,
+			"pagination": map[string]interface{}{
+				"sortBy": req.SortBy,
+				"sort":   req.Sort,
+				"skip":   req.Skip,
+				"limit":  req.Limit,
+			},
+		}
+
+		return response, nil
+	}
+}
+
+func MakeGetFundingScheduleEndpoint(fundingSvc service.FundingService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(GetFundingScheduleRequest)
+
+		fundingSchedule, err := fundingSvc.GetFundingSchedule(ctx, req.ID)
+		return fundingSchedule, err
+	}
+}
+
+func MakeCreateFundingScheduleEndpoint(fundingSvc service.FundingService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(CreateFundingScheduleRequest)
+
+		scheduleReq := service.CreateFundingScheduleRequest{
+			SourceWalletID: req.SourceWalletID,
+			TargetWalletID: req.TargetWalletID,
+			Mode:           req.Mode,
+			Amount:         req.Amount,
+			Cron:           req.Cron,
+			DayOfMonth:     req.DayOfMonth,
+		}
+
+		fundingSchedule, err := fundingSvc.CreateFundingSchedule(ctx, &scheduleReq)
+		return fundingSchedule, err
+	}
+}
+
+func MakeUpdateFundingScheduleEndpoint(fundingSvc service.FundingService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(UpdateFundingScheduleRequest)
+
+		scheduleReq := service.UpdateFundingScheduleRequest{
+			Mode:       req.Mode,
+			Amount:     req.Amount,
+			Cron:       req.Cron,
+			DayOfMonth: req.DayOfMonth,
+		}
+
+		fundingSchedule, err := fundingSvc.UpdateFundingSchedule(ctx, req.ID, &scheduleReq)
+		return fundingSchedule, err
+	}
+}
+
+func MakeDeleteFundingScheduleEndpoint(fundingSvc service.FundingService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(DeleteFundingScheduleRequest)
+
+		fundingSchedule, err := fundingSvc.DeleteFundingSchedule(ctx, req.ID)
+		return fundingSchedule, err
+	}
+}
+
+func MakeGetFundingRunsEndpoint(fundingSvc service.FundingService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(GetFundingRunsRequest)
+
+		runs, err := fundingSvc.GetFundingRuns(ctx, req.ID, req.Skip, req.Limit)
+		if err != nil {
+			return nil, err
+		}
+
+		response = map[string]interface{}{
+			"fundingRuns": runs,
+			"pagination": map[string]interface{}{
+				"skip":  req.Skip,
+				"limit": req.Limit,
+			},
+		}
+
+		return response, nil
+	}
+}
@@ -0,0 +1,25 @@
+package endpoint
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"gitlab.com/renodesper/spenmo-test/graphql"
+)
+
+type GraphQLRequest struct {
+	Query         string                 `json:"query" validate:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// MakeGraphQLEndpoint answers with a *graphql.Result, the errors of the query
+// are part of the result
+func MakeGraphQLEndpoint(schema *graphql.Schema) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(GraphQLRequest)
+
+		result := schema.Execute(ctx, req.Query, req.OperationName, req.Variables)
+		return result, nil
+	}
+}
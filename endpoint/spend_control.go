@@ -0,0 +1,98 @@
+package endpoint
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/service"
+)
+
+type (
+	GetSpendControlsRequest struct {
+		ID uuid.UUID `json:"id" validate:"required"`
+	}
+
+	SetSpendControlsRequest struct {
+		ID               uuid.UUID `json:"id" validate:"required"`
+		AllowedMCCs      []string  `json:"allowedMccs"`
+		BlockedMCCs      []string  `json:"blockedMccs"`
+		AllowedMerchants []string  `json:"allowedMerchants"`
+		BlockedMerchants []string  `json:"blockedMerchants"`
+		AllowedCountries []string  `json:"allowedCountries"`
+		BlockedCountries []string  `json:"blockedCountries"`
+	}
+
+	DeleteSpendControlsRequest struct {
+		ID uuid.UUID `json:"id" validate:"required"`
+	}
+)
+
+func MakeGetCardControlsEndpoint(spendControlSvc service.SpendControlService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(GetSpendControlsRequest)
+
+		controls, err := spendControlSvc.GetCardControls(ctx, req.ID)
+		return controls, err
+	}
+}
+
+func MakeSetCardControlsEndpoint(spendControlSvc service.SpendControlService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(SetSpendControlsRequest)
+
+		controlsReq := newSetSpendControlsRequest(req)
+
+		controls, err := spendControlSvc.SetCardControls(ctx, req.ID, &controlsReq)
+		return controls, err
+	}
+}
+
+func MakeDeleteCardControlsEndpoint(spendControlSvc service.SpendControlService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(DeleteSpendControlsRequest)
+
+		controls, err := spendControlSvc.DeleteCardControls(ctx, req.ID)
+		return controls, err
+	}
+}
+
+func MakeGetWalletControlsEndpoint(spendControlSvc service.SpendControlService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(GetSpendControlsRequest)
+
+		controls, err := spendControlSvc.GetWalletControls(ctx, req.ID)
+		return controls, err
+	}
+}
+
+func MakeSetWalletControlsEndpoint(spendControlSvc service.SpendControlService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(SetSpendControlsRequest)
+
+		controlsReq := newSetSpendControlsRequest(req)
+
+		controls, err := spendControlSvc.SetWalletControls(ctx, req.ID, &controlsReq)
+		return controls, err
+	}
+}
+
+func MakeDeleteWalletControlsEndpoint(spendControlSvc service.SpendControlService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(DeleteSpendControlsRequest)
+
+		controls, err := spendControlSvc.DeleteWalletControls(ctx, req.ID)
+		return controls, err
+	}
+}
+
+func newSetSpendControlsRequest(req SetSpendControlsRequest) service.SetSpendControlsRequest {
+	return service.SetSpendControlsRequest{
+		AllowedMCCs:      req.AllowedMCCs,
+		BlockedMCCs:      req.BlockedMCCs,
+		AllowedMerchants: req.AllowedMerchants,
+		BlockedMerchants: req.BlockedMerchants,
+		AllowedCountries: req.AllowedCountries,
+		BlockedCountries: req.BlockedCountries,
+	}
+}
@@ -0,0 +1,124 @@
+package endpoint
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/service"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+type (
+	GetSpendLimitsRequest struct {
+		WalletID uuid.UUID `json:"walletId"`
+		CardID   uuid.UUID `json:"cardId"`
+		SortBy   string    `json:"sortBy"`
+		Sort     string    `json:"sort"`
+		Skip     int       `json:"skip" validate:"min=0"`
+		Limit    int       `json:"limit" validate:"min=1,max=100"`
+	}
+
+	GetSpendLimitRequest struct {
+		ID uuid.UUID `json:"id" validate:"required"`
+	}
+
+	CreateSpendLimitRequest struct {
+		WalletID   uuid.UUID     `json:"walletId"`
+		CardID     uuid.UUID     `json:"cardId"`
+		Period     string        `json:"period" validate:"required,oneof=transaction daily weekly monthly yearly lifetime"`
+		WindowType string        `json:"windowType" validate:"omitempty,oneof=calendar rolling"`
+		Amount     money.Decimal `json:"amount"`
+	}
+
+	UpdateSpendLimitRequest struct {
+		ID         uuid.UUID     `json:"id" validate:"required"`
+		WindowType string        `json:"windowType" validate:"omitempty,oneof=calendar rolling"`
+		Amount     money.Decimal `json:"amount"`
+	}
+
+	DeleteSpendLimitRequest struct {
+		ID uuid.UUID `json:"id" validate:"required"`
+	}
+)
+
+func MakeGetSpendLimitsEndpoint(spendLimitSvc service.SpendLimitService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(GetSpendLimitsRequest)
+
+		spendLimitsReq := service.GetSpendLimitsRequest{
+			WalletID: req.WalletID,
+			CardID:   req.CardID,
+			SortBy:   req.SortBy,
+			Sort:     req.Sort,
+			Skip:     req.Skip,
+			Limit:    req.Limit,
+		}
+
+		spendLimits, err := spendLimitSvc.GetSpendLimits(ctx, &spendLimitsReq)
+		if err != nil {
+			return nil, err
+		}
+
+		response = map[string]interface{}{
+			"spendLimits": spendLimits,
+			"pagination": map[string]interface{}{
+				"sortBy": req.SortBy,
+				"sort":   req.Sort,
+				"skip":   req.Skip,
+				"limit":  req.Limit,
+			},
+		}
+
+		return response, nil
+	}
+}
+
+func MakeGetSpendLimitEndpoint(spendLimitSvc service.SpendLimitService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(GetSpendLimitRequest)
+
+		spendLimit, err := spendLimitSvc.GetSpendLimit(ctx, req.ID)
+		return spendLimit, err
+	}
+}
+
+func MakeCreateSpendLimitEndpoint(spendLimitSvc service.SpendLimitService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(CreateSpendLimitRequest)
+
+		spendLimitReq := service.CreateSpendLimitRequest{
+			WalletID:   req.WalletID,
+			CardID:     req.CardID,
+			Period:     req.Period,
+			WindowType: req.WindowType,
+			Amount:     req.Amount,
+		}
+
+		spendLimit, err := spendLimitSvc.CreateSpendLimit(ctx, &spendLimitReq)
+		return spendLimit, err
+	}
+}
+
+func MakeUpdateSpendLimitEndpoint(spendLimitSvc service.SpendLimitService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(UpdateSpendLimitRequest)
+
+		spendLimitReq := service.UpdateSpendLimitRequest{
+			WindowType: req.WindowType,
+			Amount:     req.Amount,
+		}
+
+		spendLimit, err := spendLimitSvc.UpdateSpendLimit(ctx, req.ID, &spendLimitReq)
+		return spendLimit, err
+	}
+}
+
+func MakeDeleteSpendLimitEndpoint(spendLimitSvc service.SpendLimitService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(DeleteSpendLimitRequest)
+
+		spendLimit, err := spendLimitSvc.DeleteSpendLimit(ctx, req.ID)
+		return spendLimit, err
+	}
+}
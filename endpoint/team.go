@@ -10,14 +10,15 @@ import (
 
 type (
 	CreateTeamRequest struct {
-		Name string `json:"name" validate:"required"`
+		Name     string `json:"name" validate:"required"`
+		Timezone string `json:"timezone"`
 	}
 
 	GetAllTeamsRequest struct {
 		SortBy string `json:"sortBy"`
 		Sort   string `json:"sort"`
-		Skip   int    `json:"skip"`
-		Limit  int    `json:"limit"`
+		Skip   int    `json:"skip" validate:"min=0"`
+		Limit  int    `json:"limit" validate:"min=1,max=100"`
 	}
 
 	GetTeamRequest struct {
@@ -25,8 +26,9 @@ type (
 	}
 
 	UpdateTeamRequest struct {
-		ID   uuid.UUID `json:"id" validate:"required"`
-		Name string
+		ID       uuid.UUID `json:"id" validate:"required"`
+		Name     string
+		Timezone string `json:"timezone"`
 	}
 
 	DeleteTeamRequest struct {
@@ -39,7 +41,8 @@ func MakeCreateTeamEndpoint(teamSvc service.TeamService) endpoint.Endpoint {
 		req := request.(CreateTeamRequest)
 
 		teamReq := service.CreateTeamRequest{
-			Name: req.Name,
+			Name:     req.Name,
+			Timezone: req.Timezone,
 		}
 
 		team, err := teamSvc.CreateTeam(ctx, &teamReq)
@@ -84,7 +87,8 @@ func MakeUpdateTeamEndpoint(teamSvc service.TeamService) endpoint.Endpoint {
 		req := request.(UpdateTeamRequest)
 
 		teamPayload := service.UpdateTeamRequest{
-			Name: req.Name,
+			Name:     req.Name,
+			Timezone: req.Timezone,
 		}
 
 		team, err := teamSvc.UpdateTeam(ctx, req.ID, &teamPayload)
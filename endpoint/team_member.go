@@ -14,13 +14,20 @@ type (
 		UserID uuid.UUID `json:"userId"`
 		SortBy string    `json:"sortBy"`
 		Sort   string    `json:"sort"`
-		Skip   int       `json:"skip"`
-		Limit  int       `json:"limit"`
+		Skip   int       `json:"skip" validate:"min=0"`
+		Limit  int       `json:"limit" validate:"min=1,max=100"`
 	}
 
 	CreateTeamMemberRequest struct {
 		TeamID uuid.UUID `json:"teamId" validate:"required"`
 		UserID uuid.UUID `json:"userId" validate:"required"`
+		Role   string    `json:"role" validate:"omitempty,oneof=member admin"`
+	}
+
+	UpdateTeamMemberRequest struct {
+		TeamID uuid.UUID `json:"teamId" validate:"required"`
+		UserID uuid.UUID `json:"userId" validate:"required"`
+		Role   string    `json:"role" validate:"required,oneof=member admin"`
 	}
 
 	DeleteTeamMemberRequest struct {
@@ -76,6 +83,7 @@ func MakeCreateTeamMemberEndpoint(teamMemberSvc service.TeamMemberService) endpo
 		teamReq := service.CreateTeamMemberRequest{
 			TeamID: req.TeamID,
 			UserID: req.UserID,
+			Role:   req.Role,
 		}
 
 		teamMember, err := teamMemberSvc.CreateTeamMember(ctx, &teamReq)
@@ -83,6 +91,21 @@ func MakeCreateTeamMemberEndpoint(teamMemberSvc service.TeamMemberService) endpo
 	}
 }
 
+func MakeUpdateTeamMemberEndpoint(teamMemberSvc service.TeamMemberService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(UpdateTeamMemberRequest)
+
+		teamReq := service.UpdateTeamMemberRequest{
+			TeamID: req.TeamID,
+			UserID: req.UserID,
+			Role:   req.Role,
+		}
+
+		teamMember, err := teamMemberSvc.UpdateTeamMember(ctx, &teamReq)
+		return teamMember, err
+	}
+}
+
 func MakeDeleteTeamMemberEndpoint(teamMemberSvc service.TeamMemberService) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		req := request.(DeleteTeamMemberRequest)
@@ -0,0 +1,150 @@
+package endpoint
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/service"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+type (
+	GetTransactionsRequest struct {
+		WalletID       uuid.UUID `json:"walletId"`
+		CardID         uuid.UUID `json:"cardId"`
+		Category       string    `json:"category"`
+		Tag            string    `json:"tag"`
+		MissingReceipt bool      `json:"missingReceipt"`
+		SortBy         string    `json:"sortBy"`
+		Sort           string    `json:"sort"`
+		Skip           int       `json:"skip" validate:"min=0"`
+		Limit          int       `json:"limit" validate:"min=1,max=100"`
+	}
+
+	GetTransactionRequest struct {
+		ID uuid.UUID `json:"id" validate:"required"`
+	}
+
+	UpdateTransactionRequest struct {
+		ID       uuid.UUID `json:"id" validate:"required"`
+		Memo     string    `json:"memo" validate:"max=500"`
+		Category string    `json:"category" validate:"max=64"`
+		Tags     []string  `json:"tags" validate:"max=10,dive,max=64"`
+	}
+
+	CreateTransferRequest struct {
+		FromWalletID uuid.UUID     `json:"fromWalletId" validate:"required"`
+		ToWalletID   uuid.UUID     `json:"toWalletId" validate:"required"`
+		Amount       money.Decimal `json:"amount"`
+		Currency     string        `json:"currency"`
+		Description  string        `json:"description"`
+	}
+
+	CreateCardTransactionRequest struct {
+		CardID      uuid.UUID     `json:"cardId" validate:"required"`
+		Amount      money.Decimal `json:"amount"`
+		Currency    string        `json:"currency"`
+		Merchant    string        `json:"merchant"`
+		MerchantID  string        `json:"merchantId"`
+		MCC         string        `json:"mcc" validate:"omitempty,len=4,numeric"`
+		Country     string        `json:"country" validate:"omitempty,len=2,alpha"`
+		Description string        `json:"description"`
+	}
+)
+
+func MakeGetTransactionsEndpoint(transactionSvc service.TransactionService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(GetTransactionsRequest)
+
+		transactionsReq := service.GetTransactionsRequest{
+			WalletID:       req.WalletID,
+			CardID:         req.CardID,
+			Category:       req.Category,
+			Tag:            req.Tag,
+			MissingReceipt: req.MissingReceipt,
+			SortBy:         req.SortBy,
+			Sort:           req.Sort,
+			Skip:           req.Skip,
+			Limit:          req.Limit,
+		}
+
+		transactions, err := transactionSvc.GetTransactions(ctx, &transactionsReq)
+		if err != nil {
+			return nil, err
+		}
+
+		response = map[string]interface{}{
+			"transactions": transactions,
+			"pagination": map[string]interface{}{
+				"sortBy": req.SortBy,
+				"sort":   req.Sort,
+				"skip":   req.Skip,
+				"limit":  req.Limit,
+			},
+		}
+
+		return response, nil
+	}
+}
+
+func MakeGetTransactionEndpoint(transactionSvc service.TransactionService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(GetTransactionRequest)
+
+		transaction, err := transactionSvc.GetTransaction(ctx, req.ID)
+		return transaction, err
+	}
+}
+
+func MakeUpdateTransactionEndpoint(transactionSvc service.TransactionService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(UpdateTransactionRequest)
+
+		transactionReq := service.UpdateTransactionRequest{
+			Memo:     req.Memo,
+			Category: req.Category,
+			Tags:     req.Tags,
+		}
+
+		transaction, err := transactionSvc.UpdateTransaction(ctx, req.ID, &transactionReq)
+		return transaction, err
+	}
+}
+
+func MakeCreateTransferEndpoint(transactionSvc service.TransactionService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(CreateTransferRequest)
+
+		transferReq := service.CreateTransferRequest{
+			FromWalletID: req.FromWalletID,
+			ToWalletID:   req.ToWalletID,
+			Amount:       req.Amount,
+			Currency:     req.Currency,
+			Description:  req.Description,
+		}
+
+		transactions, err := transactionSvc.CreateTransfer(ctx, &transferReq)
+		return transactions, err
+	}
+}
+
+func MakeCreateCardTransactionEndpoint(transactionSvc service.TransactionService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(CreateCardTransactionRequest)
+
+		transactionReq := service.CreateCardTransactionRequest{
+			CardID:      req.CardID,
+			Amount:      req.Amount,
+			Currency:    req.Currency,
+			Merchant:    req.Merchant,
+			MerchantID:  req.MerchantID,
+			MCC:         req.MCC,
+			Country:     req.Country,
+			Description: req.Description,
+		}
+
+		transaction, err := transactionSvc.CreateCardTransaction(ctx, &transactionReq)
+		return transaction, err
+	}
+}
@@ -17,8 +17,8 @@ type (
 	GetAllUsersRequest struct {
 		SortBy string `json:"sortBy"`
 		Sort   string `json:"sort"`
-		Skip   int    `json:"skip"`
-		Limit  int    `json:"limit"`
+		Skip   int    `json:"skip" validate:"min=0"`
+		Limit  int    `json:"limit" validate:"min=1,max=100"`
 	}
 
 	GetUserRequest struct {
@@ -27,8 +27,8 @@ type (
 
 	UpdateUserRequest struct {
 		ID    uuid.UUID `json:"id" validate:"required"`
-		Email string
-		Name  string
+		Email string    `json:"email" validate:"omitempty,email"`
+		Name  string    `json:"name"`
 	}
 
 	DeleteUserRequest struct {
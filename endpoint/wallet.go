@@ -2,23 +2,27 @@ package endpoint
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-kit/kit/endpoint"
 	"github.com/google/uuid"
 	"gitlab.com/renodesper/spenmo-test/service"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+	"gitlab.com/renodesper/spenmo-test/util/statement"
 )
 
 type (
 	CreateWalletRequest struct {
-		TeamID uuid.UUID `json:"teamId"`
-		UserID uuid.UUID `json:"userId"`
+		TeamID   uuid.UUID `json:"teamId"`
+		UserID   uuid.UUID `json:"userId"`
+		Currency string    `json:"currency"`
 	}
 
 	GetAllWalletsRequest struct {
 		SortBy string `json:"sortBy"`
 		Sort   string `json:"sort"`
-		Skip   int    `json:"skip"`
-		Limit  int    `json:"limit"`
+		Skip   int    `json:"skip" validate:"min=0"`
+		Limit  int    `json:"limit" validate:"min=1,max=100"`
 	}
 
 	GetWalletRequest struct {
@@ -26,13 +30,14 @@ type (
 	}
 
 	UpdateWalletRequest struct {
-		ID              uuid.UUID `json:"id" validate:"required"`
-		TeamID          uuid.UUID `json:"teamId"`
-		UserID          uuid.UUID `json:"userId"`
-		BalanceIncrease uint64    `json:"balanceIncrease"`
-		BalanceDecrease uint64    `json:"balanceDecrease"`
-		DailyLimit      uint64    `json:"dailyLimit"`
-		MonthlyLimit    uint64    `json:"monthlyLimit"`
+		ID              uuid.UUID     `json:"id" validate:"required"`
+		TeamID          uuid.UUID     `json:"teamId"`
+		UserID          uuid.UUID     `json:"userId"`
+		BalanceIncrease money.Decimal `json:"balanceIncrease"`
+		BalanceDecrease money.Decimal `json:"balanceDecrease"`
+		DailyLimit      money.Decimal `json:"dailyLimit"`
+		MonthlyLimit    money.Decimal `json:"monthlyLimit"`
+		Currency        string        `json:"currency"`
 	}
 
 	DeleteWalletByIDRequest struct {
@@ -46,6 +51,20 @@ type (
 	DeleteWalletsByUserIDRequest struct {
 		UserID uuid.UUID `json:"userId" validate:"required"`
 	}
+
+	GetWalletStatementRequest struct {
+		ID     uuid.UUID `json:"id" validate:"required"`
+		From   time.Time `json:"from" validate:"required"`
+		To     time.Time `json:"to" validate:"required"`
+		Format string    `json:"format"`
+	}
+
+	// GetWalletStatementResponse is not JSON encoded, the transport streams the
+	// statement in the requested format
+	GetWalletStatementResponse struct {
+		Statement *statement.Statement
+		Format    string
+	}
 )
 
 func MakeCreateWalletEndpoint(walletSvc service.WalletService) endpoint.Endpoint {
@@ -53,8 +72,9 @@ func MakeCreateWalletEndpoint(walletSvc service.WalletService) endpoint.Endpoint
 		req := request.(CreateWalletRequest)
 
 		walletReq := service.CreateWalletRequest{
-			TeamID: req.TeamID,
-			UserID: req.UserID,
+			TeamID:   req.TeamID,
+			UserID:   req.UserID,
+			Currency: req.Currency,
 		}
 
 		wallet, err := walletSvc.CreateWallet(ctx, &walletReq)
@@ -103,6 +123,7 @@ func MakeUpdateWalletEndpoint(walletSvc service.WalletService) endpoint.Endpoint
 			BalanceDecrease: req.BalanceDecrease,
 			DailyLimit:      req.DailyLimit,
 			MonthlyLimit:    req.MonthlyLimit,
+			Currency:        req.Currency,
 			TeamID:          req.TeamID,
 			UserID:          req.UserID,
 		}
@@ -138,3 +159,27 @@ func MakeDeleteWalletsByUserIDEndpoint(walletSvc service.WalletService) endpoint
 		return wallet, err
 	}
 }
+
+func MakeGetWalletStatementEndpoint(walletSvc service.WalletService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(GetWalletStatementRequest)
+
+		statementReq := service.GetStatementRequest{
+			WalletID: req.ID,
+			From:     req.From,
+			To:       req.To,
+		}
+
+		walletStatement, err := walletSvc.GetStatement(ctx, &statementReq)
+		if err != nil {
+			return nil, err
+		}
+
+		response = GetWalletStatementResponse{
+			Statement: walletStatement,
+			Format:    req.Format,
+		}
+
+		return response, nil
+	}
+}
@@ -3,6 +3,7 @@ module gitlab.com/renodesper/spenmo-test
 go 1.16
 
 require (
+	github.com/getkin/kin-openapi v0.80.0
 	github.com/go-errors/errors v1.1.1
 	github.com/go-kit/kit v0.10.0
 	github.com/go-pg/pg/v10 v10.9.1
@@ -11,18 +12,27 @@ require (
 	github.com/go-zoo/bone v1.3.0
 	github.com/golang-migrate/migrate/v4 v4.15.0
 	github.com/google/uuid v1.2.0
+	github.com/graphql-go/graphql v0.8.0
 	github.com/iancoleman/strcase v0.1.3
 	github.com/json-iterator/go v1.1.10
-	github.com/juju/ratelimit v1.0.1
 	github.com/leodido/go-urn v1.2.0 // indirect
+	github.com/modern-go/reflect2 v1.0.2 // indirect
 	github.com/onsi/ginkgo v1.15.0 // indirect
 	github.com/onsi/gomega v1.10.5 // indirect
 	github.com/prometheus/client_golang v1.3.0
 	github.com/rs/cors v1.7.0
 	github.com/spf13/viper v1.7.1
-	github.com/stretchr/testify v1.7.0
+	github.com/stretchr/testify v1.7.1
+	go.opentelemetry.io/otel v1.7.0
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc v1.7.0
+	go.opentelemetry.io/otel/exporters/stdout/stdouttrace v1.7.0
+	go.opentelemetry.io/otel/sdk v1.7.0
+	go.opentelemetry.io/otel/trace v1.7.0
 	go.uber.org/zap v1.16.0
 	golang.org/x/crypto v0.0.0-20210616213533-5ff15b29337e // indirect
 	golang.org/x/net v0.0.0-20210614182718-04defd469f4e // indirect
+	google.golang.org/genproto v0.0.0-20211118181313-81c1377c94b1
+	google.golang.org/grpc v1.46.0
+	google.golang.org/protobuf v1.28.0
 	gopkg.in/go-playground/assert.v1 v1.2.1 // indirect
 )
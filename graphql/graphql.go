@@ -0,0 +1,136 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"gitlab.com/renodesper/spenmo-test/service"
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+)
+
+type (
+	// Schema serves users, teams, team members, wallets and cards over
+	// GraphQL. The nested fields of a level are fetched together, a query
+	// costs one call per relation and level whatever the number of parents.
+	Schema struct {
+		schema gql.Schema
+		limits Limits
+
+		userSvc       service.UserService
+		teamSvc       service.TeamService
+		teamMemberSvc service.TeamMemberService
+		walletSvc     service.WalletService
+		cardSvc       service.CardService
+
+		userType       *gql.Object
+		teamType       *gql.Object
+		teamMemberType *gql.Object
+		walletType     *gql.Object
+		cardType       *gql.Object
+	}
+
+	// resolverError exposes the code and the status of an e.Error as the
+	// extensions of the GraphQL error
+	resolverError struct {
+		err e.Error
+	}
+)
+
+// NewSchema creates the GraphQL schema
+func NewSchema(limits Limits, userSvc service.UserService, teamSvc service.TeamService, teamMemberSvc service.TeamMemberService, walletSvc service.WalletService, cardSvc service.CardService) (*Schema, error) {
+	s := &Schema{
+		limits:        limits,
+		userSvc:       userSvc,
+		teamSvc:       teamSvc,
+		teamMemberSvc: teamMemberSvc,
+		walletSvc:     walletSvc,
+		cardSvc:       cardSvc,
+	}
+
+	s.userType = s.newUserType()
+	s.teamType = s.newTeamType()
+	s.teamMemberType = s.newTeamMemberType()
+	s.walletType = s.newWalletType()
+	s.cardType = s.newCardType()
+
+	schema, err := gql.NewSchema(gql.SchemaConfig{
+		Query: s.newQueryType(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.schema = schema
+
+	return s, nil
+}
+
+// Execute validates query against the schema and its limits, then resolves it
+func (s *Schema) Execute(ctx context.Context, query string, operationName string, variables map[string]interface{}) *gql.Result {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return &gql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	validation := gql.ValidateDocument(&s.schema, doc, nil)
+	if !validation.IsValid {
+		return &gql.Result{Errors: validation.Errors}
+	}
+
+	if err := s.limits.check(doc, operationName, variables); err != nil {
+		er := resolverError{err.(e.Error)}
+		return &gql.Result{Errors: []gqlerrors.FormattedError{{
+			Message:    er.Error(),
+			Locations:  []location.SourceLocation{},
+			Extensions: er.Extensions(),
+		}}}
+	}
+
+	return gql.Execute(gql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           doc,
+		OperationName: operationName,
+		Args:          variables,
+		Context:       withLoaders(ctx, s.newLoaders()),
+	})
+}
+
+func (r resolverError) Error() string {
+	return r.err.Error()
+}
+
+func (r resolverError) Extensions() map[string]interface{} {
+	return map[string]interface{}{
+		"code":   r.err.Code,
+		"status": r.err.Status,
+	}
+}
+
+// wrapError keeps the code of the errors of the services
+func wrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if er, ok := err.(e.Error); ok {
+		return resolverError{er}
+	}
+
+	return err
+}
+
+func parseID(args map[string]interface{}) (uuid.UUID, error) {
+	ID, err := uuid.Parse(args["id"].(string))
+	if err != nil {
+		return uuid.Nil, wrapError(errors.UnparsableUUID)
+	}
+
+	return ID, nil
+}
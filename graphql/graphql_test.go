@@ -0,0 +1,234 @@
+package graphql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/service"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+type (
+	fakeUserSvc struct {
+		service.UserService
+		users map[uuid.UUID]repository.User
+		calls [][]uuid.UUID
+	}
+
+	fakeTeamSvc struct {
+		service.TeamService
+		teams []repository.Team
+	}
+
+	fakeTeamMemberSvc struct {
+		service.TeamMemberService
+		members []repository.TeamMember
+		calls   [][]uuid.UUID
+	}
+
+	fakeWalletSvc struct {
+		service.WalletService
+		wallets []repository.Wallet
+		calls   [][]uuid.UUID
+	}
+
+	fakeCardSvc struct {
+		service.CardService
+		cards []repository.Card
+		calls [][]uuid.UUID
+	}
+)
+
+func (s *fakeUserSvc) GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID) ([]repository.User, error) {
+	s.calls = append(s.calls, userIDs)
+
+	users := []repository.User{}
+	for _, ID := range userIDs {
+		if user, ok := s.users[ID]; ok {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+func (s *fakeTeamSvc) GetAllTeams(ctx context.Context, sortBy string, sort string, skip int, limit int) ([]repository.Team, error) {
+	return s.teams, nil
+}
+
+func (s *fakeTeamSvc) GetTeam(ctx context.Context, teamID uuid.UUID) (*repository.Team, error) {
+	for _, team := range s.teams {
+		if team.ID == teamID {
+			return &team, nil
+		}
+	}
+	return nil, errors.FailedNoRows
+}
+
+func (s *fakeTeamMemberSvc) GetTeamMembersByTeamIDs(ctx context.Context, teamIDs []uuid.UUID) ([]repository.TeamMember, error) {
+	s.calls = append(s.calls, teamIDs)
+
+	members := []repository.TeamMember{}
+	for _, member := range s.members {
+		for _, ID := range teamIDs {
+			if member.TeamID == ID {
+				members = append(members, member)
+			}
+		}
+	}
+	return members, nil
+}
+
+func (s *fakeWalletSvc) GetWalletsByTeamIDs(ctx context.Context, teamIDs []uuid.UUID) ([]repository.Wallet, error) {
+	s.calls = append(s.calls, teamIDs)
+
+	wallets := []repository.Wallet{}
+	for _, wallet := range s.wallets {
+		for _, ID := range teamIDs {
+			if wallet.TeamID == ID {
+				wallets = append(wallets, wallet)
+			}
+		}
+	}
+	return wallets, nil
+}
+
+func (s *fakeCardSvc) GetCardsByWalletIDs(ctx context.Context, walletIDs []uuid.UUID) ([]repository.Card, error) {
+	s.calls = append(s.calls, walletIDs)
+
+	cards := []repository.Card{}
+	for _, card := range s.cards {
+		for _, ID := range walletIDs {
+			if card.WalletID == ID {
+				cards = append(cards, card)
+			}
+		}
+	}
+	return cards, nil
+}
+
+func TestSchema(t *testing.T) {
+	teams := []repository.Team{{ID: uuid.New(), Name: "Finance"}, {ID: uuid.New(), Name: "Sales"}, {ID: uuid.New(), Name: "Empty"}}
+	users := map[uuid.UUID]repository.User{}
+	for _, name := range []string{"Alice", "Bob", "Carol"} {
+		ID := uuid.New()
+		users[ID] = repository.User{ID: ID, Name: name, Email: name + "@spenmo.com"}
+	}
+
+	var members []repository.TeamMember
+	var wallets []repository.Wallet
+	var cards []repository.Card
+	for i, team := range teams[:2] {
+		for userID := range users {
+			members = append(members, repository.TeamMember{ID: uuid.New(), TeamID: team.ID, UserID: userID, Role: repository.TeamMemberRoleMember})
+		}
+
+		wallet := repository.Wallet{ID: uuid.New(), TeamID: team.ID, Balance: money.NewDecimal(int64(100*(i+1)), 0), Currency: "SGD"}
+		wallets = append(wallets, wallet)
+		cards = append(cards, repository.Card{ID: uuid.New(), WalletID: wallet.ID, Type: repository.CardTypeStandard})
+	}
+
+	userSvc := &fakeUserSvc{users: users}
+	teamSvc := &fakeTeamSvc{teams: teams}
+	teamMemberSvc := &fakeTeamMemberSvc{members: members}
+	walletSvc := &fakeWalletSvc{wallets: wallets}
+	cardSvc := &fakeCardSvc{cards: cards}
+
+	schema, err := NewSchema(Limits{MaxDepth: 5, MaxComplexity: 500}, userSvc, teamSvc, teamMemberSvc, walletSvc, cardSvc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("SuccessBatched", func(t *testing.T) {
+		result := schema.Execute(context.Background(), `{
+			teams(limit: 3) {
+				name
+				members { user { name } }
+				wallets { balance cards { type } }
+			}
+		}`, "", nil)
+		assert.Empty(t, result.Errors)
+
+		data := result.Data.(map[string]interface{})["teams"].([]interface{})
+		if assert.Len(t, data, 3) {
+			finance := data[0].(map[string]interface{})
+			assert.Equal(t, "Finance", finance["name"])
+			assert.Len(t, finance["members"], 3)
+
+			wallet := finance["wallets"].([]interface{})[0].(map[string]interface{})
+			assert.Equal(t, "100", wallet["balance"])
+			assert.Len(t, wallet["cards"], 1)
+
+			empty := data[2].(map[string]interface{})
+			assert.Empty(t, empty["members"])
+			assert.Empty(t, empty["wallets"])
+		}
+
+		// NOTE: One call per relation, the users shared by both teams are fetched once
+		assert.Len(t, teamMemberSvc.calls, 1)
+		assert.Len(t, teamMemberSvc.calls[0], 3)
+		assert.Len(t, walletSvc.calls, 1)
+		assert.Len(t, cardSvc.calls, 1)
+		assert.Len(t, cardSvc.calls[0], 2)
+		assert.Len(t, userSvc.calls, 1)
+		assert.Len(t, userSvc.calls[0], 3)
+	})
+
+	t.Run("FailedNotFound", func(t *testing.T) {
+		result := schema.Execute(context.Background(), `query($id: ID!) { team(id: $id) { name } }`, "", map[string]interface{}{"id": uuid.New().String()})
+
+		assert.Nil(t, result.Data.(map[string]interface{})["team"])
+		if assert.Len(t, result.Errors, 1) {
+			assert.Equal(t, errors.FailedNoRows.Code, result.Errors[0].Extensions["code"])
+		}
+	})
+
+	t.Run("FailedUnparsableID", func(t *testing.T) {
+		result := schema.Execute(context.Background(), `{ team(id: "finance") { name } }`, "", nil)
+
+		if assert.Len(t, result.Errors, 1) {
+			assert.Equal(t, errors.UnparsableUUID.Code, result.Errors[0].Extensions["code"])
+		}
+	})
+
+	t.Run("FailedTooDeep", func(t *testing.T) {
+		result := schema.Execute(context.Background(), `{ teams { members { user { wallets { cards { id } } } } } }`, "", nil)
+
+		assert.Nil(t, result.Data)
+		if assert.Len(t, result.Errors, 1) {
+			assert.Equal(t, errors.QueryTooDeep.Code, result.Errors[0].Extensions["code"])
+		}
+	})
+
+	t.Run("FailedTooDeepThroughFragment", func(t *testing.T) {
+		result := schema.Execute(context.Background(), `
+			{ teams { ...deep } }
+			fragment deep on Team { members { user { wallets { cards { id } } } } }
+		`, "", nil)
+
+		if assert.Len(t, result.Errors, 1) {
+			assert.Equal(t, errors.QueryTooDeep.Code, result.Errors[0].Extensions["code"])
+		}
+	})
+
+	t.Run("FailedTooComplex", func(t *testing.T) {
+		result := schema.Execute(context.Background(), `query($limit: Int) {
+			teams(limit: $limit) { members { user { name email } } }
+		}`, "", map[string]interface{}{"limit": float64(50)})
+
+		assert.Nil(t, result.Data)
+		if assert.Len(t, result.Errors, 1) {
+			assert.Equal(t, errors.QueryTooComplex.Code, result.Errors[0].Extensions["code"])
+		}
+	})
+
+	t.Run("FailedInvalidQuery", func(t *testing.T) {
+		result := schema.Execute(context.Background(), `{ teams { balance } }`, "", nil)
+
+		assert.Nil(t, result.Data)
+		assert.NotEmpty(t, result.Errors)
+	})
+}
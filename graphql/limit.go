@@ -0,0 +1,154 @@
+package graphql
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+)
+
+type (
+	// Limits bounds the queries accepted by Execute, a zero limit is not
+	// enforced
+	Limits struct {
+		// MaxDepth is the number of nested selections, `{ team { members { id } } }` is 3 deep
+		MaxDepth int
+		// MaxComplexity is the number of fields a query may resolve, the fields
+		// under a list are counted once per item
+		MaxComplexity int
+	}
+
+	measurer struct {
+		fragments map[string]*ast.FragmentDefinition
+		variables map[string]interface{}
+	}
+)
+
+const DEFAULT_LIMIT = 10
+
+// NOTE: Nested lists are not paginated, they are counted as DEFAULT_LIMIT items
+var listFields = map[string]bool{
+	"users":       true,
+	"teams":       true,
+	"wallets":     true,
+	"cards":       true,
+	"members":     true,
+	"memberships": true,
+}
+
+func (l Limits) check(doc *ast.Document, operationName string, variables map[string]interface{}) error {
+	m := measurer{
+		fragments: map[string]*ast.FragmentDefinition{},
+		variables: variables,
+	}
+
+	var operations []*ast.OperationDefinition
+	for _, definition := range doc.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			m.fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			if operationName == "" || (definition.Name != nil && definition.Name.Value == operationName) {
+				operations = append(operations, definition)
+			}
+		}
+	}
+
+	for _, operation := range operations {
+		depth, complexity := m.measure(operation.SelectionSet, 0)
+
+		if l.MaxDepth > 0 && depth > l.MaxDepth {
+			return errors.QueryTooDeep
+		}
+
+		if l.MaxComplexity > 0 && complexity > l.MaxComplexity {
+			return errors.QueryTooComplex
+		}
+	}
+
+	return nil
+}
+
+// measure returns the depth and the complexity of a selection set found at
+// depth, introspection fields are free
+func (m *measurer) measure(set *ast.SelectionSet, depth int) (int, int) {
+	if set == nil {
+		return depth, 0
+	}
+
+	maxDepth, complexity := depth, 0
+
+	for _, selection := range set.Selections {
+		var d, c int
+
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name.Value, "__") {
+				continue
+			}
+
+			d, c = m.measure(selection.SelectionSet, depth+1)
+			c = 1 + m.multiplier(selection)*c
+		case *ast.InlineFragment:
+			d, c = m.measure(selection.SelectionSet, depth)
+		case *ast.FragmentSpread:
+			fragment, ok := m.fragments[selection.Name.Value]
+			if !ok {
+				continue
+			}
+
+			d, c = m.measure(fragment.SelectionSet, depth)
+		}
+
+		if d > maxDepth {
+			maxDepth = d
+		}
+		complexity += c
+	}
+
+	return maxDepth, complexity
+}
+
+// multiplier is the number of items a list field may return
+func (m *measurer) multiplier(field *ast.Field) int {
+	if !listFields[field.Name.Value] {
+		return 1
+	}
+
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != "limit" {
+			continue
+		}
+
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			limit, _ := strconv.Atoi(value.Value)
+			return normalizeLimit(limit)
+		case *ast.Variable:
+			return normalizeLimit(toInt(m.variables[value.Name.Value]))
+		}
+	}
+
+	return DEFAULT_LIMIT
+}
+
+func normalizeLimit(limit int) int {
+	if limit <= 0 {
+		return DEFAULT_LIMIT
+	}
+
+	return limit
+}
+
+// toInt reads a variable decoded from JSON
+func toInt(value interface{}) int {
+	switch value := value.(type) {
+	case int:
+		return value
+	case float64:
+		return int(value)
+	default:
+		return 0
+	}
+}
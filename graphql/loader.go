@@ -0,0 +1,223 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+)
+
+type (
+	// batchFunc fetches the values of several keys at once, keys without a
+	// value are left out of the map
+	batchFunc func(ctx context.Context, keys []uuid.UUID) (map[uuid.UUID]interface{}, error)
+
+	// loader queues the keys requested while a level of the query resolves
+	// and fetches them with a single call of batch. graphql-go completes the
+	// thunks returned by the resolvers once every field of the level is
+	// resolved, so the first thunk called fetches the keys of all its siblings.
+	loader struct {
+		batch   batchFunc
+		mu      sync.Mutex
+		pending map[uuid.UUID]struct{}
+		results map[uuid.UUID]result
+	}
+
+	result struct {
+		value interface{}
+		err   error
+	}
+
+	// loaders live for a single query, nothing is cached between queries
+	loaders struct {
+		users         *loader
+		teams         *loader
+		wallets       *loader
+		membersByTeam *loader
+		membersByUser *loader
+		walletsByTeam *loader
+		walletsByUser *loader
+		cardsByWallet *loader
+	}
+
+	loadersKey struct{}
+)
+
+func newLoader(batch batchFunc) *loader {
+	return &loader{
+		batch:   batch,
+		pending: map[uuid.UUID]struct{}{},
+		results: map[uuid.UUID]result{},
+	}
+}
+
+func (l *loader) load(ctx context.Context, key uuid.UUID) func() (interface{}, error) {
+	l.mu.Lock()
+	if _, ok := l.results[key]; !ok {
+		l.pending[key] = struct{}{}
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if _, ok := l.results[key]; !ok {
+			l.dispatch(ctx)
+		}
+
+		r := l.results[key]
+		return r.value, r.err
+	}
+}
+
+func (l *loader) dispatch(ctx context.Context) {
+	keys := make([]uuid.UUID, 0, len(l.pending))
+	for key := range l.pending {
+		keys = append(keys, key)
+	}
+	l.pending = map[uuid.UUID]struct{}{}
+
+	values, err := l.batch(ctx, keys)
+	for _, key := range keys {
+		if err != nil {
+			l.results[key] = result{err: err}
+			continue
+		}
+
+		l.results[key] = result{value: values[key]}
+	}
+}
+
+func (s *Schema) newLoaders() *loaders {
+	return &loaders{
+		users: newLoader(func(ctx context.Context, keys []uuid.UUID) (map[uuid.UUID]interface{}, error) {
+			users, err := s.userSvc.GetUsersByIDs(ctx, keys)
+			if err != nil {
+				return nil, err
+			}
+
+			values := map[uuid.UUID]interface{}{}
+			for _, user := range users {
+				values[user.ID] = user
+			}
+			return values, nil
+		}),
+		teams: newLoader(func(ctx context.Context, keys []uuid.UUID) (map[uuid.UUID]interface{}, error) {
+			teams, err := s.teamSvc.GetTeamsByIDs(ctx, keys)
+			if err != nil {
+				return nil, err
+			}
+
+			values := map[uuid.UUID]interface{}{}
+			for _, team := range teams {
+				values[team.ID] = team
+			}
+			return values, nil
+		}),
+		wallets: newLoader(func(ctx context.Context, keys []uuid.UUID) (map[uuid.UUID]interface{}, error) {
+			wallets, err := s.walletSvc.GetWalletsByIDs(ctx, keys)
+			if err != nil {
+				return nil, err
+			}
+
+			values := map[uuid.UUID]interface{}{}
+			for _, wallet := range wallets {
+				values[wallet.ID] = wallet
+			}
+			return values, nil
+		}),
+		membersByTeam: newLoader(func(ctx context.Context, keys []uuid.UUID) (map[uuid.UUID]interface{}, error) {
+			members, err := s.teamMemberSvc.GetTeamMembersByTeamIDs(ctx, keys)
+			if err != nil {
+				return nil, err
+			}
+
+			grouped := map[uuid.UUID][]repository.TeamMember{}
+			for _, member := range members {
+				grouped[member.TeamID] = append(grouped[member.TeamID], member)
+			}
+			return groupTeamMembers(keys, grouped), nil
+		}),
+		membersByUser: newLoader(func(ctx context.Context, keys []uuid.UUID) (map[uuid.UUID]interface{}, error) {
+			members, err := s.teamMemberSvc.GetTeamMembersByUserIDs(ctx, keys)
+			if err != nil {
+				return nil, err
+			}
+
+			grouped := map[uuid.UUID][]repository.TeamMember{}
+			for _, member := range members {
+				grouped[member.UserID] = append(grouped[member.UserID], member)
+			}
+			return groupTeamMembers(keys, grouped), nil
+		}),
+		walletsByTeam: newLoader(func(ctx context.Context, keys []uuid.UUID) (map[uuid.UUID]interface{}, error) {
+			wallets, err := s.walletSvc.GetWalletsByTeamIDs(ctx, keys)
+			if err != nil {
+				return nil, err
+			}
+
+			grouped := map[uuid.UUID][]repository.Wallet{}
+			for _, wallet := range wallets {
+				grouped[wallet.TeamID] = append(grouped[wallet.TeamID], wallet)
+			}
+			return groupWallets(keys, grouped), nil
+		}),
+		walletsByUser: newLoader(func(ctx context.Context, keys []uuid.UUID) (map[uuid.UUID]interface{}, error) {
+			wallets, err := s.walletSvc.GetWalletsByUserIDs(ctx, keys)
+			if err != nil {
+				return nil, err
+			}
+
+			grouped := map[uuid.UUID][]repository.Wallet{}
+			for _, wallet := range wallets {
+				grouped[wallet.UserID] = append(grouped[wallet.UserID], wallet)
+			}
+			return groupWallets(keys, grouped), nil
+		}),
+		cardsByWallet: newLoader(func(ctx context.Context, keys []uuid.UUID) (map[uuid.UUID]interface{}, error) {
+			cards, err := s.cardSvc.GetCardsByWalletIDs(ctx, keys)
+			if err != nil {
+				return nil, err
+			}
+
+			grouped := map[uuid.UUID][]repository.Card{}
+			for _, card := range cards {
+				grouped[card.WalletID] = append(grouped[card.WalletID], card)
+			}
+
+			values := map[uuid.UUID]interface{}{}
+			for _, key := range keys {
+				values[key] = append([]repository.Card{}, grouped[key]...)
+			}
+			return values, nil
+		}),
+	}
+}
+
+// groupTeamMembers gives every key a list, empty when nothing matched it
+func groupTeamMembers(keys []uuid.UUID, grouped map[uuid.UUID][]repository.TeamMember) map[uuid.UUID]interface{} {
+	values := map[uuid.UUID]interface{}{}
+	for _, key := range keys {
+		values[key] = append([]repository.TeamMember{}, grouped[key]...)
+	}
+	return values
+}
+
+// groupWallets gives every key a list, empty when nothing matched it
+func groupWallets(keys []uuid.UUID, grouped map[uuid.UUID][]repository.Wallet) map[uuid.UUID]interface{} {
+	values := map[uuid.UUID]interface{}{}
+	for _, key := range keys {
+		values[key] = append([]repository.Wallet{}, grouped[key]...)
+	}
+	return values
+}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func getLoaders(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
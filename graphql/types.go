@@ -0,0 +1,348 @@
+package graphql
+
+import (
+	"github.com/google/uuid"
+	gql "github.com/graphql-go/graphql"
+	"gitlab.com/renodesper/spenmo-test/repository"
+)
+
+var (
+	sortFieldEnum = gql.NewEnum(gql.EnumConfig{
+		Name: "SortField",
+		Values: gql.EnumValueConfigMap{
+			"CREATED_AT": &gql.EnumValueConfig{Value: "created_at"},
+			"UPDATED_AT": &gql.EnumValueConfig{Value: "updated_at"},
+		},
+	})
+
+	sortOrderEnum = gql.NewEnum(gql.EnumConfig{
+		Name: "SortOrder",
+		Values: gql.EnumValueConfigMap{
+			"ASC":  &gql.EnumValueConfig{Value: "ASC"},
+			"DESC": &gql.EnumValueConfig{Value: "DESC"},
+		},
+	})
+
+	// NOTE: Same defaults as the list routes of the REST API
+	paginationArgs = gql.FieldConfigArgument{
+		"sortBy": &gql.ArgumentConfig{Type: sortFieldEnum, DefaultValue: "created_at"},
+		"sort":   &gql.ArgumentConfig{Type: sortOrderEnum, DefaultValue: "DESC"},
+		"skip":   &gql.ArgumentConfig{Type: gql.Int, DefaultValue: 0},
+		"limit":  &gql.ArgumentConfig{Type: gql.Int, DefaultValue: DEFAULT_LIMIT},
+	}
+
+	idArgs = gql.FieldConfigArgument{
+		"id": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)},
+	}
+)
+
+func (s *Schema) newQueryType() *gql.Object {
+	return gql.NewObject(gql.ObjectConfig{
+		Name: "Query",
+		Fields: gql.Fields{
+			"users": &gql.Field{
+				Type: listOf(s.userType),
+				Args: paginationArgs,
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					sortBy, sort, skip, limit := pagination(p.Args)
+					users, err := s.userSvc.GetAllUsers(p.Context, sortBy, sort, skip, limit)
+					return users, wrapError(err)
+				},
+			},
+			"user": &gql.Field{
+				Type: s.userType,
+				Args: idArgs,
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					ID, err := parseID(p.Args)
+					if err != nil {
+						return nil, err
+					}
+
+					user, err := s.userSvc.GetUser(p.Context, ID)
+					if err != nil {
+						return nil, wrapError(err)
+					}
+
+					return *user, nil
+				},
+			},
+			"teams": &gql.Field{
+				Type: listOf(s.teamType),
+				Args: paginationArgs,
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					sortBy, sort, skip, limit := pagination(p.Args)
+					teams, err := s.teamSvc.GetAllTeams(p.Context, sortBy, sort, skip, limit)
+					return teams, wrapError(err)
+				},
+			},
+			"team": &gql.Field{
+				Type: s.teamType,
+				Args: idArgs,
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					ID, err := parseID(p.Args)
+					if err != nil {
+						return nil, err
+					}
+
+					team, err := s.teamSvc.GetTeam(p.Context, ID)
+					if err != nil {
+						return nil, wrapError(err)
+					}
+
+					return *team, nil
+				},
+			},
+			"wallets": &gql.Field{
+				Type: listOf(s.walletType),
+				Args: paginationArgs,
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					sortBy, sort, skip, limit := pagination(p.Args)
+					wallets, err := s.walletSvc.GetAllWallets(p.Context, sortBy, sort, skip, limit)
+					return wallets, wrapError(err)
+				},
+			},
+			"wallet": &gql.Field{
+				Type: s.walletType,
+				Args: idArgs,
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					ID, err := parseID(p.Args)
+					if err != nil {
+						return nil, err
+					}
+
+					wallet, err := s.walletSvc.GetWallet(p.Context, ID)
+					if err != nil {
+						return nil, wrapError(err)
+					}
+
+					return *wallet, nil
+				},
+			},
+			"cards": &gql.Field{
+				Type: listOf(s.cardType),
+				Args: paginationArgs,
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					sortBy, sort, skip, limit := pagination(p.Args)
+					cards, err := s.cardSvc.GetAllCards(p.Context, sortBy, sort, skip, limit)
+					return cards, wrapError(err)
+				},
+			},
+			"card": &gql.Field{
+				Type: s.cardType,
+				Args: idArgs,
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					ID, err := parseID(p.Args)
+					if err != nil {
+						return nil, err
+					}
+
+					card, err := s.cardSvc.GetCard(p.Context, ID)
+					if err != nil {
+						return nil, wrapError(err)
+					}
+
+					return *card, nil
+				},
+			},
+		},
+	})
+}
+
+func (s *Schema) newUserType() *gql.Object {
+	return gql.NewObject(gql.ObjectConfig{
+		Name: "User",
+		Fields: gql.FieldsThunk(func() gql.Fields {
+			return gql.Fields{
+				"id":        &gql.Field{Type: gql.NewNonNull(gql.ID)},
+				"email":     &gql.Field{Type: gql.NewNonNull(gql.String)},
+				"name":      &gql.Field{Type: gql.NewNonNull(gql.String)},
+				"isDeleted": &gql.Field{Type: gql.NewNonNull(gql.Boolean)},
+				"createdAt": &gql.Field{Type: gql.NewNonNull(gql.DateTime)},
+				"updatedAt": &gql.Field{Type: gql.NewNonNull(gql.DateTime)},
+				"memberships": &gql.Field{
+					Type: listOf(s.teamMemberType),
+					Resolve: func(p gql.ResolveParams) (interface{}, error) {
+						user := p.Source.(repository.User)
+						return getLoaders(p.Context).membersByUser.load(p.Context, user.ID), nil
+					},
+				},
+				"wallets": &gql.Field{
+					Type: listOf(s.walletType),
+					Resolve: func(p gql.ResolveParams) (interface{}, error) {
+						user := p.Source.(repository.User)
+						return getLoaders(p.Context).walletsByUser.load(p.Context, user.ID), nil
+					},
+				},
+			}
+		}),
+	})
+}
+
+func (s *Schema) newTeamType() *gql.Object {
+	return gql.NewObject(gql.ObjectConfig{
+		Name: "Team",
+		Fields: gql.FieldsThunk(func() gql.Fields {
+			return gql.Fields{
+				"id":        &gql.Field{Type: gql.NewNonNull(gql.ID)},
+				"name":      &gql.Field{Type: gql.NewNonNull(gql.String)},
+				"timezone":  &gql.Field{Type: gql.NewNonNull(gql.String)},
+				"isDeleted": &gql.Field{Type: gql.NewNonNull(gql.Boolean)},
+				"createdAt": &gql.Field{Type: gql.NewNonNull(gql.DateTime)},
+				"updatedAt": &gql.Field{Type: gql.NewNonNull(gql.DateTime)},
+				"members": &gql.Field{
+					Type: listOf(s.teamMemberType),
+					Resolve: func(p gql.ResolveParams) (interface{}, error) {
+						team := p.Source.(repository.Team)
+						return getLoaders(p.Context).membersByTeam.load(p.Context, team.ID), nil
+					},
+				},
+				"wallets": &gql.Field{
+					Type: listOf(s.walletType),
+					Resolve: func(p gql.ResolveParams) (interface{}, error) {
+						team := p.Source.(repository.Team)
+						return getLoaders(p.Context).walletsByTeam.load(p.Context, team.ID), nil
+					},
+				},
+			}
+		}),
+	})
+}
+
+func (s *Schema) newTeamMemberType() *gql.Object {
+	return gql.NewObject(gql.ObjectConfig{
+		Name: "TeamMember",
+		Fields: gql.FieldsThunk(func() gql.Fields {
+			return gql.Fields{
+				"id":        &gql.Field{Type: gql.NewNonNull(gql.ID)},
+				"teamId":    &gql.Field{Type: gql.NewNonNull(gql.ID)},
+				"userId":    &gql.Field{Type: gql.NewNonNull(gql.ID)},
+				"role":      &gql.Field{Type: gql.NewNonNull(gql.String)},
+				"isDeleted": &gql.Field{Type: gql.NewNonNull(gql.Boolean)},
+				"createdAt": &gql.Field{Type: gql.NewNonNull(gql.DateTime)},
+				"updatedAt": &gql.Field{Type: gql.NewNonNull(gql.DateTime)},
+				"team": &gql.Field{
+					Type: s.teamType,
+					Resolve: func(p gql.ResolveParams) (interface{}, error) {
+						member := p.Source.(repository.TeamMember)
+						return getLoaders(p.Context).teams.load(p.Context, member.TeamID), nil
+					},
+				},
+				"user": &gql.Field{
+					Type: s.userType,
+					Resolve: func(p gql.ResolveParams) (interface{}, error) {
+						member := p.Source.(repository.TeamMember)
+						return getLoaders(p.Context).users.load(p.Context, member.UserID), nil
+					},
+				},
+			}
+		}),
+	})
+}
+
+func (s *Schema) newWalletType() *gql.Object {
+	return gql.NewObject(gql.ObjectConfig{
+		Name: "Wallet",
+		Fields: gql.FieldsThunk(func() gql.Fields {
+			return gql.Fields{
+				"id":           &gql.Field{Type: gql.NewNonNull(gql.ID)},
+				"balance":      &gql.Field{Type: gql.NewNonNull(gql.String)},
+				"dailyLimit":   &gql.Field{Type: gql.NewNonNull(gql.String)},
+				"monthlyLimit": &gql.Field{Type: gql.NewNonNull(gql.String)},
+				"currency":     &gql.Field{Type: gql.NewNonNull(gql.String)},
+				"teamId": &gql.Field{
+					Type: gql.ID,
+					Resolve: func(p gql.ResolveParams) (interface{}, error) {
+						return nullableID(p.Source.(repository.Wallet).TeamID), nil
+					},
+				},
+				"userId": &gql.Field{
+					Type: gql.ID,
+					Resolve: func(p gql.ResolveParams) (interface{}, error) {
+						return nullableID(p.Source.(repository.Wallet).UserID), nil
+					},
+				},
+				"isDeleted": &gql.Field{Type: gql.NewNonNull(gql.Boolean)},
+				"createdAt": &gql.Field{Type: gql.NewNonNull(gql.DateTime)},
+				"updatedAt": &gql.Field{Type: gql.NewNonNull(gql.DateTime)},
+				"team": &gql.Field{
+					Type: s.teamType,
+					Resolve: func(p gql.ResolveParams) (interface{}, error) {
+						wallet := p.Source.(repository.Wallet)
+						if wallet.TeamID == uuid.Nil {
+							return nil, nil
+						}
+
+						return getLoaders(p.Context).teams.load(p.Context, wallet.TeamID), nil
+					},
+				},
+				"user": &gql.Field{
+					Type: s.userType,
+					Resolve: func(p gql.ResolveParams) (interface{}, error) {
+						wallet := p.Source.(repository.Wallet)
+						if wallet.UserID == uuid.Nil {
+							return nil, nil
+						}
+
+						return getLoaders(p.Context).users.load(p.Context, wallet.UserID), nil
+					},
+				},
+				"cards": &gql.Field{
+					Type: listOf(s.cardType),
+					Resolve: func(p gql.ResolveParams) (interface{}, error) {
+						wallet := p.Source.(repository.Wallet)
+						return getLoaders(p.Context).cardsByWallet.load(p.Context, wallet.ID), nil
+					},
+				},
+			}
+		}),
+	})
+}
+
+// NOTE: The CVV is left out, the card pages of the UI never show it
+func (s *Schema) newCardType() *gql.Object {
+	return gql.NewObject(gql.ObjectConfig{
+		Name: "Card",
+		Fields: gql.FieldsThunk(func() gql.Fields {
+			return gql.Fields{
+				"id":             &gql.Field{Type: gql.NewNonNull(gql.ID)},
+				"cardNo":         &gql.Field{Type: gql.NewNonNull(gql.String)},
+				"expiryMonth":    &gql.Field{Type: gql.NewNonNull(gql.String)},
+				"expiryYear":     &gql.Field{Type: gql.NewNonNull(gql.String)},
+				"dailyLimit":     &gql.Field{Type: gql.NewNonNull(gql.String)},
+				"monthlyLimit":   &gql.Field{Type: gql.NewNonNull(gql.String)},
+				"currency":       &gql.Field{Type: gql.NewNonNull(gql.String)},
+				"type":           &gql.Field{Type: gql.NewNonNull(gql.String)},
+				"lockedMerchant": &gql.Field{Type: gql.String},
+				"walletId":       &gql.Field{Type: gql.NewNonNull(gql.ID)},
+				"isFrozen":       &gql.Field{Type: gql.NewNonNull(gql.Boolean)},
+				"isClosed":       &gql.Field{Type: gql.NewNonNull(gql.Boolean)},
+				"isDeleted":      &gql.Field{Type: gql.NewNonNull(gql.Boolean)},
+				"createdAt":      &gql.Field{Type: gql.NewNonNull(gql.DateTime)},
+				"updatedAt":      &gql.Field{Type: gql.NewNonNull(gql.DateTime)},
+				"wallet": &gql.Field{
+					Type: s.walletType,
+					Resolve: func(p gql.ResolveParams) (interface{}, error) {
+						card := p.Source.(repository.Card)
+						return getLoaders(p.Context).wallets.load(p.Context, card.WalletID), nil
+					},
+				},
+			}
+		}),
+	})
+}
+
+func listOf(t gql.Type) gql.Output {
+	return gql.NewNonNull(gql.NewList(gql.NewNonNull(t)))
+}
+
+func pagination(args map[string]interface{}) (string, string, int, int) {
+	return args["sortBy"].(string), args["sort"].(string), args["skip"].(int), normalizeLimit(args["limit"].(int))
+}
+
+func nullableID(ID uuid.UUID) interface{} {
+	if ID == uuid.Nil {
+		return nil
+	}
+
+	return ID
+}
@@ -5,21 +5,84 @@ import (
 	"time"
 
 	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/metrics"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
 	"gitlab.com/renodesper/spenmo-test/util/logger"
 )
 
-func CreateMiddleware(log logger.Logger, requestCount *kitprometheus.Counter, requestLatency *kitprometheus.Summary) endpoint.Middleware {
+type (
+	// Metrics are shared by the transports, so the collectors are only
+	// registered once
+	Metrics struct {
+		// RequestCount and RequestLatency are labelled by transport, method
+		// and error
+		RequestCount   metrics.Counter
+		RequestLatency metrics.Histogram
+		// HTTPDuration is labelled by method, route and status
+		HTTPDuration metrics.Histogram
+	}
+)
+
+// NoError is the error label of the calls that succeeded
+const NoError = "none"
+
+// CreateMetrics registers the collectors on the default Prometheus registry
+func CreateMetrics() *Metrics {
+	endpointKeys := []string{"transport", "method", "error"}
+
+	return &Metrics{
+		RequestCount: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "spenmo",
+			Subsystem: "test",
+			Name:      "request_count",
+			Help:      "Number of requests received",
+		}, endpointKeys),
+		RequestLatency: kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "spenmo",
+			Subsystem: "test",
+			Name:      "request_latency_seconds",
+			Help:      "Total duration of requests in seconds",
+		}, endpointKeys),
+		HTTPDuration: kitprometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: "spenmo",
+			Subsystem: "test",
+			Name:      "http_request_duration_seconds",
+			Help:      "Duration of HTTP requests in seconds by route and status",
+			Buckets:   stdprometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+	}
+}
+
+// CreateMiddleware records every call of the method, the error label is the
+// code of the returned error
+func CreateMiddleware(log logger.Logger, m *Metrics, transport string, method string) endpoint.Middleware {
 	return func(next endpoint.Endpoint) endpoint.Endpoint {
 
-		return func(ctx context.Context, request interface{}) (result interface{}, e error) {
+		return func(ctx context.Context, request interface{}) (result interface{}, err error) {
 			defer func(begin time.Time) {
-				lvs := []string{"method", "count", "error", "false"}
-				requestCount.With(lvs...).Add(1)
-				requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+				lvs := []string{"transport", transport, "method", method, "error", ErrorCode(err)}
+				m.RequestCount.With(lvs...).Add(1)
+				m.RequestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
 			}(time.Now())
 
 			return next(ctx, request)
 		}
 	}
 }
+
+// ErrorCode is the code of err, errors that are not an e.Error are reported
+// as an unexpected error
+func ErrorCode(err error) string {
+	if err == nil {
+		return NoError
+	}
+
+	if er, ok := err.(e.Error); ok && er.Code != "" {
+		return er.Code
+	}
+
+	return errors.UnexpectedError.Code
+}
@@ -12,6 +12,10 @@ type (
 		Before []endpoint.Middleware
 		After  []endpoint.Middleware
 	}
+
+	// Factory builds the middlewares of the named endpoint, so they can be
+	// labelled by it
+	Factory func(method string) Middlewares
 )
 
 // Chain will chain Before and After middlewares and traverse them
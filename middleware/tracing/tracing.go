@@ -0,0 +1,34 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	ctxUtil "gitlab.com/renodesper/spenmo-test/util/ctx"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+	tracingUtil "gitlab.com/renodesper/spenmo-test/util/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// CreateMiddleware wraps every call of the method in a span, the span fails
+// with the returned error
+func CreateMiddleware(log logger.Logger, transport string, method string) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+
+		return func(ctx context.Context, request interface{}) (result interface{}, err error) {
+			ctx, span := tracingUtil.Start(ctx, "endpoint."+method)
+			span.SetAttributes(
+				attribute.String("endpoint.transport", transport),
+				attribute.String("endpoint.method", method),
+				attribute.String("request.id", ctxUtil.GetRequestID(ctx)),
+			)
+
+			defer func() {
+				tracingUtil.RecordError(span, err)
+				span.End()
+			}()
+
+			return next(ctx, request)
+		}
+	}
+}
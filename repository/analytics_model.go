@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"time"
+
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+type (
+	// SpendRow is the card spend of one group in one time bucket
+	SpendRow struct {
+		Bucket   time.Time     `db:"bucket" json:"bucket"`
+		Key      string        `db:"key" json:"key"`
+		Currency string        `db:"currency" json:"currency"`
+		Amount   money.Decimal `db:"amount" json:"amount"`
+		Count    int           `db:"count" json:"count"`
+	}
+)
+
+const (
+	SpendGroupByCard = "card"
+	SpendGroupByUser = "user"
+	SpendGroupByMCC  = "mcc"
+	SpendGroupByDay  = "day"
+
+	SpendIntervalDay   = "day"
+	SpendIntervalWeek  = "week"
+	SpendIntervalMonth = "month"
+)
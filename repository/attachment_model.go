@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// Attachment is a file kept in the blob store under StorageKey, such as
+	// the receipt of a transaction
+	Attachment struct {
+		tableName struct{} `pg:"attachment"` //nolint
+
+		ID            uuid.UUID `db:"id" json:"id"`
+		TransactionID uuid.UUID `db:"transactionId" json:"transactionId"`
+		Kind          string    `db:"kind" json:"kind"`
+		FileName      string    `db:"fileName" json:"fileName"`
+		ContentType   string    `db:"contentType" json:"contentType"`
+		Size          int64     `db:"size" json:"size"`
+		StorageKey    string    `db:"storageKey" json:"-"`
+		UploadedBy    uuid.UUID `db:"uploadedBy" json:"uploadedBy"`
+		CreatedAt     time.Time `db:"createdAt" json:"createdAt"`
+	}
+)
+
+const (
+	AttachmentKindReceipt = "receipt"
+)
+
+// MarshalBinary ...
+func (u *Attachment) MarshalBinary() ([]byte, error) {
+	return json.Marshal(u)
+}
+
+// UnmarshalBinary ...
+func (u *Attachment) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, u)
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+type (
+	// Budget is a soft limit on the card spend of a wallet, or of a single
+	// card when CardID is set. Unlike limits it never declines a transaction,
+	// an alert is raised instead each time spend crosses one of the
+	// thresholds, expressed as a percentage of Amount.
+	Budget struct {
+		tableName struct{} `pg:"budget"` //nolint
+
+		ID         uuid.UUID     `db:"id" json:"id"`
+		Name       string        `db:"name" json:"name"`
+		WalletID   uuid.UUID     `db:"walletId" json:"walletId"`
+		CardID     uuid.UUID     `db:"cardId" json:"cardId"`
+		Amount     money.Decimal `db:"amount" json:"amount"`
+		Currency   string        `db:"currency" json:"currency"`
+		Period     string        `db:"period" json:"period"`
+		Thresholds []int         `db:"thresholds" pg:",array" json:"thresholds"`
+		IsDeleted  bool          `db:"isDeleted" json:"isDeleted"`
+		CreatedAt  time.Time     `db:"createdAt" json:"createdAt"`
+		UpdatedAt  time.Time     `db:"updatedAt" json:"updatedAt"`
+	}
+
+	// BudgetAlert records a threshold reached by a budget. A threshold is
+	// raised at most once per period.
+	BudgetAlert struct {
+		tableName struct{} `pg:"budget_alert"` //nolint
+
+		ID            uuid.UUID     `db:"id" json:"id"`
+		BudgetID      uuid.UUID     `db:"budgetId" json:"budgetId"`
+		TransactionID uuid.UUID     `db:"transactionId" json:"transactionId"`
+		Threshold     int           `db:"threshold" json:"threshold"`
+		PeriodStart   time.Time     `db:"periodStart" json:"periodStart"`
+		Spent         money.Decimal `db:"spent" json:"spent"`
+		Amount        money.Decimal `db:"amount" json:"amount"`
+		Currency      string        `db:"currency" json:"currency"`
+		CreatedAt     time.Time     `db:"createdAt" json:"createdAt"`
+	}
+)
+
+const (
+	BudgetPeriodMonthly   = "monthly"
+	BudgetPeriodQuarterly = "quarterly"
+	BudgetPeriodYearly    = "yearly"
+)
+
+// MarshalBinary ...
+func (u *Budget) MarshalBinary() ([]byte, error) {
+	return json.Marshal(u)
+}
+
+// UnmarshalBinary ...
+func (u *Budget) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, u)
+}
+
+// MarshalBinary ...
+func (u *BudgetAlert) MarshalBinary() ([]byte, error) {
+	return json.Marshal(u)
+}
+
+// UnmarshalBinary ...
+func (u *BudgetAlert) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, u)
+}
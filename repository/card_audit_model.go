@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// CardAudit records a change of state of a card and the fraud rules it
+	// triggered. UserID is empty when the action was taken automatically.
+	CardAudit struct {
+		tableName struct{} `pg:"card_audit"` //nolint
+
+		ID        uuid.UUID              `db:"id" json:"id"`
+		CardID    uuid.UUID              `db:"cardId" json:"cardId"`
+		Action    string                 `db:"action" json:"action"`
+		Rule      string                 `db:"rule" json:"rule,omitempty"`
+		Reason    string                 `db:"reason" json:"reason,omitempty"`
+		UserID    uuid.UUID              `db:"userId" json:"userId"`
+		Details   map[string]interface{} `db:"details" json:"details,omitempty"`
+		CreatedAt time.Time              `db:"createdAt" json:"createdAt"`
+	}
+)
+
+const (
+	CardAuditActionFrozen   = "frozen"
+	CardAuditActionUnfrozen = "unfrozen"
+	CardAuditActionDeclined = "declined"
+	CardAuditActionFlagged  = "flagged"
+)
+
+// MarshalBinary ...
+func (u *CardAudit) MarshalBinary() ([]byte, error) {
+	return json.Marshal(u)
+}
+
+// UnmarshalBinary ...
+func (u *CardAudit) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, u)
+}
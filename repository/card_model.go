@@ -4,26 +4,43 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/util/money"
 )
 
 type (
+	// Card limits are enforced as spend limits, DailyLimit and MonthlyLimit
+	// mirror its calendar daily and monthly SpendLimit. A single_use card is
+	// closed by its first capture, a merchant_locked card is bound to
+	// LockedMerchant by its first capture.
 	Card struct {
 		tableName struct{} `pg:"card"` //nolint
 
-		ID           uuid.UUID `db:"id" json:"id"`
-		CardNo       string    `db:"cardNo" json:"cardNo"`
-		ExpiryMonth  string    `db:"expiryMonth" json:"expiryMonth"`
-		ExpiryYear   string    `db:"expiryYear" json:"expiryYear"`
-		CVV          string    `db:"cvv" json:"cvv"`
-		DailyLimit   uint64    `db:"dailyLimit" json:"dailyLimit"`
-		MonthlyLimit uint64    `db:"monthlyLimit" json:"monthlyLimit"`
-		WalletID     uuid.UUID `db:"walletId" json:"walletId"`
-		IsDeleted    bool      `db:"isDeleted" json:"isDeleted"`
-		CreatedAt    time.Time `db:"createdAt" json:"createdAt"`
-		UpdatedAt    time.Time `db:"updatedAt" json:"updatedAt"`
+		ID             uuid.UUID      `db:"id" json:"id"`
+		CardNo         string         `db:"cardNo" json:"cardNo"`
+		ExpiryMonth    string         `db:"expiryMonth" json:"expiryMonth"`
+		ExpiryYear     string         `db:"expiryYear" json:"expiryYear"`
+		CVV            string         `db:"cvv" json:"cvv"`
+		DailyLimit     money.Decimal  `db:"dailyLimit" json:"dailyLimit"`
+		MonthlyLimit   money.Decimal  `db:"monthlyLimit" json:"monthlyLimit"`
+		Currency       string         `db:"currency" json:"currency"`
+		Type           string         `db:"type" json:"type"`
+		LockedMerchant string         `db:"lockedMerchant" json:"lockedMerchant,omitempty"`
+		WalletID       uuid.UUID      `db:"walletId" json:"walletId"`
+		Controls       *SpendControls `db:"controls" json:"controls,omitempty"`
+		IsFrozen       bool           `db:"isFrozen" json:"isFrozen"`
+		IsClosed       bool           `db:"isClosed" json:"isClosed"`
+		IsDeleted      bool           `db:"isDeleted" json:"isDeleted"`
+		CreatedAt      time.Time      `db:"createdAt" json:"createdAt"`
+		UpdatedAt      time.Time      `db:"updatedAt" json:"updatedAt"`
 	}
 )
 
+const (
+	CardTypeStandard       = "standard"
+	CardTypeSingleUse      = "single_use"
+	CardTypeMerchantLocked = "merchant_locked"
+)
+
 // MarshalBinary ...
 func (u *Card) MarshalBinary() ([]byte, error) {
 	return json.Marshal(u)
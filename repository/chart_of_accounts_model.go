@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// ChartOfAccounts maps the transactions of a team to its ledger accounts
+	// in accounting exports. Categories maps a transaction category to the
+	// account it is booked to, the other accounts apply to uncategorized
+	// transactions.
+	ChartOfAccounts struct {
+		tableName struct{} `pg:"chart_of_accounts"` //nolint
+
+		ID              uuid.UUID         `db:"id" json:"id"`
+		TeamID          uuid.UUID         `db:"teamId" json:"teamId"`
+		WalletAccount   string            `db:"walletAccount" json:"walletAccount"`
+		ExpenseAccount  string            `db:"expenseAccount" json:"expenseAccount"`
+		IncomeAccount   string            `db:"incomeAccount" json:"incomeAccount"`
+		TransferAccount string            `db:"transferAccount" json:"transferAccount"`
+		Categories      map[string]string `db:"categories" json:"categories"`
+		CreatedAt       time.Time         `db:"createdAt" json:"createdAt"`
+		UpdatedAt       time.Time         `db:"updatedAt" json:"updatedAt"`
+	}
+)
+
+// MarshalBinary ...
+func (u *ChartOfAccounts) MarshalBinary() ([]byte, error) {
+	return json.Marshal(u)
+}
+
+// UnmarshalBinary ...
+func (u *ChartOfAccounts) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, u)
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+type (
+	// FundRequest asks the admins of a team to fund a wallet. Amount is
+	// expressed in the currency of the wallet. Once approved ReferenceID
+	// points to the transfer from SourceWalletID.
+	FundRequest struct {
+		tableName struct{} `pg:"fund_request"` //nolint
+
+		ID             uuid.UUID     `db:"id" json:"id"`
+		TeamID         uuid.UUID     `db:"teamId" json:"teamId"`
+		WalletID       uuid.UUID     `db:"walletId" json:"walletId"`
+		RequesterID    uuid.UUID     `db:"requesterId" json:"requesterId"`
+		Amount         money.Decimal `db:"amount" json:"amount"`
+		Currency       string        `db:"currency" json:"currency"`
+		Reason         string        `db:"reason" json:"reason"`
+		Status         string        `db:"status" json:"status"`
+		ReviewerID     uuid.UUID     `db:"reviewerId" json:"reviewerId"`
+		ReviewNote     string        `db:"reviewNote" json:"reviewNote,omitempty"`
+		SourceWalletID uuid.UUID     `db:"sourceWalletId" json:"sourceWalletId"`
+		ReferenceID    uuid.UUID     `db:"referenceId" json:"referenceId"`
+		ReviewedAt     time.Time     `db:"reviewedAt" json:"reviewedAt"`
+		CreatedAt      time.Time     `db:"createdAt" json:"createdAt"`
+		UpdatedAt      time.Time     `db:"updatedAt" json:"updatedAt"`
+	}
+)
+
+const (
+	FundRequestStatusPending   = "pending"
+	FundRequestStatusApproved  = "approved"
+	FundRequestStatusRejected  = "rejected"
+	FundRequestStatusCancelled = "cancelled"
+)
+
+// MarshalBinary ...
+func (u *FundRequest) MarshalBinary() ([]byte, error) {
+	return json.Marshal(u)
+}
+
+// UnmarshalBinary ...
+func (u *FundRequest) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, u)
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+type (
+	// FundingSchedule moves money from a team wallet into another wallet on a
+	// schedule, either a cron expression or a day of the month evaluated in
+	// the timezone of the team. A top up transfers Amount every period, a
+	// reset transfers what the target wallet lacks to hold Amount.
+	FundingSchedule struct {
+		tableName struct{} `pg:"funding_schedule"` //nolint
+
+		ID             uuid.UUID     `db:"id" json:"id"`
+		TeamID         uuid.UUID     `db:"teamId" json:"teamId"`
+		SourceWalletID uuid.UUID     `db:"sourceWalletId" json:"sourceWalletId"`
+		TargetWalletID uuid.UUID     `db:"targetWalletId" json:"targetWalletId"`
+		Mode           string        `db:"mode" json:"mode"`
+		Amount         money.Decimal `db:"amount" json:"amount"`
+		Currency       string        `db:"currency" json:"currency"`
+		Cron           string        `db:"cron" json:"cron,omitempty"`
+		DayOfMonth     int           `db:"dayOfMonth" json:"dayOfMonth,omitempty"`
+		NextRunAt      time.Time     `db:"nextRunAt" json:"nextRunAt"`
+		LastRunAt      time.Time     `db:"lastRunAt" json:"lastRunAt"`
+		IsDeleted      bool          `db:"isDeleted" json:"isDeleted"`
+		CreatedAt      time.Time     `db:"createdAt" json:"createdAt"`
+		UpdatedAt      time.Time     `db:"updatedAt" json:"updatedAt"`
+	}
+
+	// FundingRun records the outcome of a schedule for one period, a period
+	// is never run twice
+	FundingRun struct {
+		tableName struct{} `pg:"funding_run"` //nolint
+
+		ID            uuid.UUID     `db:"id" json:"id"`
+		ScheduleID    uuid.UUID     `db:"scheduleId" json:"scheduleId"`
+		Period        time.Time     `db:"period" json:"period"`
+		Status        string        `db:"status" json:"status"`
+		Amount        money.Decimal `db:"amount" json:"amount"`
+		Currency      string        `db:"currency" json:"currency,omitempty"`
+		TransactionID uuid.UUID     `db:"transactionId" json:"transactionId"`
+		Error         string        `db:"error" json:"error,omitempty"`
+		CreatedAt     time.Time     `db:"createdAt" json:"createdAt"`
+	}
+)
+
+const (
+	FundingModeTopUp = "top_up"
+	FundingModeReset = "reset"
+
+	FundingRunStatusSucceeded = "succeeded"
+	FundingRunStatusFailed    = "failed"
+	FundingRunStatusSkipped   = "skipped"
+)
+
+// MarshalBinary ...
+func (u *FundingSchedule) MarshalBinary() ([]byte, error) {
+	return json.Marshal(u)
+}
+
+// UnmarshalBinary ...
+func (u *FundingSchedule) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, u)
+}
+
+// MarshalBinary ...
+func (u *FundingRun) MarshalBinary() ([]byte, error) {
+	return json.Marshal(u)
+}
+
+// UnmarshalBinary ...
+func (u *FundingRun) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, u)
+}
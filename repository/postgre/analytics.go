@@ -0,0 +1,79 @@
+package postgre
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+)
+
+type (
+	AnalyticsRepository interface {
+		GetTeamSpend(ctx context.Context, teamID uuid.UUID, groupBy string, interval string, from time.Time, to time.Time) ([]repository.SpendRow, error)
+	}
+
+	AnalyticsRepo struct {
+		Log logger.Logger
+		Db  *pg.DB
+	}
+)
+
+// spendKeys whitelists the expressions a spend can be grouped by, they are
+// interpolated in the query and must never come from the caller directly
+var spendKeys = map[string]string{
+	repository.SpendGroupByCard: "t.card_id::text",
+	repository.SpendGroupByUser: "COALESCE(w.user_id::text, '')",
+	repository.SpendGroupByMCC:  "COALESCE(t.mcc, '')",
+	repository.SpendGroupByDay:  "''",
+}
+
+var spendIntervals = map[string]bool{
+	repository.SpendIntervalDay:   true,
+	repository.SpendIntervalWeek:  true,
+	repository.SpendIntervalMonth: true,
+}
+
+// CreateAnalyticsRepository creates analytics repository
+func CreateAnalyticsRepository(log logger.Logger, db *pg.DB) AnalyticsRepository {
+	return &AnalyticsRepo{
+		Log: log,
+		Db:  db,
+	}
+}
+
+// GetTeamSpend sums the card spend of the team wallet and of the wallets of
+// its active members, per group, currency and time bucket
+func (r *AnalyticsRepo) GetTeamSpend(ctx context.Context, teamID uuid.UUID, groupBy string, interval string, from time.Time, to time.Time) ([]repository.SpendRow, error) {
+	rows := []repository.SpendRow{}
+
+	key, ok := spendKeys[groupBy]
+	if !ok {
+		return nil, errors.InvalidSpendGroupBy
+	}
+
+	if !spendIntervals[interval] {
+		return nil, errors.InvalidSpendInterval
+	}
+
+	_, err := r.Db.WithContext(ctx).Query(&rows, `
+		SELECT date_trunc(?0, t.created_at) AS bucket, ?1 AS key, t.currency, SUM(t.amount) AS amount, COUNT(*) AS count
+		FROM "transaction" AS t
+		JOIN wallet AS w ON w.id = t.wallet_id
+		WHERE t.type = ?2 AND t.direction = ?3
+			AND t.created_at >= ?4 AND t.created_at < ?5
+			AND (w.team_id = ?6 OR w.user_id IN (
+				SELECT tm.user_id FROM team_member AS tm WHERE tm.team_id = ?6 AND tm.is_deleted = FALSE
+			))
+		GROUP BY 1, 2, 3
+		ORDER BY 2, 3, 1`,
+		interval, pg.Safe(key), repository.TransactionTypeCard, repository.TransactionDirectionDebit, from, to, teamID)
+	if err != nil {
+		return nil, errors.FailedSpendFetch.AppendError(err)
+	}
+
+	return rows, nil
+}
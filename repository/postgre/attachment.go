@@ -0,0 +1,78 @@
+package postgre
+
+import (
+	"context"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+)
+
+type (
+	AttachmentRepository interface {
+		GetAttachments(ctx context.Context, transactionID uuid.UUID, kind string) ([]repository.Attachment, error)
+		GetAttachmentByID(ctx context.Context, attachmentID uuid.UUID) (*repository.Attachment, error)
+		CreateAttachment(ctx context.Context, attachmentPayload *repository.Attachment) (*repository.Attachment, error)
+	}
+
+	AttachmentRepo struct {
+		Log logger.Logger
+		Db  *pg.DB
+	}
+)
+
+// CreateAttachmentRepository creates attachment repository
+func CreateAttachmentRepository(log logger.Logger, db *pg.DB) AttachmentRepository {
+	return &AttachmentRepo{
+		Log: log,
+		Db:  db,
+	}
+}
+
+// GetAttachments returns the attachments of a transaction, oldest first
+func (r *AttachmentRepo) GetAttachments(ctx context.Context, transactionID uuid.UUID, kind string) ([]repository.Attachment, error) {
+	attachments := []repository.Attachment{}
+
+	sql := r.Db.WithContext(ctx).Model(&attachments).Where("transaction_id = ?", transactionID)
+
+	if kind != "" {
+		sql = sql.Where("kind = ?", kind)
+	}
+
+	err := sql.Order("created_at ASC").Select()
+	if err != nil {
+		return nil, errors.FailedAttachmentsFetch.AppendError(err)
+	}
+
+	return attachments, nil
+}
+
+// GetAttachmentByID ...
+func (r *AttachmentRepo) GetAttachmentByID(ctx context.Context, attachmentID uuid.UUID) (*repository.Attachment, error) {
+	attachment := repository.Attachment{}
+
+	err := r.Db.WithContext(ctx).Model(&attachment).Where("id = ?", attachmentID).Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, errors.FailedAttachmentNotFound
+		}
+
+		return nil, errors.FailedAttachmentFetch.AppendError(err)
+	}
+
+	return &attachment, nil
+}
+
+// CreateAttachment ...
+func (r *AttachmentRepo) CreateAttachment(ctx context.Context, attachmentPayload *repository.Attachment) (*repository.Attachment, error) {
+	var attachment repository.Attachment
+
+	_, err := r.Db.WithContext(ctx).Model(attachmentPayload).Returning("*").Insert(&attachment)
+	if err != nil {
+		return nil, errors.FailedAttachmentCreate.AppendError(err)
+	}
+
+	return &attachment, nil
+}
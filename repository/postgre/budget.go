@@ -0,0 +1,199 @@
+package postgre
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+type (
+	BudgetRepository interface {
+		GetBudgets(ctx context.Context, walletID uuid.UUID, cardID uuid.UUID, sortBy string, sort string, skip int, limit int) ([]repository.Budget, error)
+		GetBudgetByID(ctx context.Context, budgetID uuid.UUID) (*repository.Budget, error)
+		GetActiveBudgets(ctx context.Context, walletID uuid.UUID, cardID uuid.UUID) ([]repository.Budget, error)
+		CreateBudget(ctx context.Context, budgetPayload *repository.Budget) (*repository.Budget, error)
+		UpdateBudget(ctx context.Context, budgetID uuid.UUID, budgetPayload map[string]interface{}) (*repository.Budget, error)
+		DeleteBudget(ctx context.Context, budgetID uuid.UUID) (*repository.Budget, error)
+		GetBudgetSpend(ctx context.Context, budget *repository.Budget, from time.Time, to time.Time) (money.Decimal, error)
+		GetBudgetAlerts(ctx context.Context, budgetID uuid.UUID) ([]repository.BudgetAlert, error)
+		CreateBudgetAlert(ctx context.Context, alertPayload *repository.BudgetAlert) (bool, error)
+	}
+
+	BudgetRepo struct {
+		Log logger.Logger
+		Db  *pg.DB
+	}
+)
+
+var (
+	budgetTable = "budget"
+)
+
+// CreateBudgetRepository creates budget repository
+func CreateBudgetRepository(log logger.Logger, db *pg.DB) BudgetRepository {
+	return &BudgetRepo{
+		Log: log,
+		Db:  db,
+	}
+}
+
+// GetBudgets ...
+func (r *BudgetRepo) GetBudgets(ctx context.Context, walletID uuid.UUID, cardID uuid.UUID, sortBy string, sort string, skip int, limit int) ([]repository.Budget, error) {
+	budgets := []repository.Budget{}
+
+	if sortBy == "" {
+		sortBy = "created_at"
+	}
+	if sort == "" {
+		sort = "DESC"
+	}
+	order := fmt.Sprintf("%s %s", sortBy, sort)
+
+	sql := r.Db.WithContext(ctx).Model(&budgets)
+
+	if walletID != uuid.Nil {
+		sql = sql.Where("wallet_id = ?", walletID)
+	}
+
+	if cardID != uuid.Nil {
+		sql = sql.Where("card_id = ?", cardID)
+	}
+
+	err := sql.Limit(limit).Offset(skip).Order(order).Select()
+	if err != nil {
+		return nil, errors.FailedBudgetsFetch.AppendError(err)
+	}
+
+	return budgets, nil
+}
+
+// GetBudgetByID ...
+func (r *BudgetRepo) GetBudgetByID(ctx context.Context, budgetID uuid.UUID) (*repository.Budget, error) {
+	budget := repository.Budget{}
+
+	sql := r.Db.WithContext(ctx).Model(&budget).Where("id = ?", budgetID)
+
+	err := sql.Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, errors.FailedNoRows.AppendError(err)
+		}
+
+		return nil, errors.FailedBudgetFetch.AppendError(err)
+	}
+
+	return &budget, nil
+}
+
+// GetActiveBudgets returns the budgets covering a transaction of the wallet,
+// i.e. the budgets of the wallet itself and the budgets of the card used
+func (r *BudgetRepo) GetActiveBudgets(ctx context.Context, walletID uuid.UUID, cardID uuid.UUID) ([]repository.Budget, error) {
+	budgets := []repository.Budget{}
+
+	err := r.Db.WithContext(ctx).Model(&budgets).
+		Where("wallet_id = ?", walletID).
+		Where("is_deleted = FALSE").
+		WhereGroup(func(q *pg.Query) (*pg.Query, error) {
+			q = q.WhereOr("card_id IS NULL").WhereOr("card_id = ?", cardID)
+			return q, nil
+		}).
+		Select()
+	if err != nil {
+		return nil, errors.FailedBudgetsFetch.AppendError(err)
+	}
+
+	return budgets, nil
+}
+
+// CreateBudget ...
+func (r *BudgetRepo) CreateBudget(ctx context.Context, budgetPayload *repository.Budget) (*repository.Budget, error) {
+	var budget repository.Budget
+
+	_, err := r.Db.WithContext(ctx).Model(budgetPayload).Returning("*").Insert(&budget)
+	if err != nil {
+		return nil, errors.FailedBudgetCreate.AppendError(err)
+	}
+
+	return &budget, nil
+}
+
+func (r *BudgetRepo) UpdateBudget(ctx context.Context, budgetID uuid.UUID, budgetPayload map[string]interface{}) (*repository.Budget, error) {
+	budgetPayload["updated_at"] = time.Now()
+
+	var budget repository.Budget
+	_, err := r.Db.WithContext(ctx).Model(&budgetPayload).Table(budgetTable).Where("id = ?", budgetID).Returning("*").Update(&budget)
+	if err != nil {
+		return nil, errors.FailedBudgetUpdate.AppendError(err)
+	}
+
+	return &budget, nil
+}
+
+func (r *BudgetRepo) DeleteBudget(ctx context.Context, budgetID uuid.UUID) (*repository.Budget, error) {
+	budgetPayload := map[string]interface{}{
+		"is_deleted": true,
+		"updated_at": time.Now(),
+	}
+
+	var budget repository.Budget
+	_, err := r.Db.WithContext(ctx).Model(&budgetPayload).Table(budgetTable).Where("id = ?", budgetID).Returning("*").Update(&budget)
+	if err != nil {
+		return nil, errors.FailedBudgetDelete.AppendError(err)
+	}
+
+	return &budget, nil
+}
+
+// GetBudgetSpend sums the card spend counted by the budget in [from, to)
+func (r *BudgetRepo) GetBudgetSpend(ctx context.Context, budget *repository.Budget, from time.Time, to time.Time) (money.Decimal, error) {
+	var spend money.Decimal
+
+	sql := r.Db.WithContext(ctx).Model((*repository.Transaction)(nil)).
+		ColumnExpr("COALESCE(SUM(amount), 0)").
+		Where("wallet_id = ?", budget.WalletID).
+		Where("type = ?", repository.TransactionTypeCard).
+		Where("direction = ?", repository.TransactionDirectionDebit).
+		Where("created_at >= ?", from).
+		Where("created_at < ?", to)
+
+	if budget.CardID != uuid.Nil {
+		sql = sql.Where("card_id = ?", budget.CardID)
+	}
+
+	err := sql.Select(pg.Scan(&spend))
+	if err != nil {
+		return money.Decimal{}, errors.FailedSpendFetch.AppendError(err)
+	}
+
+	return spend, nil
+}
+
+// GetBudgetAlerts ...
+func (r *BudgetRepo) GetBudgetAlerts(ctx context.Context, budgetID uuid.UUID) ([]repository.BudgetAlert, error) {
+	alerts := []repository.BudgetAlert{}
+
+	err := r.Db.WithContext(ctx).Model(&alerts).Where("budget_id = ?", budgetID).Order("created_at DESC").Select()
+	if err != nil {
+		return nil, errors.FailedBudgetAlertsFetch.AppendError(err)
+	}
+
+	return alerts, nil
+}
+
+// CreateBudgetAlert records the alert unless the same threshold was already
+// reached in the period, it reports whether the alert is new
+func (r *BudgetRepo) CreateBudgetAlert(ctx context.Context, alertPayload *repository.BudgetAlert) (bool, error) {
+	res, err := r.Db.WithContext(ctx).Model(alertPayload).OnConflict("DO NOTHING").Insert()
+	if err != nil {
+		return false, errors.FailedBudgetAlertCreate.AppendError(err)
+	}
+
+	return res.RowsAffected() > 0, nil
+}
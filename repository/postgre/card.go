@@ -17,9 +17,12 @@ type (
 		GetAllCards(ctx context.Context, sortBy string, sort string, skip int, limit int) ([]repository.Card, error)
 		GetCards(ctx context.Context, walletID uuid.UUID, sortBy string, sort string, skip int, limit int) ([]repository.Card, error)
 		GetCardByID(ctx context.Context, cardID uuid.UUID) (*repository.Card, error)
+		GetCardsByWalletIDs(ctx context.Context, walletIDs []uuid.UUID) ([]repository.Card, error)
 		GetCardByCardNo(ctx context.Context, cardNo string) (*repository.Card, error)
 		CreateCard(ctx context.Context, cardPayload *repository.Card) (*repository.Card, error)
 		UpdateCard(ctx context.Context, cardID uuid.UUID, cardPayload map[string]interface{}) (*repository.Card, error)
+		GetCardForUpdate(ctx context.Context, tx *pg.Tx, cardID uuid.UUID) (*repository.Card, error)
+		UpdateCardInTx(ctx context.Context, tx *pg.Tx, cardID uuid.UUID, cardPayload map[string]interface{}) error
 		DeleteCardByID(ctx context.Context, cardID uuid.UUID) (*repository.Card, error)
 		DeleteCardsByWalletID(ctx context.Context, walletID uuid.UUID) ([]repository.Card, error)
 	}
@@ -105,6 +108,22 @@ func (r *CardRepo) GetCardByID(ctx context.Context, cardID uuid.UUID) (*reposito
 	return &card, nil
 }
 
+// GetCardsByWalletIDs fetches the cards of several wallets at once
+func (r *CardRepo) GetCardsByWalletIDs(ctx context.Context, walletIDs []uuid.UUID) ([]repository.Card, error) {
+	cards := []repository.Card{}
+
+	if len(walletIDs) == 0 {
+		return cards, nil
+	}
+
+	err := r.Db.WithContext(ctx).Model(&cards).WhereIn("wallet_id IN (?)", walletIDs).Order("created_at ASC").Select()
+	if err != nil {
+		return nil, errors.FailedCardsFetch.AppendError(err)
+	}
+
+	return cards, nil
+}
+
 // GetCardByCardNo ...
 func (r *CardRepo) GetCardByCardNo(ctx context.Context, cardNo string) (*repository.Card, error) {
 	card := repository.Card{}
@@ -147,6 +166,34 @@ func (r *CardRepo) UpdateCard(ctx context.Context, cardID uuid.UUID, cardPayload
 	return &card, nil
 }
 
+// GetCardForUpdate locks the card row until tx ends
+func (r *CardRepo) GetCardForUpdate(ctx context.Context, tx *pg.Tx, cardID uuid.UUID) (*repository.Card, error) {
+	card := repository.Card{}
+
+	err := tx.ModelContext(ctx, &card).Where("id = ?", cardID).For("UPDATE").Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, errors.FailedCardNotFound
+		}
+
+		return nil, errors.FailedCardFetch.AppendError(err)
+	}
+
+	return &card, nil
+}
+
+// UpdateCardInTx ...
+func (r *CardRepo) UpdateCardInTx(ctx context.Context, tx *pg.Tx, cardID uuid.UUID, cardPayload map[string]interface{}) error {
+	cardPayload["updated_at"] = time.Now()
+
+	_, err := tx.ModelContext(ctx, &cardPayload).Table(cardTable).Where("id = ?", cardID).Update()
+	if err != nil {
+		return errors.FailedCardUpdate.AppendError(err)
+	}
+
+	return nil
+}
+
 func (r *CardRepo) DeleteCardByID(ctx context.Context, cardID uuid.UUID) (*repository.Card, error) {
 	cardPayload := map[string]interface{}{
 		"is_deleted": true,
@@ -0,0 +1,104 @@
+package postgre
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+)
+
+type (
+	CardAuditRepository interface {
+		GetCardAudits(ctx context.Context, cardID uuid.UUID, skip int, limit int) ([]repository.CardAudit, error)
+		CreateCardAudit(ctx context.Context, auditPayload *repository.CardAudit) (*repository.CardAudit, error)
+		FreezeCard(ctx context.Context, cardID uuid.UUID, frozen bool, auditPayload *repository.CardAudit) (*repository.Card, error)
+	}
+
+	CardAuditRepo struct {
+		Log logger.Logger
+		Db  *pg.DB
+	}
+)
+
+// CreateCardAuditRepository creates card audit repository
+func CreateCardAuditRepository(log logger.Logger, db *pg.DB) CardAuditRepository {
+	return &CardAuditRepo{
+		Log: log,
+		Db:  db,
+	}
+}
+
+// GetCardAudits returns the audit trail of a card, latest first
+func (r *CardAuditRepo) GetCardAudits(ctx context.Context, cardID uuid.UUID, skip int, limit int) ([]repository.CardAudit, error) {
+	audits := []repository.CardAudit{}
+
+	err := r.Db.WithContext(ctx).Model(&audits).
+		Where("card_id = ?", cardID).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(skip).
+		Select()
+	if err != nil {
+		return nil, errors.FailedCardAuditsFetch.AppendError(err)
+	}
+
+	return audits, nil
+}
+
+// CreateCardAudit ...
+func (r *CardAuditRepo) CreateCardAudit(ctx context.Context, auditPayload *repository.CardAudit) (*repository.CardAudit, error) {
+	var audit repository.CardAudit
+	_, err := r.Db.WithContext(ctx).Model(auditPayload).Returning("*").Insert(&audit)
+	if err != nil {
+		return nil, errors.FailedCardAuditCreate.AppendError(err)
+	}
+
+	return &audit, nil
+}
+
+// FreezeCard sets the frozen state of a card and records its audit entry in
+// the same transaction. Nothing is recorded when the card already was in that
+// state.
+func (r *CardAuditRepo) FreezeCard(ctx context.Context, cardID uuid.UUID, frozen bool, auditPayload *repository.CardAudit) (*repository.Card, error) {
+	var card repository.Card
+
+	err := r.Db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		cardPayload := map[string]interface{}{
+			"is_frozen":  frozen,
+			"updated_at": time.Now(),
+		}
+
+		res, err := tx.ModelContext(ctx, &cardPayload).Table(cardTable).
+			Where("id = ?", cardID).
+			Where("is_frozen = ?", !frozen).
+			Returning("*").
+			Update(&card)
+		if err != nil {
+			return errors.FailedCardUpdate.AppendError(err)
+		}
+
+		if res.RowsAffected() == 0 && frozen {
+			return errors.CardAlreadyFrozen
+		}
+
+		if res.RowsAffected() == 0 {
+			return errors.CardNotFrozen
+		}
+
+		_, err = tx.ModelContext(ctx, auditPayload).Insert()
+		if err != nil {
+			return errors.FailedCardAuditCreate.AppendError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &card, nil
+}
@@ -0,0 +1,71 @@
+package postgre
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+)
+
+type (
+	ChartOfAccountsRepository interface {
+		GetChartOfAccounts(ctx context.Context, teamID uuid.UUID) (*repository.ChartOfAccounts, error)
+		SetChartOfAccounts(ctx context.Context, chartPayload *repository.ChartOfAccounts) (*repository.ChartOfAccounts, error)
+	}
+
+	ChartOfAccountsRepo struct {
+		Log logger.Logger
+		Db  *pg.DB
+	}
+)
+
+// CreateChartOfAccountsRepository creates chart of accounts repository
+func CreateChartOfAccountsRepository(log logger.Logger, db *pg.DB) ChartOfAccountsRepository {
+	return &ChartOfAccountsRepo{
+		Log: log,
+		Db:  db,
+	}
+}
+
+// GetChartOfAccounts ...
+func (r *ChartOfAccountsRepo) GetChartOfAccounts(ctx context.Context, teamID uuid.UUID) (*repository.ChartOfAccounts, error) {
+	chart := repository.ChartOfAccounts{}
+
+	err := r.Db.WithContext(ctx).Model(&chart).Where("team_id = ?", teamID).Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, errors.FailedChartOfAccountsNotFound
+		}
+
+		return nil, errors.FailedChartOfAccountsFetch.AppendError(err)
+	}
+
+	return &chart, nil
+}
+
+// SetChartOfAccounts creates the chart of accounts of a team or replaces it
+func (r *ChartOfAccountsRepo) SetChartOfAccounts(ctx context.Context, chartPayload *repository.ChartOfAccounts) (*repository.ChartOfAccounts, error) {
+	var chart repository.ChartOfAccounts
+
+	chartPayload.UpdatedAt = time.Now()
+
+	_, err := r.Db.WithContext(ctx).Model(chartPayload).
+		OnConflict("(team_id) DO UPDATE").
+		Set("wallet_account = EXCLUDED.wallet_account").
+		Set("expense_account = EXCLUDED.expense_account").
+		Set("income_account = EXCLUDED.income_account").
+		Set("transfer_account = EXCLUDED.transfer_account").
+		Set("categories = EXCLUDED.categories").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("*").
+		Insert(&chart)
+	if err != nil {
+		return nil, errors.FailedChartOfAccountsSet.AppendError(err)
+	}
+
+	return &chart, nil
+}
@@ -0,0 +1,136 @@
+package postgre
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+)
+
+type (
+	FundRequestRepository interface {
+		GetFundRequests(ctx context.Context, teamID uuid.UUID, requesterID uuid.UUID, status string, sortBy string, sort string, skip int, limit int) ([]repository.FundRequest, error)
+		GetFundRequestByID(ctx context.Context, fundRequestID uuid.UUID) (*repository.FundRequest, error)
+		CreateFundRequest(ctx context.Context, fundRequestPayload *repository.FundRequest) (*repository.FundRequest, error)
+		UpdatePendingFundRequest(ctx context.Context, fundRequestID uuid.UUID, fundRequestPayload map[string]interface{}) (*repository.FundRequest, error)
+		UpdatePendingFundRequestInTx(ctx context.Context, tx *pg.Tx, fundRequestID uuid.UUID, fundRequestPayload map[string]interface{}) (*repository.FundRequest, error)
+	}
+
+	FundRequestRepo struct {
+		Log logger.Logger
+		Db  *pg.DB
+	}
+)
+
+var (
+	fundRequestTable = "fund_request"
+)
+
+// CreateFundRequestRepository creates fund request repository
+func CreateFundRequestRepository(log logger.Logger, db *pg.DB) FundRequestRepository {
+	return &FundRequestRepo{
+		Log: log,
+		Db:  db,
+	}
+}
+
+// GetFundRequests ...
+func (r *FundRequestRepo) GetFundRequests(ctx context.Context, teamID uuid.UUID, requesterID uuid.UUID, status string, sortBy string, sort string, skip int, limit int) ([]repository.FundRequest, error) {
+	fundRequests := []repository.FundRequest{}
+
+	if sortBy == "" {
+		sortBy = "created_at"
+	}
+	if sort == "" {
+		sort = "DESC"
+	}
+	order := fmt.Sprintf("%s %s", sortBy, sort)
+
+	sql := r.Db.WithContext(ctx).Model(&fundRequests)
+
+	if teamID != uuid.Nil {
+		sql = sql.Where("team_id = ?", teamID)
+	}
+
+	if requesterID != uuid.Nil {
+		sql = sql.Where("requester_id = ?", requesterID)
+	}
+
+	if status != "" {
+		sql = sql.Where("status = ?", status)
+	}
+
+	err := sql.Limit(limit).Offset(skip).Order(order).Select()
+	if err != nil {
+		return nil, errors.FailedFundRequestsFetch.AppendError(err)
+	}
+
+	return fundRequests, nil
+}
+
+// GetFundRequestByID ...
+func (r *FundRequestRepo) GetFundRequestByID(ctx context.Context, fundRequestID uuid.UUID) (*repository.FundRequest, error) {
+	fundRequest := repository.FundRequest{}
+
+	sql := r.Db.WithContext(ctx).Model(&fundRequest).Where("id = ?", fundRequestID)
+
+	err := sql.Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, errors.FailedNoRows.AppendError(err)
+		}
+
+		return nil, errors.FailedFundRequestFetch.AppendError(err)
+	}
+
+	return &fundRequest, nil
+}
+
+// CreateFundRequest ...
+func (r *FundRequestRepo) CreateFundRequest(ctx context.Context, fundRequestPayload *repository.FundRequest) (*repository.FundRequest, error) {
+	var fundRequest repository.FundRequest
+	_, err := r.Db.WithContext(ctx).Model(fundRequestPayload).Returning("*").Insert(&fundRequest)
+	if err != nil {
+		return nil, errors.FailedFundRequestCreate.AppendError(err)
+	}
+
+	return &fundRequest, nil
+}
+
+// UpdatePendingFundRequest moves a pending fund request to another state, it
+// fails when the request was already reviewed or cancelled
+func (r *FundRequestRepo) UpdatePendingFundRequest(ctx context.Context, fundRequestID uuid.UUID, fundRequestPayload map[string]interface{}) (*repository.FundRequest, error) {
+	return updatePendingFundRequest(ctx, r.Db, fundRequestID, fundRequestPayload)
+}
+
+// UpdatePendingFundRequestInTx is UpdatePendingFundRequest in the transaction
+// moving the requested money
+func (r *FundRequestRepo) UpdatePendingFundRequestInTx(ctx context.Context, tx *pg.Tx, fundRequestID uuid.UUID, fundRequestPayload map[string]interface{}) (*repository.FundRequest, error) {
+	return updatePendingFundRequest(ctx, tx, fundRequestID, fundRequestPayload)
+}
+
+func updatePendingFundRequest(ctx context.Context, db orm.DB, fundRequestID uuid.UUID, fundRequestPayload map[string]interface{}) (*repository.FundRequest, error) {
+	fundRequestPayload["updated_at"] = time.Now()
+
+	var fundRequest repository.FundRequest
+	res, err := db.ModelContext(ctx, &fundRequestPayload).Table(fundRequestTable).
+		Where("id = ?", fundRequestID).
+		Where("status = ?", repository.FundRequestStatusPending).
+		Returning("*").
+		Update(&fundRequest)
+	if err != nil {
+		return nil, errors.FailedFundRequestUpdate.AppendError(err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return nil, errors.FundRequestNotPending
+	}
+
+	return &fundRequest, nil
+}
@@ -0,0 +1,188 @@
+package postgre
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+)
+
+type (
+	FundingRepository interface {
+		GetFundingSchedules(ctx context.Context, teamID uuid.UUID, sortBy string, sort string, skip int, limit int) ([]repository.FundingSchedule, error)
+		GetFundingScheduleByID(ctx context.Context, scheduleID uuid.UUID) (*repository.FundingSchedule, error)
+		GetDueFundingSchedules(ctx context.Context, now time.Time, limit int) ([]repository.FundingSchedule, error)
+		CreateFundingSchedule(ctx context.Context, schedulePayload *repository.FundingSchedule) (*repository.FundingSchedule, error)
+		UpdateFundingSchedule(ctx context.Context, scheduleID uuid.UUID, schedulePayload map[string]interface{}) (*repository.FundingSchedule, error)
+		DeleteFundingSchedule(ctx context.Context, scheduleID uuid.UUID) (*repository.FundingSchedule, error)
+		GetFundingRuns(ctx context.Context, scheduleID uuid.UUID, skip int, limit int) ([]repository.FundingRun, error)
+		CreateFundingRun(ctx context.Context, tx *pg.Tx, runPayload *repository.FundingRun) (*repository.FundingRun, error)
+		RecordFundingRun(ctx context.Context, runPayload *repository.FundingRun) (bool, error)
+	}
+
+	FundingRepo struct {
+		Log logger.Logger
+		Db  *pg.DB
+	}
+)
+
+var (
+	fundingScheduleTable = "funding_schedule"
+)
+
+// CreateFundingRepository creates funding repository
+func CreateFundingRepository(log logger.Logger, db *pg.DB) FundingRepository {
+	return &FundingRepo{
+		Log: log,
+		Db:  db,
+	}
+}
+
+// GetFundingSchedules ...
+func (r *FundingRepo) GetFundingSchedules(ctx context.Context, teamID uuid.UUID, sortBy string, sort string, skip int, limit int) ([]repository.FundingSchedule, error) {
+	schedules := []repository.FundingSchedule{}
+
+	if sortBy == "" {
+		sortBy = "created_at"
+	}
+	if sort == "" {
+		sort = "DESC"
+	}
+	order := fmt.Sprintf("%s %s", sortBy, sort)
+
+	sql := r.Db.WithContext(ctx).Model(&schedules)
+
+	if teamID != uuid.Nil {
+		sql = sql.Where("team_id = ?", teamID)
+	}
+
+	err := sql.Limit(limit).Offset(skip).Order(order).Select()
+	if err != nil {
+		return nil, errors.FailedFundingSchedulesFetch.AppendError(err)
+	}
+
+	return schedules, nil
+}
+
+// GetFundingScheduleByID ...
+func (r *FundingRepo) GetFundingScheduleByID(ctx context.Context, scheduleID uuid.UUID) (*repository.FundingSchedule, error) {
+	schedule := repository.FundingSchedule{}
+
+	sql := r.Db.WithContext(ctx).Model(&schedule).Where("id = ?", scheduleID)
+
+	err := sql.Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, errors.FailedNoRows.AppendError(err)
+		}
+
+		return nil, errors.FailedFundingScheduleFetch.AppendError(err)
+	}
+
+	return &schedule, nil
+}
+
+// GetDueFundingSchedules returns the active schedules with a run due at now,
+// the most overdue first
+func (r *FundingRepo) GetDueFundingSchedules(ctx context.Context, now time.Time, limit int) ([]repository.FundingSchedule, error) {
+	schedules := []repository.FundingSchedule{}
+
+	err := r.Db.WithContext(ctx).Model(&schedules).
+		Where("is_deleted = FALSE").
+		Where("next_run_at <= ?", now).
+		Order("next_run_at ASC").
+		Limit(limit).
+		Select()
+	if err != nil {
+		return nil, errors.FailedFundingSchedulesFetch.AppendError(err)
+	}
+
+	return schedules, nil
+}
+
+// CreateFundingSchedule ...
+func (r *FundingRepo) CreateFundingSchedule(ctx context.Context, schedulePayload *repository.FundingSchedule) (*repository.FundingSchedule, error) {
+	var schedule repository.FundingSchedule
+	_, err := r.Db.WithContext(ctx).Model(schedulePayload).Returning("*").Insert(&schedule)
+	if err != nil {
+		return nil, errors.FailedFundingScheduleCreate.AppendError(err)
+	}
+
+	return &schedule, nil
+}
+
+func (r *FundingRepo) UpdateFundingSchedule(ctx context.Context, scheduleID uuid.UUID, schedulePayload map[string]interface{}) (*repository.FundingSchedule, error) {
+	schedulePayload["updated_at"] = time.Now()
+
+	var schedule repository.FundingSchedule
+	_, err := r.Db.WithContext(ctx).Model(&schedulePayload).Table(fundingScheduleTable).Where("id = ?", scheduleID).Returning("*").Update(&schedule)
+	if err != nil {
+		return nil, errors.FailedFundingScheduleUpdate.AppendError(err)
+	}
+
+	return &schedule, nil
+}
+
+func (r *FundingRepo) DeleteFundingSchedule(ctx context.Context, scheduleID uuid.UUID) (*repository.FundingSchedule, error) {
+	schedulePayload := map[string]interface{}{
+		"is_deleted": true,
+		"updated_at": time.Now(),
+	}
+
+	var schedule repository.FundingSchedule
+	_, err := r.Db.WithContext(ctx).Model(&schedulePayload).Table(fundingScheduleTable).Where("id = ?", scheduleID).Returning("*").Update(&schedule)
+	if err != nil {
+		return nil, errors.FailedFundingScheduleDelete.AppendError(err)
+	}
+
+	return &schedule, nil
+}
+
+// GetFundingRuns returns the runs of a schedule, latest period first
+func (r *FundingRepo) GetFundingRuns(ctx context.Context, scheduleID uuid.UUID, skip int, limit int) ([]repository.FundingRun, error) {
+	runs := []repository.FundingRun{}
+
+	err := r.Db.WithContext(ctx).Model(&runs).
+		Where("schedule_id = ?", scheduleID).
+		Order("period DESC").
+		Limit(limit).
+		Offset(skip).
+		Select()
+	if err != nil {
+		return nil, errors.FailedFundingRunsFetch.AppendError(err)
+	}
+
+	return runs, nil
+}
+
+// CreateFundingRun records a run in the transaction moving its money, the
+// transaction is rolled back when the period already ran
+func (r *FundingRepo) CreateFundingRun(ctx context.Context, tx *pg.Tx, runPayload *repository.FundingRun) (*repository.FundingRun, error) {
+	var run repository.FundingRun
+	_, err := tx.ModelContext(ctx, runPayload).Returning("*").Insert(&run)
+	if err != nil {
+		if pgErr, ok := err.(pg.Error); ok && pgErr.Field('C') == pgUniqueViolation {
+			return nil, errors.FundingRunAlreadyExecuted
+		}
+
+		return nil, errors.FailedFundingRunCreate.AppendError(err)
+	}
+
+	return &run, nil
+}
+
+// RecordFundingRun records a run that moved no money, it reports false when
+// the period already ran
+func (r *FundingRepo) RecordFundingRun(ctx context.Context, runPayload *repository.FundingRun) (bool, error) {
+	res, err := r.Db.WithContext(ctx).Model(runPayload).OnConflict("(schedule_id, period) DO NOTHING").Insert()
+	if err != nil {
+		return false, errors.FailedFundingRunCreate.AppendError(err)
+	}
+
+	return res.RowsAffected() > 0, nil
+}
@@ -3,10 +3,15 @@ package postgre
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/go-pg/pg/v10"
 	"github.com/spf13/viper"
 	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type DbLogger struct {
@@ -29,6 +34,64 @@ func NewDbLogger(log logger.Logger) *DbLogger {
 	}
 }
 
+// DbTracer wraps the queries of a traced call in a span, the statement is
+// recorded with its placeholders so the values of the query are not exported
+type DbTracer struct {
+	Name string
+}
+
+func (d DbTracer) BeforeQuery(ctx context.Context, q *pg.QueryEvent) (context.Context, error) {
+	// NOTE: The queries outside of a trace, e.g. of the schedulers, do not
+	// start their own
+	if !trace.SpanFromContext(ctx).IsRecording() {
+		return ctx, nil
+	}
+
+	query, _ := q.UnformattedQuery()
+	operation := queryOperation(string(query))
+
+	ctx, _ = tracing.Start(ctx, "db."+strings.ToLower(operation),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBNameKey.String(d.Name),
+			semconv.DBOperationKey.String(operation),
+			semconv.DBStatementKey.String(string(query)),
+		),
+	)
+
+	return ctx, nil
+}
+
+func (d DbTracer) AfterQuery(ctx context.Context, q *pg.QueryEvent) error {
+	span := trace.SpanFromContext(ctx)
+	if q.Result != nil {
+		span.SetAttributes(attribute.Int("db.rows_affected", q.Result.RowsAffected()))
+	}
+	if q.Err != nil && q.Err != pg.ErrNoRows {
+		tracing.RecordError(span, q.Err)
+	}
+
+	span.End()
+	return nil
+}
+
+func NewDbTracer(dbName string) *DbTracer {
+	return &DbTracer{
+		Name: dbName,
+	}
+}
+
+// queryOperation is the first keyword of query, e.g. SELECT
+func queryOperation(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "QUERY"
+	}
+
+	return strings.ToUpper(fields[0])
+}
+
 // NewPostgreClient ...
 func NewPostgreClient(log logger.Logger, username, password, host string, port int, dbName string) *pg.DB {
 	addr := fmt.Sprintf("%s:%d", host, port)
@@ -44,6 +107,8 @@ func NewPostgreClient(log logger.Logger, username, password, host string, port i
 		db.AddQueryHook(dbLogger)
 	}
 
+	db.AddQueryHook(NewDbTracer(dbName))
+
 	_, err := db.Exec("SELECT 1")
 	if err != nil {
 		panic(err)
@@ -0,0 +1,65 @@
+package postgre
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/ratelimit"
+)
+
+type (
+	// RateLimitRepository is the ratelimit.Store shared by every instance
+	RateLimitRepository interface {
+		ratelimit.Store
+	}
+
+	RateLimitRepo struct {
+		Log logger.Logger
+		Db  *pg.DB
+	}
+)
+
+// CreateRateLimitRepository creates rate limit repository
+func CreateRateLimitRepository(log logger.Logger, db *pg.DB) RateLimitRepository {
+	return &RateLimitRepo{
+		Log: log,
+		Db:  db,
+	}
+}
+
+// Increment counts the request with an upsert, so concurrent replicas never
+// lose a request. The first request of a window drops the expired windows of
+// key.
+func (r *RateLimitRepo) Increment(ctx context.Context, key string, start time.Time, ttl time.Duration) (int, error) {
+	rateLimitPayload := repository.RateLimit{
+		Key:         key,
+		WindowStart: start,
+		Count:       1,
+		ExpiresAt:   start.Add(ttl),
+	}
+
+	_, err := r.Db.WithContext(ctx).Model(&rateLimitPayload).
+		OnConflict("(key, window_start) DO UPDATE").
+		Set("count = rate_limit.count + 1").
+		Returning("count").
+		Insert()
+	if err != nil {
+		return 0, errors.FailedRateLimitIncrement.AppendError(err)
+	}
+
+	if rateLimitPayload.Count == 1 {
+		_, err = r.Db.WithContext(ctx).Model((*repository.RateLimit)(nil)).
+			Where("key = ?", key).
+			Where("expires_at <= ?", start).
+			Delete()
+		if err != nil {
+			r.Log.Warnw("unable to drop expired rate limit windows", "key", key, "error", err)
+		}
+	}
+
+	return rateLimitPayload.Count, nil
+}
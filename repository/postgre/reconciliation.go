@@ -0,0 +1,125 @@
+package postgre
+
+import (
+	"context"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+)
+
+type (
+	ReconciliationRepository interface {
+		GetWalletDrifts(ctx context.Context, afterWalletID uuid.UUID, limit int) ([]repository.WalletDrift, error)
+		CorrectWalletDrift(ctx context.Context, walletID uuid.UUID) (*repository.Transaction, error)
+	}
+
+	ReconciliationRepo struct {
+		Log logger.Logger
+		Db  *pg.DB
+	}
+)
+
+// walletDriftQuery compares the balance of every active wallet with the sum
+// of its transactions. Both values are read in one statement so concurrent
+// transactions cannot skew the result.
+const walletDriftQuery = `
+	SELECT w.id AS wallet_id, w.currency, w.balance, l.ledger_balance, w.balance - l.ledger_balance AS drift,
+		l.transaction_count, l.last_transaction_at
+	FROM wallet AS w
+	CROSS JOIN LATERAL (
+		SELECT COALESCE(SUM(CASE WHEN t.direction = ?0 THEN t.amount ELSE -t.amount END), 0) AS ledger_balance,
+			COUNT(t.id) AS transaction_count, MAX(t.created_at) AS last_transaction_at
+		FROM "transaction" AS t
+		WHERE t.wallet_id = w.id
+	) AS l`
+
+// CreateReconciliationRepository creates reconciliation repository
+func CreateReconciliationRepository(log logger.Logger, db *pg.DB) ReconciliationRepository {
+	return &ReconciliationRepo{
+		Log: log,
+		Db:  db,
+	}
+}
+
+// GetWalletDrifts returns the active wallets ordered by id after
+// afterWalletID, so every wallet is visited once when paging
+func (r *ReconciliationRepo) GetWalletDrifts(ctx context.Context, afterWalletID uuid.UUID, limit int) ([]repository.WalletDrift, error) {
+	drifts := []repository.WalletDrift{}
+
+	_, err := r.Db.WithContext(ctx).Query(&drifts, walletDriftQuery+`
+		WHERE w.is_deleted = FALSE AND w.id > ?1
+		ORDER BY w.id
+		LIMIT ?2`,
+		repository.TransactionDirectionCredit, afterWalletID, limit)
+	if err != nil {
+		return nil, errors.FailedWalletDriftsFetch.AppendError(err)
+	}
+
+	return drifts, nil
+}
+
+// CorrectWalletDrift records an adjustment explaining the drift of a wallet
+// without changing its balance. The wallet row is locked and the drift
+// computed again, so a transaction posted meanwhile is never corrected
+// twice. Nothing is recorded when the wallet is in sync.
+func (r *ReconciliationRepo) CorrectWalletDrift(ctx context.Context, walletID uuid.UUID) (*repository.Transaction, error) {
+	var correction *repository.Transaction
+
+	err := r.Db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.ExecContext(ctx, `SELECT 1 FROM wallet WHERE id = ? FOR UPDATE`, walletID)
+		if err != nil {
+			return errors.FailedWalletDriftCorrect.AppendError(err)
+		}
+
+		var drift repository.WalletDrift
+		_, err = tx.QueryOneContext(ctx, &drift, walletDriftQuery+`
+			WHERE w.id = ?1`,
+			repository.TransactionDirectionCredit, walletID)
+		if err != nil {
+			if err == pg.ErrNoRows {
+				return errors.FailedWalletNotFound
+			}
+
+			return errors.FailedWalletDriftCorrect.AppendError(err)
+		}
+
+		if drift.Drift.Sign() == 0 {
+			return nil
+		}
+
+		direction := repository.TransactionDirectionCredit
+		amount := drift.Drift
+		if amount.Sign() < 0 {
+			direction = repository.TransactionDirectionDebit
+			amount = amount.Neg()
+		}
+
+		transactionPayload := repository.Transaction{
+			ID:          uuid.New(),
+			ReferenceID: uuid.New(),
+			WalletID:    walletID,
+			Type:        repository.TransactionTypeAdjustment,
+			Direction:   direction,
+			Amount:      amount,
+			Currency:    drift.Currency,
+			Description: repository.ReconciliationDescription,
+		}
+
+		var transaction repository.Transaction
+		_, err = tx.ModelContext(ctx, &transactionPayload).Returning("*").Insert(&transaction)
+		if err != nil {
+			return errors.FailedTransactionCreate.AppendError(err)
+		}
+
+		correction = &transaction
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return correction, nil
+}
@@ -0,0 +1,236 @@
+package postgre
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+type (
+	SpendLimitRepository interface {
+		GetSpendLimits(ctx context.Context, walletID uuid.UUID, cardID uuid.UUID, sortBy string, sort string, skip int, limit int) ([]repository.SpendLimit, error)
+		GetSpendLimitByID(ctx context.Context, spendLimitID uuid.UUID) (*repository.SpendLimit, error)
+		GetActiveSpendLimits(ctx context.Context, walletID uuid.UUID, cardID uuid.UUID) ([]repository.SpendLimit, error)
+		CreateSpendLimit(ctx context.Context, spendLimitPayload *repository.SpendLimit) (*repository.SpendLimit, error)
+		SetSpendLimit(ctx context.Context, spendLimitPayload *repository.SpendLimit) (*repository.SpendLimit, error)
+		UpdateSpendLimit(ctx context.Context, spendLimitID uuid.UUID, spendLimitPayload map[string]interface{}) (*repository.SpendLimit, error)
+		DeleteSpendLimit(ctx context.Context, spendLimitID uuid.UUID) (*repository.SpendLimit, error)
+		GetSpend(ctx context.Context, tx *pg.Tx, spendLimit *repository.SpendLimit, from time.Time) (money.Decimal, error)
+	}
+
+	SpendLimitRepo struct {
+		Log logger.Logger
+		Db  *pg.DB
+	}
+)
+
+var (
+	spendLimitTable = "spend_limit"
+)
+
+// pgUniqueViolation is the SQLSTATE raised when a unique index is violated
+const pgUniqueViolation = "23505"
+
+// CreateSpendLimitRepository creates spend limit repository
+func CreateSpendLimitRepository(log logger.Logger, db *pg.DB) SpendLimitRepository {
+	return &SpendLimitRepo{
+		Log: log,
+		Db:  db,
+	}
+}
+
+// GetSpendLimits ...
+func (r *SpendLimitRepo) GetSpendLimits(ctx context.Context, walletID uuid.UUID, cardID uuid.UUID, sortBy string, sort string, skip int, limit int) ([]repository.SpendLimit, error) {
+	spendLimits := []repository.SpendLimit{}
+
+	if sortBy == "" {
+		sortBy = "created_at"
+	}
+	if sort == "" {
+		sort = "DESC"
+	}
+	order := fmt.Sprintf("%s %s", sortBy, sort)
+
+	sql := r.Db.WithContext(ctx).Model(&spendLimits)
+
+	if walletID != uuid.Nil {
+		sql = sql.Where("wallet_id = ?", walletID)
+	}
+
+	if cardID != uuid.Nil {
+		sql = sql.Where("card_id = ?", cardID)
+	}
+
+	err := sql.Limit(limit).Offset(skip).Order(order).Select()
+	if err != nil {
+		return nil, errors.FailedSpendLimitsFetch.AppendError(err)
+	}
+
+	return spendLimits, nil
+}
+
+// GetSpendLimitByID ...
+func (r *SpendLimitRepo) GetSpendLimitByID(ctx context.Context, spendLimitID uuid.UUID) (*repository.SpendLimit, error) {
+	spendLimit := repository.SpendLimit{}
+
+	sql := r.Db.WithContext(ctx).Model(&spendLimit).Where("id = ?", spendLimitID)
+
+	err := sql.Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, errors.FailedNoRows.AppendError(err)
+		}
+
+		return nil, errors.FailedSpendLimitFetch.AppendError(err)
+	}
+
+	return &spendLimit, nil
+}
+
+// GetActiveSpendLimits returns the limits covering a debit of the wallet, i.e.
+// the limits of the wallet itself and the limits of the card used
+func (r *SpendLimitRepo) GetActiveSpendLimits(ctx context.Context, walletID uuid.UUID, cardID uuid.UUID) ([]repository.SpendLimit, error) {
+	spendLimits := []repository.SpendLimit{}
+
+	err := r.Db.WithContext(ctx).Model(&spendLimits).
+		Where("wallet_id = ?", walletID).
+		Where("is_deleted = FALSE").
+		WhereGroup(func(q *pg.Query) (*pg.Query, error) {
+			q = q.WhereOr("card_id IS NULL").WhereOr("card_id = ?", cardID)
+			return q, nil
+		}).
+		Select()
+	if err != nil {
+		return nil, errors.FailedSpendLimitsFetch.AppendError(err)
+	}
+
+	return spendLimits, nil
+}
+
+// CreateSpendLimit fails when the wallet or card already has an active limit
+// for the same period and window
+func (r *SpendLimitRepo) CreateSpendLimit(ctx context.Context, spendLimitPayload *repository.SpendLimit) (*repository.SpendLimit, error) {
+	var spendLimit repository.SpendLimit
+
+	_, err := r.Db.WithContext(ctx).Model(spendLimitPayload).Returning("*").Insert(&spendLimit)
+	if err != nil {
+		if pgErr, ok := err.(pg.Error); ok && pgErr.Field('C') == pgUniqueViolation {
+			return nil, errors.FailedSpendLimitExist
+		}
+
+		return nil, errors.FailedSpendLimitCreate.AppendError(err)
+	}
+
+	return &spendLimit, nil
+}
+
+// SetSpendLimit updates the amount of the active limit with the same owner,
+// period and window, or creates it
+func (r *SpendLimitRepo) SetSpendLimit(ctx context.Context, spendLimitPayload *repository.SpendLimit) (*repository.SpendLimit, error) {
+	var spendLimit repository.SpendLimit
+
+	err := r.Db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		sql := tx.ModelContext(ctx, &spendLimit).
+			Set("amount = ?", spendLimitPayload.Amount).
+			Set("updated_at = ?", time.Now()).
+			Where("wallet_id = ?", spendLimitPayload.WalletID).
+			Where("period = ?", spendLimitPayload.Period).
+			Where("window_type = ?", spendLimitPayload.WindowType).
+			Where("is_deleted = FALSE")
+
+		if spendLimitPayload.CardID != uuid.Nil {
+			sql = sql.Where("card_id = ?", spendLimitPayload.CardID)
+		} else {
+			sql = sql.Where("card_id IS NULL")
+		}
+
+		res, err := sql.Returning("*").Update()
+		if err != nil {
+			return errors.FailedSpendLimitUpdate.AppendError(err)
+		}
+
+		if res.RowsAffected() > 0 {
+			return nil
+		}
+
+		_, err = tx.ModelContext(ctx, spendLimitPayload).Returning("*").Insert(&spendLimit)
+		if err != nil {
+			return errors.FailedSpendLimitCreate.AppendError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &spendLimit, nil
+}
+
+func (r *SpendLimitRepo) UpdateSpendLimit(ctx context.Context, spendLimitID uuid.UUID, spendLimitPayload map[string]interface{}) (*repository.SpendLimit, error) {
+	spendLimitPayload["updated_at"] = time.Now()
+
+	var spendLimit repository.SpendLimit
+	_, err := r.Db.WithContext(ctx).Model(&spendLimitPayload).Table(spendLimitTable).Where("id = ?", spendLimitID).Returning("*").Update(&spendLimit)
+	if err != nil {
+		if pgErr, ok := err.(pg.Error); ok && pgErr.Field('C') == pgUniqueViolation {
+			return nil, errors.FailedSpendLimitExist
+		}
+
+		return nil, errors.FailedSpendLimitUpdate.AppendError(err)
+	}
+
+	return &spendLimit, nil
+}
+
+func (r *SpendLimitRepo) DeleteSpendLimit(ctx context.Context, spendLimitID uuid.UUID) (*repository.SpendLimit, error) {
+	spendLimitPayload := map[string]interface{}{
+		"is_deleted": true,
+		"updated_at": time.Now(),
+	}
+
+	var spendLimit repository.SpendLimit
+	_, err := r.Db.WithContext(ctx).Model(&spendLimitPayload).Table(spendLimitTable).Where("id = ?", spendLimitID).Returning("*").Update(&spendLimit)
+	if err != nil {
+		return nil, errors.FailedSpendLimitDelete.AppendError(err)
+	}
+
+	return &spendLimit, nil
+}
+
+// GetSpend sums the debits counted by the limit since from, a zero from
+// covers the whole history. It runs in the transaction posting the debit so
+// the spend cannot change before the debit is recorded.
+func (r *SpendLimitRepo) GetSpend(ctx context.Context, tx *pg.Tx, spendLimit *repository.SpendLimit, from time.Time) (money.Decimal, error) {
+	var spend money.Decimal
+
+	sql := tx.ModelContext(ctx, (*repository.Transaction)(nil)).
+		ColumnExpr("COALESCE(SUM(amount), 0)").
+		Where("wallet_id = ?", spendLimit.WalletID).
+		Where("direction = ?", repository.TransactionDirectionDebit)
+
+	// NOTE: A card only spends through card transactions, a wallet also spends through transfers
+	if spendLimit.CardID != uuid.Nil {
+		sql = sql.Where("card_id = ?", spendLimit.CardID).Where("type = ?", repository.TransactionTypeCard)
+	} else {
+		sql = sql.WhereIn("type IN (?)", []string{repository.TransactionTypeCard, repository.TransactionTypeTransfer})
+	}
+
+	if !from.IsZero() {
+		sql = sql.Where("created_at >= ?", from)
+	}
+
+	err := sql.Select(pg.Scan(&spend))
+	if err != nil {
+		return money.Decimal{}, errors.FailedSpendFetch.AppendError(err)
+	}
+
+	return spend, nil
+}
@@ -16,6 +16,7 @@ type (
 	TeamRepository interface {
 		GetAllTeams(ctx context.Context, sortBy string, sort string, skip int, limit int) ([]repository.Team, error)
 		GetTeamByID(ctx context.Context, teamID uuid.UUID) (*repository.Team, error)
+		GetTeamsByIDs(ctx context.Context, teamIDs []uuid.UUID) ([]repository.Team, error)
 		GetTeamByName(ctx context.Context, name string) (*repository.Team, error)
 		CreateTeam(ctx context.Context, teamPayload *repository.Team) (*repository.Team, error)
 		UpdateTeam(ctx context.Context, teamID uuid.UUID, teamPayload map[string]interface{}) (*repository.Team, error)
@@ -78,6 +79,22 @@ func (r *TeamRepo) GetTeamByID(ctx context.Context, teamID uuid.UUID) (*reposito
 	return &team, nil
 }
 
+// GetTeamsByIDs fetches several teams at once, unknown IDs are left out
+func (r *TeamRepo) GetTeamsByIDs(ctx context.Context, teamIDs []uuid.UUID) ([]repository.Team, error) {
+	teams := []repository.Team{}
+
+	if len(teamIDs) == 0 {
+		return teams, nil
+	}
+
+	err := r.Db.WithContext(ctx).Model(&teams).WhereIn("id IN (?)", teamIDs).Order("created_at ASC").Select()
+	if err != nil {
+		return nil, errors.FailedTeamsFetch.AppendError(err)
+	}
+
+	return teams, nil
+}
+
 // GetTeamByName ...
 func (r *TeamRepo) GetTeamByName(ctx context.Context, name string) (*repository.Team, error) {
 	team := repository.Team{}
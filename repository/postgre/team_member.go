@@ -16,7 +16,11 @@ type (
 	TeamMemberRepository interface {
 		GetTeamMember(ctx context.Context, teamID uuid.UUID, userID uuid.UUID) (*repository.TeamMember, error)
 		GetTeamMembers(ctx context.Context, teamID uuid.UUID, userID uuid.UUID, sortBy string, sort string, skip int, limit int) ([]repository.TeamMember, error)
+		GetTeamMembersByRole(ctx context.Context, teamID uuid.UUID, role string) ([]repository.TeamMember, error)
+		GetTeamMembersByTeamIDs(ctx context.Context, teamIDs []uuid.UUID) ([]repository.TeamMember, error)
+		GetTeamMembersByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]repository.TeamMember, error)
 		CreateTeamMember(ctx context.Context, teamMemberPayload *repository.TeamMember) (*repository.TeamMember, error)
+		UpdateTeamMember(ctx context.Context, teamID uuid.UUID, userID uuid.UUID, teamMemberPayload map[string]interface{}) (*repository.TeamMember, error)
 		DeleteTeamMember(ctx context.Context, teamID uuid.UUID, userID uuid.UUID) (*repository.TeamMember, error)
 		DeleteTeamMembersByTeamID(ctx context.Context, teamID uuid.UUID) ([]repository.TeamMember, error)
 		DeleteTeamMembersByUserID(ctx context.Context, userID uuid.UUID) ([]repository.TeamMember, error)
@@ -86,6 +90,55 @@ func (r *TeamMemberRepo) GetTeamMembers(ctx context.Context, teamID uuid.UUID, u
 	return teamMembers, nil
 }
 
+// GetTeamMembersByRole returns the active members of a team with role
+func (r *TeamMemberRepo) GetTeamMembersByRole(ctx context.Context, teamID uuid.UUID, role string) ([]repository.TeamMember, error) {
+	teamMembers := []repository.TeamMember{}
+
+	err := r.Db.WithContext(ctx).Model(&teamMembers).
+		Where("team_id = ?", teamID).
+		Where("role = ?", role).
+		Where("is_deleted = FALSE").
+		Order("created_at ASC").
+		Select()
+	if err != nil {
+		return nil, errors.FailedTeamMembersFetch.AppendError(err)
+	}
+
+	return teamMembers, nil
+}
+
+// GetTeamMembersByTeamIDs fetches the members of several teams at once
+func (r *TeamMemberRepo) GetTeamMembersByTeamIDs(ctx context.Context, teamIDs []uuid.UUID) ([]repository.TeamMember, error) {
+	teamMembers := []repository.TeamMember{}
+
+	if len(teamIDs) == 0 {
+		return teamMembers, nil
+	}
+
+	err := r.Db.WithContext(ctx).Model(&teamMembers).WhereIn("team_id IN (?)", teamIDs).Order("created_at ASC").Select()
+	if err != nil {
+		return nil, errors.FailedTeamMembersFetch.AppendError(err)
+	}
+
+	return teamMembers, nil
+}
+
+// GetTeamMembersByUserIDs fetches the memberships of several users at once
+func (r *TeamMemberRepo) GetTeamMembersByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]repository.TeamMember, error) {
+	teamMembers := []repository.TeamMember{}
+
+	if len(userIDs) == 0 {
+		return teamMembers, nil
+	}
+
+	err := r.Db.WithContext(ctx).Model(&teamMembers).WhereIn("user_id IN (?)", userIDs).Order("created_at ASC").Select()
+	if err != nil {
+		return nil, errors.FailedTeamMembersFetch.AppendError(err)
+	}
+
+	return teamMembers, nil
+}
+
 // CreateTeamMember ...
 func (r *TeamMemberRepo) CreateTeamMember(ctx context.Context, teamMemberPayload *repository.TeamMember) (*repository.TeamMember, error) {
 	var teamMember repository.TeamMember
@@ -98,6 +151,22 @@ func (r *TeamMemberRepo) CreateTeamMember(ctx context.Context, teamMemberPayload
 	return &teamMember, nil
 }
 
+func (r *TeamMemberRepo) UpdateTeamMember(ctx context.Context, teamID uuid.UUID, userID uuid.UUID, teamMemberPayload map[string]interface{}) (*repository.TeamMember, error) {
+	teamMemberPayload["updated_at"] = time.Now()
+
+	var teamMember repository.TeamMember
+	res, err := r.Db.WithContext(ctx).Model(&teamMemberPayload).Table(teamMemberTable).Where("team_id = ?", teamID).Where("user_id = ?", userID).Where("is_deleted = FALSE").Returning("*").Update(&teamMember)
+	if err != nil {
+		return nil, errors.FailedTeamMemberUpdate.AppendError(err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return nil, errors.FailedTeamMemberNotFound
+	}
+
+	return &teamMember, nil
+}
+
 func (r *TeamMemberRepo) DeleteTeamMember(ctx context.Context, teamID uuid.UUID, userID uuid.UUID) (*repository.TeamMember, error) {
 	teamMemberPayload := map[string]interface{}{
 		"is_deleted": true,
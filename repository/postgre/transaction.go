@@ -0,0 +1,358 @@
+package postgre
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+type (
+	TransactionRepository interface {
+		GetTransactions(ctx context.Context, walletID uuid.UUID, cardID uuid.UUID, category string, tag string, missingReceipt bool, sortBy string, sort string, skip int, limit int) ([]repository.Transaction, error)
+		GetTransactionByID(ctx context.Context, transactionID uuid.UUID) (*repository.Transaction, error)
+		UpdateTransaction(ctx context.Context, transactionID uuid.UUID, transactionPayload map[string]interface{}) (*repository.Transaction, error)
+		CreateTransfer(ctx context.Context, debit *repository.Transaction, credit *repository.Transaction, guard TransactionGuard) ([]repository.Transaction, error)
+		CreateCardTransaction(ctx context.Context, transactionPayload *repository.Transaction, guard TransactionGuard) (*repository.Transaction, error)
+		CreateAdjustment(ctx context.Context, transactionPayload *repository.Transaction) (*repository.Transaction, error)
+		GetBalanceAt(ctx context.Context, walletID uuid.UUID, at time.Time) (money.Decimal, error)
+		ForEachTransaction(ctx context.Context, walletID uuid.UUID, from time.Time, to time.Time, fn func(*repository.Transaction) error) error
+		ForEachTeamTransaction(ctx context.Context, teamID uuid.UUID, from time.Time, to time.Time, fn func(*repository.Transaction) error) error
+	}
+
+	TransactionRepo struct {
+		Log logger.Logger
+		Db  *pg.DB
+	}
+
+	// TransactionGuard runs in the database transaction posting a debit, once
+	// the wallet row is locked and before the debit is recorded. Returning an
+	// error rolls the debit back.
+	TransactionGuard func(ctx context.Context, tx *pg.Tx, transaction *repository.Transaction) error
+)
+
+var (
+	transactionTable = "transaction"
+)
+
+// ChainTransactionGuards runs every guard in order, skipping nil guards
+func ChainTransactionGuards(guards ...TransactionGuard) TransactionGuard {
+	return func(ctx context.Context, tx *pg.Tx, transaction *repository.Transaction) error {
+		for _, guard := range guards {
+			if guard == nil {
+				continue
+			}
+
+			if err := guard(ctx, tx, transaction); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// CreateTransactionRepository creates transaction repository
+func CreateTransactionRepository(log logger.Logger, db *pg.DB) TransactionRepository {
+	return &TransactionRepo{
+		Log: log,
+		Db:  db,
+	}
+}
+
+// GetTransactions lists the transactions matching every filter that is set.
+// With missingReceipt only the card debits without any receipt are returned.
+func (r *TransactionRepo) GetTransactions(ctx context.Context, walletID uuid.UUID, cardID uuid.UUID, category string, tag string, missingReceipt bool, sortBy string, sort string, skip int, limit int) ([]repository.Transaction, error) {
+	transactions := []repository.Transaction{}
+
+	if sortBy == "" {
+		sortBy = "created_at"
+	}
+	if sort == "" {
+		sort = "DESC"
+	}
+	order := fmt.Sprintf("%s %s", sortBy, sort)
+
+	sql := r.Db.WithContext(ctx).Model(&transactions)
+
+	if walletID != uuid.Nil {
+		sql = sql.Where("wallet_id = ?", walletID)
+	}
+
+	if cardID != uuid.Nil {
+		sql = sql.Where("card_id = ?", cardID)
+	}
+
+	if category != "" {
+		sql = sql.Where("category = ?", category)
+	}
+
+	if tag != "" {
+		sql = sql.Where("? = ANY(tags)", tag)
+	}
+
+	if missingReceipt {
+		sql = sql.
+			Where("type = ?", repository.TransactionTypeCard).
+			Where("direction = ?", repository.TransactionDirectionDebit).
+			Where("NOT EXISTS (SELECT 1 FROM attachment AS a WHERE a.transaction_id = ?TableAlias.id AND a.kind = ?)", repository.AttachmentKindReceipt)
+	}
+
+	err := sql.Limit(limit).Offset(skip).Order(order).Select()
+	if err != nil {
+		return nil, errors.FailedTransactionsFetch.AppendError(err)
+	}
+
+	return transactions, nil
+}
+
+// GetTransactionByID ...
+func (r *TransactionRepo) GetTransactionByID(ctx context.Context, transactionID uuid.UUID) (*repository.Transaction, error) {
+	transaction := repository.Transaction{}
+
+	sql := r.Db.WithContext(ctx).Model(&transaction).Where("id = ?", transactionID)
+
+	err := sql.Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, errors.FailedNoRows.AppendError(err)
+		}
+
+		return nil, errors.FailedTransactionFetch.AppendError(err)
+	}
+
+	return &transaction, nil
+}
+
+// UpdateTransaction only changes the expense metadata of a transaction, the
+// amounts of a posted transaction never change
+func (r *TransactionRepo) UpdateTransaction(ctx context.Context, transactionID uuid.UUID, transactionPayload map[string]interface{}) (*repository.Transaction, error) {
+	transactionPayload["updated_at"] = time.Now()
+
+	var transaction repository.Transaction
+	res, err := r.Db.WithContext(ctx).Model(&transactionPayload).Table(transactionTable).Where("id = ?", transactionID).Returning("*").Update(&transaction)
+	if err != nil {
+		return nil, errors.FailedTransactionUpdate.AppendError(err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return nil, errors.FailedTransactionNotFound
+	}
+
+	return &transaction, nil
+}
+
+// CreateTransfer debits and credits both wallets and records both legs atomically
+func (r *TransactionRepo) CreateTransfer(ctx context.Context, debit *repository.Transaction, credit *repository.Transaction, guard TransactionGuard) ([]repository.Transaction, error) {
+	var transactions []repository.Transaction
+
+	err := r.Db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if err := debitWallet(ctx, tx, debit.WalletID, debit.Amount); err != nil {
+			return err
+		}
+
+		if guard != nil {
+			if err := guard(ctx, tx, debit); err != nil {
+				return err
+			}
+		}
+
+		if err := creditWallet(ctx, tx, credit.WalletID, credit.Amount); err != nil {
+			return err
+		}
+
+		for _, transactionPayload := range []*repository.Transaction{debit, credit} {
+			var transaction repository.Transaction
+
+			_, err := tx.ModelContext(ctx, transactionPayload).Returning("*").Insert(&transaction)
+			if err != nil {
+				return errors.FailedTransactionCreate.AppendError(err)
+			}
+
+			transactions = append(transactions, transaction)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// CreateCardTransaction debits the wallet of the card and records the transaction atomically
+func (r *TransactionRepo) CreateCardTransaction(ctx context.Context, transactionPayload *repository.Transaction, guard TransactionGuard) (*repository.Transaction, error) {
+	var transaction repository.Transaction
+
+	err := r.Db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if err := debitWallet(ctx, tx, transactionPayload.WalletID, transactionPayload.Amount); err != nil {
+			return err
+		}
+
+		if guard != nil {
+			if err := guard(ctx, tx, transactionPayload); err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.ModelContext(ctx, transactionPayload).Returning("*").Insert(&transaction)
+		if err != nil {
+			return errors.FailedTransactionCreate.AppendError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &transaction, nil
+}
+
+// CreateAdjustment credits or debits the wallet according to the direction of
+// the transaction and records it atomically
+func (r *TransactionRepo) CreateAdjustment(ctx context.Context, transactionPayload *repository.Transaction) (*repository.Transaction, error) {
+	var transaction repository.Transaction
+
+	err := r.Db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		var err error
+		if transactionPayload.Direction == repository.TransactionDirectionDebit {
+			err = debitWallet(ctx, tx, transactionPayload.WalletID, transactionPayload.Amount)
+		} else {
+			err = creditWallet(ctx, tx, transactionPayload.WalletID, transactionPayload.Amount)
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ModelContext(ctx, transactionPayload).Returning("*").Insert(&transaction)
+		if err != nil {
+			return errors.FailedTransactionCreate.AppendError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &transaction, nil
+}
+
+// GetBalanceAt returns the balance of the wallet right before at, by reverting
+// every transaction recorded since. Both values are read in one statement so
+// concurrent transactions cannot skew the result.
+func (r *TransactionRepo) GetBalanceAt(ctx context.Context, walletID uuid.UUID, at time.Time) (money.Decimal, error) {
+	var balance money.Decimal
+
+	_, err := r.Db.WithContext(ctx).QueryOne(pg.Scan(&balance), `
+		SELECT w.balance - COALESCE((
+			SELECT SUM(CASE WHEN t.direction = ? THEN t.amount ELSE -t.amount END)
+			FROM "transaction" AS t
+			WHERE t.wallet_id = w.id AND t.created_at >= ?
+		), 0)
+		FROM wallet AS w
+		WHERE w.id = ?`, repository.TransactionDirectionCredit, at, walletID)
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return money.Decimal{}, errors.FailedNoRows.AppendError(err)
+		}
+
+		return money.Decimal{}, errors.FailedTransactionsFetch.AppendError(err)
+	}
+
+	return balance, nil
+}
+
+// ForEachTransaction streams the transactions of a wallet created in [from, to)
+// in chronological order without loading them all in memory
+func (r *TransactionRepo) ForEachTransaction(ctx context.Context, walletID uuid.UUID, from time.Time, to time.Time, fn func(*repository.Transaction) error) error {
+	err := r.Db.WithContext(ctx).Model((*repository.Transaction)(nil)).
+		Where("wallet_id = ?", walletID).
+		Where("created_at >= ?", from).
+		Where("created_at < ?", to).
+		Order("created_at ASC", "id ASC").
+		ForEach(fn)
+	if err != nil {
+		if _, ok := err.(e.Error); ok {
+			return err
+		}
+
+		return errors.FailedTransactionsFetch.AppendError(err)
+	}
+
+	return nil
+}
+
+// ForEachTeamTransaction streams the transactions created in [from, to) on the
+// team wallets and on the wallets of the active members of the team, in
+// chronological order
+func (r *TransactionRepo) ForEachTeamTransaction(ctx context.Context, teamID uuid.UUID, from time.Time, to time.Time, fn func(*repository.Transaction) error) error {
+	err := r.Db.WithContext(ctx).Model((*repository.Transaction)(nil)).
+		Where(`wallet_id IN (
+			SELECT w.id FROM wallet AS w
+			WHERE w.team_id = ?0 OR w.user_id IN (
+				SELECT tm.user_id FROM team_member AS tm WHERE tm.team_id = ?0 AND tm.is_deleted = FALSE
+			)
+		)`, teamID).
+		Where("created_at >= ?", from).
+		Where("created_at < ?", to).
+		Order("created_at ASC", "id ASC").
+		ForEach(fn)
+	if err != nil {
+		if _, ok := err.(e.Error); ok {
+			return err
+		}
+
+		return errors.FailedTransactionsFetch.AppendError(err)
+	}
+
+	return nil
+}
+
+// debitWallet only succeeds when the wallet holds enough balance, the check
+// and the update happen in a single statement so concurrent debits are safe.
+func debitWallet(ctx context.Context, tx *pg.Tx, walletID uuid.UUID, amount money.Decimal) error {
+	res, err := tx.ModelContext(ctx, (*repository.Wallet)(nil)).
+		Set("balance = balance - ?", amount).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", walletID).
+		Where("is_deleted = FALSE").
+		Where("balance >= ?", amount).
+		Update()
+	if err != nil {
+		return errors.FailedWalletUpdate.AppendError(err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return errors.NotEnoughBalance
+	}
+
+	return nil
+}
+
+func creditWallet(ctx context.Context, tx *pg.Tx, walletID uuid.UUID, amount money.Decimal) error {
+	res, err := tx.ModelContext(ctx, (*repository.Wallet)(nil)).
+		Set("balance = balance + ?", amount).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", walletID).
+		Where("is_deleted = FALSE").
+		Update()
+	if err != nil {
+		return errors.FailedWalletUpdate.AppendError(err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return errors.FailedWalletNotFound
+	}
+
+	return nil
+}
@@ -16,6 +16,7 @@ type (
 	UserRepository interface {
 		GetAllUsers(ctx context.Context, sortBy string, sort string, skip int, limit int) ([]repository.User, error)
 		GetUserByID(ctx context.Context, userID uuid.UUID) (*repository.User, error)
+		GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID) ([]repository.User, error)
 		GetUserByEmail(ctx context.Context, email string) (*repository.User, error)
 		CreateUser(ctx context.Context, userPayload *repository.User) (*repository.User, error)
 		UpdateUser(ctx context.Context, userID uuid.UUID, userPayload map[string]interface{}) (*repository.User, error)
@@ -78,6 +79,22 @@ func (r *UserRepo) GetUserByID(ctx context.Context, userID uuid.UUID) (*reposito
 	return &user, nil
 }
 
+// GetUsersByIDs fetches several users at once, unknown IDs are left out
+func (r *UserRepo) GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID) ([]repository.User, error) {
+	users := []repository.User{}
+
+	if len(userIDs) == 0 {
+		return users, nil
+	}
+
+	err := r.Db.WithContext(ctx).Model(&users).WhereIn("id IN (?)", userIDs).Order("created_at ASC").Select()
+	if err != nil {
+		return nil, errors.FailedUsersFetch.AppendError(err)
+	}
+
+	return users, nil
+}
+
 // GetUserByEmail ...
 func (r *UserRepo) GetUserByEmail(ctx context.Context, email string) (*repository.User, error) {
 	user := repository.User{}
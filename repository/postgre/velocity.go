@@ -0,0 +1,105 @@
+package postgre
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+	"gitlab.com/renodesper/spenmo-test/util/velocity"
+)
+
+type (
+	// VelocityRepository is the velocity.Store shared by every instance
+	VelocityRepository interface {
+		velocity.Store
+	}
+
+	VelocityRepo struct {
+		Log logger.Logger
+		Db  *pg.DB
+	}
+)
+
+// CreateVelocityRepository creates velocity repository
+func CreateVelocityRepository(log logger.Logger, db *pg.DB) VelocityRepository {
+	return &VelocityRepo{
+		Log: log,
+		Db:  db,
+	}
+}
+
+// Record stores the event and drops the events of key older than ttl
+func (r *VelocityRepo) Record(ctx context.Context, key string, event velocity.Event, ttl time.Duration) error {
+	eventPayload := repository.VelocityEvent{
+		ID:        uuid.New(),
+		Key:       key,
+		Amount:    event.Amount,
+		CreatedAt: event.At,
+	}
+
+	_, err := r.Db.WithContext(ctx).Model(&eventPayload).Insert()
+	if err != nil {
+		return errors.FailedVelocityEventCreate.AppendError(err)
+	}
+
+	_, err = r.Db.WithContext(ctx).Model((*repository.VelocityEvent)(nil)).
+		Where("key = ?", key).
+		Where("created_at < ?", event.At.Add(-ttl)).
+		Delete()
+	if err != nil {
+		r.Log.Warnw("unable to drop expired velocity events", "key", key, "error", err)
+	}
+
+	return nil
+}
+
+// Window ...
+func (r *VelocityRepo) Window(ctx context.Context, key string, since time.Time) (velocity.Window, error) {
+	var count int
+	var amount money.Decimal
+
+	err := r.Db.WithContext(ctx).Model((*repository.VelocityEvent)(nil)).
+		ColumnExpr("COUNT(*), COALESCE(SUM(amount), 0)").
+		Where("key = ?", key).
+		Where("created_at >= ?", since).
+		Select(pg.Scan(&count, &amount))
+	if err != nil {
+		return velocity.Window{}, errors.FailedVelocityEventsFetch.AppendError(err)
+	}
+
+	return velocity.Window{Count: count, Amount: amount}, nil
+}
+
+// HasMember ...
+func (r *VelocityRepo) HasMember(ctx context.Context, key string, member string) (bool, error) {
+	exists, err := r.Db.WithContext(ctx).Model((*repository.VelocityMember)(nil)).
+		Where("key = ?", key).
+		Where("member = ?", member).
+		Exists()
+	if err != nil {
+		return false, errors.FailedVelocityMemberFetch.AppendError(err)
+	}
+
+	return exists, nil
+}
+
+// AddMember ...
+func (r *VelocityRepo) AddMember(ctx context.Context, key string, member string) error {
+	memberPayload := repository.VelocityMember{
+		Key:       key,
+		Member:    member,
+		CreatedAt: time.Now(),
+	}
+
+	_, err := r.Db.WithContext(ctx).Model(&memberPayload).OnConflict("DO NOTHING").Insert()
+	if err != nil {
+		return errors.FailedVelocityMemberCreate.AppendError(err)
+	}
+
+	return nil
+}
@@ -17,6 +17,9 @@ type (
 		GetAllWallets(ctx context.Context, sortBy string, sort string, skip int, limit int) ([]repository.Wallet, error)
 		GetWallets(ctx context.Context, teamID uuid.UUID, userID uuid.UUID, sortBy string, sort string, skip int, limit int) ([]repository.Wallet, error)
 		GetWalletByID(ctx context.Context, walletID uuid.UUID) (*repository.Wallet, error)
+		GetWalletsByIDs(ctx context.Context, walletIDs []uuid.UUID) ([]repository.Wallet, error)
+		GetWalletsByTeamIDs(ctx context.Context, teamIDs []uuid.UUID) ([]repository.Wallet, error)
+		GetWalletsByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]repository.Wallet, error)
 		CreateWallet(ctx context.Context, walletPayload *repository.Wallet) (*repository.Wallet, error)
 		UpdateWallet(ctx context.Context, walletID uuid.UUID, walletPayload map[string]interface{}) (*repository.Wallet, error)
 		DeleteWalletByID(ctx context.Context, walletID uuid.UUID) (*repository.Wallet, error)
@@ -109,6 +112,54 @@ func (r *WalletRepo) GetWalletByID(ctx context.Context, walletID uuid.UUID) (*re
 	return &wallet, nil
 }
 
+// GetWalletsByIDs fetches several wallets at once, unknown IDs are left out
+func (r *WalletRepo) GetWalletsByIDs(ctx context.Context, walletIDs []uuid.UUID) ([]repository.Wallet, error) {
+	wallets := []repository.Wallet{}
+
+	if len(walletIDs) == 0 {
+		return wallets, nil
+	}
+
+	err := r.Db.WithContext(ctx).Model(&wallets).WhereIn("id IN (?)", walletIDs).Order("created_at ASC").Select()
+	if err != nil {
+		return nil, errors.FailedWalletsFetch.AppendError(err)
+	}
+
+	return wallets, nil
+}
+
+// GetWalletsByTeamIDs fetches the wallets of several teams at once
+func (r *WalletRepo) GetWalletsByTeamIDs(ctx context.Context, teamIDs []uuid.UUID) ([]repository.Wallet, error) {
+	wallets := []repository.Wallet{}
+
+	if len(teamIDs) == 0 {
+		return wallets, nil
+	}
+
+	err := r.Db.WithContext(ctx).Model(&wallets).WhereIn("team_id IN (?)", teamIDs).Order("created_at ASC").Select()
+	if err != nil {
+		return nil, errors.FailedWalletsFetch.AppendError(err)
+	}
+
+	return wallets, nil
+}
+
+// GetWalletsByUserIDs fetches the wallets of several users at once
+func (r *WalletRepo) GetWalletsByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]repository.Wallet, error) {
+	wallets := []repository.Wallet{}
+
+	if len(userIDs) == 0 {
+		return wallets, nil
+	}
+
+	err := r.Db.WithContext(ctx).Model(&wallets).WhereIn("user_id IN (?)", userIDs).Order("created_at ASC").Select()
+	if err != nil {
+		return nil, errors.FailedWalletsFetch.AppendError(err)
+	}
+
+	return wallets, nil
+}
+
 // CreateWallet ...
 func (r *WalletRepo) CreateWallet(ctx context.Context, walletPayload *repository.Wallet) (*repository.Wallet, error) {
 	var wallet repository.Wallet
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"time"
+)
+
+type (
+	// RateLimit counts the requests of a rate limit key in a window
+	RateLimit struct {
+		tableName struct{} `pg:"rate_limit"` //nolint
+
+		Key         string    `db:"key" json:"key"`
+		WindowStart time.Time `db:"windowStart" json:"windowStart"`
+		Count       int       `db:"count" json:"count"`
+		ExpiresAt   time.Time `db:"expiresAt" json:"expiresAt"`
+	}
+)
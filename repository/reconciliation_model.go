@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+type (
+	// WalletDrift compares the balance of a wallet with the sum of its
+	// transactions. Drift is Balance minus LedgerBalance, a wallet is in sync
+	// when it is zero.
+	WalletDrift struct {
+		WalletID          uuid.UUID     `db:"walletId" json:"walletId"`
+		Currency          string        `db:"currency" json:"currency"`
+		Balance           money.Decimal `db:"balance" json:"balance"`
+		LedgerBalance     money.Decimal `db:"ledgerBalance" json:"ledgerBalance"`
+		Drift             money.Decimal `db:"drift" json:"drift"`
+		TransactionCount  int           `db:"transactionCount" json:"transactionCount"`
+		LastTransactionAt time.Time     `db:"lastTransactionAt" json:"lastTransactionAt,omitempty"`
+	}
+)
+
+const (
+	ReconciliationDescription = "Reconciliation adjustment"
+)
@@ -0,0 +1,38 @@
+package repository
+
+type (
+	// SpendControls restrict where a card, or every card of a wallet, can
+	// spend. Blocked lists always win, an allowed list that is not empty only
+	// lets the listed values through. MCCs are either a single code such as
+	// "7311" or an inclusive range such as "7800-7802".
+	SpendControls struct {
+		AllowedMCCs      []string `json:"allowedMccs,omitempty"`
+		BlockedMCCs      []string `json:"blockedMccs,omitempty"`
+		AllowedMerchants []string `json:"allowedMerchants,omitempty"`
+		BlockedMerchants []string `json:"blockedMerchants,omitempty"`
+		AllowedCountries []string `json:"allowedCountries,omitempty"`
+		BlockedCountries []string `json:"blockedCountries,omitempty"`
+	}
+)
+
+const (
+	SpendControlScopeWallet = "wallet"
+	SpendControlScopeCard   = "card"
+
+	SpendControlReasonMCCBlocked         = "mcc_blocked"
+	SpendControlReasonMCCNotAllowed      = "mcc_not_allowed"
+	SpendControlReasonMerchantBlocked    = "merchant_blocked"
+	SpendControlReasonMerchantNotAllowed = "merchant_not_allowed"
+	SpendControlReasonCountryBlocked     = "country_blocked"
+	SpendControlReasonCountryNotAllowed  = "country_not_allowed"
+)
+
+// MarshalBinary ...
+func (u *SpendControls) MarshalBinary() ([]byte, error) {
+	return json.Marshal(u)
+}
+
+// UnmarshalBinary ...
+func (u *SpendControls) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, u)
+}
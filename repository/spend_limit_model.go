@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+type (
+	// SpendLimit caps the spend of a wallet, or of a single card when CardID
+	// is set, over a period. Calendar windows start at the beginning of the
+	// day, week, month or year in the timezone of the team, rolling windows
+	// cover the same duration up to the time of the transaction.
+	SpendLimit struct {
+		tableName struct{} `pg:"spend_limit"` //nolint
+
+		ID         uuid.UUID     `db:"id" json:"id"`
+		WalletID   uuid.UUID     `db:"walletId" json:"walletId"`
+		CardID     uuid.UUID     `db:"cardId" json:"cardId"`
+		Period     string        `db:"period" json:"period"`
+		WindowType string        `db:"windowType" json:"windowType"`
+		Amount     money.Decimal `db:"amount" json:"amount"`
+		Currency   string        `db:"currency" json:"currency"`
+		IsDeleted  bool          `db:"isDeleted" json:"isDeleted"`
+		CreatedAt  time.Time     `db:"createdAt" json:"createdAt"`
+		UpdatedAt  time.Time     `db:"updatedAt" json:"updatedAt"`
+	}
+)
+
+const (
+	SpendLimitPeriodTransaction = "transaction"
+	SpendLimitPeriodDaily       = "daily"
+	SpendLimitPeriodWeekly      = "weekly"
+	SpendLimitPeriodMonthly     = "monthly"
+	SpendLimitPeriodYearly      = "yearly"
+	SpendLimitPeriodLifetime    = "lifetime"
+
+	SpendLimitWindowCalendar = "calendar"
+	SpendLimitWindowRolling  = "rolling"
+)
+
+// MarshalBinary ...
+func (u *SpendLimit) MarshalBinary() ([]byte, error) {
+	return json.Marshal(u)
+}
+
+// UnmarshalBinary ...
+func (u *SpendLimit) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, u)
+}
@@ -7,18 +7,26 @@ import (
 )
 
 type (
+	// TeamMember links a user to a team. Admins review the fund requests of
+	// the team.
 	TeamMember struct {
 		tableName struct{} `pg:"team_member"` //nolint
 
 		ID        uuid.UUID `db:"id" json:"id"`
 		TeamID    uuid.UUID `db:"teamId" json:"teamId"`
 		UserID    uuid.UUID `db:"userId" json:"userId"`
+		Role      string    `db:"role" json:"role"`
 		IsDeleted bool      `db:"isDeleted" json:"isDeleted"`
 		CreatedAt time.Time `db:"createdAt" json:"createdAt"`
 		UpdatedAt time.Time `db:"updatedAt" json:"updatedAt"`
 	}
 )
 
+const (
+	TeamMemberRoleMember = "member"
+	TeamMemberRoleAdmin  = "admin"
+)
+
 // MarshalBinary ...
 func (u *TeamMember) MarshalBinary() ([]byte, error) {
 	return json.Marshal(u)
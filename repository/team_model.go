@@ -7,11 +7,14 @@ import (
 )
 
 type (
+	// Team owns wallets and members. Timezone is an IANA name used to
+	// compute the calendar windows of the spend limits of its wallets.
 	Team struct {
 		tableName struct{} `pg:"team"` //nolint
 
 		ID        uuid.UUID `db:"id" json:"id"`
 		Name      string    `db:"name" json:"name"`
+		Timezone  string    `db:"timezone" json:"timezone"`
 		IsDeleted bool      `db:"isDeleted" json:"isDeleted"`
 		CreatedAt time.Time `db:"createdAt" json:"createdAt"`
 		UpdatedAt time.Time `db:"updatedAt" json:"updatedAt"`
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+type (
+	// Transaction is a single movement of money on a wallet. A transfer is
+	// recorded as a debit and a credit sharing the same ReferenceID. The
+	// Original* and Fx* fields are only set on converted transactions.
+	Transaction struct {
+		tableName struct{} `pg:"transaction"` //nolint
+
+		ID                   uuid.UUID      `db:"id" json:"id"`
+		ReferenceID          uuid.UUID      `db:"referenceId" json:"referenceId"`
+		WalletID             uuid.UUID      `db:"walletId" json:"walletId"`
+		CardID               uuid.UUID      `db:"cardId" json:"cardId"`
+		CounterpartyWalletID uuid.UUID      `db:"counterpartyWalletId" json:"counterpartyWalletId"`
+		Type                 string         `db:"type" json:"type"`
+		Direction            string         `db:"direction" json:"direction"`
+		Amount               money.Decimal  `db:"amount" json:"amount"`
+		Currency             string         `db:"currency" json:"currency"`
+		OriginalAmount       *money.Decimal `db:"originalAmount" json:"originalAmount,omitempty"`
+		OriginalCurrency     string         `db:"originalCurrency" json:"originalCurrency,omitempty"`
+		FxRate               string         `db:"fxRate" json:"fxRate,omitempty"`
+		FxMarkupBps          uint64         `db:"fxMarkupBps" json:"fxMarkupBps,omitempty"`
+		FxRounding           string         `db:"fxRounding" json:"fxRounding,omitempty"`
+		Merchant             string         `db:"merchant" json:"merchant,omitempty"`
+		MerchantID           string         `db:"merchantId" json:"merchantId,omitempty"`
+		MCC                  string         `db:"mcc" json:"mcc,omitempty"`
+		Country              string         `db:"country" json:"country,omitempty"`
+		Description          string         `db:"description" json:"description,omitempty"`
+		Memo                 string         `db:"memo" json:"memo,omitempty"`
+		Category             string         `db:"category" json:"category,omitempty"`
+		Tags                 []string       `db:"tags" pg:",array" json:"tags,omitempty"`
+		CreatedAt            time.Time      `db:"createdAt" json:"createdAt"`
+		UpdatedAt            time.Time      `db:"updatedAt" json:"updatedAt"`
+	}
+)
+
+const (
+	TransactionTypeTransfer   = "transfer"
+	TransactionTypeCard       = "card"
+	TransactionTypeAdjustment = "adjustment"
+
+	TransactionDirectionDebit  = "debit"
+	TransactionDirectionCredit = "credit"
+)
+
+// MarshalBinary ...
+func (u *Transaction) MarshalBinary() ([]byte, error) {
+	return json.Marshal(u)
+}
+
+// UnmarshalBinary ...
+func (u *Transaction) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, u)
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+type (
+	// VelocityEvent is a counted event of a velocity key
+	VelocityEvent struct {
+		tableName struct{} `pg:"velocity_event"` //nolint
+
+		ID        uuid.UUID     `db:"id" json:"id"`
+		Key       string        `db:"key" json:"key"`
+		Amount    money.Decimal `db:"amount" json:"amount"`
+		CreatedAt time.Time     `db:"createdAt" json:"createdAt"`
+	}
+
+	// VelocityMember is a member of the set of a velocity key
+	VelocityMember struct {
+		tableName struct{} `pg:"velocity_member"` //nolint
+
+		Key       string    `db:"key" json:"key"`
+		Member    string    `db:"member" json:"member"`
+		CreatedAt time.Time `db:"createdAt" json:"createdAt"`
+	}
+)
@@ -4,21 +4,26 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/util/money"
 )
 
 type (
+	// Wallet limits are enforced as spend limits, DailyLimit and MonthlyLimit
+	// mirror its calendar daily and monthly SpendLimit.
 	Wallet struct {
 		tableName struct{} `pg:"wallet"` //nolint
 
-		ID           uuid.UUID `db:"id" json:"id"`
-		Balance      uint64    `db:"balance" json:"balance"`
-		DailyLimit   uint64    `db:"dailyLimit" json:"dailyLimit"`
-		MonthlyLimit uint64    `db:"monthlyLimit" json:"monthlyLimit"`
-		TeamID       uuid.UUID `db:"teamId" json:"teamId"`
-		UserID       uuid.UUID `db:"userId" json:"userId"`
-		IsDeleted    bool      `db:"isDeleted" json:"isDeleted"`
-		CreatedAt    time.Time `db:"createdAt" json:"createdAt"`
-		UpdatedAt    time.Time `db:"updatedAt" json:"updatedAt"`
+		ID           uuid.UUID      `db:"id" json:"id"`
+		Balance      money.Decimal  `db:"balance" json:"balance"`
+		DailyLimit   money.Decimal  `db:"dailyLimit" json:"dailyLimit"`
+		MonthlyLimit money.Decimal  `db:"monthlyLimit" json:"monthlyLimit"`
+		Currency     string         `db:"currency" json:"currency"`
+		TeamID       uuid.UUID      `db:"teamId" json:"teamId"`
+		UserID       uuid.UUID      `db:"userId" json:"userId"`
+		Controls     *SpendControls `db:"controls" json:"controls,omitempty"`
+		IsDeleted    bool           `db:"isDeleted" json:"isDeleted"`
+		CreatedAt    time.Time      `db:"createdAt" json:"createdAt"`
+		UpdatedAt    time.Time      `db:"updatedAt" json:"updatedAt"`
 	}
 )
 
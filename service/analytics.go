@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/repository/postgre"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+	"gitlab.com/renodesper/spenmo-test/util/tracing"
+)
+
+type (
+	// AnalyticsService ...
+	AnalyticsService interface {
+		GetTeamSpend(ctx context.Context, payload *GetTeamSpendRequest) (*TeamSpend, error)
+	}
+
+	AnalyticsSvc struct {
+		Log        logger.Logger
+		Analytics  postgre.AnalyticsRepository
+		TeamMember postgre.TeamMemberRepository
+	}
+
+	// GetTeamSpendRequest covers the card spend created in [From, To)
+	GetTeamSpendRequest struct {
+		TeamID   uuid.UUID
+		GroupBy  string
+		Interval string
+		From     time.Time
+		To       time.Time
+	}
+
+	// TeamSpend is a time series per group and currency, amounts in different
+	// currencies are never added together
+	TeamSpend struct {
+		TeamID   uuid.UUID     `json:"teamId"`
+		GroupBy  string        `json:"groupBy"`
+		Interval string        `json:"interval"`
+		From     time.Time     `json:"from"`
+		To       time.Time     `json:"to"`
+		Series   []SpendSeries `json:"series"`
+	}
+
+	SpendSeries struct {
+		Key      string        `json:"key"`
+		Currency string        `json:"currency"`
+		Total    money.Decimal `json:"total"`
+		Count    int           `json:"count"`
+		Points   []SpendPoint  `json:"points"`
+	}
+
+	SpendPoint struct {
+		Bucket time.Time     `json:"bucket"`
+		Amount money.Decimal `json:"amount"`
+		Count  int           `json:"count"`
+	}
+)
+
+// NewAnalyticsService creates analytics service
+func NewAnalyticsService(log logger.Logger, db *pg.DB) AnalyticsService {
+	analyticsRepo := postgre.CreateAnalyticsRepository(log, db)
+	teamMemberRepo := postgre.CreateTeamMemberRepository(log, db)
+
+	return &AnalyticsSvc{
+		Log:        log,
+		Analytics:  analyticsRepo,
+		TeamMember: teamMemberRepo,
+	}
+}
+
+func (s *AnalyticsSvc) GetTeamSpend(ctx context.Context, payload *GetTeamSpendRequest) (*TeamSpend, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsService.GetTeamSpend")
+	defer span.End()
+
+	if !payload.From.Before(payload.To) {
+		return nil, errors.InvalidSpendPeriod
+	}
+
+	if payload.GroupBy == "" {
+		payload.GroupBy = repository.SpendGroupByDay
+	}
+
+	if payload.Interval == "" || payload.GroupBy == repository.SpendGroupByDay {
+		payload.Interval = repository.SpendIntervalDay
+	}
+
+	err := requireTeamMember(ctx, s.TeamMember, payload.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.Analytics.GetTeamSpend(ctx, payload.TeamID, payload.GroupBy, payload.Interval, payload.From, payload.To)
+	if err != nil {
+		return nil, err
+	}
+
+	series := []SpendSeries{}
+	for _, row := range rows {
+		// NOTE: Rows are ordered by key and currency, so a series is a run of consecutive rows
+		last := len(series) - 1
+		if last < 0 || series[last].Key != row.Key || series[last].Currency != row.Currency {
+			series = append(series, SpendSeries{Key: row.Key, Currency: row.Currency, Points: []SpendPoint{}})
+			last++
+		}
+
+		total, err := series[last].Total.Add(row.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		series[last].Total = total
+		series[last].Count += row.Count
+		series[last].Points = append(series[last].Points, SpendPoint{
+			Bucket: row.Bucket,
+			Amount: row.Amount,
+			Count:  row.Count,
+		})
+	}
+
+	return &TeamSpend{
+		TeamID:   payload.TeamID,
+		GroupBy:  payload.GroupBy,
+		Interval: payload.Interval,
+		From:     payload.From,
+		To:       payload.To,
+		Series:   series,
+	}, nil
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	ctxUtil "gitlab.com/renodesper/spenmo-test/util/ctx"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+func TestGetTeamSpendIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	reinitializeDB()
+
+	ctx := context.Background()
+	svc := NewAnalyticsService(Log, DB)
+	transactionSvc := NewTransactionService(Log, DB, newTestConverter(t), Notifier, newTestFraudEngine(t))
+	cardSvc := NewCardService(Log, DB)
+
+	topUpTeamWallet(t, ctx, "d4a6607a-1af7-4571-bdff-2672be72ba0e", "933efe12-2219-42df-bd51-a2e84888432d", money.MustParseDecimal("1000.00"))
+
+	card, err := cardSvc.CreateCard(ctx, &CreateCardRequest{
+		CardNo:      "4012888888881881",
+		ExpiryMonth: "12",
+		ExpiryYear:  fmt.Sprintf("%d", time.Now().Year()+1),
+		CVV:         "123",
+		WalletID:    uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e"),
+	})
+	assert.NoError(t, err)
+
+	for _, mcc := range []string{"5812", "5812", "4121"} {
+		_, err := transactionSvc.CreateCardTransaction(ctx, &CreateCardTransactionRequest{
+			CardID:   card.ID,
+			Amount:   money.MustParseDecimal("10.00"),
+			Merchant: "ACME",
+			MCC:      mcc,
+		})
+		assert.NoError(t, err)
+	}
+
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+
+	cases := map[string]struct {
+		UserID         string
+		TeamID         string
+		GroupBy        string
+		From           time.Time
+		To             time.Time
+		ExpectedSeries int
+		ExpectedError  bool
+	}{
+		"SuccessGetTeamSpendByDay": {
+			UserID:         "8e159833-5078-4b0a-80a0-363d82bafd60",
+			TeamID:         "933efe12-2219-42df-bd51-a2e84888432d",
+			From:           from,
+			To:             to,
+			ExpectedSeries: 1,
+			ExpectedError:  false,
+		},
+		"SuccessGetTeamSpendByMCC": {
+			UserID:         "8e159833-5078-4b0a-80a0-363d82bafd60",
+			TeamID:         "933efe12-2219-42df-bd51-a2e84888432d",
+			GroupBy:        repository.SpendGroupByMCC,
+			From:           from,
+			To:             to,
+			ExpectedSeries: 2,
+			ExpectedError:  false,
+		},
+		"SuccessGetTeamSpendEmptyPeriod": {
+			UserID:         "8e159833-5078-4b0a-80a0-363d82bafd60",
+			TeamID:         "933efe12-2219-42df-bd51-a2e84888432d",
+			GroupBy:        repository.SpendGroupByCard,
+			From:           from.AddDate(0, -1, 0),
+			To:             from.AddDate(0, 0, -1),
+			ExpectedSeries: 0,
+			ExpectedError:  false,
+		},
+		"FailedInvalidPeriod": {
+			UserID:        "8e159833-5078-4b0a-80a0-363d82bafd60",
+			TeamID:        "933efe12-2219-42df-bd51-a2e84888432d",
+			From:          to,
+			To:            from,
+			ExpectedError: true,
+		},
+		"FailedUnauthenticated": {
+			TeamID:        "933efe12-2219-42df-bd51-a2e84888432d",
+			From:          from,
+			To:            to,
+			ExpectedError: true,
+		},
+		"FailedNotTeamMember": {
+			UserID:        "0e49e11c-660c-43c5-954e-ef9e89b45833",
+			TeamID:        "933efe12-2219-42df-bd51-a2e84888432d",
+			From:          from,
+			To:            to,
+			ExpectedError: true,
+		},
+	}
+
+	for v, tc := range cases {
+		t.Run(v, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.UserID != "" {
+				ctx = ctxUtil.SetUserID(ctx, uuid.MustParse(tc.UserID))
+			}
+
+			payload := GetTeamSpendRequest{
+				TeamID:  uuid.MustParse(tc.TeamID),
+				GroupBy: tc.GroupBy,
+				From:    tc.From,
+				To:      tc.To,
+			}
+
+			spend, err := svc.GetTeamSpend(ctx, &payload)
+
+			if tc.ExpectedError {
+				assert.Error(t, err)
+				assert.Nil(t, spend)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, spend.Series, tc.ExpectedSeries)
+
+				if v == "SuccessGetTeamSpendByDay" {
+					assert.Zero(t, money.MustParseDecimal("30.00").Cmp(spend.Series[0].Total))
+					assert.Equal(t, 3, spend.Series[0].Count)
+				}
+			}
+		})
+	}
+}
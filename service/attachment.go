@@ -0,0 +1,183 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/repository/postgre"
+	"gitlab.com/renodesper/spenmo-test/util/blob"
+	ctxUtil "gitlab.com/renodesper/spenmo-test/util/ctx"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/tracing"
+)
+
+type (
+	// AttachmentService ...
+	AttachmentService interface {
+		GetReceipts(ctx context.Context, transactionID uuid.UUID) ([]repository.Attachment, error)
+		GetReceipt(ctx context.Context, transactionID uuid.UUID, attachmentID uuid.UUID) (*repository.Attachment, io.ReadCloser, error)
+		CreateReceipt(ctx context.Context, payload *CreateReceiptRequest) (*repository.Attachment, error)
+	}
+
+	AttachmentSvc struct {
+		Log         logger.Logger
+		Attachment  postgre.AttachmentRepository
+		Transaction postgre.TransactionRepository
+		Store       blob.Store
+		MaxSize     int64
+	}
+
+	// CreateReceiptRequest attaches Content to a transaction, its type is
+	// sniffed from the content rather than trusted from the client
+	CreateReceiptRequest struct {
+		TransactionID uuid.UUID
+		FileName      string
+		Content       io.Reader
+	}
+)
+
+const RECEIPT_MAX_SIZE_DEFAULT = 10 << 20
+
+var receiptContentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"application/pdf": true,
+}
+
+// NewAttachmentService creates attachment service, receipts larger than
+// maxSize bytes are rejected
+func NewAttachmentService(log logger.Logger, db *pg.DB, store blob.Store, maxSize int64) AttachmentService {
+	attachmentRepo := postgre.CreateAttachmentRepository(log, db)
+	transactionRepo := postgre.CreateTransactionRepository(log, db)
+
+	if maxSize <= 0 {
+		maxSize = RECEIPT_MAX_SIZE_DEFAULT
+	}
+
+	return &AttachmentSvc{
+		Log:         log,
+		Attachment:  attachmentRepo,
+		Transaction: transactionRepo,
+		Store:       store,
+		MaxSize:     maxSize,
+	}
+}
+
+func (s *AttachmentSvc) GetReceipts(ctx context.Context, transactionID uuid.UUID) ([]repository.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.GetReceipts")
+	defer span.End()
+
+	_, err := s.Transaction.GetTransactionByID(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.Attachment.GetAttachments(ctx, transactionID, repository.AttachmentKindReceipt)
+	return attachments, err
+}
+
+// GetReceipt returns the receipt and its content, the caller closes the content
+func (s *AttachmentSvc) GetReceipt(ctx context.Context, transactionID uuid.UUID, attachmentID uuid.UUID) (*repository.Attachment, io.ReadCloser, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.GetReceipt")
+	defer span.End()
+
+	attachment, err := s.Attachment.GetAttachmentByID(ctx, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if attachment.TransactionID != transactionID || attachment.Kind != repository.AttachmentKindReceipt {
+		return nil, nil, errors.FailedAttachmentNotFound
+	}
+
+	content, err := s.Store.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return attachment, content, nil
+}
+
+func (s *AttachmentSvc) CreateReceipt(ctx context.Context, payload *CreateReceiptRequest) (*repository.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.CreateReceipt")
+	defer span.End()
+
+	transaction, err := s.Transaction.GetTransactionByID(ctx, payload.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.Content == nil {
+		return nil, errors.MissingReceipt
+	}
+
+	// NOTE: One byte past the limit is enough to know the receipt is too large
+	content, err := ioutil.ReadAll(io.LimitReader(payload.Content, s.MaxSize+1))
+	if err != nil {
+		return nil, errors.FailedBlobRead.AppendError(err)
+	}
+
+	if len(content) == 0 {
+		return nil, errors.MissingReceipt
+	}
+
+	if int64(len(content)) > s.MaxSize {
+		return nil, errors.ReceiptTooLarge
+	}
+
+	contentType := http.DetectContentType(content)
+	if !receiptContentTypes[contentType] {
+		return nil, errors.UnsupportedReceiptType
+	}
+
+	attachmentID := uuid.New()
+	storageKey := fmt.Sprintf("receipts/%s/%s", transaction.ID, attachmentID)
+
+	err = s.Store.Put(ctx, storageKey, bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	attachmentPayload := repository.Attachment{
+		ID:            attachmentID,
+		TransactionID: transaction.ID,
+		Kind:          repository.AttachmentKindReceipt,
+		FileName:      receiptFileName(payload.FileName),
+		ContentType:   contentType,
+		Size:          int64(len(content)),
+		StorageKey:    storageKey,
+		UploadedBy:    ctxUtil.GetUserID(ctx),
+	}
+
+	attachment, err := s.Attachment.CreateAttachment(ctx, &attachmentPayload)
+	if err != nil {
+		if deleteErr := s.Store.Delete(ctx, storageKey); deleteErr != nil {
+			s.Log.Errorw("unable to delete orphan receipt", "storageKey", storageKey, "error", deleteErr)
+		}
+
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// receiptFileName keeps the base name sent by the client for display only,
+// it is never used to build the storage key
+func receiptFileName(fileName string) string {
+	fileName = strings.TrimSpace(filepath.Base(strings.ReplaceAll(fileName, "\\", "/")))
+	if fileName == "" || fileName == "." || fileName == "/" {
+		return "receipt"
+	}
+
+	return fileName
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/util/blob/local"
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+func TestReceiptFileName(t *testing.T) {
+	cases := map[string]string{
+		"lunch.pdf":               "lunch.pdf",
+		"../../etc/passwd":        "passwd",
+		"C:\\receipts\\taxi.jpeg": "taxi.jpeg",
+		"":                        "receipt",
+		"/":                       "receipt",
+	}
+
+	for fileName, expected := range cases {
+		t.Run(fileName, func(t *testing.T) {
+			assert.Equal(t, expected, receiptFileName(fileName))
+		})
+	}
+}
+
+func TestCreateReceiptIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	reinitializeDB()
+
+	ctx := context.Background()
+	transactionSvc := NewTransactionService(Log, DB, newTestConverter(t), Notifier, newTestFraudEngine(t))
+	cardSvc := NewCardService(Log, DB)
+
+	store, err := local.CreateStore(t.TempDir())
+	assert.NoError(t, err)
+
+	svc := NewAttachmentService(Log, DB, store, 1024)
+
+	topUpTeamWallet(t, ctx, "d4a6607a-1af7-4571-bdff-2672be72ba0e", "933efe12-2219-42df-bd51-a2e84888432d", money.MustParseDecimal("1000.00"))
+
+	activeCard, err := cardSvc.CreateCard(ctx, &CreateCardRequest{
+		CardNo:      "4012888888881881",
+		ExpiryMonth: "12",
+		ExpiryYear:  fmt.Sprintf("%d", time.Now().Year()+1),
+		CVV:         "123",
+		WalletID:    uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e"),
+	})
+	assert.NoError(t, err)
+
+	transaction, err := transactionSvc.CreateCardTransaction(ctx, &CreateCardTransactionRequest{
+		CardID:   activeCard.ID,
+		Amount:   money.MustParseDecimal("10.00"),
+		Merchant: "Coffee Shop",
+	})
+	assert.NoError(t, err)
+
+	pdf := []byte("%PDF-1.4\n1 0 obj\n<<>>\nendobj\n")
+
+	cases := map[string]struct {
+		TransactionID uuid.UUID
+		Content       []byte
+		ExpectedError e.Error
+	}{
+		"SuccessCreateReceipt": {
+			TransactionID: transaction.ID,
+			Content:       pdf,
+		},
+		"FailedEmptyReceipt": {
+			TransactionID: transaction.ID,
+			Content:       []byte{},
+			ExpectedError: errors.MissingReceipt,
+		},
+		"FailedTooLarge": {
+			TransactionID: transaction.ID,
+			Content:       append(pdf, bytes.Repeat([]byte{' '}, 1024)...),
+			ExpectedError: errors.ReceiptTooLarge,
+		},
+		"FailedUnsupportedType": {
+			TransactionID: transaction.ID,
+			Content:       []byte("<html><body>receipt</body></html>"),
+			ExpectedError: errors.UnsupportedReceiptType,
+		},
+		"FailedUnknownTransaction": {
+			TransactionID: uuid.New(),
+			Content:       pdf,
+			ExpectedError: errors.FailedNoRows,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			attachment, err := svc.CreateReceipt(ctx, &CreateReceiptRequest{
+				TransactionID: tc.TransactionID,
+				FileName:      "receipt.pdf",
+				Content:       bytes.NewReader(tc.Content),
+			})
+
+			if tc.ExpectedError.Code != "" {
+				if assert.Error(t, err) {
+					assert.Equal(t, tc.ExpectedError.Code, err.(e.Error).Code)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "application/pdf", attachment.ContentType)
+			assert.Equal(t, int64(len(tc.Content)), attachment.Size)
+
+			_, content, err := svc.GetReceipt(ctx, tc.TransactionID, attachment.ID)
+			if assert.NoError(t, err) {
+				data, err := ioutil.ReadAll(content)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.Content, data)
+				assert.NoError(t, content.Close())
+			}
+		})
+	}
+
+	receipts, err := svc.GetReceipts(ctx, transaction.ID)
+	assert.NoError(t, err)
+	assert.Len(t, receipts, 1)
+}
+
+func TestTransactionMetadataIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	reinitializeDB()
+
+	ctx := context.Background()
+	transactionSvc := NewTransactionService(Log, DB, newTestConverter(t), Notifier, newTestFraudEngine(t))
+	cardSvc := NewCardService(Log, DB)
+
+	store, err := local.CreateStore(t.TempDir())
+	assert.NoError(t, err)
+
+	svc := NewAttachmentService(Log, DB, store, 0)
+
+	walletID := uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e")
+	topUpTeamWallet(t, ctx, walletID.String(), "933efe12-2219-42df-bd51-a2e84888432d", money.MustParseDecimal("1000.00"))
+
+	activeCard, err := cardSvc.CreateCard(ctx, &CreateCardRequest{
+		CardNo:      "4012888888881881",
+		ExpiryMonth: "12",
+		ExpiryYear:  fmt.Sprintf("%d", time.Now().Year()+1),
+		CVV:         "123",
+		WalletID:    walletID,
+	})
+	assert.NoError(t, err)
+
+	var transactionIDs []uuid.UUID
+	for i := 0; i < 2; i++ {
+		transaction, err := transactionSvc.CreateCardTransaction(ctx, &CreateCardTransactionRequest{
+			CardID:   activeCard.ID,
+			Amount:   money.MustParseDecimal("10.00"),
+			Merchant: "Coffee Shop",
+		})
+		assert.NoError(t, err)
+
+		transactionIDs = append(transactionIDs, transaction.ID)
+	}
+
+	updated, err := transactionSvc.UpdateTransaction(ctx, transactionIDs[0], &UpdateTransactionRequest{
+		Memo:     " Team coffee ",
+		Category: "Meals",
+		Tags:     []string{"Client", "client", "q3"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Team coffee", updated.Memo)
+	assert.Equal(t, "meals", updated.Category)
+	assert.Equal(t, []string{"client", "q3"}, updated.Tags)
+
+	_, err = transactionSvc.UpdateTransaction(ctx, uuid.New(), &UpdateTransactionRequest{Memo: "unknown"})
+	if assert.Error(t, err) {
+		assert.Equal(t, errors.FailedTransactionNotFound.Code, err.(e.Error).Code)
+	}
+
+	_, err = svc.CreateReceipt(ctx, &CreateReceiptRequest{
+		TransactionID: transactionIDs[0],
+		FileName:      "coffee.png",
+		Content:       strings.NewReader("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"),
+	})
+	assert.NoError(t, err)
+
+	cases := map[string]struct {
+		Request     GetTransactionsRequest
+		ExpectedIDs []uuid.UUID
+	}{
+		"FilterCategory": {
+			Request:     GetTransactionsRequest{WalletID: walletID, Category: "MEALS"},
+			ExpectedIDs: transactionIDs[:1],
+		},
+		"FilterTag": {
+			Request:     GetTransactionsRequest{WalletID: walletID, Tag: "client"},
+			ExpectedIDs: transactionIDs[:1],
+		},
+		"FilterMissingReceipt": {
+			Request:     GetTransactionsRequest{WalletID: walletID, MissingReceipt: true},
+			ExpectedIDs: transactionIDs[1:],
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.Request.Limit = 10
+
+			transactions, err := transactionSvc.GetTransactions(ctx, &tc.Request)
+			assert.NoError(t, err)
+
+			var ids []uuid.UUID
+			for _, transaction := range transactions {
+				ids = append(ids, transaction.ID)
+			}
+			assert.ElementsMatch(t, tc.ExpectedIDs, ids)
+		})
+	}
+}
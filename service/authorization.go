@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/repository/postgre"
+	ctxUtil "gitlab.com/renodesper/spenmo-test/util/ctx"
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+)
+
+// requireTeamMember only lets active members of the team through
+func requireTeamMember(ctx context.Context, teamMember postgre.TeamMemberRepository, teamID uuid.UUID) error {
+	_, err := getCallerMembership(ctx, teamMember, teamID)
+	return err
+}
+
+// requireTeamAdmin only lets active admins of the team through
+func requireTeamAdmin(ctx context.Context, teamMember postgre.TeamMemberRepository, teamID uuid.UUID) (*repository.TeamMember, error) {
+	member, err := getCallerMembership(ctx, teamMember, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if member.Role != repository.TeamMemberRoleAdmin {
+		return nil, errors.Forbidden
+	}
+
+	return member, nil
+}
+
+func getCallerMembership(ctx context.Context, teamMember postgre.TeamMemberRepository, teamID uuid.UUID) (*repository.TeamMember, error) {
+	userID := ctxUtil.GetUserID(ctx)
+	if userID == uuid.Nil {
+		return nil, errors.Unauthenticated
+	}
+
+	member, err := teamMember.GetTeamMember(ctx, teamID, userID)
+	if err != nil {
+		if er := err.(e.Error); er.Code == errors.FailedNoRows.Code {
+			return nil, errors.Forbidden
+		}
+
+		return nil, err
+	}
+
+	if member.IsDeleted {
+		return nil, errors.Forbidden
+	}
+
+	return member, nil
+}
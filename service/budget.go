@@ -0,0 +1,412 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/repository/postgre"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+	"gitlab.com/renodesper/spenmo-test/util/notifier"
+	"gitlab.com/renodesper/spenmo-test/util/tracing"
+)
+
+type (
+	// BudgetService ...
+	BudgetService interface {
+		GetBudgets(ctx context.Context, payload *GetBudgetsRequest) ([]repository.Budget, error)
+		GetBudget(ctx context.Context, budgetID uuid.UUID) (*repository.Budget, error)
+		CreateBudget(ctx context.Context, payload *CreateBudgetRequest) (*repository.Budget, error)
+		UpdateBudget(ctx context.Context, budgetID uuid.UUID, payload *UpdateBudgetRequest) (*repository.Budget, error)
+		DeleteBudget(ctx context.Context, budgetID uuid.UUID) (*repository.Budget, error)
+		GetBudgetAlerts(ctx context.Context, budgetID uuid.UUID) ([]repository.BudgetAlert, error)
+	}
+
+	BudgetSvc struct {
+		Log    logger.Logger
+		Budget postgre.BudgetRepository
+		Wallet postgre.WalletRepository
+		Card   postgre.CardRepository
+	}
+
+	// BudgetChecker raises the alerts of the budgets covering a transaction
+	// once it is posted
+	BudgetChecker struct {
+		Log      logger.Logger
+		Budget   postgre.BudgetRepository
+		Wallet   postgre.WalletRepository
+		Team     postgre.TeamRepository
+		Notifier notifier.Notifier
+	}
+
+	GetBudgetsRequest struct {
+		WalletID uuid.UUID
+		CardID   uuid.UUID
+		SortBy   string
+		Sort     string
+		Skip     int
+		Limit    int
+	}
+
+	// CreateBudgetRequest attaches a budget to a wallet, or to a card when
+	// CardID is set. Amount is expressed in the currency of the wallet.
+	CreateBudgetRequest struct {
+		Name       string
+		WalletID   uuid.UUID
+		CardID     uuid.UUID
+		Amount     money.Decimal
+		Period     string
+		Thresholds []int
+	}
+
+	UpdateBudgetRequest struct {
+		Name       string
+		Amount     money.Decimal
+		Period     string
+		Thresholds []int
+	}
+)
+
+const BUDGET_THRESHOLD_MAXIMUM = 1000
+
+// EventBudgetThresholdReached is emitted with the repository.BudgetAlert raised
+const EventBudgetThresholdReached = "budget.threshold_reached"
+
+var budgetThresholdsDefault = []int{50, 80, 100}
+
+// NewBudgetService creates budget service
+func NewBudgetService(log logger.Logger, db *pg.DB) BudgetService {
+	budgetRepo := postgre.CreateBudgetRepository(log, db)
+	walletRepo := postgre.CreateWalletRepository(log, db)
+	cardRepo := postgre.CreateCardRepository(log, db)
+
+	return &BudgetSvc{
+		Log:    log,
+		Budget: budgetRepo,
+		Wallet: walletRepo,
+		Card:   cardRepo,
+	}
+}
+
+// NewBudgetChecker creates budget checker
+func NewBudgetChecker(log logger.Logger, db *pg.DB, notify notifier.Notifier) *BudgetChecker {
+	budgetRepo := postgre.CreateBudgetRepository(log, db)
+	walletRepo := postgre.CreateWalletRepository(log, db)
+	teamRepo := postgre.CreateTeamRepository(log, db)
+
+	return &BudgetChecker{
+		Log:      log,
+		Budget:   budgetRepo,
+		Wallet:   walletRepo,
+		Team:     teamRepo,
+		Notifier: notify,
+	}
+}
+
+func (s *BudgetSvc) GetBudgets(ctx context.Context, payload *GetBudgetsRequest) ([]repository.Budget, error) {
+	ctx, span := tracing.Start(ctx, "BudgetService.GetBudgets")
+	defer span.End()
+
+	budgets, err := s.Budget.GetBudgets(ctx, payload.WalletID, payload.CardID, payload.SortBy, payload.Sort, payload.Skip, payload.Limit)
+	return budgets, err
+}
+
+func (s *BudgetSvc) GetBudget(ctx context.Context, budgetID uuid.UUID) (*repository.Budget, error) {
+	ctx, span := tracing.Start(ctx, "BudgetService.GetBudget")
+	defer span.End()
+
+	budget, err := s.Budget.GetBudgetByID(ctx, budgetID)
+	return budget, err
+}
+
+func (s *BudgetSvc) CreateBudget(ctx context.Context, payload *CreateBudgetRequest) (*repository.Budget, error) {
+	ctx, span := tracing.Start(ctx, "BudgetService.CreateBudget")
+	defer span.End()
+
+	if payload.WalletID == uuid.Nil && payload.CardID == uuid.Nil {
+		return nil, errors.MissingBudgetOwner
+	}
+
+	// NOTE: A card budget is stored with the wallet of the card so both are checked together
+	walletID := payload.WalletID
+	if payload.CardID != uuid.Nil {
+		existingCard, err := s.Card.GetCardByID(ctx, payload.CardID)
+		if err != nil {
+			return nil, err
+		}
+
+		if existingCard.IsDeleted {
+			return nil, errors.FailedCardNotFound
+		}
+
+		if walletID != uuid.Nil && walletID != existingCard.WalletID {
+			return nil, errors.InvalidBudgetOwner
+		}
+
+		walletID = existingCard.WalletID
+	}
+
+	wallet, err := s.Wallet.GetWalletByID(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if wallet.IsDeleted {
+		return nil, errors.FailedWalletNotFound
+	}
+
+	amount, err := newBudgetAmount(payload.Amount, wallet.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.Period == "" {
+		payload.Period = repository.BudgetPeriodMonthly
+	}
+
+	if _, _, err := budgetPeriod(payload.Period, time.Now(), time.UTC); err != nil {
+		return nil, err
+	}
+
+	if payload.Thresholds == nil {
+		payload.Thresholds = budgetThresholdsDefault
+	}
+
+	thresholds, err := newBudgetThresholds(payload.Thresholds)
+	if err != nil {
+		return nil, err
+	}
+
+	budgetPayload := repository.Budget{
+		ID:         uuid.New(),
+		Name:       payload.Name,
+		WalletID:   wallet.ID,
+		CardID:     payload.CardID,
+		Amount:     amount.Amount,
+		Currency:   amount.Currency,
+		Period:     payload.Period,
+		Thresholds: thresholds,
+	}
+
+	budget, err := s.Budget.CreateBudget(ctx, &budgetPayload)
+	return budget, err
+}
+
+func (s *BudgetSvc) UpdateBudget(ctx context.Context, budgetID uuid.UUID, payload *UpdateBudgetRequest) (*repository.Budget, error) {
+	ctx, span := tracing.Start(ctx, "BudgetService.UpdateBudget")
+	defer span.End()
+
+	var budgetPayload = make(map[string]interface{})
+
+	existingBudget, err := s.Budget.GetBudgetByID(ctx, budgetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingBudget.IsDeleted {
+		return nil, errors.FailedBudgetNotFound
+	}
+
+	if payload.Name != "" {
+		budgetPayload["name"] = payload.Name
+	}
+
+	if !payload.Amount.IsZero() {
+		amount, err := newBudgetAmount(payload.Amount, existingBudget.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		budgetPayload["amount"] = amount.Amount
+	}
+
+	if payload.Period != "" {
+		if _, _, err := budgetPeriod(payload.Period, time.Now(), time.UTC); err != nil {
+			return nil, err
+		}
+
+		budgetPayload["period"] = payload.Period
+	}
+
+	if payload.Thresholds != nil {
+		thresholds, err := newBudgetThresholds(payload.Thresholds)
+		if err != nil {
+			return nil, err
+		}
+
+		budgetPayload["thresholds"] = pg.Array(thresholds)
+	}
+
+	budget, err := s.Budget.UpdateBudget(ctx, budgetID, budgetPayload)
+	return budget, err
+}
+
+func (s *BudgetSvc) DeleteBudget(ctx context.Context, budgetID uuid.UUID) (*repository.Budget, error) {
+	ctx, span := tracing.Start(ctx, "BudgetService.DeleteBudget")
+	defer span.End()
+
+	budget, err := s.Budget.DeleteBudget(ctx, budgetID)
+	return budget, err
+}
+
+func (s *BudgetSvc) GetBudgetAlerts(ctx context.Context, budgetID uuid.UUID) ([]repository.BudgetAlert, error) {
+	ctx, span := tracing.Start(ctx, "BudgetService.GetBudgetAlerts")
+	defer span.End()
+
+	_, err := s.Budget.GetBudgetByID(ctx, budgetID)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts, err := s.Budget.GetBudgetAlerts(ctx, budgetID)
+	return alerts, err
+}
+
+// Check evaluates every budget covering the transaction and notifies each
+// threshold reached for the first time in the current period. Only card spend
+// counts towards a budget, other transactions are ignored.
+func (c *BudgetChecker) Check(ctx context.Context, transaction *repository.Transaction) error {
+	if transaction.Type != repository.TransactionTypeCard || transaction.Direction != repository.TransactionDirectionDebit {
+		return nil
+	}
+
+	budgets, err := c.Budget.GetActiveBudgets(ctx, transaction.WalletID, transaction.CardID)
+	if err != nil {
+		return err
+	}
+
+	if len(budgets) == 0 {
+		return nil
+	}
+
+	loc := c.location(ctx, transaction.WalletID)
+
+	for i := range budgets {
+		budget := &budgets[i]
+
+		from, to, err := budgetPeriod(budget.Period, transaction.CreatedAt, loc)
+		if err != nil {
+			return err
+		}
+
+		spent, err := c.Budget.GetBudgetSpend(ctx, budget, from, to)
+		if err != nil {
+			return err
+		}
+
+		for _, threshold := range budget.Thresholds {
+			if !isBudgetThresholdReached(spent, budget.Amount, threshold) {
+				break
+			}
+
+			alert := repository.BudgetAlert{
+				ID:            uuid.New(),
+				BudgetID:      budget.ID,
+				TransactionID: transaction.ID,
+				Threshold:     threshold,
+				PeriodStart:   from,
+				Spent:         spent,
+				Amount:        budget.Amount,
+				Currency:      budget.Currency,
+			}
+
+			created, err := c.Budget.CreateBudgetAlert(ctx, &alert)
+			if err != nil {
+				return err
+			}
+
+			// NOTE: The threshold was already raised in this period, possibly by a concurrent transaction
+			if !created {
+				continue
+			}
+
+			err = c.Notifier.Notify(ctx, notifier.Event{
+				Type:       EventBudgetThresholdReached,
+				Message:    fmt.Sprintf("budget %q reached %d%% of %s %s", budget.Name, threshold, budget.Amount, budget.Currency),
+				Data:       alert,
+				OccurredAt: time.Now(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// location returns the timezone of the team owning the wallet, falling back
+// to UTC
+func (c *BudgetChecker) location(ctx context.Context, walletID uuid.UUID) *time.Location {
+	wallet, err := c.Wallet.GetWalletByID(ctx, walletID)
+	if err != nil {
+		c.Log.Warnw("unable to fetch wallet of budget", "walletId", walletID, "error", err)
+		return time.UTC
+	}
+
+	return walletLocation(ctx, c.Log, c.Team, wallet)
+}
+
+func newBudgetAmount(amount money.Decimal, currency string) (money.Money, error) {
+	if amount.Sign() <= 0 {
+		return money.Money{}, errors.InvalidBudgetAmount
+	}
+
+	return money.New(amount, currency)
+}
+
+// newBudgetThresholds returns the thresholds sorted in ascending order
+func newBudgetThresholds(thresholds []int) ([]int, error) {
+	if len(thresholds) == 0 {
+		return nil, errors.InvalidBudgetThreshold
+	}
+
+	sorted := append([]int{}, thresholds...)
+	sort.Ints(sorted)
+
+	for i, threshold := range sorted {
+		if threshold < 1 || threshold > BUDGET_THRESHOLD_MAXIMUM {
+			return nil, errors.InvalidBudgetThreshold
+		}
+
+		if i > 0 && sorted[i-1] == threshold {
+			return nil, errors.InvalidBudgetThreshold
+		}
+	}
+
+	return sorted, nil
+}
+
+// budgetPeriod returns the calendar period [from, to) of the budget containing
+// at, the periods start at midnight in loc
+func budgetPeriod(period string, at time.Time, loc *time.Location) (time.Time, time.Time, error) {
+	at = at.In(loc)
+
+	switch period {
+	case repository.BudgetPeriodMonthly:
+		from := time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, loc)
+		return from, from.AddDate(0, 1, 0), nil
+	case repository.BudgetPeriodQuarterly:
+		month := (at.Month()-1)/3*3 + 1
+		from := time.Date(at.Year(), month, 1, 0, 0, 0, 0, loc)
+		return from, from.AddDate(0, 3, 0), nil
+	case repository.BudgetPeriodYearly:
+		from := time.Date(at.Year(), time.January, 1, 0, 0, 0, 0, loc)
+		return from, from.AddDate(1, 0, 0), nil
+	default:
+		return time.Time{}, time.Time{}, errors.InvalidBudgetPeriod
+	}
+}
+
+// isBudgetThresholdReached reports whether spent >= amount * threshold / 100
+func isBudgetThresholdReached(spent money.Decimal, amount money.Decimal, threshold int) bool {
+	limit := new(big.Rat).Mul(amount.Rat(), big.NewRat(int64(threshold), 100))
+	return spent.Rat().Cmp(limit) >= 0
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+	"gitlab.com/renodesper/spenmo-test/util/notifier"
+)
+
+type recordingNotifier struct {
+	mu     sync.Mutex
+	events []notifier.Event
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, event notifier.Event) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.events = append(n.events, event)
+	return nil
+}
+
+func TestBudgetPeriod(t *testing.T) {
+	at := time.Date(2021, time.August, 17, 10, 30, 0, 0, time.UTC)
+	singapore, err := time.LoadLocation("Asia/Singapore")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		Period        string
+		At            time.Time
+		Location      *time.Location
+		ExpectedFrom  time.Time
+		ExpectedTo    time.Time
+		ExpectedError bool
+	}{
+		"SuccessMonthly": {
+			Period:       repository.BudgetPeriodMonthly,
+			ExpectedFrom: time.Date(2021, time.August, 1, 0, 0, 0, 0, time.UTC),
+			ExpectedTo:   time.Date(2021, time.September, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"SuccessQuarterly": {
+			Period:       repository.BudgetPeriodQuarterly,
+			ExpectedFrom: time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC),
+			ExpectedTo:   time.Date(2021, time.October, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"SuccessYearly": {
+			Period:       repository.BudgetPeriodYearly,
+			ExpectedFrom: time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+			ExpectedTo:   time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"SuccessQuarterlyTeamTimezone": {
+			Period:       repository.BudgetPeriodQuarterly,
+			At:           time.Date(2021, time.June, 30, 17, 30, 0, 0, time.UTC),
+			Location:     singapore,
+			ExpectedFrom: time.Date(2021, time.July, 1, 0, 0, 0, 0, singapore),
+			ExpectedTo:   time.Date(2021, time.October, 1, 0, 0, 0, 0, singapore),
+		},
+		"FailedUnknownPeriod": {
+			Period:        "weekly",
+			ExpectedError: true,
+		},
+	}
+
+	for v, tc := range cases {
+		t.Run(v, func(t *testing.T) {
+			if tc.At.IsZero() {
+				tc.At = at
+			}
+			if tc.Location == nil {
+				tc.Location = time.UTC
+			}
+
+			from, to, err := budgetPeriod(tc.Period, tc.At, tc.Location)
+
+			if tc.ExpectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.ExpectedFrom, from)
+				assert.Equal(t, tc.ExpectedTo, to)
+			}
+		})
+	}
+}
+
+func TestIsBudgetThresholdReached(t *testing.T) {
+	amount := money.MustParseDecimal("2000000.00")
+
+	assert.False(t, isBudgetThresholdReached(money.MustParseDecimal("999999.99"), amount, 50))
+	assert.True(t, isBudgetThresholdReached(money.MustParseDecimal("1000000.00"), amount, 50))
+	assert.True(t, isBudgetThresholdReached(money.MustParseDecimal("2000000.01"), amount, 100))
+	assert.False(t, isBudgetThresholdReached(money.MustParseDecimal("2000000.00"), amount, 120))
+}
+
+func TestCreateBudgetIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	reinitializeDB()
+
+	cases := map[string]struct {
+		WalletID           string
+		CardID             string
+		Amount             money.Decimal
+		Period             string
+		Thresholds         []int
+		ExpectedThresholds []int
+		ExpectedError      bool
+	}{
+		"SuccessCreateWalletBudget": {
+			WalletID:           "d4a6607a-1af7-4571-bdff-2672be72ba0e",
+			Amount:             money.MustParseDecimal("2000000.00"),
+			Period:             repository.BudgetPeriodQuarterly,
+			Thresholds:         []int{100, 50, 80},
+			ExpectedThresholds: []int{50, 80, 100},
+			ExpectedError:      false,
+		},
+		"SuccessCreateCardBudgetWithDefaults": {
+			CardID:             "cb21fe95-37e7-4e67-aac3-1b633fe1036d",
+			Amount:             money.MustParseDecimal("1000.00"),
+			ExpectedThresholds: []int{50, 80, 100},
+			ExpectedError:      false,
+		},
+		"FailedMissingOwner": {
+			Amount:        money.MustParseDecimal("1000.00"),
+			ExpectedError: true,
+		},
+		"FailedCardOfAnotherWallet": {
+			WalletID:      "370a9739-b90b-4264-81a2-f8d0d3236011",
+			CardID:        "cb21fe95-37e7-4e67-aac3-1b633fe1036d",
+			Amount:        money.MustParseDecimal("1000.00"),
+			ExpectedError: true,
+		},
+		"FailedNegativeAmount": {
+			WalletID:      "d4a6607a-1af7-4571-bdff-2672be72ba0e",
+			Amount:        money.MustParseDecimal("-1000.00"),
+			ExpectedError: true,
+		},
+		"FailedDuplicateThreshold": {
+			WalletID:      "d4a6607a-1af7-4571-bdff-2672be72ba0e",
+			Amount:        money.MustParseDecimal("1000.00"),
+			Thresholds:    []int{80, 80},
+			ExpectedError: true,
+		},
+		"FailedUnknownPeriod": {
+			WalletID:      "d4a6607a-1af7-4571-bdff-2672be72ba0e",
+			Amount:        money.MustParseDecimal("1000.00"),
+			Period:        "weekly",
+			ExpectedError: true,
+		},
+	}
+
+	for v, tc := range cases {
+		t.Run(v, func(t *testing.T) {
+			ctx := context.Background()
+			svc := NewBudgetService(Log, DB)
+
+			payload := CreateBudgetRequest{
+				Name:       v,
+				Amount:     tc.Amount,
+				Period:     tc.Period,
+				Thresholds: tc.Thresholds,
+			}
+			if tc.WalletID != "" {
+				payload.WalletID = uuid.MustParse(tc.WalletID)
+			}
+			if tc.CardID != "" {
+				payload.CardID = uuid.MustParse(tc.CardID)
+			}
+
+			budget, err := svc.CreateBudget(ctx, &payload)
+
+			if tc.ExpectedError {
+				assert.Error(t, err)
+				assert.Nil(t, budget)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e"), budget.WalletID)
+				assert.Equal(t, tc.ExpectedThresholds, budget.Thresholds)
+			}
+		})
+	}
+}
+
+func TestBudgetCheckerIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	reinitializeDB()
+
+	ctx := context.Background()
+	notify := &recordingNotifier{}
+	svc := NewTransactionService(Log, DB, newTestConverter(t), notify, newTestFraudEngine(t))
+	budgetSvc := NewBudgetService(Log, DB)
+	cardSvc := NewCardService(Log, DB)
+
+	topUpTeamWallet(t, ctx, "d4a6607a-1af7-4571-bdff-2672be72ba0e", "933efe12-2219-42df-bd51-a2e84888432d", money.MustParseDecimal("1000.00"))
+
+	card, err := cardSvc.CreateCard(ctx, &CreateCardRequest{
+		CardNo:      "4012888888881881",
+		ExpiryMonth: "12",
+		ExpiryYear:  fmt.Sprintf("%d", time.Now().Year()+1),
+		CVV:         "123",
+		WalletID:    uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e"),
+	})
+	assert.NoError(t, err)
+
+	budget, err := budgetSvc.CreateBudget(ctx, &CreateBudgetRequest{
+		Name:     "Team budget",
+		WalletID: uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e"),
+		Amount:   money.MustParseDecimal("100.00"),
+	})
+	assert.NoError(t, err)
+
+	// NOTE: Each step is cumulative, the spend after it and the thresholds expected so far
+	steps := []struct {
+		Amount             money.Decimal
+		ExpectedThresholds []int
+	}{
+		{Amount: money.MustParseDecimal("40.00"), ExpectedThresholds: []int{}},
+		{Amount: money.MustParseDecimal("45.00"), ExpectedThresholds: []int{50, 80}},
+		{Amount: money.MustParseDecimal("5.00"), ExpectedThresholds: []int{50, 80}},
+		{Amount: money.MustParseDecimal("20.00"), ExpectedThresholds: []int{50, 80, 100}},
+		{Amount: money.MustParseDecimal("20.00"), ExpectedThresholds: []int{50, 80, 100}},
+	}
+
+	for _, step := range steps {
+		_, err := svc.CreateCardTransaction(ctx, &CreateCardTransactionRequest{
+			CardID:   card.ID,
+			Amount:   step.Amount,
+			Merchant: "ACME",
+		})
+		assert.NoError(t, err)
+
+		thresholds := []int{}
+		for _, event := range notify.events {
+			assert.Equal(t, EventBudgetThresholdReached, event.Type)
+			thresholds = append(thresholds, event.Data.(repository.BudgetAlert).Threshold)
+		}
+		assert.Equal(t, step.ExpectedThresholds, thresholds)
+	}
+
+	alerts, err := budgetSvc.GetBudgetAlerts(ctx, budget.ID)
+	assert.NoError(t, err)
+	assert.Len(t, alerts, 3)
+}
@@ -10,9 +10,12 @@ import (
 	"gitlab.com/renodesper/spenmo-test/repository"
 	"gitlab.com/renodesper/spenmo-test/repository/postgre"
 	"gitlab.com/renodesper/spenmo-test/util/card"
+	ctxUtil "gitlab.com/renodesper/spenmo-test/util/ctx"
 	e "gitlab.com/renodesper/spenmo-test/util/error"
 	"gitlab.com/renodesper/spenmo-test/util/errors"
 	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+	"gitlab.com/renodesper/spenmo-test/util/tracing"
 )
 
 type (
@@ -21,15 +24,22 @@ type (
 		GetAllCards(ctx context.Context, sortBy string, sort string, skip int, limit int) ([]repository.Card, error)
 		GetCards(ctx context.Context, payload *GetCardsRequest) ([]repository.Card, error)
 		GetCard(ctx context.Context, cardID uuid.UUID) (*repository.Card, error)
+		GetCardsByWalletIDs(ctx context.Context, walletIDs []uuid.UUID) ([]repository.Card, error)
 		CreateCard(ctx context.Context, payload *CreateCardRequest) (*repository.Card, error)
 		UpdateCard(ctx context.Context, cardID uuid.UUID, payload *UpdateCardRequest) (*repository.Card, error)
 		DeleteCardByID(ctx context.Context, cardID uuid.UUID) (*repository.Card, error)
 		DeleteCardsByWalletID(ctx context.Context, walletID uuid.UUID) ([]repository.Card, error)
+		FreezeCard(ctx context.Context, cardID uuid.UUID, reason string) (*repository.Card, error)
+		UnfreezeCard(ctx context.Context, cardID uuid.UUID, reason string) (*repository.Card, error)
+		GetCardAudits(ctx context.Context, cardID uuid.UUID, skip int, limit int) ([]repository.CardAudit, error)
 	}
 
 	CardSvc struct {
-		Log  logger.Logger
-		Card postgre.CardRepository
+		Log        logger.Logger
+		Card       postgre.CardRepository
+		Wallet     postgre.WalletRepository
+		SpendLimit postgre.SpendLimitRepository
+		CardAudit  postgre.CardAuditRepository
 	}
 
 	GetCardsRequest struct {
@@ -45,6 +55,8 @@ type (
 		ExpiryMonth string
 		ExpiryYear  string
 		CVV         string
+		Currency    string
+		Type        string
 		WalletID    uuid.UUID
 	}
 
@@ -54,11 +66,12 @@ type (
 		ExpiryMonth  string
 		ExpiryYear   string
 		CVV          string
-		DailyLimit   uint64
-		MonthlyLimit uint64
+		DailyLimit   money.Decimal
+		MonthlyLimit money.Decimal
 	}
 )
 
+// NOTE: The limits are in the minor unit of the wallet currency
 const CARD_DAILY_LIMIT_DEFAULT = 500000
 const CARD_MONTHLY_LIMIT_DEFAULT = 500000
 const CARD_LIMIT_MINIMUM = 10000
@@ -66,14 +79,23 @@ const CARD_LIMIT_MINIMUM = 10000
 // NewCardService creates user service
 func NewCardService(log logger.Logger, db *pg.DB) CardService {
 	cardRepo := postgre.CreateCardRepository(log, db)
+	walletRepo := postgre.CreateWalletRepository(log, db)
+	spendLimitRepo := postgre.CreateSpendLimitRepository(log, db)
+	cardAuditRepo := postgre.CreateCardAuditRepository(log, db)
 
 	return &CardSvc{
-		Log:  log,
-		Card: cardRepo,
+		Log:        log,
+		Card:       cardRepo,
+		Wallet:     walletRepo,
+		SpendLimit: spendLimitRepo,
+		CardAudit:  cardAuditRepo,
 	}
 }
 
 func (s *CardSvc) GetAllCards(ctx context.Context, sortBy string, sort string, skip int, limit int) ([]repository.Card, error) {
+	ctx, span := tracing.Start(ctx, "CardService.GetAllCards")
+	defer span.End()
+
 	var cards []repository.Card
 
 	cards, err := s.Card.GetAllCards(ctx, sortBy, sort, skip, limit)
@@ -89,16 +111,33 @@ func (s *CardSvc) GetAllCards(ctx context.Context, sortBy string, sort string, s
 }
 
 func (s *CardSvc) GetCards(ctx context.Context, payload *GetCardsRequest) ([]repository.Card, error) {
+	ctx, span := tracing.Start(ctx, "CardService.GetCards")
+	defer span.End()
+
 	cards, err := s.Card.GetCards(ctx, payload.WalletID, payload.SortBy, payload.Sort, payload.Skip, payload.Limit)
 	return cards, err
 }
 
 func (s *CardSvc) GetCard(ctx context.Context, cardID uuid.UUID) (*repository.Card, error) {
+	ctx, span := tracing.Start(ctx, "CardService.GetCard")
+	defer span.End()
+
 	card, err := s.Card.GetCardByID(ctx, cardID)
 	return card, err
 }
 
+func (s *CardSvc) GetCardsByWalletIDs(ctx context.Context, walletIDs []uuid.UUID) ([]repository.Card, error) {
+	ctx, span := tracing.Start(ctx, "CardService.GetCardsByWalletIDs")
+	defer span.End()
+
+	cards, err := s.Card.GetCardsByWalletIDs(ctx, walletIDs)
+	return cards, err
+}
+
 func (s *CardSvc) CreateCard(ctx context.Context, payload *CreateCardRequest) (*repository.Card, error) {
+	ctx, span := tracing.Start(ctx, "CardService.CreateCard")
+	defer span.End()
+
 	ID := uuid.New()
 
 	err := card.IsCardNumberValid(payload.CardNo)
@@ -126,6 +165,31 @@ func (s *CardSvc) CreateCard(ctx context.Context, payload *CreateCardRequest) (*
 		return nil, err
 	}
 
+	wallet, err := s.Wallet.GetWalletByID(ctx, payload.WalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: A card always spends in the currency of its wallet
+	if payload.Currency != "" {
+		currency, err := money.GetCurrency(payload.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		if currency.Code != wallet.Currency {
+			return nil, errors.CurrencyMismatch
+		}
+	}
+
+	switch payload.Type {
+	case "":
+		payload.Type = repository.CardTypeStandard
+	case repository.CardTypeStandard, repository.CardTypeSingleUse, repository.CardTypeMerchantLocked:
+	default:
+		return nil, errors.InvalidCardType
+	}
+
 	month, _ := strconv.Atoi(payload.ExpiryMonth)
 	if month > 9 {
 		payload.ExpiryMonth = fmt.Sprintf("%d", month)
@@ -133,21 +197,46 @@ func (s *CardSvc) CreateCard(ctx context.Context, payload *CreateCardRequest) (*
 		payload.ExpiryMonth = fmt.Sprintf("0%d", month)
 	}
 
+	walletCurrency, err := money.GetCurrency(wallet.Currency)
+	if err != nil {
+		return nil, err
+	}
+
 	cardPayload := repository.Card{
 		ID:           ID,
 		CardNo:       payload.CardNo,
 		ExpiryMonth:  payload.ExpiryMonth,
 		ExpiryYear:   payload.ExpiryYear,
 		CVV:          payload.CVV,
-		DailyLimit:   CARD_DAILY_LIMIT_DEFAULT,
-		MonthlyLimit: CARD_MONTHLY_LIMIT_DEFAULT,
+		DailyLimit:   walletCurrency.FromMinorUnits(CARD_DAILY_LIMIT_DEFAULT),
+		MonthlyLimit: walletCurrency.FromMinorUnits(CARD_MONTHLY_LIMIT_DEFAULT),
+		Currency:     wallet.Currency,
+		Type:         payload.Type,
 		WalletID:     payload.WalletID,
 	}
 	card, err := s.Card.CreateCard(ctx, &cardPayload)
-	return card, err
+	if err != nil {
+		return nil, err
+	}
+
+	limits := map[string]money.Decimal{
+		repository.SpendLimitPeriodDaily:   card.DailyLimit,
+		repository.SpendLimitPeriodMonthly: card.MonthlyLimit,
+	}
+	for period, amount := range limits {
+		err := setCalendarSpendLimit(ctx, s.SpendLimit, card.WalletID, card.ID, period, money.Money{Amount: amount, Currency: card.Currency})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return card, nil
 }
 
 func (s *CardSvc) UpdateCard(ctx context.Context, cardID uuid.UUID, payload *UpdateCardRequest) (*repository.Card, error) {
+	ctx, span := tracing.Start(ctx, "CardService.UpdateCard")
+	defer span.End()
+
 	var cardPayload = make(map[string]interface{})
 
 	existingCard, err := s.Card.GetCardByID(ctx, cardID)
@@ -205,32 +294,114 @@ func (s *CardSvc) UpdateCard(ctx context.Context, cardID uuid.UUID, payload *Upd
 		cardPayload["cvv"] = payload.CVV
 	}
 
-	if payload.DailyLimit > 0 && payload.DailyLimit < CARD_LIMIT_MINIMUM {
-		return nil, errors.InvalidDailyLimit
+	cardCurrency, err := money.GetCurrency(existingCard.Currency)
+	if err != nil {
+		return nil, err
 	}
 
-	if payload.MonthlyLimit > 0 && payload.MonthlyLimit < CARD_LIMIT_MINIMUM {
-		return nil, errors.InvalidMonthlyLimit
-	}
+	minimum := cardCurrency.FromMinorUnits(CARD_LIMIT_MINIMUM)
+	limits := map[string]money.Money{}
 
-	if payload.DailyLimit >= CARD_LIMIT_MINIMUM {
-		cardPayload["daily_limit"] = payload.DailyLimit
+	if !payload.DailyLimit.IsZero() {
+		dailyLimit, err := money.New(payload.DailyLimit, existingCard.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		if dailyLimit.Amount.Cmp(minimum) < 0 {
+			return nil, errors.InvalidDailyLimit
+		}
+
+		cardPayload["daily_limit"] = dailyLimit.Amount
+		limits[repository.SpendLimitPeriodDaily] = dailyLimit
 	}
 
-	if payload.MonthlyLimit >= CARD_LIMIT_MINIMUM {
-		cardPayload["monthly_limit"] = payload.MonthlyLimit
+	if !payload.MonthlyLimit.IsZero() {
+		monthlyLimit, err := money.New(payload.MonthlyLimit, existingCard.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		if monthlyLimit.Amount.Cmp(minimum) < 0 {
+			return nil, errors.InvalidMonthlyLimit
+		}
+
+		cardPayload["monthly_limit"] = monthlyLimit.Amount
+		limits[repository.SpendLimitPeriodMonthly] = monthlyLimit
 	}
 
 	card, err := s.Card.UpdateCard(ctx, cardID, cardPayload)
-	return card, err
+	if err != nil {
+		return nil, err
+	}
+
+	for period, amount := range limits {
+		err := setCalendarSpendLimit(ctx, s.SpendLimit, card.WalletID, card.ID, period, amount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return card, nil
 }
 
 func (s *CardSvc) DeleteCardByID(ctx context.Context, cardID uuid.UUID) (*repository.Card, error) {
+	ctx, span := tracing.Start(ctx, "CardService.DeleteCardByID")
+	defer span.End()
+
 	card, err := s.Card.DeleteCardByID(ctx, cardID)
 	return card, err
 }
 
 func (s *CardSvc) DeleteCardsByWalletID(ctx context.Context, walletID uuid.UUID) ([]repository.Card, error) {
+	ctx, span := tracing.Start(ctx, "CardService.DeleteCardsByWalletID")
+	defer span.End()
+
 	cards, err := s.Card.DeleteCardsByWalletID(ctx, walletID)
 	return cards, err
 }
+
+func (s *CardSvc) FreezeCard(ctx context.Context, cardID uuid.UUID, reason string) (*repository.Card, error) {
+	ctx, span := tracing.Start(ctx, "CardService.FreezeCard")
+	defer span.End()
+
+	return s.setFrozen(ctx, cardID, true, repository.CardAuditActionFrozen, reason)
+}
+
+func (s *CardSvc) UnfreezeCard(ctx context.Context, cardID uuid.UUID, reason string) (*repository.Card, error) {
+	ctx, span := tracing.Start(ctx, "CardService.UnfreezeCard")
+	defer span.End()
+
+	return s.setFrozen(ctx, cardID, false, repository.CardAuditActionUnfrozen, reason)
+}
+
+func (s *CardSvc) GetCardAudits(ctx context.Context, cardID uuid.UUID, skip int, limit int) ([]repository.CardAudit, error) {
+	ctx, span := tracing.Start(ctx, "CardService.GetCardAudits")
+	defer span.End()
+
+	audits, err := s.CardAudit.GetCardAudits(ctx, cardID, skip, limit)
+	return audits, err
+}
+
+// setFrozen records the caller in the audit trail of the card
+func (s *CardSvc) setFrozen(ctx context.Context, cardID uuid.UUID, frozen bool, action string, reason string) (*repository.Card, error) {
+	existingCard, err := s.Card.GetCardByID(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingCard.IsDeleted {
+		return nil, errors.FailedCardNotFound
+	}
+
+	audit := repository.CardAudit{
+		ID:     uuid.New(),
+		CardID: existingCard.ID,
+		Action: action,
+		Reason: reason,
+		UserID: ctxUtil.GetUserID(ctx),
+	}
+
+	card, err := s.CardAudit.FreezeCard(ctx, existingCard.ID, frozen, &audit)
+	return card, err
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/util/money"
 )
 
 func TestGetAllCardsIntegration(t *testing.T) {
@@ -203,6 +204,7 @@ func TestCreateCardIntegration(t *testing.T) {
 		ExpiryMonth   string
 		ExpiryYear    string
 		CVV           string
+		Type          string
 		ExpectedError bool
 	}{
 		"SuccessCreateCard": {
@@ -269,6 +271,15 @@ func TestCreateCardIntegration(t *testing.T) {
 			CVV:           "123",
 			ExpectedError: false,
 		},
+		"FailedInvalidType": {
+			WalletID:      "d4a6607a-1af7-4571-bdff-2672be72ba0e",
+			CardNo:        "378282246310005",
+			ExpiryMonth:   "12",
+			ExpiryYear:    "2022",
+			CVV:           "123",
+			Type:          "burner",
+			ExpectedError: true,
+		},
 	}
 
 	for v, tc := range cases {
@@ -281,6 +292,7 @@ func TestCreateCardIntegration(t *testing.T) {
 				ExpiryMonth: tc.ExpiryMonth,
 				ExpiryYear:  tc.ExpiryYear,
 				CVV:         tc.CVV,
+				Type:        tc.Type,
 			}
 
 			var err error
@@ -329,8 +341,8 @@ func TestUpdateCardIntegration(t *testing.T) {
 		ExpiryMonth   string
 		ExpiryYear    string
 		CVV           string
-		DailyLimit    uint64
-		MonthlyLimit  uint64
+		DailyLimit    money.Decimal
+		MonthlyLimit  money.Decimal
 		ExpectedError bool
 	}{
 		"SuccessUpdateUser": {
@@ -340,8 +352,8 @@ func TestUpdateCardIntegration(t *testing.T) {
 			ExpiryMonth:   "12",
 			ExpiryYear:    "2022",
 			CVV:           "1234",
-			DailyLimit:    200000,
-			MonthlyLimit:  300000,
+			DailyLimit:    money.NewDecimal(200000, 0),
+			MonthlyLimit:  money.NewDecimal(300000, 0),
 			ExpectedError: false,
 		},
 		"FailedUnableToFetchCard": {
@@ -351,8 +363,8 @@ func TestUpdateCardIntegration(t *testing.T) {
 			ExpiryMonth:   "12",
 			ExpiryYear:    "2022",
 			CVV:           "1234",
-			DailyLimit:    200000,
-			MonthlyLimit:  300000,
+			DailyLimit:    money.NewDecimal(200000, 0),
+			MonthlyLimit:  money.NewDecimal(300000, 0),
 			ExpectedError: true,
 		},
 		"FailedInvalidCardOwner": {
@@ -362,8 +374,8 @@ func TestUpdateCardIntegration(t *testing.T) {
 			ExpiryMonth:   "12",
 			ExpiryYear:    "2022",
 			CVV:           "1234",
-			DailyLimit:    200000,
-			MonthlyLimit:  300000,
+			DailyLimit:    money.NewDecimal(200000, 0),
+			MonthlyLimit:  money.NewDecimal(300000, 0),
 			ExpectedError: true,
 		},
 		"FailedInvalidCardNumber": {
@@ -373,8 +385,8 @@ func TestUpdateCardIntegration(t *testing.T) {
 			ExpiryMonth:   "12",
 			ExpiryYear:    "2022",
 			CVV:           "1234",
-			DailyLimit:    200000,
-			MonthlyLimit:  300000,
+			DailyLimit:    money.NewDecimal(200000, 0),
+			MonthlyLimit:  money.NewDecimal(300000, 0),
 			ExpectedError: true,
 		},
 		// NOTE: It looks like there is a race condition when running the integration test that makes the below case failed intermittently
@@ -396,8 +408,8 @@ func TestUpdateCardIntegration(t *testing.T) {
 			ExpiryMonth:   "13",
 			ExpiryYear:    "2022",
 			CVV:           "1234",
-			DailyLimit:    200000,
-			MonthlyLimit:  300000,
+			DailyLimit:    money.NewDecimal(200000, 0),
+			MonthlyLimit:  money.NewDecimal(300000, 0),
 			ExpectedError: true,
 		},
 		"FailedInvalidExpiryYear": {
@@ -407,8 +419,8 @@ func TestUpdateCardIntegration(t *testing.T) {
 			ExpiryMonth:   "12",
 			ExpiryYear:    "2020",
 			CVV:           "1234",
-			DailyLimit:    200000,
-			MonthlyLimit:  300000,
+			DailyLimit:    money.NewDecimal(200000, 0),
+			MonthlyLimit:  money.NewDecimal(300000, 0),
 			ExpectedError: true,
 		},
 		"FailedInvalidCVV": {
@@ -418,8 +430,8 @@ func TestUpdateCardIntegration(t *testing.T) {
 			ExpiryMonth:   "12",
 			ExpiryYear:    "2022",
 			CVV:           "12345",
-			DailyLimit:    200000,
-			MonthlyLimit:  300000,
+			DailyLimit:    money.NewDecimal(200000, 0),
+			MonthlyLimit:  money.NewDecimal(300000, 0),
 			ExpectedError: true,
 		},
 		"FailedInvalidDailyLimit": {
@@ -429,8 +441,8 @@ func TestUpdateCardIntegration(t *testing.T) {
 			ExpiryMonth:   "12",
 			ExpiryYear:    "2022",
 			CVV:           "1234",
-			DailyLimit:    5000,
-			MonthlyLimit:  300000,
+			DailyLimit:    money.MustParseDecimal("50.00"),
+			MonthlyLimit:  money.NewDecimal(300000, 0),
 			ExpectedError: true,
 		},
 		"FailedInvalidMonthlyLimit": {
@@ -440,8 +452,8 @@ func TestUpdateCardIntegration(t *testing.T) {
 			ExpiryMonth:   "12",
 			ExpiryYear:    "2022",
 			CVV:           "1234",
-			DailyLimit:    300000,
-			MonthlyLimit:  5000,
+			DailyLimit:    money.NewDecimal(300000, 0),
+			MonthlyLimit:  money.MustParseDecimal("50.00"),
 			ExpectedError: true,
 		},
 	}
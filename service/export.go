@@ -0,0 +1,302 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/repository/postgre"
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/export"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/tracing"
+)
+
+type (
+	// ExportService ...
+	ExportService interface {
+		GetExport(ctx context.Context, payload *GetExportRequest) (*export.Export, error)
+		GetChartOfAccounts(ctx context.Context, teamID uuid.UUID) (*repository.ChartOfAccounts, error)
+		SetChartOfAccounts(ctx context.Context, payload *SetChartOfAccountsRequest) (*repository.ChartOfAccounts, error)
+	}
+
+	ExportSvc struct {
+		Log             logger.Logger
+		Exporter        *Exporter
+		ChartOfAccounts postgre.ChartOfAccountsRepository
+		TeamMember      postgre.TeamMemberRepository
+	}
+
+	// Exporter builds accounting exports without checking the caller, the
+	// CLI runs it with the access of the operator
+	Exporter struct {
+		Log             logger.Logger
+		Team            postgre.TeamRepository
+		Wallet          postgre.WalletRepository
+		TeamMember      postgre.TeamMemberRepository
+		Transaction     postgre.TransactionRepository
+		ChartOfAccounts postgre.ChartOfAccountsRepository
+	}
+
+	// GetExportRequest covers the days in [From, To) in the timezone of the
+	// team, only the date of From and To is used. Bank statement formats
+	// require WalletID, the journal covers every wallet of the team without
+	// it.
+	GetExportRequest struct {
+		TeamID   uuid.UUID
+		WalletID uuid.UUID
+		From     time.Time
+		To       time.Time
+		Format   string
+	}
+
+	// SetChartOfAccountsRequest replaces the chart of accounts of a team,
+	// blank accounts fall back to the default accounts
+	SetChartOfAccountsRequest struct {
+		TeamID          uuid.UUID
+		WalletAccount   string
+		ExpenseAccount  string
+		IncomeAccount   string
+		TransferAccount string
+		Categories      map[string]string
+	}
+)
+
+// NewExportService creates export service
+func NewExportService(log logger.Logger, db *pg.DB) ExportService {
+	chartOfAccountsRepo := postgre.CreateChartOfAccountsRepository(log, db)
+	teamMemberRepo := postgre.CreateTeamMemberRepository(log, db)
+
+	return &ExportSvc{
+		Log:             log,
+		Exporter:        NewExporter(log, db),
+		ChartOfAccounts: chartOfAccountsRepo,
+		TeamMember:      teamMemberRepo,
+	}
+}
+
+// NewExporter creates exporter
+func NewExporter(log logger.Logger, db *pg.DB) *Exporter {
+	teamRepo := postgre.CreateTeamRepository(log, db)
+	walletRepo := postgre.CreateWalletRepository(log, db)
+	teamMemberRepo := postgre.CreateTeamMemberRepository(log, db)
+	transactionRepo := postgre.CreateTransactionRepository(log, db)
+	chartOfAccountsRepo := postgre.CreateChartOfAccountsRepository(log, db)
+
+	return &Exporter{
+		Log:             log,
+		Team:            teamRepo,
+		Wallet:          walletRepo,
+		TeamMember:      teamMemberRepo,
+		Transaction:     transactionRepo,
+		ChartOfAccounts: chartOfAccountsRepo,
+	}
+}
+
+// GetExport is limited to the admins of the team, an export discloses the
+// spend of every member
+func (s *ExportSvc) GetExport(ctx context.Context, payload *GetExportRequest) (*export.Export, error) {
+	ctx, span := tracing.Start(ctx, "ExportService.GetExport")
+	defer span.End()
+
+	_, err := requireTeamAdmin(ctx, s.TeamMember, payload.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Exporter.Build(ctx, payload)
+}
+
+func (s *ExportSvc) GetChartOfAccounts(ctx context.Context, teamID uuid.UUID) (*repository.ChartOfAccounts, error) {
+	ctx, span := tracing.Start(ctx, "ExportService.GetChartOfAccounts")
+	defer span.End()
+
+	err := requireTeamMember(ctx, s.TeamMember, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	chart, err := s.ChartOfAccounts.GetChartOfAccounts(ctx, teamID)
+	return chart, err
+}
+
+func (s *ExportSvc) SetChartOfAccounts(ctx context.Context, payload *SetChartOfAccountsRequest) (*repository.ChartOfAccounts, error) {
+	ctx, span := tracing.Start(ctx, "ExportService.SetChartOfAccounts")
+	defer span.End()
+
+	_, err := requireTeamAdmin(ctx, s.TeamMember, payload.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := normalizeCategoryAccounts(payload.Categories)
+	if err != nil {
+		return nil, err
+	}
+
+	chartPayload := repository.ChartOfAccounts{
+		ID:              uuid.New(),
+		TeamID:          payload.TeamID,
+		WalletAccount:   strings.TrimSpace(payload.WalletAccount),
+		ExpenseAccount:  strings.TrimSpace(payload.ExpenseAccount),
+		IncomeAccount:   strings.TrimSpace(payload.IncomeAccount),
+		TransferAccount: strings.TrimSpace(payload.TransferAccount),
+		Categories:      categories,
+	}
+
+	chart, err := s.ChartOfAccounts.SetChartOfAccounts(ctx, &chartPayload)
+	return chart, err
+}
+
+// Build returns the export of the team, its entries are only read once the
+// export is written
+func (x *Exporter) Build(ctx context.Context, payload *GetExportRequest) (*export.Export, error) {
+	if !payload.From.Before(payload.To) {
+		return nil, errors.InvalidExportPeriod
+	}
+
+	if err := export.IsFormatValid(payload.Format); err != nil {
+		return nil, err
+	}
+
+	if export.IsBankFormat(payload.Format) && payload.WalletID == uuid.Nil {
+		return nil, errors.MissingExportWallet
+	}
+
+	team, err := x.Team.GetTeamByID(ctx, payload.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := teamLocation(team)
+	from := time.Date(payload.From.Year(), payload.From.Month(), payload.From.Day(), 0, 0, 0, 0, loc)
+	to := time.Date(payload.To.Year(), payload.To.Month(), payload.To.Day(), 0, 0, 0, 0, loc)
+
+	accounts, err := x.getAccounts(ctx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	accountingExport := &export.Export{
+		TeamID:   team.ID.String(),
+		From:     from,
+		To:       to,
+		Accounts: accounts,
+	}
+
+	forEach := func(fn func(*repository.Transaction) error) error {
+		return x.Transaction.ForEachTeamTransaction(ctx, team.ID, from.UTC(), to.UTC(), fn)
+	}
+
+	if payload.WalletID != uuid.Nil {
+		wallet, err := x.getTeamWallet(ctx, team.ID, payload.WalletID)
+		if err != nil {
+			return nil, err
+		}
+
+		accountingExport.WalletID = wallet.ID.String()
+		forEach = func(fn func(*repository.Transaction) error) error {
+			return x.Transaction.ForEachTransaction(ctx, wallet.ID, from.UTC(), to.UTC(), fn)
+		}
+	}
+
+	accountingExport.Entries = func(fn func(export.Entry) error) error {
+		return forEach(func(transaction *repository.Transaction) error {
+			return fn(newExportEntry(transaction, loc))
+		})
+	}
+
+	return accountingExport, nil
+}
+
+// getAccounts falls back to the default accounts when the team has no chart
+// of accounts
+func (x *Exporter) getAccounts(ctx context.Context, teamID uuid.UUID) (export.Accounts, error) {
+	chart, err := x.ChartOfAccounts.GetChartOfAccounts(ctx, teamID)
+	if err != nil {
+		if er, ok := err.(e.Error); ok && er.Code == errors.FailedChartOfAccountsNotFound.Code {
+			return export.DefaultAccounts, nil
+		}
+
+		return export.Accounts{}, err
+	}
+
+	return export.Accounts{
+		Wallet:     chart.WalletAccount,
+		Expense:    chart.ExpenseAccount,
+		Income:     chart.IncomeAccount,
+		Transfer:   chart.TransferAccount,
+		Categories: chart.Categories,
+	}, nil
+}
+
+// getTeamWallet only returns the team wallets and the wallets of the active
+// members of the team
+func (x *Exporter) getTeamWallet(ctx context.Context, teamID uuid.UUID, walletID uuid.UUID) (*repository.Wallet, error) {
+	wallet, err := x.Wallet.GetWalletByID(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if wallet.TeamID == teamID {
+		return wallet, nil
+	}
+
+	if wallet.UserID == uuid.Nil {
+		return nil, errors.InvalidExportWallet
+	}
+
+	member, err := x.TeamMember.GetTeamMember(ctx, teamID, wallet.UserID)
+	if err != nil {
+		if er, ok := err.(e.Error); ok && er.Code == errors.FailedNoRows.Code {
+			return nil, errors.InvalidExportWallet
+		}
+
+		return nil, err
+	}
+
+	if member.IsDeleted {
+		return nil, errors.InvalidExportWallet
+	}
+
+	return wallet, nil
+}
+
+func newExportEntry(transaction *repository.Transaction, loc *time.Location) export.Entry {
+	return export.Entry{
+		Date:        transaction.CreatedAt.In(loc),
+		Reference:   transaction.ReferenceID.String(),
+		WalletID:    transaction.WalletID.String(),
+		Type:        transaction.Type,
+		Direction:   transaction.Direction,
+		Payee:       transaction.Merchant,
+		Description: transaction.Description,
+		Memo:        transaction.Memo,
+		Category:    transaction.Category,
+		Amount:      transaction.Amount,
+		Currency:    transaction.Currency,
+	}
+}
+
+// normalizeCategoryAccounts lowercases the categories the same way
+// transactions are categorized
+func normalizeCategoryAccounts(categories map[string]string) (map[string]string, error) {
+	normalized := map[string]string{}
+
+	for category, account := range categories {
+		category = strings.ToLower(strings.TrimSpace(category))
+		account = strings.TrimSpace(account)
+
+		if category == "" || account == "" {
+			return nil, errors.InvalidAccount
+		}
+
+		normalized[category] = account
+	}
+
+	return normalized, nil
+}
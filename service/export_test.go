@@ -0,0 +1,180 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	ctxUtil "gitlab.com/renodesper/spenmo-test/util/ctx"
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/export"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+func TestNormalizeCategoryAccounts(t *testing.T) {
+	cases := map[string]struct {
+		Categories         map[string]string
+		ExpectedCategories map[string]string
+		ExpectedError      bool
+	}{
+		"SuccessNormalize": {
+			Categories:         map[string]string{" Meals ": " 6100 Meals "},
+			ExpectedCategories: map[string]string{"meals": "6100 Meals"},
+		},
+		"SuccessEmpty": {
+			Categories:         nil,
+			ExpectedCategories: map[string]string{},
+		},
+		"FailedBlankCategory": {
+			Categories:    map[string]string{" ": "6100 Meals"},
+			ExpectedError: true,
+		},
+		"FailedBlankAccount": {
+			Categories:    map[string]string{"meals": ""},
+			ExpectedError: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			categories, err := normalizeCategoryAccounts(tc.Categories)
+			if tc.ExpectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ExpectedCategories, categories)
+		})
+	}
+}
+
+func TestExportIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	reinitializeDB()
+
+	teamID := uuid.MustParse("933efe12-2219-42df-bd51-a2e84888432d")
+	teamWalletID := uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e")
+	adminCtx := ctxUtil.SetUserID(context.Background(), uuid.MustParse("8e159833-5078-4b0a-80a0-363d82bafd60"))
+	memberCtx := ctxUtil.SetUserID(context.Background(), uuid.MustParse("51241303-ebe0-4c2b-98be-44d93439f6d9"))
+
+	svc := NewExportService(Log, DB)
+	transactionSvc := NewTransactionService(Log, DB, newTestConverter(t), Notifier, newTestFraudEngine(t))
+	cardSvc := NewCardService(Log, DB)
+
+	topUpTeamWallet(t, adminCtx, teamWalletID.String(), teamID.String(), money.MustParseDecimal("1000.00"))
+
+	activeCard, err := cardSvc.CreateCard(adminCtx, &CreateCardRequest{
+		CardNo:      "4012888888881881",
+		ExpiryMonth: "12",
+		ExpiryYear:  fmt.Sprintf("%d", time.Now().Year()+1),
+		CVV:         "123",
+		WalletID:    teamWalletID,
+	})
+	assert.NoError(t, err)
+
+	transaction, err := transactionSvc.CreateCardTransaction(adminCtx, &CreateCardTransactionRequest{
+		CardID:   activeCard.ID,
+		Amount:   money.MustParseDecimal("20.50"),
+		Merchant: "Coffee Shop",
+	})
+	assert.NoError(t, err)
+
+	_, err = transactionSvc.UpdateTransaction(adminCtx, transaction.ID, &UpdateTransactionRequest{Category: "meals"})
+	assert.NoError(t, err)
+
+	_, err = svc.SetChartOfAccounts(memberCtx, &SetChartOfAccountsRequest{TeamID: teamID, WalletAccount: "1010 Wallet"})
+	if assert.Error(t, err) {
+		assert.Equal(t, errors.Forbidden.Code, err.(e.Error).Code)
+	}
+
+	chart, err := svc.SetChartOfAccounts(adminCtx, &SetChartOfAccountsRequest{
+		TeamID:        teamID,
+		WalletAccount: "1010 Wallet",
+		Categories:    map[string]string{"Meals": "6100 Meals"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "6100 Meals", chart.Categories["meals"])
+
+	// NOTE: Setting the chart again replaces it
+	chart, err = svc.SetChartOfAccounts(adminCtx, &SetChartOfAccountsRequest{
+		TeamID:        teamID,
+		WalletAccount: "1020 Wallet",
+		Categories:    map[string]string{"meals": "6150 Meals"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "1020 Wallet", chart.WalletAccount)
+
+	today := time.Now().UTC()
+	from := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+	to := from.AddDate(0, 0, 3)
+
+	cases := map[string]struct {
+		Ctx           context.Context
+		Request       GetExportRequest
+		ExpectedLines []string
+		ExpectedError e.Error
+	}{
+		"SuccessJournal": {
+			Ctx:           adminCtx,
+			Request:       GetExportRequest{TeamID: teamID, From: from, To: to},
+			ExpectedLines: []string{",6150 Meals,Coffee Shop,20.50,,SGD", ",1020 Wallet,Coffee Shop,,20.50,SGD"},
+		},
+		"SuccessQBO": {
+			Ctx:           adminCtx,
+			Request:       GetExportRequest{TeamID: teamID, WalletID: teamWalletID, From: from, To: to, Format: export.FormatQBO},
+			ExpectedLines: []string{",Coffee Shop,-20.50"},
+		},
+		"FailedMissingWallet": {
+			Ctx:           adminCtx,
+			Request:       GetExportRequest{TeamID: teamID, From: from, To: to, Format: export.FormatXero},
+			ExpectedError: errors.MissingExportWallet,
+		},
+		"FailedOtherWallet": {
+			Ctx:           adminCtx,
+			Request:       GetExportRequest{TeamID: teamID, WalletID: uuid.MustParse("370a9739-b90b-4264-81a2-f8d0d3236011"), From: from, To: to},
+			ExpectedError: errors.InvalidExportWallet,
+		},
+		"FailedPeriod": {
+			Ctx:           adminCtx,
+			Request:       GetExportRequest{TeamID: teamID, From: to, To: from},
+			ExpectedError: errors.InvalidExportPeriod,
+		},
+		"FailedMember": {
+			Ctx:           memberCtx,
+			Request:       GetExportRequest{TeamID: teamID, From: from, To: to},
+			ExpectedError: errors.Forbidden,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			teamExport, err := svc.GetExport(tc.Ctx, &tc.Request)
+			if tc.ExpectedError.Code != "" {
+				if assert.Error(t, err) {
+					assert.Equal(t, tc.ExpectedError.Code, err.(e.Error).Code)
+				}
+				return
+			}
+			assert.NoError(t, err)
+
+			var b bytes.Buffer
+			writer, err := export.NewWriter(tc.Request.Format, &b)
+			assert.NoError(t, err)
+			assert.NoError(t, teamExport.Write(writer))
+
+			for _, line := range tc.ExpectedLines {
+				assert.True(t, strings.Contains(b.String(), line), "missing %q in %q", line, b.String())
+			}
+		})
+	}
+}
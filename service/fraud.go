@@ -0,0 +1,365 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/repository/postgre"
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+	"gitlab.com/renodesper/spenmo-test/util/velocity"
+)
+
+type (
+	// FraudRule is a velocity rule evaluated on every card authorization.
+	// Count bounds the transaction_count and new_merchant rules, Amount and
+	// Currency the amount rule and Countries lists the home countries of the
+	// foreign_country rule.
+	FraudRule struct {
+		Name      string        `mapstructure:"name" json:"name"`
+		Type      string        `mapstructure:"type" json:"type"`
+		Window    time.Duration `mapstructure:"window" json:"window"`
+		Count     int           `mapstructure:"count" json:"count,omitempty"`
+		Amount    string        `mapstructure:"amount" json:"amount,omitempty"`
+		Currency  string        `mapstructure:"currency" json:"currency,omitempty"`
+		Countries []string      `mapstructure:"countries" json:"countries,omitempty"`
+		Action    string        `mapstructure:"action" json:"action"`
+
+		amount money.Decimal
+	}
+
+	// FraudVerdict is the rule declining a card transaction and the counters
+	// it was evaluated against
+	FraudVerdict struct {
+		Rule    *FraudRule
+		Details map[string]interface{}
+	}
+
+	// FraudEngine evaluates the fraud rules while a card transaction is
+	// posted and records the counters of the transactions it lets through.
+	// A rule with the freeze action freezes the card, every triggered rule is
+	// kept in the card audit.
+	FraudEngine struct {
+		Log       logger.Logger
+		Store     velocity.Store
+		CardAudit postgre.CardAuditRepository
+		Rules     []FraudRule
+		ttl       time.Duration
+	}
+)
+
+const (
+	FraudRuleTypeTransactionCount = "transaction_count"
+	FraudRuleTypeAmount           = "amount"
+	FraudRuleTypeNewMerchant      = "new_merchant"
+	FraudRuleTypeForeignCountry   = "foreign_country"
+
+	FraudActionDecline = "decline"
+	FraudActionFreeze  = "freeze"
+	FraudActionFlag    = "flag"
+)
+
+// FRAUD_COUNTER_TTL_MINIMUM keeps the counters for a day even when every rule
+// has a shorter window
+const FRAUD_COUNTER_TTL_MINIMUM = 24 * time.Hour
+
+// NewFraudEngine validates the rules and creates the fraud engine
+func NewFraudEngine(log logger.Logger, db *pg.DB, store velocity.Store, rules []FraudRule) (*FraudEngine, error) {
+	cardAuditRepo := postgre.CreateCardAuditRepository(log, db)
+
+	ttl := FRAUD_COUNTER_TTL_MINIMUM
+	validRules := make([]FraudRule, 0, len(rules))
+	for _, rule := range rules {
+		validRule, err := newFraudRule(rule)
+		if err != nil {
+			return nil, err
+		}
+
+		if validRule.Window > ttl {
+			ttl = validRule.Window
+		}
+
+		validRules = append(validRules, validRule)
+	}
+
+	return &FraudEngine{
+		Log:       log,
+		Store:     store,
+		CardAudit: cardAuditRepo,
+		Rules:     validRules,
+		ttl:       ttl,
+	}, nil
+}
+
+// Check evaluates the rules against a card transaction about to be posted
+// and counts it when no rule declines it. It runs in the guard of the
+// posting transaction once the card row is locked, so the authorizations of
+// a card are evaluated one after the other against up to date counters.
+// Flagged rules are audited right away, the first rule declining the
+// transaction is returned and only enforced by Decline once the posting
+// transaction rolled back.
+func (f *FraudEngine) Check(ctx context.Context, card *repository.Card, transaction *repository.Transaction) (*FraudVerdict, error) {
+	now := time.Now()
+
+	for i := range f.Rules {
+		rule := &f.Rules[i]
+
+		triggered, details, err := f.evaluate(ctx, rule, card, transaction, now)
+		if err != nil {
+			return nil, err
+		}
+
+		if !triggered {
+			continue
+		}
+
+		details["amount"] = transaction.Amount
+		details["currency"] = transaction.Currency
+		if transaction.Merchant != "" {
+			details["merchant"] = transaction.Merchant
+		}
+		if transaction.MerchantID != "" {
+			details["merchantId"] = transaction.MerchantID
+		}
+		if transaction.Country != "" {
+			details["country"] = transaction.Country
+		}
+
+		if rule.Action == FraudActionFlag {
+			audit := newFraudAudit(rule, card, details)
+			audit.Action = repository.CardAuditActionFlagged
+			if _, err := f.CardAudit.CreateCardAudit(ctx, &audit); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		return &FraudVerdict{Rule: rule, Details: details}, nil
+	}
+
+	if err := f.Record(ctx, transaction); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// Decline audits the transaction declined by verdict, freezing the card when
+// the rule asks to, and returns the error of the rule
+func (f *FraudEngine) Decline(ctx context.Context, card *repository.Card, verdict *FraudVerdict) error {
+	audit := newFraudAudit(verdict.Rule, card, verdict.Details)
+
+	switch verdict.Rule.Action {
+	case FraudActionFreeze:
+		audit.Action = repository.CardAuditActionFrozen
+		_, err := f.CardAudit.FreezeCard(ctx, card.ID, true, &audit)
+		if er, ok := err.(e.Error); err != nil && (!ok || er.Code != errors.CardAlreadyFrozen.Code) {
+			return err
+		}
+	default:
+		audit.Action = repository.CardAuditActionDeclined
+		if _, err := f.CardAudit.CreateCardAudit(ctx, &audit); err != nil {
+			return err
+		}
+	}
+
+	return newFraudRuleTriggered(verdict.Rule, card, verdict.Details)
+}
+
+// Record counts a card transaction. Check records the transactions it lets
+// through, declined attempts are never counted.
+func (f *FraudEngine) Record(ctx context.Context, transaction *repository.Transaction) error {
+	at := transaction.CreatedAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	err := f.Store.Record(ctx, fraudTransactionsKey(transaction.CardID), velocity.Event{At: at}, f.ttl)
+	if err != nil {
+		return err
+	}
+
+	err = f.Store.Record(ctx, fraudAmountKey(transaction.CardID, transaction.Currency), velocity.Event{At: at, Amount: transaction.Amount}, f.ttl)
+	if err != nil {
+		return err
+	}
+
+	merchant := transactionMerchant(transaction)
+	if merchant == "" {
+		return nil
+	}
+
+	seen, err := f.Store.HasMember(ctx, fraudMerchantsKey(transaction.CardID), merchant)
+	if err != nil || seen {
+		return err
+	}
+
+	err = f.Store.Record(ctx, fraudNewMerchantsKey(transaction.CardID), velocity.Event{At: at}, f.ttl)
+	if err != nil {
+		return err
+	}
+
+	return f.Store.AddMember(ctx, fraudMerchantsKey(transaction.CardID), merchant)
+}
+
+// evaluate reports whether rule is triggered by transaction and the counters
+// it was evaluated against
+func (f *FraudEngine) evaluate(ctx context.Context, rule *FraudRule, card *repository.Card, transaction *repository.Transaction, now time.Time) (bool, map[string]interface{}, error) {
+	since := now.Add(-rule.Window)
+
+	switch rule.Type {
+	case FraudRuleTypeTransactionCount:
+		window, err := f.Store.Window(ctx, fraudTransactionsKey(card.ID), since)
+		if err != nil {
+			return false, nil, err
+		}
+
+		return window.Count+1 > rule.Count, map[string]interface{}{"count": window.Count + 1, "limit": rule.Count}, nil
+	case FraudRuleTypeAmount:
+		if transaction.Currency != rule.Currency {
+			return false, nil, nil
+		}
+
+		window, err := f.Store.Window(ctx, fraudAmountKey(card.ID, transaction.Currency), since)
+		if err != nil {
+			return false, nil, err
+		}
+
+		total, err := window.Amount.Add(transaction.Amount)
+		if err != nil {
+			return false, nil, err
+		}
+
+		return total.Cmp(rule.amount) > 0, map[string]interface{}{"total": total, "limit": rule.amount}, nil
+	case FraudRuleTypeNewMerchant:
+		merchant := transactionMerchant(transaction)
+		if merchant == "" {
+			return false, nil, nil
+		}
+
+		seen, err := f.Store.HasMember(ctx, fraudMerchantsKey(card.ID), merchant)
+		if err != nil || seen {
+			return false, nil, err
+		}
+
+		window, err := f.Store.Window(ctx, fraudNewMerchantsKey(card.ID), since)
+		if err != nil {
+			return false, nil, err
+		}
+
+		return window.Count+1 > rule.Count, map[string]interface{}{"count": window.Count + 1, "limit": rule.Count}, nil
+	case FraudRuleTypeForeignCountry:
+		if transaction.Country == "" || containsString(rule.Countries, transaction.Country) {
+			return false, nil, nil
+		}
+
+		return true, map[string]interface{}{"homeCountries": rule.Countries}, nil
+	default:
+		return false, nil, nil
+	}
+}
+
+func newFraudRule(rule FraudRule) (FraudRule, error) {
+	invalid := func(reason string) (FraudRule, error) {
+		return FraudRule{}, errors.InvalidFraudRule.AppendError(fmt.Errorf("%s: %s", rule.Name, reason))
+	}
+
+	if rule.Name == "" {
+		return invalid("missing name")
+	}
+
+	switch rule.Action {
+	case "":
+		rule.Action = FraudActionDecline
+	case FraudActionDecline, FraudActionFreeze, FraudActionFlag:
+	default:
+		return invalid("unknown action")
+	}
+
+	switch rule.Type {
+	case FraudRuleTypeTransactionCount, FraudRuleTypeNewMerchant:
+		if rule.Window <= 0 || rule.Count <= 0 {
+			return invalid("window and count must be positive")
+		}
+	case FraudRuleTypeAmount:
+		amount, err := money.ParseDecimal(rule.Amount)
+		if err != nil || rule.Window <= 0 {
+			return invalid("window and amount must be positive")
+		}
+
+		limit, err := newSpendLimitAmount(amount, rule.Currency)
+		if err != nil {
+			return invalid("amount must be positive in a known currency")
+		}
+
+		rule.amount = limit.Amount
+		rule.Currency = limit.Currency
+	case FraudRuleTypeForeignCountry:
+		countries, err := newSpendControlList(rule.Countries, normalizeCountry)
+		if err != nil || len(countries) == 0 {
+			return invalid("countries must be ISO 3166-1 alpha-2 codes")
+		}
+
+		rule.Countries = countries
+	default:
+		return invalid("unknown type")
+	}
+
+	return rule, nil
+}
+
+func newFraudAudit(rule *FraudRule, card *repository.Card, details map[string]interface{}) repository.CardAudit {
+	return repository.CardAudit{
+		ID:      uuid.New(),
+		CardID:  card.ID,
+		Rule:    rule.Name,
+		Reason:  rule.Type,
+		Details: details,
+	}
+}
+
+func newFraudRuleTriggered(rule *FraudRule, card *repository.Card, details map[string]interface{}) e.Error {
+	err := errors.FraudRuleTriggered.AppendError(fmt.Errorf("%s rule %s", rule.Type, rule.Name))
+	err.Meta = e.Meta{
+		"rule":    rule.Name,
+		"type":    rule.Type,
+		"action":  rule.Action,
+		"cardId":  card.ID,
+		"details": details,
+	}
+
+	return err
+}
+
+// transactionMerchant identifies the merchant of a transaction, by its ID when the
+// network sent one
+func transactionMerchant(transaction *repository.Transaction) string {
+	if transaction.MerchantID != "" {
+		return transaction.MerchantID
+	}
+
+	return strings.ToLower(strings.TrimSpace(transaction.Merchant))
+}
+
+func fraudTransactionsKey(cardID uuid.UUID) string {
+	return "card:" + cardID.String() + ":transactions"
+}
+
+func fraudAmountKey(cardID uuid.UUID, currency string) string {
+	return "card:" + cardID.String() + ":amount:" + currency
+}
+
+func fraudNewMerchantsKey(cardID uuid.UUID) string {
+	return "card:" + cardID.String() + ":new_merchants"
+}
+
+func fraudMerchantsKey(cardID uuid.UUID) string {
+	return "card:" + cardID.String() + ":merchants"
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+	"gitlab.com/renodesper/spenmo-test/util/velocity/memory"
+)
+
+func TestNewFraudRule(t *testing.T) {
+	cases := map[string]struct {
+		Rule          FraudRule
+		ExpectedError bool
+	}{
+		"SuccessTransactionCount": {
+			Rule: FraudRule{Name: "burst", Type: FraudRuleTypeTransactionCount, Window: time.Minute, Count: 5, Action: FraudActionFreeze},
+		},
+		"SuccessAmount": {
+			Rule: FraudRule{Name: "hourly", Type: FraudRuleTypeAmount, Window: time.Hour, Amount: "500.00", Currency: "sgd"},
+		},
+		"SuccessForeignCountry": {
+			Rule: FraudRule{Name: "foreign", Type: FraudRuleTypeForeignCountry, Countries: []string{"sg"}, Action: FraudActionFlag},
+		},
+		"FailedMissingName": {
+			Rule:          FraudRule{Type: FraudRuleTypeTransactionCount, Window: time.Minute, Count: 5},
+			ExpectedError: true,
+		},
+		"FailedMissingWindow": {
+			Rule:          FraudRule{Name: "burst", Type: FraudRuleTypeNewMerchant, Count: 5},
+			ExpectedError: true,
+		},
+		"FailedAmountWithoutCurrency": {
+			Rule:          FraudRule{Name: "hourly", Type: FraudRuleTypeAmount, Window: time.Hour, Amount: "500.00"},
+			ExpectedError: true,
+		},
+		"FailedUnknownAction": {
+			Rule:          FraudRule{Name: "burst", Type: FraudRuleTypeTransactionCount, Window: time.Minute, Count: 5, Action: "block"},
+			ExpectedError: true,
+		},
+		"FailedUnknownType": {
+			Rule:          FraudRule{Name: "burst", Type: "velocity", Window: time.Minute, Count: 5},
+			ExpectedError: true,
+		},
+	}
+
+	for v, tc := range cases {
+		t.Run(v, func(t *testing.T) {
+			rule, err := newFraudRule(tc.Rule)
+
+			if tc.ExpectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, rule.Action)
+			}
+		})
+	}
+}
+
+func TestFraudEngineEvaluate(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	card := &repository.Card{ID: uuid.New()}
+
+	fraud, err := NewFraudEngine(Log, DB, memory.CreateStore(), []FraudRule{
+		{Name: "burst", Type: FraudRuleTypeTransactionCount, Window: time.Minute, Count: 2},
+		{Name: "hourly", Type: FraudRuleTypeAmount, Window: time.Hour, Amount: "100.00", Currency: "SGD"},
+		{Name: "new-merchants", Type: FraudRuleTypeNewMerchant, Window: time.Hour, Count: 1},
+		{Name: "foreign", Type: FraudRuleTypeForeignCountry, Countries: []string{"SG"}},
+	})
+	assert.NoError(t, err)
+
+	posted := &repository.Transaction{CardID: card.ID, Amount: money.MustParseDecimal("60.00"), Currency: "SGD", Merchant: "ACME", CreatedAt: now}
+	assert.NoError(t, fraud.Record(ctx, posted))
+
+	cases := map[string]struct {
+		Rule              int
+		Transaction       repository.Transaction
+		ExpectedTriggered bool
+	}{
+		"SuccessBelowCount": {
+			Rule:        0,
+			Transaction: repository.Transaction{},
+		},
+		"SuccessBelowAmount": {
+			Rule:        1,
+			Transaction: repository.Transaction{Amount: money.MustParseDecimal("40.00"), Currency: "SGD"},
+		},
+		"FailedAboveAmount": {
+			Rule:              1,
+			Transaction:       repository.Transaction{Amount: money.MustParseDecimal("40.01"), Currency: "SGD"},
+			ExpectedTriggered: true,
+		},
+		"SuccessAmountInAnotherCurrency": {
+			Rule:        1,
+			Transaction: repository.Transaction{Amount: money.MustParseDecimal("400.00"), Currency: "USD"},
+		},
+		"SuccessKnownMerchant": {
+			Rule:        2,
+			Transaction: repository.Transaction{Merchant: " acme "},
+		},
+		"FailedNewMerchantBurst": {
+			Rule:              2,
+			Transaction:       repository.Transaction{Merchant: "Casino"},
+			ExpectedTriggered: true,
+		},
+		"SuccessHomeCountry": {
+			Rule:        3,
+			Transaction: repository.Transaction{Country: "SG"},
+		},
+		"FailedForeignCountry": {
+			Rule:              3,
+			Transaction:       repository.Transaction{Country: "US"},
+			ExpectedTriggered: true,
+		},
+	}
+
+	for v, tc := range cases {
+		t.Run(v, func(t *testing.T) {
+			triggered, _, err := fraud.evaluate(ctx, &fraud.Rules[tc.Rule], card, &tc.Transaction, now)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ExpectedTriggered, triggered)
+		})
+	}
+
+	assert.NoError(t, fraud.Record(ctx, posted))
+
+	triggered, _, err := fraud.evaluate(ctx, &fraud.Rules[0], card, &repository.Transaction{}, now)
+	assert.NoError(t, err)
+	assert.True(t, triggered)
+}
+
+func TestFraudEngineCheck(t *testing.T) {
+	ctx := context.Background()
+	card := &repository.Card{ID: uuid.New()}
+
+	fraud, err := NewFraudEngine(Log, DB, memory.CreateStore(), []FraudRule{
+		{Name: "burst", Type: FraudRuleTypeTransactionCount, Window: time.Minute, Count: 2},
+	})
+	assert.NoError(t, err)
+
+	// NOTE: Every transaction let through is counted by the check itself, so
+	// the next authorization of the card sees it without waiting for a record
+	for i := 0; i < 2; i++ {
+		verdict, err := fraud.Check(ctx, card, &repository.Transaction{CardID: card.ID, Amount: money.MustParseDecimal("1.00"), Currency: "SGD"})
+		assert.NoError(t, err)
+		assert.Nil(t, verdict)
+	}
+
+	verdict, err := fraud.Check(ctx, card, &repository.Transaction{CardID: card.ID, Amount: money.MustParseDecimal("1.00"), Currency: "SGD"})
+	assert.NoError(t, err)
+	if assert.NotNil(t, verdict) {
+		assert.Equal(t, "burst", verdict.Rule.Name)
+		assert.Equal(t, 3, verdict.Details["count"])
+	}
+
+	// NOTE: The declined attempt is not counted
+	window, err := fraud.Store.Window(ctx, fraudTransactionsKey(card.ID), time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, window.Count)
+}
+
+func TestFraudEngineIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	reinitializeDB()
+
+	ctx := context.Background()
+	svc := NewTransactionService(Log, DB, newTestConverter(t), Notifier, newTestFraudEngine(t,
+		FraudRule{Name: "foreign", Type: FraudRuleTypeForeignCountry, Countries: []string{"SG"}, Action: FraudActionFlag},
+		FraudRule{Name: "burst", Type: FraudRuleTypeTransactionCount, Window: time.Minute, Count: 2, Action: FraudActionFreeze},
+	))
+	cardSvc := NewCardService(Log, DB)
+
+	topUpTeamWallet(t, ctx, "d4a6607a-1af7-4571-bdff-2672be72ba0e", "933efe12-2219-42df-bd51-a2e84888432d", money.MustParseDecimal("1000.00"))
+
+	card, err := cardSvc.CreateCard(ctx, &CreateCardRequest{
+		CardNo:      "4012888888881881",
+		ExpiryMonth: "12",
+		ExpiryYear:  fmt.Sprintf("%d", time.Now().Year()+1),
+		CVV:         "123",
+		WalletID:    uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e"),
+	})
+	assert.NoError(t, err)
+
+	// NOTE: Each step is cumulative, the third charge within a minute freezes the card
+	steps := []struct {
+		Country       string
+		ExpectedError bool
+	}{
+		{Country: "SG", ExpectedError: false},
+		{Country: "US", ExpectedError: false},
+		{Country: "SG", ExpectedError: true},
+		{Country: "SG", ExpectedError: true},
+	}
+
+	for _, step := range steps {
+		transaction, err := svc.CreateCardTransaction(ctx, &CreateCardTransactionRequest{
+			CardID:   card.ID,
+			Amount:   money.MustParseDecimal("1.00"),
+			Merchant: "ACME",
+			Country:  step.Country,
+		})
+
+		if step.ExpectedError {
+			assert.Error(t, err)
+			assert.Nil(t, transaction)
+		} else {
+			assert.NoError(t, err)
+			assert.NotNil(t, transaction)
+		}
+	}
+
+	frozen, err := cardSvc.GetCard(ctx, card.ID)
+	assert.NoError(t, err)
+	assert.True(t, frozen.IsFrozen)
+
+	audits, err := cardSvc.GetCardAudits(ctx, card.ID, 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, audits, 2)
+	assert.Equal(t, repository.CardAuditActionFrozen, audits[0].Action)
+	assert.Equal(t, "burst", audits[0].Rule)
+	assert.Equal(t, repository.CardAuditActionFlagged, audits[1].Action)
+
+	unfrozen, err := cardSvc.UnfreezeCard(ctx, card.ID, "confirmed with the cardholder")
+	assert.NoError(t, err)
+	assert.False(t, unfrozen.IsFrozen)
+
+	_, err = cardSvc.UnfreezeCard(ctx, card.ID, "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,349 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/repository/postgre"
+	ctxUtil "gitlab.com/renodesper/spenmo-test/util/ctx"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+	"gitlab.com/renodesper/spenmo-test/util/notifier"
+	"gitlab.com/renodesper/spenmo-test/util/tracing"
+)
+
+type (
+	// FundRequestService lets members ask for funds and the admins of their
+	// team approve or reject them. An approved request is funded right away.
+	FundRequestService interface {
+		GetFundRequests(ctx context.Context, payload *GetFundRequestsRequest) ([]repository.FundRequest, error)
+		GetFundRequest(ctx context.Context, fundRequestID uuid.UUID) (*repository.FundRequest, error)
+		CreateFundRequest(ctx context.Context, payload *CreateFundRequestRequest) (*repository.FundRequest, error)
+		ApproveFundRequest(ctx context.Context, fundRequestID uuid.UUID, payload *ApproveFundRequestRequest) (*repository.FundRequest, error)
+		RejectFundRequest(ctx context.Context, fundRequestID uuid.UUID, payload *RejectFundRequestRequest) (*repository.FundRequest, error)
+		CancelFundRequest(ctx context.Context, fundRequestID uuid.UUID) (*repository.FundRequest, error)
+	}
+
+	FundRequestSvc struct {
+		Log            logger.Logger
+		FundRequest    postgre.FundRequestRepository
+		Wallet         postgre.WalletRepository
+		TeamMemberRepo postgre.TeamMemberRepository
+		TeamMember     TeamMemberService
+		Transaction    TransactionService
+		Notifier       notifier.Notifier
+	}
+
+	GetFundRequestsRequest struct {
+		TeamID      uuid.UUID
+		RequesterID uuid.UUID
+		Status      string
+		SortBy      string
+		Sort        string
+		Skip        int
+		Limit       int
+	}
+
+	// CreateFundRequestRequest asks to fund WalletID, a wallet of the team or
+	// of the requester. Amount is expressed in the currency of the wallet.
+	CreateFundRequestRequest struct {
+		TeamID   uuid.UUID
+		WalletID uuid.UUID
+		Amount   money.Decimal
+		Reason   string
+	}
+
+	// ApproveFundRequestRequest funds the request from SourceWalletID, a
+	// wallet of the team
+	ApproveFundRequestRequest struct {
+		SourceWalletID uuid.UUID
+		Note           string
+	}
+
+	RejectFundRequestRequest struct {
+		Note string
+	}
+
+	// FundRequestNotification is the data of the fund request events,
+	// Recipients are the users expected to act on it
+	FundRequestNotification struct {
+		FundRequest *repository.FundRequest `json:"fundRequest"`
+		Recipients  []uuid.UUID             `json:"recipients"`
+	}
+)
+
+const (
+	// EventFundRequestCreated is sent to the admins of the team
+	EventFundRequestCreated = "fund_request.created"
+	// EventFundRequestApproved is sent to the requester
+	EventFundRequestApproved = "fund_request.approved"
+	// EventFundRequestRejected is sent to the requester
+	EventFundRequestRejected = "fund_request.rejected"
+)
+
+// NewFundRequestService creates fund request service
+func NewFundRequestService(log logger.Logger, db *pg.DB, transaction TransactionService, teamMember TeamMemberService, notify notifier.Notifier) FundRequestService {
+	fundRequestRepo := postgre.CreateFundRequestRepository(log, db)
+	walletRepo := postgre.CreateWalletRepository(log, db)
+	teamMemberRepo := postgre.CreateTeamMemberRepository(log, db)
+
+	return &FundRequestSvc{
+		Log:            log,
+		FundRequest:    fundRequestRepo,
+		Wallet:         walletRepo,
+		TeamMemberRepo: teamMemberRepo,
+		TeamMember:     teamMember,
+		Transaction:    transaction,
+		Notifier:       notify,
+	}
+}
+
+func (s *FundRequestSvc) GetFundRequests(ctx context.Context, payload *GetFundRequestsRequest) ([]repository.FundRequest, error) {
+	ctx, span := tracing.Start(ctx, "FundRequestService.GetFundRequests")
+	defer span.End()
+
+	fundRequests, err := s.FundRequest.GetFundRequests(ctx, payload.TeamID, payload.RequesterID, payload.Status, payload.SortBy, payload.Sort, payload.Skip, payload.Limit)
+	return fundRequests, err
+}
+
+func (s *FundRequestSvc) GetFundRequest(ctx context.Context, fundRequestID uuid.UUID) (*repository.FundRequest, error) {
+	ctx, span := tracing.Start(ctx, "FundRequestService.GetFundRequest")
+	defer span.End()
+
+	fundRequest, err := s.FundRequest.GetFundRequestByID(ctx, fundRequestID)
+	return fundRequest, err
+}
+
+func (s *FundRequestSvc) CreateFundRequest(ctx context.Context, payload *CreateFundRequestRequest) (*repository.FundRequest, error) {
+	ctx, span := tracing.Start(ctx, "FundRequestService.CreateFundRequest")
+	defer span.End()
+
+	if payload.Amount.Sign() <= 0 {
+		return nil, errors.InvalidFundRequestAmount
+	}
+
+	if err := requireTeamMember(ctx, s.TeamMemberRepo, payload.TeamID); err != nil {
+		return nil, err
+	}
+
+	requesterID := ctxUtil.GetUserID(ctx)
+
+	wallet, err := s.getActiveWallet(ctx, payload.WalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if wallet.TeamID != payload.TeamID && wallet.UserID != requesterID {
+		return nil, errors.InvalidFundRequestWallet
+	}
+
+	amount, err := money.New(payload.Amount, wallet.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	fundRequestPayload := repository.FundRequest{
+		ID:          uuid.New(),
+		TeamID:      payload.TeamID,
+		WalletID:    wallet.ID,
+		RequesterID: requesterID,
+		Amount:      amount.Amount,
+		Currency:    amount.Currency,
+		Reason:      payload.Reason,
+		Status:      repository.FundRequestStatusPending,
+	}
+
+	fundRequest, err := s.FundRequest.CreateFundRequest(ctx, &fundRequestPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyApprovers(ctx, fundRequest)
+
+	return fundRequest, nil
+}
+
+// ApproveFundRequest transfers the requested amount and approves the request
+// in the same transaction, a request approved or cancelled concurrently is
+// never funded
+func (s *FundRequestSvc) ApproveFundRequest(ctx context.Context, fundRequestID uuid.UUID, payload *ApproveFundRequestRequest) (*repository.FundRequest, error) {
+	ctx, span := tracing.Start(ctx, "FundRequestService.ApproveFundRequest")
+	defer span.End()
+
+	fundRequest, reviewer, err := s.getReviewableFundRequest(ctx, fundRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := s.getActiveWallet(ctx, payload.SourceWalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if source.TeamID != fundRequest.TeamID || source.ID == fundRequest.WalletID {
+		return nil, errors.InvalidFundRequestSource
+	}
+
+	var approved *repository.FundRequest
+	_, err = s.Transaction.CreateTransfer(ctx, &CreateTransferRequest{
+		FromWalletID: source.ID,
+		ToWalletID:   fundRequest.WalletID,
+		Amount:       fundRequest.Amount,
+		Currency:     fundRequest.Currency,
+		Description:  fmt.Sprintf("Fund request: %s", fundRequest.Reason),
+		Guard: func(ctx context.Context, tx *pg.Tx, debit *repository.Transaction) error {
+			var err error
+			approved, err = s.FundRequest.UpdatePendingFundRequestInTx(ctx, tx, fundRequest.ID, map[string]interface{}{
+				"status":           repository.FundRequestStatusApproved,
+				"reviewer_id":      reviewer.UserID,
+				"review_note":      nullString(payload.Note),
+				"source_wallet_id": source.ID,
+				"reference_id":     debit.ReferenceID,
+				"reviewed_at":      time.Now(),
+			})
+			return err
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyRequester(ctx, EventFundRequestApproved, approved)
+
+	return approved, nil
+}
+
+func (s *FundRequestSvc) RejectFundRequest(ctx context.Context, fundRequestID uuid.UUID, payload *RejectFundRequestRequest) (*repository.FundRequest, error) {
+	ctx, span := tracing.Start(ctx, "FundRequestService.RejectFundRequest")
+	defer span.End()
+
+	fundRequest, reviewer, err := s.getReviewableFundRequest(ctx, fundRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	rejected, err := s.FundRequest.UpdatePendingFundRequest(ctx, fundRequest.ID, map[string]interface{}{
+		"status":      repository.FundRequestStatusRejected,
+		"reviewer_id": reviewer.UserID,
+		"review_note": nullString(payload.Note),
+		"reviewed_at": time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyRequester(ctx, EventFundRequestRejected, rejected)
+
+	return rejected, nil
+}
+
+// CancelFundRequest withdraws a pending request, only its requester can
+func (s *FundRequestSvc) CancelFundRequest(ctx context.Context, fundRequestID uuid.UUID) (*repository.FundRequest, error) {
+	ctx, span := tracing.Start(ctx, "FundRequestService.CancelFundRequest")
+	defer span.End()
+
+	userID := ctxUtil.GetUserID(ctx)
+	if userID == uuid.Nil {
+		return nil, errors.Unauthenticated
+	}
+
+	fundRequest, err := s.FundRequest.GetFundRequestByID(ctx, fundRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if fundRequest.RequesterID != userID {
+		return nil, errors.Forbidden
+	}
+
+	cancelled, err := s.FundRequest.UpdatePendingFundRequest(ctx, fundRequest.ID, map[string]interface{}{
+		"status": repository.FundRequestStatusCancelled,
+	})
+	return cancelled, err
+}
+
+// getReviewableFundRequest returns a pending request and the caller, an admin
+// of its team other than the requester
+func (s *FundRequestSvc) getReviewableFundRequest(ctx context.Context, fundRequestID uuid.UUID) (*repository.FundRequest, *repository.TeamMember, error) {
+	fundRequest, err := s.FundRequest.GetFundRequestByID(ctx, fundRequestID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reviewer, err := requireTeamAdmin(ctx, s.TeamMemberRepo, fundRequest.TeamID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if reviewer.UserID == fundRequest.RequesterID {
+		return nil, nil, errors.FundRequestSelfReview
+	}
+
+	if fundRequest.Status != repository.FundRequestStatusPending {
+		return nil, nil, errors.FundRequestNotPending
+	}
+
+	return fundRequest, reviewer, nil
+}
+
+func (s *FundRequestSvc) getActiveWallet(ctx context.Context, walletID uuid.UUID) (*repository.Wallet, error) {
+	wallet, err := s.Wallet.GetWalletByID(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if wallet.IsDeleted {
+		return nil, errors.FailedWalletNotFound
+	}
+
+	return wallet, nil
+}
+
+// notifyApprovers tells the admins of the team a request awaits them, a
+// request is kept even when they cannot be notified
+func (s *FundRequestSvc) notifyApprovers(ctx context.Context, fundRequest *repository.FundRequest) {
+	approvers, err := s.TeamMember.GetTeamApprovers(ctx, fundRequest.TeamID)
+	if err != nil {
+		s.Log.Warnw("unable to fetch fund request approvers", "fundRequestId", fundRequest.ID, "error", err)
+		return
+	}
+
+	recipients := []uuid.UUID{}
+	for _, approver := range approvers {
+		if approver.UserID != fundRequest.RequesterID {
+			recipients = append(recipients, approver.UserID)
+		}
+	}
+
+	if len(recipients) == 0 {
+		s.Log.Warnw("fund request has no approver", "fundRequestId", fundRequest.ID, "teamId", fundRequest.TeamID)
+		return
+	}
+
+	s.notify(ctx, EventFundRequestCreated, fmt.Sprintf("fund request of %s %s awaits approval", fundRequest.Amount, fundRequest.Currency), fundRequest, recipients)
+}
+
+func (s *FundRequestSvc) notifyRequester(ctx context.Context, eventType string, fundRequest *repository.FundRequest) {
+	message := fmt.Sprintf("fund request of %s %s is %s", fundRequest.Amount, fundRequest.Currency, fundRequest.Status)
+	s.notify(ctx, eventType, message, fundRequest, []uuid.UUID{fundRequest.RequesterID})
+}
+
+func (s *FundRequestSvc) notify(ctx context.Context, eventType string, message string, fundRequest *repository.FundRequest, recipients []uuid.UUID) {
+	err := s.Notifier.Notify(ctx, notifier.Event{
+		Type:    eventType,
+		Message: message,
+		Data: FundRequestNotification{
+			FundRequest: fundRequest,
+			Recipients:  recipients,
+		},
+		OccurredAt: time.Now(),
+	})
+	if err != nil {
+		s.Log.Warnw("unable to notify fund request", "fundRequestId", fundRequest.ID, "event", eventType, "error", err)
+	}
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	ctxUtil "gitlab.com/renodesper/spenmo-test/util/ctx"
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+func TestFundRequestIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	reinitializeDB()
+
+	teamID := uuid.MustParse("933efe12-2219-42df-bd51-a2e84888432d")
+	teamWalletID := uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e")
+	adminID := uuid.MustParse("8e159833-5078-4b0a-80a0-363d82bafd60")
+	memberID := uuid.MustParse("51241303-ebe0-4c2b-98be-44d93439f6d9")
+
+	adminCtx := ctxUtil.SetUserID(context.Background(), adminID)
+	memberCtx := ctxUtil.SetUserID(context.Background(), memberID)
+
+	walletSvc := NewWalletService(Log, DB)
+	transactionSvc := NewTransactionService(Log, DB, newTestConverter(t), Notifier, newTestFraudEngine(t))
+	svc := NewFundRequestService(Log, DB, transactionSvc, NewTeamMemberService(Log, DB), Notifier)
+
+	topUpTeamWallet(t, adminCtx, teamWalletID.String(), teamID.String(), money.MustParseDecimal("1000.00"))
+
+	memberWallet, err := walletSvc.CreateWallet(memberCtx, &CreateWalletRequest{UserID: memberID})
+	assert.NoError(t, err)
+
+	newRequest := func(t *testing.T) *repository.FundRequest {
+		fundRequest, err := svc.CreateFundRequest(memberCtx, &CreateFundRequestRequest{
+			TeamID:   teamID,
+			WalletID: memberWallet.ID,
+			Amount:   money.MustParseDecimal("120.00"),
+			Reason:   "client dinner",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, repository.FundRequestStatusPending, fundRequest.Status)
+
+		return fundRequest
+	}
+
+	assertErrorCode := func(t *testing.T, expected e.Error, err error) {
+		if assert.Error(t, err) {
+			assert.Equal(t, expected.Code, err.(e.Error).Code)
+		}
+	}
+
+	t.Run("SuccessApprove", func(t *testing.T) {
+		fundRequest := newRequest(t)
+
+		approved, err := svc.ApproveFundRequest(adminCtx, fundRequest.ID, &ApproveFundRequestRequest{SourceWalletID: teamWalletID})
+		assert.NoError(t, err)
+		assert.Equal(t, repository.FundRequestStatusApproved, approved.Status)
+		assert.Equal(t, adminID, approved.ReviewerID)
+		assert.NotEqual(t, uuid.Nil, approved.ReferenceID)
+
+		wallet, err := walletSvc.GetWallet(memberCtx, memberWallet.ID)
+		assert.NoError(t, err)
+		assert.Zero(t, money.MustParseDecimal("120.00").Cmp(wallet.Balance))
+
+		// NOTE: A request is only funded once
+		_, err = svc.ApproveFundRequest(adminCtx, fundRequest.ID, &ApproveFundRequestRequest{SourceWalletID: teamWalletID})
+		assertErrorCode(t, errors.FundRequestNotPending, err)
+
+		_, err = svc.CancelFundRequest(memberCtx, fundRequest.ID)
+		assertErrorCode(t, errors.FundRequestNotPending, err)
+	})
+
+	t.Run("SuccessReject", func(t *testing.T) {
+		fundRequest := newRequest(t)
+
+		rejected, err := svc.RejectFundRequest(adminCtx, fundRequest.ID, &RejectFundRequestRequest{Note: "out of budget"})
+		assert.NoError(t, err)
+		assert.Equal(t, repository.FundRequestStatusRejected, rejected.Status)
+		assert.Equal(t, "out of budget", rejected.ReviewNote)
+	})
+
+	t.Run("SuccessCancel", func(t *testing.T) {
+		fundRequest := newRequest(t)
+
+		_, err := svc.CancelFundRequest(adminCtx, fundRequest.ID)
+		assertErrorCode(t, errors.Forbidden, err)
+
+		cancelled, err := svc.CancelFundRequest(memberCtx, fundRequest.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, repository.FundRequestStatusCancelled, cancelled.Status)
+
+		_, err = svc.ApproveFundRequest(adminCtx, fundRequest.ID, &ApproveFundRequestRequest{SourceWalletID: teamWalletID})
+		assertErrorCode(t, errors.FundRequestNotPending, err)
+	})
+
+	t.Run("FailedMemberApproval", func(t *testing.T) {
+		fundRequest := newRequest(t)
+
+		_, err := svc.ApproveFundRequest(memberCtx, fundRequest.ID, &ApproveFundRequestRequest{SourceWalletID: teamWalletID})
+		assertErrorCode(t, errors.Forbidden, err)
+	})
+
+	t.Run("FailedSelfReview", func(t *testing.T) {
+		adminWallet, err := walletSvc.CreateWallet(adminCtx, &CreateWalletRequest{UserID: adminID})
+		assert.NoError(t, err)
+
+		fundRequest, err := svc.CreateFundRequest(adminCtx, &CreateFundRequestRequest{
+			TeamID:   teamID,
+			WalletID: adminWallet.ID,
+			Amount:   money.MustParseDecimal("10.00"),
+			Reason:   "taxi",
+		})
+		assert.NoError(t, err)
+
+		_, err = svc.ApproveFundRequest(adminCtx, fundRequest.ID, &ApproveFundRequestRequest{SourceWalletID: teamWalletID})
+		assertErrorCode(t, errors.FundRequestSelfReview, err)
+	})
+
+	t.Run("FailedInvalidSource", func(t *testing.T) {
+		fundRequest := newRequest(t)
+
+		_, err := svc.ApproveFundRequest(adminCtx, fundRequest.ID, &ApproveFundRequestRequest{SourceWalletID: uuid.MustParse("370a9739-b90b-4264-81a2-f8d0d3236011")})
+		assertErrorCode(t, errors.InvalidFundRequestSource, err)
+	})
+
+	t.Run("FailedOtherUserWallet", func(t *testing.T) {
+		_, err := svc.CreateFundRequest(memberCtx, &CreateFundRequestRequest{
+			TeamID:   teamID,
+			WalletID: uuid.MustParse("370a9739-b90b-4264-81a2-f8d0d3236011"),
+			Amount:   money.MustParseDecimal("10.00"),
+			Reason:   "taxi",
+		})
+		assertErrorCode(t, errors.InvalidFundRequestWallet, err)
+	})
+
+	t.Run("FailedNotTeamMember", func(t *testing.T) {
+		outsiderCtx := ctxUtil.SetUserID(context.Background(), uuid.MustParse("0e49e11c-660c-43c5-954e-ef9e89b45833"))
+
+		_, err := svc.CreateFundRequest(outsiderCtx, &CreateFundRequestRequest{
+			TeamID:   teamID,
+			WalletID: uuid.MustParse("370a9739-b90b-4264-81a2-f8d0d3236011"),
+			Amount:   money.MustParseDecimal("10.00"),
+			Reason:   "taxi",
+		})
+		assertErrorCode(t, errors.Forbidden, err)
+	})
+}
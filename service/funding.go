@@ -0,0 +1,577 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/repository/postgre"
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+	"gitlab.com/renodesper/spenmo-test/util/schedule"
+	"gitlab.com/renodesper/spenmo-test/util/tracing"
+)
+
+type (
+	// FundingService ...
+	FundingService interface {
+		GetFundingSchedules(ctx context.Context, payload *GetFundingSchedulesRequest) ([]repository.FundingSchedule, error)
+		GetFundingSchedule(ctx context.Context, scheduleID uuid.UUID) (*repository.FundingSchedule, error)
+		CreateFundingSchedule(ctx context.Context, payload *CreateFundingScheduleRequest) (*repository.FundingSchedule, error)
+		UpdateFundingSchedule(ctx context.Context, scheduleID uuid.UUID, payload *UpdateFundingScheduleRequest) (*repository.FundingSchedule, error)
+		DeleteFundingSchedule(ctx context.Context, scheduleID uuid.UUID) (*repository.FundingSchedule, error)
+		GetFundingRuns(ctx context.Context, scheduleID uuid.UUID, skip int, limit int) ([]repository.FundingRun, error)
+	}
+
+	FundingSvc struct {
+		Log        logger.Logger
+		Funding    postgre.FundingRepository
+		Wallet     postgre.WalletRepository
+		Team       postgre.TeamRepository
+		TeamMember postgre.TeamMemberRepository
+	}
+
+	// FundingScheduler executes the funding schedules due. Periods missed
+	// while it was down are caught up, every period is funded at most once
+	// even when several schedulers run.
+	FundingScheduler struct {
+		Log         logger.Logger
+		Funding     postgre.FundingRepository
+		Wallet      postgre.WalletRepository
+		Team        postgre.TeamRepository
+		Transaction TransactionService
+	}
+
+	GetFundingSchedulesRequest struct {
+		TeamID uuid.UUID
+		SortBy string
+		Sort   string
+		Skip   int
+		Limit  int
+	}
+
+	// CreateFundingScheduleRequest funds TargetWalletID from the team wallet
+	// SourceWalletID on Cron or on DayOfMonth, exactly one of them is set.
+	// Amount is expressed in the currency of the target wallet.
+	CreateFundingScheduleRequest struct {
+		SourceWalletID uuid.UUID
+		TargetWalletID uuid.UUID
+		Mode           string
+		Amount         money.Decimal
+		Cron           string
+		DayOfMonth     int
+	}
+
+	// UpdateFundingScheduleRequest replaces the schedule when Cron or
+	// DayOfMonth is set, the next run is then computed from now
+	UpdateFundingScheduleRequest struct {
+		Mode       string
+		Amount     money.Decimal
+		Cron       string
+		DayOfMonth int
+	}
+)
+
+const (
+	// FUNDING_SCHEDULES_BATCH is the number of due schedules loaded at once
+	FUNDING_SCHEDULES_BATCH = 100
+	// FUNDING_CATCH_UP_MAXIMUM bounds the periods caught up by a schedule,
+	// older periods are dropped
+	FUNDING_CATCH_UP_MAXIMUM = 366
+)
+
+// NewFundingService creates funding service
+func NewFundingService(log logger.Logger, db *pg.DB) FundingService {
+	fundingRepo := postgre.CreateFundingRepository(log, db)
+	walletRepo := postgre.CreateWalletRepository(log, db)
+	teamRepo := postgre.CreateTeamRepository(log, db)
+	teamMemberRepo := postgre.CreateTeamMemberRepository(log, db)
+
+	return &FundingSvc{
+		Log:        log,
+		Funding:    fundingRepo,
+		Wallet:     walletRepo,
+		Team:       teamRepo,
+		TeamMember: teamMemberRepo,
+	}
+}
+
+// NewFundingScheduler creates funding scheduler
+func NewFundingScheduler(log logger.Logger, db *pg.DB, transaction TransactionService) *FundingScheduler {
+	fundingRepo := postgre.CreateFundingRepository(log, db)
+	walletRepo := postgre.CreateWalletRepository(log, db)
+	teamRepo := postgre.CreateTeamRepository(log, db)
+
+	return &FundingScheduler{
+		Log:         log,
+		Funding:     fundingRepo,
+		Wallet:      walletRepo,
+		Team:        teamRepo,
+		Transaction: transaction,
+	}
+}
+
+func (s *FundingSvc) GetFundingSchedules(ctx context.Context, payload *GetFundingSchedulesRequest) ([]repository.FundingSchedule, error) {
+	ctx, span := tracing.Start(ctx, "FundingService.GetFundingSchedules")
+	defer span.End()
+
+	schedules, err := s.Funding.GetFundingSchedules(ctx, payload.TeamID, payload.SortBy, payload.Sort, payload.Skip, payload.Limit)
+	return schedules, err
+}
+
+func (s *FundingSvc) GetFundingSchedule(ctx context.Context, scheduleID uuid.UUID) (*repository.FundingSchedule, error) {
+	ctx, span := tracing.Start(ctx, "FundingService.GetFundingSchedule")
+	defer span.End()
+
+	fundingSchedule, err := s.Funding.GetFundingScheduleByID(ctx, scheduleID)
+	return fundingSchedule, err
+}
+
+func (s *FundingSvc) CreateFundingSchedule(ctx context.Context, payload *CreateFundingScheduleRequest) (*repository.FundingSchedule, error) {
+	ctx, span := tracing.Start(ctx, "FundingService.CreateFundingSchedule")
+	defer span.End()
+
+	mode, err := newFundingMode(payload.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	sched, err := newFundingSchedule(payload.Cron, payload.DayOfMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := s.getActiveWallet(ctx, payload.SourceWalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if source.TeamID == uuid.Nil {
+		return nil, errors.InvalidFundingSource
+	}
+
+	if _, err := requireTeamAdmin(ctx, s.TeamMember, source.TeamID); err != nil {
+		return nil, err
+	}
+
+	target, err := s.getFundingTarget(ctx, source, payload.TargetWalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := newFundingAmount(payload.Amount, target.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	nextRunAt, err := s.nextRunAt(ctx, source.TeamID, sched)
+	if err != nil {
+		return nil, err
+	}
+
+	schedulePayload := repository.FundingSchedule{
+		ID:             uuid.New(),
+		TeamID:         source.TeamID,
+		SourceWalletID: source.ID,
+		TargetWalletID: target.ID,
+		Mode:           mode,
+		Amount:         amount.Amount,
+		Currency:       amount.Currency,
+		Cron:           payload.Cron,
+		DayOfMonth:     payload.DayOfMonth,
+		NextRunAt:      nextRunAt,
+	}
+
+	fundingSchedule, err := s.Funding.CreateFundingSchedule(ctx, &schedulePayload)
+	return fundingSchedule, err
+}
+
+func (s *FundingSvc) UpdateFundingSchedule(ctx context.Context, scheduleID uuid.UUID, payload *UpdateFundingScheduleRequest) (*repository.FundingSchedule, error) {
+	ctx, span := tracing.Start(ctx, "FundingService.UpdateFundingSchedule")
+	defer span.End()
+
+	fundingSchedule, err := s.getActiveFundingSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := requireTeamAdmin(ctx, s.TeamMember, fundingSchedule.TeamID); err != nil {
+		return nil, err
+	}
+
+	schedulePayload := make(map[string]interface{})
+
+	if payload.Mode != "" {
+		mode, err := newFundingMode(payload.Mode)
+		if err != nil {
+			return nil, err
+		}
+
+		schedulePayload["mode"] = mode
+	}
+
+	if !payload.Amount.IsZero() {
+		amount, err := newFundingAmount(payload.Amount, fundingSchedule.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		schedulePayload["amount"] = amount.Amount
+	}
+
+	if payload.Cron != "" || payload.DayOfMonth != 0 {
+		sched, err := newFundingSchedule(payload.Cron, payload.DayOfMonth)
+		if err != nil {
+			return nil, err
+		}
+
+		nextRunAt, err := s.nextRunAt(ctx, fundingSchedule.TeamID, sched)
+		if err != nil {
+			return nil, err
+		}
+
+		schedulePayload["cron"] = nullString(payload.Cron)
+		schedulePayload["day_of_month"] = nullInt(payload.DayOfMonth)
+		schedulePayload["next_run_at"] = nextRunAt
+	}
+
+	fundingSchedule, err = s.Funding.UpdateFundingSchedule(ctx, scheduleID, schedulePayload)
+	return fundingSchedule, err
+}
+
+func (s *FundingSvc) DeleteFundingSchedule(ctx context.Context, scheduleID uuid.UUID) (*repository.FundingSchedule, error) {
+	ctx, span := tracing.Start(ctx, "FundingService.DeleteFundingSchedule")
+	defer span.End()
+
+	fundingSchedule, err := s.getActiveFundingSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := requireTeamAdmin(ctx, s.TeamMember, fundingSchedule.TeamID); err != nil {
+		return nil, err
+	}
+
+	fundingSchedule, err = s.Funding.DeleteFundingSchedule(ctx, scheduleID)
+	return fundingSchedule, err
+}
+
+func (s *FundingSvc) GetFundingRuns(ctx context.Context, scheduleID uuid.UUID, skip int, limit int) ([]repository.FundingRun, error) {
+	ctx, span := tracing.Start(ctx, "FundingService.GetFundingRuns")
+	defer span.End()
+
+	if _, err := s.Funding.GetFundingScheduleByID(ctx, scheduleID); err != nil {
+		return nil, err
+	}
+
+	runs, err := s.Funding.GetFundingRuns(ctx, scheduleID, skip, limit)
+	return runs, err
+}
+
+func (s *FundingSvc) getActiveWallet(ctx context.Context, walletID uuid.UUID) (*repository.Wallet, error) {
+	wallet, err := s.Wallet.GetWalletByID(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if wallet.IsDeleted {
+		return nil, errors.FailedWalletNotFound
+	}
+
+	return wallet, nil
+}
+
+func (s *FundingSvc) getActiveFundingSchedule(ctx context.Context, scheduleID uuid.UUID) (*repository.FundingSchedule, error) {
+	fundingSchedule, err := s.Funding.GetFundingScheduleByID(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if fundingSchedule.IsDeleted {
+		return nil, errors.FailedFundingScheduleNotFound
+	}
+
+	return fundingSchedule, nil
+}
+
+// getFundingTarget only lets a team fund its other wallets and the wallets of
+// its members
+func (s *FundingSvc) getFundingTarget(ctx context.Context, source *repository.Wallet, targetWalletID uuid.UUID) (*repository.Wallet, error) {
+	if targetWalletID == source.ID {
+		return nil, errors.InvalidFundingTarget
+	}
+
+	target, err := s.getActiveWallet(ctx, targetWalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if target.TeamID == source.TeamID {
+		return target, nil
+	}
+
+	if target.UserID == uuid.Nil {
+		return nil, errors.InvalidFundingTarget
+	}
+
+	member, err := s.TeamMember.GetTeamMember(ctx, source.TeamID, target.UserID)
+	if err != nil {
+		if er, ok := err.(e.Error); ok && er.Code == errors.FailedNoRows.Code {
+			return nil, errors.InvalidFundingTarget
+		}
+
+		return nil, err
+	}
+
+	if member.IsDeleted {
+		return nil, errors.InvalidFundingTarget
+	}
+
+	return target, nil
+}
+
+// nextRunAt returns the first run of sched after now in the timezone of the
+// team, stored in UTC
+func (s *FundingSvc) nextRunAt(ctx context.Context, teamID uuid.UUID, sched schedule.Schedule) (time.Time, error) {
+	team, err := s.Team.GetTeamByID(ctx, teamID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	next := sched.Next(time.Now().In(teamLocation(team)))
+	if next.IsZero() {
+		return time.Time{}, errors.InvalidCronExpression
+	}
+
+	return next.UTC(), nil
+}
+
+// Start runs the due schedules every interval until ctx is done
+func (f *FundingScheduler) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := f.RunDue(ctx, time.Now()); err != nil {
+			f.Log.Errorw("unable to run funding schedules", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue executes every schedule with a run due at now. A schedule failing is
+// logged and retried on the next call, the others still run.
+func (f *FundingScheduler) RunDue(ctx context.Context, now time.Time) error {
+	for {
+		schedules, err := f.Funding.GetDueFundingSchedules(ctx, now, FUNDING_SCHEDULES_BATCH)
+		if err != nil {
+			return err
+		}
+
+		advanced := 0
+		for i := range schedules {
+			if err := f.run(ctx, &schedules[i], now); err != nil {
+				f.Log.Errorw("unable to run funding schedule", "scheduleId", schedules[i].ID, "error", err)
+				continue
+			}
+
+			advanced++
+		}
+
+		// NOTE: Schedules failing before being advanced would be loaded again, wait for the next call
+		if len(schedules) < FUNDING_SCHEDULES_BATCH || advanced == 0 {
+			return nil
+		}
+	}
+}
+
+// run funds every period of the schedule due at now and advances it to its
+// next period. A reset lands on the same balance whatever the number of
+// periods missed, only its latest period moves money.
+func (f *FundingScheduler) run(ctx context.Context, fundingSchedule *repository.FundingSchedule, now time.Time) error {
+	sched, err := newFundingSchedule(fundingSchedule.Cron, fundingSchedule.DayOfMonth)
+	if err != nil {
+		return err
+	}
+
+	team, err := f.Team.GetTeamByID(ctx, fundingSchedule.TeamID)
+	if err != nil {
+		return err
+	}
+
+	loc := teamLocation(team)
+	periods, next := duePeriods(sched, fundingSchedule.NextRunAt.In(loc), now.In(loc), FUNDING_CATCH_UP_MAXIMUM)
+
+	if len(periods) == FUNDING_CATCH_UP_MAXIMUM && !next.After(now) {
+		f.Log.Warnw("funding schedule catch up truncated", "scheduleId", fundingSchedule.ID, "from", next)
+		next = sched.Next(now.In(loc))
+	}
+
+	for i, period := range periods {
+		if fundingSchedule.Mode == repository.FundingModeReset && i < len(periods)-1 {
+			f.record(ctx, fundingSchedule, period, repository.FundingRunStatusSkipped, money.Decimal{}, "superseded by a later period")
+			continue
+		}
+
+		f.execute(ctx, fundingSchedule, period)
+	}
+
+	schedulePayload := map[string]interface{}{
+		"next_run_at": next.UTC(),
+		"last_run_at": now.UTC(),
+	}
+
+	// NOTE: A schedule that never runs again, such as 0 0 30 2 *, is retired
+	if next.IsZero() {
+		schedulePayload["is_deleted"] = true
+		delete(schedulePayload, "next_run_at")
+	}
+
+	_, err = f.Funding.UpdateFundingSchedule(ctx, fundingSchedule.ID, schedulePayload)
+	return err
+}
+
+// execute funds a single period. The run is recorded in the transaction
+// moving the money, so a period another scheduler funded is rolled back. A
+// failed period is recorded and not retried, the next period funds again.
+func (f *FundingScheduler) execute(ctx context.Context, fundingSchedule *repository.FundingSchedule, period time.Time) {
+	amount := fundingSchedule.Amount
+
+	if fundingSchedule.Mode == repository.FundingModeReset {
+		target, err := f.Wallet.GetWalletByID(ctx, fundingSchedule.TargetWalletID)
+		if err != nil {
+			f.record(ctx, fundingSchedule, period, repository.FundingRunStatusFailed, money.Decimal{}, err.Error())
+			return
+		}
+
+		amount, err = fundingSchedule.Amount.Sub(target.Balance)
+		if err != nil {
+			f.record(ctx, fundingSchedule, period, repository.FundingRunStatusFailed, money.Decimal{}, err.Error())
+			return
+		}
+
+		if amount.Sign() <= 0 {
+			f.record(ctx, fundingSchedule, period, repository.FundingRunStatusSkipped, money.Decimal{}, "target wallet already holds the amount")
+			return
+		}
+	}
+
+	runPayload := repository.FundingRun{
+		ID:         uuid.New(),
+		ScheduleID: fundingSchedule.ID,
+		Period:     period.UTC(),
+		Status:     repository.FundingRunStatusSucceeded,
+		Amount:     amount,
+		Currency:   fundingSchedule.Currency,
+	}
+
+	_, err := f.Transaction.CreateTransfer(ctx, &CreateTransferRequest{
+		FromWalletID: fundingSchedule.SourceWalletID,
+		ToWalletID:   fundingSchedule.TargetWalletID,
+		Amount:       amount,
+		Currency:     fundingSchedule.Currency,
+		Description:  fmt.Sprintf("Scheduled funding for %s", period.Format("2006-01-02 15:04 MST")),
+		Guard: func(ctx context.Context, tx *pg.Tx, debit *repository.Transaction) error {
+			runPayload.TransactionID = debit.ID
+			_, err := f.Funding.CreateFundingRun(ctx, tx, &runPayload)
+			return err
+		},
+	})
+	if err != nil {
+		if er, ok := err.(e.Error); ok && er.Code == errors.FundingRunAlreadyExecuted.Code {
+			return
+		}
+
+		f.record(ctx, fundingSchedule, period, repository.FundingRunStatusFailed, amount, err.Error())
+	}
+}
+
+// record keeps a run that moved no money, a period already recorded is left
+// untouched
+func (f *FundingScheduler) record(ctx context.Context, fundingSchedule *repository.FundingSchedule, period time.Time, status string, amount money.Decimal, reason string) {
+	runPayload := repository.FundingRun{
+		ID:         uuid.New(),
+		ScheduleID: fundingSchedule.ID,
+		Period:     period.UTC(),
+		Status:     status,
+		Amount:     amount,
+		Currency:   fundingSchedule.Currency,
+		Error:      reason,
+	}
+
+	if _, err := f.Funding.RecordFundingRun(ctx, &runPayload); err != nil {
+		f.Log.Errorw("unable to record funding run", "scheduleId", fundingSchedule.ID, "period", period, "error", err)
+	}
+}
+
+// duePeriods lists the periods of sched from first up to now, at most limit,
+// and returns the period following them
+func duePeriods(sched schedule.Schedule, first time.Time, now time.Time, limit int) ([]time.Time, time.Time) {
+	periods := []time.Time{}
+
+	period := first
+	for !period.IsZero() && !period.After(now) && len(periods) < limit {
+		periods = append(periods, period)
+		period = sched.Next(period)
+	}
+
+	return periods, period
+}
+
+// newFundingSchedule parses the cron expression or the day of the month,
+// exactly one of them is set
+func newFundingSchedule(cron string, dayOfMonth int) (schedule.Schedule, error) {
+	switch {
+	case cron != "" && dayOfMonth == 0:
+		return schedule.ParseCron(cron)
+	case cron == "" && dayOfMonth != 0:
+		return schedule.NewMonthly(dayOfMonth)
+	default:
+		return nil, errors.MissingFundingSchedule
+	}
+}
+
+func newFundingMode(mode string) (string, error) {
+	switch mode {
+	case "":
+		return repository.FundingModeTopUp, nil
+	case repository.FundingModeTopUp, repository.FundingModeReset:
+		return mode, nil
+	default:
+		return "", errors.InvalidFundingMode
+	}
+}
+
+func newFundingAmount(amount money.Decimal, currency string) (money.Money, error) {
+	if amount.Sign() <= 0 {
+		return money.Money{}, errors.InvalidFundingAmount
+	}
+
+	return money.New(amount, currency)
+}
+
+func nullString(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+
+	return value
+}
+
+func nullInt(value int) interface{} {
+	if value == 0 {
+		return nil
+	}
+
+	return value
+}
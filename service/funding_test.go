@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	ctxUtil "gitlab.com/renodesper/spenmo-test/util/ctx"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+	"gitlab.com/renodesper/spenmo-test/util/schedule"
+)
+
+func TestDuePeriods(t *testing.T) {
+	monthly, err := schedule.NewMonthly(1)
+	assert.NoError(t, err)
+
+	first := time.Date(2021, time.May, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2021, time.August, 17, 10, 30, 0, 0, time.UTC)
+
+	periods, next := duePeriods(monthly, first, now, FUNDING_CATCH_UP_MAXIMUM)
+	assert.Equal(t, []time.Time{
+		time.Date(2021, time.May, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, time.August, 1, 0, 0, 0, 0, time.UTC),
+	}, periods)
+	assert.Equal(t, time.Date(2021, time.September, 1, 0, 0, 0, 0, time.UTC), next)
+
+	periods, next = duePeriods(monthly, first, now, 2)
+	assert.Len(t, periods, 2)
+	assert.Equal(t, time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC), next)
+
+	periods, next = duePeriods(monthly, next, first, FUNDING_CATCH_UP_MAXIMUM)
+	assert.Empty(t, periods)
+	assert.Equal(t, time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC), next)
+}
+
+func TestNewFundingSchedule(t *testing.T) {
+	cases := map[string]struct {
+		Cron          string
+		DayOfMonth    int
+		ExpectedError bool
+	}{
+		"SuccessCron":       {Cron: "0 9 1 * *"},
+		"SuccessDayOfMonth": {DayOfMonth: 31},
+		"FailedMissing":     {ExpectedError: true},
+		"FailedBoth":        {Cron: "0 9 1 * *", DayOfMonth: 1, ExpectedError: true},
+		"FailedInvalidCron": {Cron: "0 9 1", ExpectedError: true},
+		"FailedInvalidDay":  {DayOfMonth: 32, ExpectedError: true},
+	}
+
+	for v, tc := range cases {
+		t.Run(v, func(t *testing.T) {
+			_, err := newFundingSchedule(tc.Cron, tc.DayOfMonth)
+
+			if tc.ExpectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFundingSchedulerIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	reinitializeDB()
+
+	teamWalletID := "d4a6607a-1af7-4571-bdff-2672be72ba0e"
+	teamID := "933efe12-2219-42df-bd51-a2e84888432d"
+	memberID := uuid.MustParse("8e159833-5078-4b0a-80a0-363d82bafd60")
+
+	ctx := ctxUtil.SetUserID(context.Background(), memberID)
+	walletSvc := NewWalletService(Log, DB)
+	fundingSvc := NewFundingService(Log, DB)
+	transactionSvc := NewTransactionService(Log, DB, newTestConverter(t), Notifier, newTestFraudEngine(t))
+	scheduler := NewFundingScheduler(Log, DB, transactionSvc)
+
+	topUpTeamWallet(t, ctx, teamWalletID, teamID, money.MustParseDecimal("10000.00"))
+
+	cases := map[string]struct {
+		Mode             string
+		Amount           money.Decimal
+		ExpectedBalance  func(periods int) money.Decimal
+		ExpectedStatuses func(periods int) []string
+	}{
+		"SuccessTopUpEveryMissedPeriod": {
+			Mode:   repository.FundingModeTopUp,
+			Amount: money.MustParseDecimal("100.00"),
+			ExpectedBalance: func(periods int) money.Decimal {
+				return money.NewDecimal(int64(periods)*10000, 2)
+			},
+			ExpectedStatuses: func(periods int) []string {
+				statuses := []string{}
+				for i := 0; i < periods; i++ {
+					statuses = append(statuses, repository.FundingRunStatusSucceeded)
+				}
+				return statuses
+			},
+		},
+		"SuccessResetLatestPeriodOnly": {
+			Mode:   repository.FundingModeReset,
+			Amount: money.MustParseDecimal("250.00"),
+			ExpectedBalance: func(periods int) money.Decimal {
+				return money.MustParseDecimal("250.00")
+			},
+			ExpectedStatuses: func(periods int) []string {
+				statuses := []string{repository.FundingRunStatusSucceeded}
+				for i := 1; i < periods; i++ {
+					statuses = append(statuses, repository.FundingRunStatusSkipped)
+				}
+				return statuses
+			},
+		},
+	}
+
+	for v, tc := range cases {
+		t.Run(v, func(t *testing.T) {
+			target, err := walletSvc.CreateWallet(ctx, &CreateWalletRequest{UserID: memberID})
+			assert.NoError(t, err)
+
+			fundingSchedule, err := fundingSvc.CreateFundingSchedule(ctx, &CreateFundingScheduleRequest{
+				SourceWalletID: uuid.MustParse(teamWalletID),
+				TargetWalletID: target.ID,
+				Mode:           tc.Mode,
+				Amount:         tc.Amount,
+				DayOfMonth:     1,
+			})
+			assert.NoError(t, err)
+
+			// NOTE: Pretend the scheduler was down for the last three months
+			now := time.Now()
+			first := time.Date(now.Year(), now.Month()-3, 1, 0, 0, 0, 0, time.UTC)
+			periods, _ := duePeriods(schedule.Monthly{Day: 1}, first, now, FUNDING_CATCH_UP_MAXIMUM)
+
+			for i := 0; i < 2; i++ {
+				_, err = scheduler.Funding.UpdateFundingSchedule(ctx, fundingSchedule.ID, map[string]interface{}{"next_run_at": first})
+				assert.NoError(t, err)
+
+				// NOTE: The second run covers the same periods and must not fund them again
+				assert.NoError(t, scheduler.RunDue(ctx, now))
+			}
+
+			runs, err := fundingSvc.GetFundingRuns(ctx, fundingSchedule.ID, 0, 100)
+			assert.NoError(t, err)
+
+			statuses := []string{}
+			for _, run := range runs {
+				statuses = append(statuses, run.Status)
+			}
+			assert.Equal(t, tc.ExpectedStatuses(len(periods)), statuses)
+
+			wallet, err := walletSvc.GetWallet(ctx, target.ID)
+			assert.NoError(t, err)
+			assert.Zero(t, tc.ExpectedBalance(len(periods)).Cmp(wallet.Balance), "balance %s", wallet.Balance)
+
+			fundingSchedule, err = fundingSvc.GetFundingSchedule(ctx, fundingSchedule.ID)
+			assert.NoError(t, err)
+			assert.True(t, fundingSchedule.NextRunAt.After(now))
+		})
+	}
+}
+
+func TestCreateFundingScheduleIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	reinitializeDB()
+
+	memberID := uuid.MustParse("8e159833-5078-4b0a-80a0-363d82bafd60")
+	ctx := ctxUtil.SetUserID(context.Background(), memberID)
+	walletSvc := NewWalletService(Log, DB)
+	svc := NewFundingService(Log, DB)
+
+	memberWallet, err := walletSvc.CreateWallet(ctx, &CreateWalletRequest{UserID: memberID})
+	assert.NoError(t, err)
+
+	cases := map[string]struct {
+		UserID        uuid.UUID
+		Payload       CreateFundingScheduleRequest
+		ExpectedError bool
+	}{
+		"SuccessMemberWallet": {
+			UserID: memberID,
+			Payload: CreateFundingScheduleRequest{
+				SourceWalletID: uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e"),
+				TargetWalletID: memberWallet.ID,
+				Amount:         money.MustParseDecimal("100.00"),
+				Cron:           "0 9 1 * *",
+			},
+		},
+		"FailedNonMemberWallet": {
+			UserID: memberID,
+			Payload: CreateFundingScheduleRequest{
+				SourceWalletID: uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e"),
+				TargetWalletID: uuid.MustParse("370a9739-b90b-4264-81a2-f8d0d3236011"),
+				Amount:         money.MustParseDecimal("100.00"),
+				DayOfMonth:     1,
+			},
+			ExpectedError: true,
+		},
+		"FailedPersonalSource": {
+			UserID: memberID,
+			Payload: CreateFundingScheduleRequest{
+				SourceWalletID: uuid.MustParse("370a9739-b90b-4264-81a2-f8d0d3236011"),
+				TargetWalletID: memberWallet.ID,
+				Amount:         money.MustParseDecimal("100.00"),
+				DayOfMonth:     1,
+			},
+			ExpectedError: true,
+		},
+		"FailedNotTeamMember": {
+			UserID: uuid.MustParse("0e49e11c-660c-43c5-954e-ef9e89b45833"),
+			Payload: CreateFundingScheduleRequest{
+				SourceWalletID: uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e"),
+				TargetWalletID: memberWallet.ID,
+				Amount:         money.MustParseDecimal("100.00"),
+				DayOfMonth:     1,
+			},
+			ExpectedError: true,
+		},
+		"FailedNotTeamAdmin": {
+			UserID: uuid.MustParse("51241303-ebe0-4c2b-98be-44d93439f6d9"),
+			Payload: CreateFundingScheduleRequest{
+				SourceWalletID: uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e"),
+				TargetWalletID: memberWallet.ID,
+				Amount:         money.MustParseDecimal("100.00"),
+				DayOfMonth:     1,
+			},
+			ExpectedError: true,
+		},
+		"FailedMissingSchedule": {
+			UserID: memberID,
+			Payload: CreateFundingScheduleRequest{
+				SourceWalletID: uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e"),
+				TargetWalletID: memberWallet.ID,
+				Amount:         money.MustParseDecimal("100.00"),
+			},
+			ExpectedError: true,
+		},
+		"FailedInvalidAmount": {
+			UserID: memberID,
+			Payload: CreateFundingScheduleRequest{
+				SourceWalletID: uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e"),
+				TargetWalletID: memberWallet.ID,
+				Amount:         money.MustParseDecimal("-1.00"),
+				DayOfMonth:     1,
+			},
+			ExpectedError: true,
+		},
+	}
+
+	for v, tc := range cases {
+		t.Run(v, func(t *testing.T) {
+			ctx := ctxUtil.SetUserID(context.Background(), tc.UserID)
+			fundingSchedule, err := svc.CreateFundingSchedule(ctx, &tc.Payload)
+
+			if tc.ExpectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, repository.FundingModeTopUp, fundingSchedule.Mode)
+				assert.True(t, fundingSchedule.NextRunAt.After(time.Now()))
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+type (
+	// BusinessMetrics are recorded by the instrumented services.
+	// WalletsCreated is labelled by currency, BalanceVolume by type,
+	// direction and currency.
+	BusinessMetrics struct {
+		WalletsCreated metrics.Counter
+		CardsIssued    metrics.Counter
+		BalanceVolume  metrics.Counter
+	}
+
+	instrumentedWalletService struct {
+		WalletService
+		metrics BusinessMetrics
+	}
+
+	instrumentedCardService struct {
+		CardService
+		metrics BusinessMetrics
+	}
+
+	instrumentedTransactionService struct {
+		TransactionService
+		metrics BusinessMetrics
+	}
+)
+
+// NewInstrumentedWalletService counts the wallets created by next and the
+// balance moved by its adjustments
+func NewInstrumentedWalletService(next WalletService, m BusinessMetrics) WalletService {
+	return &instrumentedWalletService{WalletService: next, metrics: m}
+}
+
+func (s *instrumentedWalletService) CreateWallet(ctx context.Context, payload *CreateWalletRequest) (*repository.Wallet, error) {
+	wallet, err := s.WalletService.CreateWallet(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	s.metrics.WalletsCreated.With("currency", wallet.Currency).Add(1)
+	return wallet, nil
+}
+
+func (s *instrumentedWalletService) UpdateWallet(ctx context.Context, walletID uuid.UUID, payload *UpdateWalletRequest) (*repository.Wallet, error) {
+	wallet, err := s.WalletService.UpdateWallet(ctx, walletID, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	addVolume(s.metrics.BalanceVolume, repository.TransactionTypeAdjustment, repository.TransactionDirectionCredit, wallet.Currency, payload.BalanceIncrease)
+	addVolume(s.metrics.BalanceVolume, repository.TransactionTypeAdjustment, repository.TransactionDirectionDebit, wallet.Currency, payload.BalanceDecrease)
+	return wallet, nil
+}
+
+// NewInstrumentedCardService counts the cards issued by next
+func NewInstrumentedCardService(next CardService, m BusinessMetrics) CardService {
+	return &instrumentedCardService{CardService: next, metrics: m}
+}
+
+func (s *instrumentedCardService) CreateCard(ctx context.Context, payload *CreateCardRequest) (*repository.Card, error) {
+	card, err := s.CardService.CreateCard(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	s.metrics.CardsIssued.Add(1)
+	return card, nil
+}
+
+// NewInstrumentedTransactionService adds the amount of every transaction
+// posted by next to the balance volume, both legs of a transfer are counted
+func NewInstrumentedTransactionService(next TransactionService, m BusinessMetrics) TransactionService {
+	return &instrumentedTransactionService{TransactionService: next, metrics: m}
+}
+
+func (s *instrumentedTransactionService) CreateTransfer(ctx context.Context, payload *CreateTransferRequest) ([]repository.Transaction, error) {
+	transactions, err := s.TransactionService.CreateTransfer(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, transaction := range transactions {
+		addVolume(s.metrics.BalanceVolume, transaction.Type, transaction.Direction, transaction.Currency, transaction.Amount)
+	}
+	return transactions, nil
+}
+
+func (s *instrumentedTransactionService) CreateCardTransaction(ctx context.Context, payload *CreateCardTransactionRequest) (*repository.Transaction, error) {
+	transaction, err := s.TransactionService.CreateCardTransaction(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	addVolume(s.metrics.BalanceVolume, transaction.Type, transaction.Direction, transaction.Currency, transaction.Amount)
+	return transaction, nil
+}
+
+func addVolume(volume metrics.Counter, transactionType string, direction string, currency string, amount money.Decimal) {
+	if amount.Sign() <= 0 {
+		return
+	}
+
+	value, _ := amount.Rat().Float64()
+	volume.With("type", transactionType, "direction", direction, "currency", currency).Add(value)
+}
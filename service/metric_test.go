@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/google/uuid"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+type (
+	fakeWalletService struct {
+		WalletService
+		err error
+	}
+
+	fakeTransactionService struct {
+		TransactionService
+	}
+)
+
+func (s *fakeWalletService) CreateWallet(ctx context.Context, payload *CreateWalletRequest) (*repository.Wallet, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return &repository.Wallet{Currency: payload.Currency}, nil
+}
+
+func (s *fakeWalletService) UpdateWallet(ctx context.Context, walletID uuid.UUID, payload *UpdateWalletRequest) (*repository.Wallet, error) {
+	return &repository.Wallet{Currency: "SGD"}, nil
+}
+
+func (s *fakeTransactionService) CreateTransfer(ctx context.Context, payload *CreateTransferRequest) ([]repository.Transaction, error) {
+	return []repository.Transaction{
+		{Type: repository.TransactionTypeTransfer, Direction: repository.TransactionDirectionDebit, Amount: payload.Amount, Currency: payload.Currency},
+		{Type: repository.TransactionTypeTransfer, Direction: repository.TransactionDirectionCredit, Amount: payload.Amount, Currency: payload.Currency},
+	}, nil
+}
+
+func TestInstrumentedServices(t *testing.T) {
+	ctx := context.Background()
+
+	walletsCreated := stdprometheus.NewCounterVec(stdprometheus.CounterOpts{Name: "wallets_created_total"}, []string{"currency"})
+	balanceVolume := stdprometheus.NewCounterVec(stdprometheus.CounterOpts{Name: "balance_volume_total"}, []string{"type", "direction", "currency"})
+	m := BusinessMetrics{
+		WalletsCreated: kitprometheus.NewCounter(walletsCreated),
+		BalanceVolume:  kitprometheus.NewCounter(balanceVolume),
+	}
+
+	t.Run("SuccessWalletsCreated", func(t *testing.T) {
+		wallets := NewInstrumentedWalletService(&fakeWalletService{}, m)
+		_, _ = wallets.CreateWallet(ctx, &CreateWalletRequest{Currency: "SGD"})
+		_, _ = wallets.CreateWallet(ctx, &CreateWalletRequest{Currency: "SGD"})
+
+		failing := NewInstrumentedWalletService(&fakeWalletService{err: fmt.Errorf("connection refused")}, m)
+		_, _ = failing.CreateWallet(ctx, &CreateWalletRequest{Currency: "SGD"})
+
+		assert.Equal(t, float64(2), testutil.ToFloat64(walletsCreated.WithLabelValues("SGD")))
+	})
+
+	t.Run("SuccessBalanceVolume", func(t *testing.T) {
+		wallets := NewInstrumentedWalletService(&fakeWalletService{}, m)
+		_, _ = wallets.UpdateWallet(ctx, uuid.New(), &UpdateWalletRequest{BalanceIncrease: money.MustParseDecimal("12.50")})
+
+		transactions := NewInstrumentedTransactionService(&fakeTransactionService{}, m)
+		_, _ = transactions.CreateTransfer(ctx, &CreateTransferRequest{Amount: money.MustParseDecimal("5.25"), Currency: "SGD"})
+
+		assert.Equal(t, 12.5, testutil.ToFloat64(balanceVolume.WithLabelValues(repository.TransactionTypeAdjustment, repository.TransactionDirectionCredit, "SGD")))
+		assert.Equal(t, 5.25, testutil.ToFloat64(balanceVolume.WithLabelValues(repository.TransactionTypeTransfer, repository.TransactionDirectionDebit, "SGD")))
+		assert.Equal(t, 5.25, testutil.ToFloat64(balanceVolume.WithLabelValues(repository.TransactionTypeTransfer, repository.TransactionDirectionCredit, "SGD")))
+
+		// NOTE: The decrease was not set, so no debit adjustment is counted
+		assert.Equal(t, float64(0), testutil.ToFloat64(balanceVolume.WithLabelValues(repository.TransactionTypeAdjustment, repository.TransactionDirectionDebit, "SGD")))
+	})
+}
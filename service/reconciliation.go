@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-pg/pg/v10"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/repository/postgre"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+)
+
+type (
+	// Reconciler compares the balance of every wallet with the sum of its
+	// transactions. A drift is reported, and corrected when asked, by an
+	// adjustment recorded in the ledger, the balance is never changed.
+	Reconciler struct {
+		Log            logger.Logger
+		Reconciliation postgre.ReconciliationRepository
+		Drifting       metrics.Gauge
+	}
+
+	ReconciliationReport struct {
+		Checked     int                      `json:"checked"`
+		Drifts      []repository.WalletDrift `json:"drifts"`
+		Corrections []repository.Transaction `json:"corrections"`
+		StartedAt   time.Time                `json:"startedAt"`
+		FinishedAt  time.Time                `json:"finishedAt"`
+	}
+)
+
+// RECONCILIATION_WALLETS_BATCH is the number of wallets compared at once
+const RECONCILIATION_WALLETS_BATCH = 500
+
+// NewReconciler creates reconciler, drifting is set to the number of wallets
+// drifting after every run
+func NewReconciler(log logger.Logger, db *pg.DB, drifting metrics.Gauge) *Reconciler {
+	reconciliationRepo := postgre.CreateReconciliationRepository(log, db)
+
+	return &Reconciler{
+		Log:            log,
+		Reconciliation: reconciliationRepo,
+		Drifting:       drifting,
+	}
+}
+
+// Start reconciles the wallets every interval until ctx is done
+func (r *Reconciler) Start(ctx context.Context, interval time.Duration, correct bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.Run(ctx, correct); err != nil {
+			r.Log.Errorw("unable to reconcile wallets", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run compares every active wallet with its ledger. With correct, a drift is
+// explained by an adjustment transaction and the wallet is no longer
+// drifting on the next run. A correction failing is logged, the others still
+// run.
+func (r *Reconciler) Run(ctx context.Context, correct bool) (*ReconciliationReport, error) {
+	report := ReconciliationReport{
+		Drifts:      []repository.WalletDrift{},
+		Corrections: []repository.Transaction{},
+		StartedAt:   time.Now().UTC(),
+	}
+
+	afterWalletID := uuid.Nil
+	for {
+		drifts, err := r.Reconciliation.GetWalletDrifts(ctx, afterWalletID, RECONCILIATION_WALLETS_BATCH)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, drift := range drifts {
+			report.Checked++
+			if drift.Drift.Sign() == 0 {
+				continue
+			}
+
+			r.Log.Warnw("wallet balance drifting from ledger", "walletId", drift.WalletID, "currency", drift.Currency,
+				"balance", drift.Balance, "ledgerBalance", drift.LedgerBalance, "drift", drift.Drift)
+			report.Drifts = append(report.Drifts, drift)
+
+			if !correct {
+				continue
+			}
+
+			correction, err := r.Reconciliation.CorrectWalletDrift(ctx, drift.WalletID)
+			if err != nil {
+				r.Log.Errorw("unable to correct wallet drift", "walletId", drift.WalletID, "error", err)
+				continue
+			}
+
+			if correction != nil {
+				report.Corrections = append(report.Corrections, *correction)
+			}
+		}
+
+		if len(drifts) < RECONCILIATION_WALLETS_BATCH {
+			break
+		}
+
+		afterWalletID = drifts[len(drifts)-1].WalletID
+	}
+
+	report.FinishedAt = time.Now().UTC()
+	r.Drifting.Set(float64(len(report.Drifts) - len(report.Corrections)))
+
+	return &report, nil
+}
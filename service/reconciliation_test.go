@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kit/kit/metrics/generic"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	ctxUtil "gitlab.com/renodesper/spenmo-test/util/ctx"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+func TestReconcilerIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	reinitializeDB()
+
+	teamID := "933efe12-2219-42df-bd51-a2e84888432d"
+	teamWalletID := uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e")
+	ctx := ctxUtil.SetUserID(context.Background(), uuid.MustParse("8e159833-5078-4b0a-80a0-363d82bafd60"))
+
+	topUpTeamWallet(t, ctx, teamWalletID.String(), teamID, money.MustParseDecimal("100.00"))
+
+	drifting := generic.NewGauge("drifting_wallets")
+	reconciler := NewReconciler(Log, DB, drifting)
+
+	t.Run("SuccessInSync", func(t *testing.T) {
+		report, err := reconciler.Run(ctx, false)
+		assert.NoError(t, err)
+		assert.NotZero(t, report.Checked)
+		assert.Empty(t, report.Drifts)
+		assert.Equal(t, float64(0), drifting.Value())
+	})
+
+	// NOTE: Changing the balance without a transaction is the drift looked for
+	_, err := DB.Exec(`UPDATE wallet SET balance = balance - 7.50 WHERE id = ?`, teamWalletID)
+	assert.NoError(t, err)
+
+	t.Run("SuccessReport", func(t *testing.T) {
+		report, err := reconciler.Run(ctx, false)
+		assert.NoError(t, err)
+		assert.Empty(t, report.Corrections)
+		assert.Equal(t, float64(1), drifting.Value())
+
+		if assert.Len(t, report.Drifts, 1) {
+			drift := report.Drifts[0]
+			assert.Equal(t, teamWalletID, drift.WalletID)
+			assert.Zero(t, drift.Drift.Cmp(money.MustParseDecimal("-7.50")))
+			assert.Zero(t, drift.LedgerBalance.Cmp(money.MustParseDecimal("100.00")))
+			assert.Equal(t, 1, drift.TransactionCount)
+		}
+	})
+
+	t.Run("SuccessCorrect", func(t *testing.T) {
+		report, err := reconciler.Run(ctx, true)
+		assert.NoError(t, err)
+		assert.Len(t, report.Drifts, 1)
+		assert.Equal(t, float64(0), drifting.Value())
+
+		if assert.Len(t, report.Corrections, 1) {
+			correction := report.Corrections[0]
+			assert.Equal(t, teamWalletID, correction.WalletID)
+			assert.Equal(t, repository.TransactionTypeAdjustment, correction.Type)
+			assert.Equal(t, repository.TransactionDirectionDebit, correction.Direction)
+			assert.Zero(t, correction.Amount.Cmp(money.MustParseDecimal("7.50")))
+		}
+
+		report, err = reconciler.Run(ctx, true)
+		assert.NoError(t, err)
+		assert.Empty(t, report.Drifts)
+		assert.Empty(t, report.Corrections)
+	})
+}
+
+func TestReconcilerOpeningBalanceIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	reinitializeDB()
+
+	teamWalletID := uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e")
+	ctx := context.Background()
+
+	// NOTE: A balance set in minor units before the ledger existed gets its
+	// opening adjustment, then both are converted to major units
+	assert.NoError(t, M.Steps(-2))
+	_, err := DB.Exec(`UPDATE wallet SET balance = 25000 WHERE id = ?`, teamWalletID)
+	assert.NoError(t, err)
+	assert.NoError(t, M.Steps(2))
+
+	report, err := NewReconciler(Log, DB, generic.NewGauge("drifting_wallets")).Run(ctx, false)
+	assert.NoError(t, err)
+	assert.Empty(t, report.Drifts)
+
+	wallet, err := NewWalletService(Log, DB).GetWallet(ctx, teamWalletID)
+	assert.NoError(t, err)
+	assert.Zero(t, wallet.Balance.Cmp(money.MustParseDecimal("250.00")))
+}
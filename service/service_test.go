@@ -16,9 +16,12 @@ import (
 	"gitlab.com/renodesper/spenmo-test/util/logger"
 	"gitlab.com/renodesper/spenmo-test/util/logger/noop"
 	"gitlab.com/renodesper/spenmo-test/util/logger/zap"
+	"gitlab.com/renodesper/spenmo-test/util/notifier"
+	notifierNoop "gitlab.com/renodesper/spenmo-test/util/notifier/noop"
 )
 
 var Log *logger.Loggers
+var Notifier *notifier.Notifiers
 var DB *pg.DB
 var M *migrate.Migrate
 
@@ -51,6 +54,8 @@ func initService() {
 		Log = logger.New(noop.CreateLogger())
 	}
 
+	Notifier = notifier.New(notifierNoop.CreateNotifier())
+
 	if !testing.Short() {
 		dbUsername := viper.GetString("db.username")
 		dbPassword := viper.GetString("db.password")
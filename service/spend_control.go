@@ -0,0 +1,383 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/repository/postgre"
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/tracing"
+)
+
+type (
+	// SpendControlService ...
+	SpendControlService interface {
+		GetCardControls(ctx context.Context, cardID uuid.UUID) (*repository.SpendControls, error)
+		SetCardControls(ctx context.Context, cardID uuid.UUID, payload *SetSpendControlsRequest) (*repository.SpendControls, error)
+		DeleteCardControls(ctx context.Context, cardID uuid.UUID) (*repository.SpendControls, error)
+		GetWalletControls(ctx context.Context, walletID uuid.UUID) (*repository.SpendControls, error)
+		SetWalletControls(ctx context.Context, walletID uuid.UUID, payload *SetSpendControlsRequest) (*repository.SpendControls, error)
+		DeleteWalletControls(ctx context.Context, walletID uuid.UUID) (*repository.SpendControls, error)
+	}
+
+	SpendControlSvc struct {
+		Log    logger.Logger
+		Card   postgre.CardRepository
+		Wallet postgre.WalletRepository
+	}
+
+	// SetSpendControlsRequest replaces every list of the controls, a list left
+	// empty is removed
+	SetSpendControlsRequest struct {
+		AllowedMCCs      []string
+		BlockedMCCs      []string
+		AllowedMerchants []string
+		BlockedMerchants []string
+		AllowedCountries []string
+		BlockedCountries []string
+	}
+)
+
+// NewSpendControlService creates spend control service
+func NewSpendControlService(log logger.Logger, db *pg.DB) SpendControlService {
+	cardRepo := postgre.CreateCardRepository(log, db)
+	walletRepo := postgre.CreateWalletRepository(log, db)
+
+	return &SpendControlSvc{
+		Log:    log,
+		Card:   cardRepo,
+		Wallet: walletRepo,
+	}
+}
+
+func (s *SpendControlSvc) GetCardControls(ctx context.Context, cardID uuid.UUID) (*repository.SpendControls, error) {
+	ctx, span := tracing.Start(ctx, "SpendControlService.GetCardControls")
+	defer span.End()
+
+	card, err := s.getActiveCard(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	return spendControlsOrEmpty(card.Controls), nil
+}
+
+func (s *SpendControlSvc) SetCardControls(ctx context.Context, cardID uuid.UUID, payload *SetSpendControlsRequest) (*repository.SpendControls, error) {
+	ctx, span := tracing.Start(ctx, "SpendControlService.SetCardControls")
+	defer span.End()
+
+	controls, err := newSpendControls(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getActiveCard(ctx, cardID); err != nil {
+		return nil, err
+	}
+
+	card, err := s.Card.UpdateCard(ctx, cardID, map[string]interface{}{"controls": controls})
+	if err != nil {
+		return nil, err
+	}
+
+	return spendControlsOrEmpty(card.Controls), nil
+}
+
+func (s *SpendControlSvc) DeleteCardControls(ctx context.Context, cardID uuid.UUID) (*repository.SpendControls, error) {
+	ctx, span := tracing.Start(ctx, "SpendControlService.DeleteCardControls")
+	defer span.End()
+
+	return s.SetCardControls(ctx, cardID, &SetSpendControlsRequest{})
+}
+
+func (s *SpendControlSvc) GetWalletControls(ctx context.Context, walletID uuid.UUID) (*repository.SpendControls, error) {
+	ctx, span := tracing.Start(ctx, "SpendControlService.GetWalletControls")
+	defer span.End()
+
+	wallet, err := s.getActiveWallet(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	return spendControlsOrEmpty(wallet.Controls), nil
+}
+
+func (s *SpendControlSvc) SetWalletControls(ctx context.Context, walletID uuid.UUID, payload *SetSpendControlsRequest) (*repository.SpendControls, error) {
+	ctx, span := tracing.Start(ctx, "SpendControlService.SetWalletControls")
+	defer span.End()
+
+	controls, err := newSpendControls(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getActiveWallet(ctx, walletID); err != nil {
+		return nil, err
+	}
+
+	wallet, err := s.Wallet.UpdateWallet(ctx, walletID, map[string]interface{}{"controls": controls})
+	if err != nil {
+		return nil, err
+	}
+
+	return spendControlsOrEmpty(wallet.Controls), nil
+}
+
+func (s *SpendControlSvc) DeleteWalletControls(ctx context.Context, walletID uuid.UUID) (*repository.SpendControls, error) {
+	ctx, span := tracing.Start(ctx, "SpendControlService.DeleteWalletControls")
+	defer span.End()
+
+	return s.SetWalletControls(ctx, walletID, &SetSpendControlsRequest{})
+}
+
+func (s *SpendControlSvc) getActiveCard(ctx context.Context, cardID uuid.UUID) (*repository.Card, error) {
+	card, err := s.Card.GetCardByID(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if card.IsDeleted {
+		return nil, errors.FailedCardNotFound
+	}
+
+	return card, nil
+}
+
+func (s *SpendControlSvc) getActiveWallet(ctx context.Context, walletID uuid.UUID) (*repository.Wallet, error) {
+	wallet, err := s.Wallet.GetWalletByID(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if wallet.IsDeleted {
+		return nil, errors.FailedWalletNotFound
+	}
+
+	return wallet, nil
+}
+
+// checkSpendControls declines a card transaction the controls of its wallet
+// or card do not let through, the wallet controls apply to every card
+func checkSpendControls(wallet *repository.Wallet, card *repository.Card, transaction *repository.Transaction) error {
+	scopes := []struct {
+		Scope    string
+		Controls *repository.SpendControls
+	}{
+		{Scope: repository.SpendControlScopeWallet, Controls: wallet.Controls},
+		{Scope: repository.SpendControlScopeCard, Controls: card.Controls},
+	}
+
+	for _, scope := range scopes {
+		if scope.Controls == nil {
+			continue
+		}
+
+		reason := spendControlReason(scope.Controls, transaction)
+		if reason != "" {
+			return newSpendControlDeclined(scope.Scope, reason, transaction)
+		}
+	}
+
+	return nil
+}
+
+// spendControlReason returns why controls decline transaction, or an empty
+// string. A transaction missing a value is declined by an allowed list.
+func spendControlReason(controls *repository.SpendControls, transaction *repository.Transaction) string {
+	switch {
+	case transaction.MCC != "" && containsMCC(controls.BlockedMCCs, transaction.MCC):
+		return repository.SpendControlReasonMCCBlocked
+	case len(controls.AllowedMCCs) > 0 && !containsMCC(controls.AllowedMCCs, transaction.MCC):
+		return repository.SpendControlReasonMCCNotAllowed
+	case transaction.MerchantID != "" && containsString(controls.BlockedMerchants, transaction.MerchantID):
+		return repository.SpendControlReasonMerchantBlocked
+	case len(controls.AllowedMerchants) > 0 && !containsString(controls.AllowedMerchants, transaction.MerchantID):
+		return repository.SpendControlReasonMerchantNotAllowed
+	case transaction.Country != "" && containsString(controls.BlockedCountries, strings.ToUpper(transaction.Country)):
+		return repository.SpendControlReasonCountryBlocked
+	case len(controls.AllowedCountries) > 0 && !containsString(controls.AllowedCountries, strings.ToUpper(transaction.Country)):
+		return repository.SpendControlReasonCountryNotAllowed
+	default:
+		return ""
+	}
+}
+
+func newSpendControlDeclined(scope string, reason string, transaction *repository.Transaction) e.Error {
+	err := errors.SpendControlDeclined.AppendError(fmt.Errorf("%s on %s", reason, scope))
+	err.Meta = e.Meta{
+		"reason":   reason,
+		"scope":    scope,
+		"walletId": transaction.WalletID,
+		"cardId":   transaction.CardID,
+	}
+	if transaction.MCC != "" {
+		err.Meta["mcc"] = transaction.MCC
+	}
+	if transaction.MerchantID != "" {
+		err.Meta["merchantId"] = transaction.MerchantID
+	}
+	if transaction.Country != "" {
+		err.Meta["country"] = strings.ToUpper(transaction.Country)
+	}
+
+	return err
+}
+
+// newSpendControls validates and normalizes the lists, returning nil when
+// every list is empty
+func newSpendControls(payload *SetSpendControlsRequest) (*repository.SpendControls, error) {
+	var err error
+	controls := repository.SpendControls{}
+
+	if controls.AllowedMCCs, err = newSpendControlList(payload.AllowedMCCs, normalizeMCC); err != nil {
+		return nil, err
+	}
+	if controls.BlockedMCCs, err = newSpendControlList(payload.BlockedMCCs, normalizeMCC); err != nil {
+		return nil, err
+	}
+	if controls.AllowedMerchants, err = newSpendControlList(payload.AllowedMerchants, normalizeMerchantID); err != nil {
+		return nil, err
+	}
+	if controls.BlockedMerchants, err = newSpendControlList(payload.BlockedMerchants, normalizeMerchantID); err != nil {
+		return nil, err
+	}
+	if controls.AllowedCountries, err = newSpendControlList(payload.AllowedCountries, normalizeCountry); err != nil {
+		return nil, err
+	}
+	if controls.BlockedCountries, err = newSpendControlList(payload.BlockedCountries, normalizeCountry); err != nil {
+		return nil, err
+	}
+
+	if len(controls.AllowedMCCs)+len(controls.BlockedMCCs)+len(controls.AllowedMerchants)+
+		len(controls.BlockedMerchants)+len(controls.AllowedCountries)+len(controls.BlockedCountries) == 0 {
+		return nil, nil
+	}
+
+	return &controls, nil
+}
+
+// newSpendControlList normalizes every value, dropping duplicates and sorting
+// the result so stored controls are stable
+func newSpendControlList(values []string, normalize func(string) (string, error)) ([]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	seen := map[string]bool{}
+	list := make([]string, 0, len(values))
+	for _, value := range values {
+		normalized, err := normalize(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if !seen[normalized] {
+			seen[normalized] = true
+			list = append(list, normalized)
+		}
+	}
+
+	sort.Strings(list)
+	return list, nil
+}
+
+// normalizeMCC accepts a single MCC such as "7311" or a range such as "7800-7802"
+func normalizeMCC(value string) (string, error) {
+	value = strings.TrimSpace(value)
+
+	parts := strings.Split(value, "-")
+	if len(parts) > 2 {
+		return "", errors.InvalidSpendControlMCC
+	}
+
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+		if !isMCC(parts[i]) {
+			return "", errors.InvalidSpendControlMCC
+		}
+	}
+
+	if len(parts) == 2 && parts[0] > parts[1] {
+		return "", errors.InvalidSpendControlMCC
+	}
+
+	return strings.Join(parts, "-"), nil
+}
+
+func normalizeMerchantID(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", errors.InvalidSpendControlMerchant
+	}
+
+	return value, nil
+}
+
+func normalizeCountry(value string) (string, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if len(value) != 2 || value[0] < 'A' || value[0] > 'Z' || value[1] < 'A' || value[1] > 'Z' {
+		return "", errors.InvalidSpendControlCountry
+	}
+
+	return value, nil
+}
+
+func isMCC(value string) bool {
+	if len(value) != 4 {
+		return false
+	}
+
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// containsMCC matches mcc against single codes and inclusive ranges, MCCs are
+// fixed width so they compare as strings
+func containsMCC(list []string, mcc string) bool {
+	if mcc == "" {
+		return false
+	}
+
+	for _, entry := range list {
+		from, to := entry, entry
+		if i := strings.IndexByte(entry, '-'); i >= 0 {
+			from, to = entry[:i], entry[i+1:]
+		}
+
+		if mcc >= from && mcc <= to {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsString(list []string, value string) bool {
+	for _, entry := range list {
+		if entry == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func spendControlsOrEmpty(controls *repository.SpendControls) *repository.SpendControls {
+	if controls == nil {
+		return &repository.SpendControls{}
+	}
+
+	return controls
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+func TestNewSpendControls(t *testing.T) {
+	cases := map[string]struct {
+		Payload          SetSpendControlsRequest
+		ExpectedControls *repository.SpendControls
+		ExpectedError    bool
+	}{
+		"SuccessNormalize": {
+			Payload: SetSpendControlsRequest{
+				AllowedMCCs:      []string{"7311", " 7800-7802 ", "7311"},
+				BlockedCountries: []string{"kp", "IR"},
+			},
+			ExpectedControls: &repository.SpendControls{
+				AllowedMCCs:      []string{"7311", "7800-7802"},
+				BlockedCountries: []string{"IR", "KP"},
+			},
+		},
+		"SuccessEmpty": {
+			Payload:          SetSpendControlsRequest{AllowedMCCs: []string{}},
+			ExpectedControls: nil,
+		},
+		"FailedInvalidMCC": {
+			Payload:       SetSpendControlsRequest{BlockedMCCs: []string{"79"}},
+			ExpectedError: true,
+		},
+		"FailedReversedMCCRange": {
+			Payload:       SetSpendControlsRequest{BlockedMCCs: []string{"7999-7800"}},
+			ExpectedError: true,
+		},
+		"FailedInvalidCountry": {
+			Payload:       SetSpendControlsRequest{AllowedCountries: []string{"SGP"}},
+			ExpectedError: true,
+		},
+		"FailedEmptyMerchant": {
+			Payload:       SetSpendControlsRequest{BlockedMerchants: []string{" "}},
+			ExpectedError: true,
+		},
+	}
+
+	for v, tc := range cases {
+		t.Run(v, func(t *testing.T) {
+			controls, err := newSpendControls(&tc.Payload)
+
+			if tc.ExpectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.ExpectedControls, controls)
+			}
+		})
+	}
+}
+
+func TestCheckSpendControls(t *testing.T) {
+	wallet := &repository.Wallet{
+		Controls: &repository.SpendControls{
+			BlockedMCCs: []string{"7800-7802", "7995"},
+		},
+	}
+	card := &repository.Card{
+		Controls: &repository.SpendControls{
+			AllowedMCCs:      []string{"7311"},
+			BlockedMerchants: []string{"M-BLOCKED"},
+			AllowedCountries: []string{"SG"},
+		},
+	}
+
+	cases := map[string]struct {
+		Transaction    repository.Transaction
+		ExpectedScope  string
+		ExpectedReason string
+	}{
+		"SuccessAllowed": {
+			Transaction: repository.Transaction{MCC: "7311", MerchantID: "M-1", Country: "sg"},
+		},
+		"FailedBlockedOnWallet": {
+			Transaction:    repository.Transaction{MCC: "7801", Country: "SG"},
+			ExpectedScope:  repository.SpendControlScopeWallet,
+			ExpectedReason: repository.SpendControlReasonMCCBlocked,
+		},
+		"FailedNotAllowedOnCard": {
+			Transaction:    repository.Transaction{MCC: "5812", Country: "SG"},
+			ExpectedScope:  repository.SpendControlScopeCard,
+			ExpectedReason: repository.SpendControlReasonMCCNotAllowed,
+		},
+		"FailedMissingMCC": {
+			Transaction:    repository.Transaction{Country: "SG"},
+			ExpectedScope:  repository.SpendControlScopeCard,
+			ExpectedReason: repository.SpendControlReasonMCCNotAllowed,
+		},
+		"FailedBlockedMerchant": {
+			Transaction:    repository.Transaction{MCC: "7311", MerchantID: "M-BLOCKED", Country: "SG"},
+			ExpectedScope:  repository.SpendControlScopeCard,
+			ExpectedReason: repository.SpendControlReasonMerchantBlocked,
+		},
+		"FailedCountryNotAllowed": {
+			Transaction:    repository.Transaction{MCC: "7311", Country: "US"},
+			ExpectedScope:  repository.SpendControlScopeCard,
+			ExpectedReason: repository.SpendControlReasonCountryNotAllowed,
+		},
+	}
+
+	for v, tc := range cases {
+		t.Run(v, func(t *testing.T) {
+			err := checkSpendControls(wallet, card, &tc.Transaction)
+
+			if tc.ExpectedReason == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, tc.ExpectedScope, err.(e.Error).Meta["scope"])
+				assert.Equal(t, tc.ExpectedReason, err.(e.Error).Meta["reason"])
+			}
+		})
+	}
+}
+
+func TestSpendControlsIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	reinitializeDB()
+
+	ctx := context.Background()
+	svc := NewTransactionService(Log, DB, newTestConverter(t), Notifier, newTestFraudEngine(t))
+	spendControlSvc := NewSpendControlService(Log, DB)
+	cardSvc := NewCardService(Log, DB)
+
+	topUpTeamWallet(t, ctx, "d4a6607a-1af7-4571-bdff-2672be72ba0e", "933efe12-2219-42df-bd51-a2e84888432d", money.MustParseDecimal("1000.00"))
+
+	card, err := cardSvc.CreateCard(ctx, &CreateCardRequest{
+		CardNo:      "4012888888881881",
+		ExpiryMonth: "12",
+		ExpiryYear:  fmt.Sprintf("%d", time.Now().Year()+1),
+		CVV:         "123",
+		WalletID:    uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e"),
+	})
+	assert.NoError(t, err)
+
+	_, err = spendControlSvc.SetWalletControls(ctx, uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e"), &SetSpendControlsRequest{
+		BlockedMCCs: []string{"7995"},
+	})
+	assert.NoError(t, err)
+
+	controls, err := spendControlSvc.SetCardControls(ctx, card.ID, &SetSpendControlsRequest{
+		AllowedMCCs: []string{"7311", "7995"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"7311", "7995"}, controls.AllowedMCCs)
+
+	cases := map[string]struct {
+		MCC           string
+		ExpectedError bool
+	}{
+		"SuccessAdvertising": {
+			MCC:           "7311",
+			ExpectedError: false,
+		},
+		"FailedGamblingBlockedOnWallet": {
+			MCC:           "7995",
+			ExpectedError: true,
+		},
+		"FailedRestaurantNotAllowedOnCard": {
+			MCC:           "5812",
+			ExpectedError: true,
+		},
+	}
+
+	for v, tc := range cases {
+		t.Run(v, func(t *testing.T) {
+			transaction, err := svc.CreateCardTransaction(ctx, &CreateCardTransactionRequest{
+				CardID:   card.ID,
+				Amount:   money.MustParseDecimal("10.00"),
+				Merchant: "ACME",
+				MCC:      tc.MCC,
+			})
+
+			if tc.ExpectedError {
+				assert.Error(t, err)
+				assert.Nil(t, transaction)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.MCC, transaction.MCC)
+			}
+		})
+	}
+
+	controls, err = spendControlSvc.DeleteCardControls(ctx, card.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, controls.AllowedMCCs)
+}
@@ -0,0 +1,447 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/repository/postgre"
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+	"gitlab.com/renodesper/spenmo-test/util/tracing"
+)
+
+type (
+	// SpendLimitService ...
+	SpendLimitService interface {
+		GetSpendLimits(ctx context.Context, payload *GetSpendLimitsRequest) ([]repository.SpendLimit, error)
+		GetSpendLimit(ctx context.Context, spendLimitID uuid.UUID) (*repository.SpendLimit, error)
+		CreateSpendLimit(ctx context.Context, payload *CreateSpendLimitRequest) (*repository.SpendLimit, error)
+		UpdateSpendLimit(ctx context.Context, spendLimitID uuid.UUID, payload *UpdateSpendLimitRequest) (*repository.SpendLimit, error)
+		DeleteSpendLimit(ctx context.Context, spendLimitID uuid.UUID) (*repository.SpendLimit, error)
+	}
+
+	SpendLimitSvc struct {
+		Log        logger.Logger
+		SpendLimit postgre.SpendLimitRepository
+		Wallet     postgre.WalletRepository
+		Card       postgre.CardRepository
+	}
+
+	// LimitEngine checks debits against the spend limits of their wallet and
+	// card. Card authorizations and wallet transfers both go through it.
+	LimitEngine struct {
+		Log        logger.Logger
+		SpendLimit postgre.SpendLimitRepository
+		Team       postgre.TeamRepository
+	}
+
+	GetSpendLimitsRequest struct {
+		WalletID uuid.UUID
+		CardID   uuid.UUID
+		SortBy   string
+		Sort     string
+		Skip     int
+		Limit    int
+	}
+
+	// CreateSpendLimitRequest attaches a limit to a wallet, or to a card when
+	// CardID is set. Amount is expressed in the currency of the wallet.
+	CreateSpendLimitRequest struct {
+		WalletID   uuid.UUID
+		CardID     uuid.UUID
+		Period     string
+		WindowType string
+		Amount     money.Decimal
+	}
+
+	UpdateSpendLimitRequest struct {
+		WindowType string
+		Amount     money.Decimal
+	}
+)
+
+// NewSpendLimitService creates spend limit service
+func NewSpendLimitService(log logger.Logger, db *pg.DB) SpendLimitService {
+	spendLimitRepo := postgre.CreateSpendLimitRepository(log, db)
+	walletRepo := postgre.CreateWalletRepository(log, db)
+	cardRepo := postgre.CreateCardRepository(log, db)
+
+	return &SpendLimitSvc{
+		Log:        log,
+		SpendLimit: spendLimitRepo,
+		Wallet:     walletRepo,
+		Card:       cardRepo,
+	}
+}
+
+// NewLimitEngine creates limit engine
+func NewLimitEngine(log logger.Logger, db *pg.DB) *LimitEngine {
+	spendLimitRepo := postgre.CreateSpendLimitRepository(log, db)
+	teamRepo := postgre.CreateTeamRepository(log, db)
+
+	return &LimitEngine{
+		Log:        log,
+		SpendLimit: spendLimitRepo,
+		Team:       teamRepo,
+	}
+}
+
+func (s *SpendLimitSvc) GetSpendLimits(ctx context.Context, payload *GetSpendLimitsRequest) ([]repository.SpendLimit, error) {
+	ctx, span := tracing.Start(ctx, "SpendLimitService.GetSpendLimits")
+	defer span.End()
+
+	spendLimits, err := s.SpendLimit.GetSpendLimits(ctx, payload.WalletID, payload.CardID, payload.SortBy, payload.Sort, payload.Skip, payload.Limit)
+	return spendLimits, err
+}
+
+func (s *SpendLimitSvc) GetSpendLimit(ctx context.Context, spendLimitID uuid.UUID) (*repository.SpendLimit, error) {
+	ctx, span := tracing.Start(ctx, "SpendLimitService.GetSpendLimit")
+	defer span.End()
+
+	spendLimit, err := s.SpendLimit.GetSpendLimitByID(ctx, spendLimitID)
+	return spendLimit, err
+}
+
+func (s *SpendLimitSvc) CreateSpendLimit(ctx context.Context, payload *CreateSpendLimitRequest) (*repository.SpendLimit, error) {
+	ctx, span := tracing.Start(ctx, "SpendLimitService.CreateSpendLimit")
+	defer span.End()
+
+	if payload.WalletID == uuid.Nil && payload.CardID == uuid.Nil {
+		return nil, errors.MissingSpendLimitOwner
+	}
+
+	// NOTE: A card limit is stored with the wallet of the card so both are loaded together
+	walletID := payload.WalletID
+	if payload.CardID != uuid.Nil {
+		existingCard, err := s.Card.GetCardByID(ctx, payload.CardID)
+		if err != nil {
+			return nil, err
+		}
+
+		if existingCard.IsDeleted {
+			return nil, errors.FailedCardNotFound
+		}
+
+		if walletID != uuid.Nil && walletID != existingCard.WalletID {
+			return nil, errors.InvalidSpendLimitOwner
+		}
+
+		walletID = existingCard.WalletID
+	}
+
+	wallet, err := s.Wallet.GetWalletByID(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if wallet.IsDeleted {
+		return nil, errors.FailedWalletNotFound
+	}
+
+	if payload.WindowType == "" {
+		payload.WindowType = repository.SpendLimitWindowCalendar
+	}
+
+	if _, err := spendLimitWindowStart(payload.Period, payload.WindowType, time.Now(), time.UTC); err != nil {
+		return nil, err
+	}
+
+	amount, err := newSpendLimitAmount(payload.Amount, wallet.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	spendLimitPayload := repository.SpendLimit{
+		ID:         uuid.New(),
+		WalletID:   wallet.ID,
+		CardID:     payload.CardID,
+		Period:     payload.Period,
+		WindowType: payload.WindowType,
+		Amount:     amount.Amount,
+		Currency:   amount.Currency,
+	}
+
+	spendLimit, err := s.SpendLimit.CreateSpendLimit(ctx, &spendLimitPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.syncCalendarSpendLimit(ctx, nil, spendLimit); err != nil {
+		return nil, err
+	}
+
+	return spendLimit, nil
+}
+
+func (s *SpendLimitSvc) UpdateSpendLimit(ctx context.Context, spendLimitID uuid.UUID, payload *UpdateSpendLimitRequest) (*repository.SpendLimit, error) {
+	ctx, span := tracing.Start(ctx, "SpendLimitService.UpdateSpendLimit")
+	defer span.End()
+
+	var spendLimitPayload = make(map[string]interface{})
+
+	existingSpendLimit, err := s.SpendLimit.GetSpendLimitByID(ctx, spendLimitID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingSpendLimit.IsDeleted {
+		return nil, errors.FailedSpendLimitNotFound
+	}
+
+	if payload.WindowType != "" {
+		if _, err := spendLimitWindowStart(existingSpendLimit.Period, payload.WindowType, time.Now(), time.UTC); err != nil {
+			return nil, err
+		}
+
+		spendLimitPayload["window_type"] = payload.WindowType
+	}
+
+	if !payload.Amount.IsZero() {
+		amount, err := newSpendLimitAmount(payload.Amount, existingSpendLimit.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		spendLimitPayload["amount"] = amount.Amount
+	}
+
+	spendLimit, err := s.SpendLimit.UpdateSpendLimit(ctx, spendLimitID, spendLimitPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.syncCalendarSpendLimit(ctx, existingSpendLimit, spendLimit); err != nil {
+		return nil, err
+	}
+
+	return spendLimit, nil
+}
+
+func (s *SpendLimitSvc) DeleteSpendLimit(ctx context.Context, spendLimitID uuid.UUID) (*repository.SpendLimit, error) {
+	ctx, span := tracing.Start(ctx, "SpendLimitService.DeleteSpendLimit")
+	defer span.End()
+
+	spendLimit, err := s.SpendLimit.DeleteSpendLimit(ctx, spendLimitID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.syncCalendarSpendLimit(ctx, spendLimit, spendLimit); err != nil {
+		return nil, err
+	}
+
+	return spendLimit, nil
+}
+
+// syncCalendarSpendLimit is the other side of setCalendarSpendLimit, it copies
+// a change of a calendar daily or monthly spend limit back to the limit column
+// of its wallet or card. The column is cleared once the limit is deleted or no
+// longer calendar based.
+func (s *SpendLimitSvc) syncCalendarSpendLimit(ctx context.Context, before *repository.SpendLimit, after *repository.SpendLimit) error {
+	column, ok := calendarLimitColumns[after.Period]
+	if !ok || (!isCalendarSpendLimit(before) && !isCalendarSpendLimit(after)) {
+		return nil
+	}
+
+	amount := money.Decimal{}
+	if isCalendarSpendLimit(after) && !after.IsDeleted {
+		amount = after.Amount
+	}
+
+	payload := map[string]interface{}{column: amount}
+	if after.CardID != uuid.Nil {
+		_, err := s.Card.UpdateCard(ctx, after.CardID, payload)
+		return err
+	}
+
+	_, err := s.Wallet.UpdateWallet(ctx, after.WalletID, payload)
+	return err
+}
+
+// Guard loads the limits covering a debit of the wallet, and of the card when
+// set, and returns the guard checking them once the wallet is locked
+func (l *LimitEngine) Guard(ctx context.Context, wallet *repository.Wallet, cardID uuid.UUID) (postgre.TransactionGuard, error) {
+	spendLimits, err := l.SpendLimit.GetActiveSpendLimits(ctx, wallet.ID, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(spendLimits) == 0 {
+		return nil, nil
+	}
+
+	loc := walletLocation(ctx, l.Log, l.Team, wallet)
+
+	return func(ctx context.Context, tx *pg.Tx, transaction *repository.Transaction) error {
+		now := time.Now()
+
+		for i := range spendLimits {
+			spendLimit := &spendLimits[i]
+
+			spent := money.Decimal{}
+			if spendLimit.Period != repository.SpendLimitPeriodTransaction {
+				from, err := spendLimitWindowStart(spendLimit.Period, spendLimit.WindowType, now, loc)
+				if err != nil {
+					return err
+				}
+
+				spent, err = l.SpendLimit.GetSpend(ctx, tx, spendLimit, from)
+				if err != nil {
+					return err
+				}
+			}
+
+			total, err := spent.Add(transaction.Amount)
+			if err != nil {
+				return err
+			}
+
+			if total.Cmp(spendLimit.Amount) > 0 {
+				return newSpendLimitExceeded(spendLimit, spent)
+			}
+		}
+
+		return nil
+	}, nil
+}
+
+// walletLocation returns the timezone of the team owning the wallet, personal
+// wallets and unknown timezones fall back to UTC
+func walletLocation(ctx context.Context, log logger.Logger, teamRepo postgre.TeamRepository, wallet *repository.Wallet) *time.Location {
+	if wallet.TeamID == uuid.Nil {
+		return time.UTC
+	}
+
+	team, err := teamRepo.GetTeamByID(ctx, wallet.TeamID)
+	if err != nil {
+		log.Warnw("unable to fetch team timezone", "teamId", wallet.TeamID, "error", err)
+		return time.UTC
+	}
+
+	return teamLocation(team)
+}
+
+// teamLocation loads the timezone of a team, falling back to UTC
+func teamLocation(team *repository.Team) *time.Location {
+	loc, err := time.LoadLocation(team.Timezone)
+	if err != nil || team.Timezone == "" {
+		return time.UTC
+	}
+
+	return loc
+}
+
+func newSpendLimitAmount(amount money.Decimal, currency string) (money.Money, error) {
+	if amount.Sign() <= 0 {
+		return money.Money{}, errors.InvalidSpendLimitAmount
+	}
+
+	return money.New(amount, currency)
+}
+
+func newSpendLimitExceeded(spendLimit *repository.SpendLimit, spent money.Decimal) e.Error {
+	err := errors.SpendLimitExceeded.AppendError(fmt.Errorf("%s %s limit of %s %s", spendLimit.WindowType, spendLimit.Period, spendLimit.Amount, spendLimit.Currency))
+	err.Meta = e.Meta{
+		"spendLimitId": spendLimit.ID,
+		"walletId":     spendLimit.WalletID,
+		"period":       spendLimit.Period,
+		"windowType":   spendLimit.WindowType,
+		"amount":       spendLimit.Amount,
+		"spent":        spent,
+		"currency":     spendLimit.Currency,
+	}
+	if spendLimit.CardID != uuid.Nil {
+		err.Meta["cardId"] = spendLimit.CardID
+	}
+
+	return err
+}
+
+// newTimezone validates an IANA timezone name
+func newTimezone(name string) (string, error) {
+	if name == "" || name == "Local" {
+		return "", errors.InvalidTimezone
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return "", errors.InvalidTimezone
+	}
+
+	return loc.String(), nil
+}
+
+// spendLimitWindowStart returns the start of the window of the limit
+// containing at. A zero time means the window covers the whole history.
+// Per-transaction limits have no window and start at at.
+func spendLimitWindowStart(period string, windowType string, at time.Time, loc *time.Location) (time.Time, error) {
+	if windowType != repository.SpendLimitWindowCalendar && windowType != repository.SpendLimitWindowRolling {
+		return time.Time{}, errors.InvalidSpendLimitWindow
+	}
+
+	rolling := windowType == repository.SpendLimitWindowRolling
+	local := at.In(loc)
+
+	switch period {
+	case repository.SpendLimitPeriodTransaction:
+		return at, nil
+	case repository.SpendLimitPeriodLifetime:
+		return time.Time{}, nil
+	case repository.SpendLimitPeriodDaily:
+		if rolling {
+			return at.Add(-24 * time.Hour), nil
+		}
+		return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc), nil
+	case repository.SpendLimitPeriodWeekly:
+		if rolling {
+			return at.AddDate(0, 0, -7), nil
+		}
+		// NOTE: Calendar weeks start on Monday
+		offset := (int(local.Weekday()) + 6) % 7
+		return time.Date(local.Year(), local.Month(), local.Day()-offset, 0, 0, 0, 0, loc), nil
+	case repository.SpendLimitPeriodMonthly:
+		if rolling {
+			return at.AddDate(0, -1, 0), nil
+		}
+		return time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, loc), nil
+	case repository.SpendLimitPeriodYearly:
+		if rolling {
+			return at.AddDate(-1, 0, 0), nil
+		}
+		return time.Date(local.Year(), time.January, 1, 0, 0, 0, 0, loc), nil
+	default:
+		return time.Time{}, errors.InvalidSpendLimitPeriod
+	}
+}
+
+// calendarLimitColumns are the wallet and card columns mirroring the calendar
+// spend limits of a period
+var calendarLimitColumns = map[string]string{
+	repository.SpendLimitPeriodDaily:   "daily_limit",
+	repository.SpendLimitPeriodMonthly: "monthly_limit",
+}
+
+func isCalendarSpendLimit(spendLimit *repository.SpendLimit) bool {
+	return spendLimit != nil && spendLimit.WindowType == repository.SpendLimitWindowCalendar
+}
+
+// setCalendarSpendLimit keeps the daily and monthly limit columns of wallets
+// and cards in sync with the calendar spend limits enforced by LimitEngine
+func setCalendarSpendLimit(ctx context.Context, spendLimitRepo postgre.SpendLimitRepository, walletID uuid.UUID, cardID uuid.UUID, period string, amount money.Money) error {
+	_, err := spendLimitRepo.SetSpendLimit(ctx, &repository.SpendLimit{
+		ID:         uuid.New(),
+		WalletID:   walletID,
+		CardID:     cardID,
+		Period:     period,
+		WindowType: repository.SpendLimitWindowCalendar,
+		Amount:     amount.Amount,
+		Currency:   amount.Currency,
+	})
+	return err
+}
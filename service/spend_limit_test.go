@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/repository/postgre"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+func TestSpendLimitWindowStart(t *testing.T) {
+	singapore, err := time.LoadLocation("Asia/Singapore")
+	assert.NoError(t, err)
+
+	// NOTE: Tuesday 17 August 2021 in UTC, already Wednesday in Singapore
+	at := time.Date(2021, time.August, 17, 20, 30, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		Period        string
+		WindowType    string
+		Location      *time.Location
+		ExpectedStart time.Time
+		ExpectedError bool
+	}{
+		"SuccessCalendarDaily": {
+			Period:        repository.SpendLimitPeriodDaily,
+			WindowType:    repository.SpendLimitWindowCalendar,
+			Location:      time.UTC,
+			ExpectedStart: time.Date(2021, time.August, 17, 0, 0, 0, 0, time.UTC),
+		},
+		"SuccessCalendarDailyInTeamTimezone": {
+			Period:        repository.SpendLimitPeriodDaily,
+			WindowType:    repository.SpendLimitWindowCalendar,
+			Location:      singapore,
+			ExpectedStart: time.Date(2021, time.August, 18, 0, 0, 0, 0, singapore),
+		},
+		"SuccessCalendarWeeklyStartsOnMonday": {
+			Period:        repository.SpendLimitPeriodWeekly,
+			WindowType:    repository.SpendLimitWindowCalendar,
+			Location:      time.UTC,
+			ExpectedStart: time.Date(2021, time.August, 16, 0, 0, 0, 0, time.UTC),
+		},
+		"SuccessCalendarMonthly": {
+			Period:        repository.SpendLimitPeriodMonthly,
+			WindowType:    repository.SpendLimitWindowCalendar,
+			Location:      time.UTC,
+			ExpectedStart: time.Date(2021, time.August, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"SuccessCalendarYearly": {
+			Period:        repository.SpendLimitPeriodYearly,
+			WindowType:    repository.SpendLimitWindowCalendar,
+			Location:      time.UTC,
+			ExpectedStart: time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"SuccessRollingDaily": {
+			Period:        repository.SpendLimitPeriodDaily,
+			WindowType:    repository.SpendLimitWindowRolling,
+			Location:      singapore,
+			ExpectedStart: time.Date(2021, time.August, 16, 20, 30, 0, 0, time.UTC),
+		},
+		"SuccessRollingWeekly": {
+			Period:        repository.SpendLimitPeriodWeekly,
+			WindowType:    repository.SpendLimitWindowRolling,
+			Location:      time.UTC,
+			ExpectedStart: time.Date(2021, time.August, 10, 20, 30, 0, 0, time.UTC),
+		},
+		"SuccessRollingMonthly": {
+			Period:        repository.SpendLimitPeriodMonthly,
+			WindowType:    repository.SpendLimitWindowRolling,
+			Location:      time.UTC,
+			ExpectedStart: time.Date(2021, time.July, 17, 20, 30, 0, 0, time.UTC),
+		},
+		"SuccessTransaction": {
+			Period:        repository.SpendLimitPeriodTransaction,
+			WindowType:    repository.SpendLimitWindowCalendar,
+			Location:      time.UTC,
+			ExpectedStart: at,
+		},
+		"SuccessLifetime": {
+			Period:        repository.SpendLimitPeriodLifetime,
+			WindowType:    repository.SpendLimitWindowRolling,
+			Location:      time.UTC,
+			ExpectedStart: time.Time{},
+		},
+		"FailedUnknownPeriod": {
+			Period:        "quarterly",
+			WindowType:    repository.SpendLimitWindowCalendar,
+			Location:      time.UTC,
+			ExpectedError: true,
+		},
+		"FailedUnknownWindow": {
+			Period:        repository.SpendLimitPeriodDaily,
+			WindowType:    "sliding",
+			Location:      time.UTC,
+			ExpectedError: true,
+		},
+	}
+
+	for v, tc := range cases {
+		t.Run(v, func(t *testing.T) {
+			start, err := spendLimitWindowStart(tc.Period, tc.WindowType, at, tc.Location)
+
+			if tc.ExpectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, tc.ExpectedStart.Equal(start), "expected %s, got %s", tc.ExpectedStart, start)
+			}
+		})
+	}
+}
+
+func TestNewTimezone(t *testing.T) {
+	timezone, err := newTimezone("Asia/Singapore")
+	assert.NoError(t, err)
+	assert.Equal(t, "Asia/Singapore", timezone)
+
+	for _, name := range []string{"", "Local", "Mars/Olympus_Mons"} {
+		_, err := newTimezone(name)
+		assert.Error(t, err)
+	}
+}
+
+func TestCreateSpendLimitIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	reinitializeDB()
+
+	cases := map[string]struct {
+		WalletID      string
+		CardID        string
+		Period        string
+		WindowType    string
+		Amount        money.Decimal
+		ExpectedError bool
+	}{
+		"SuccessCreateRollingWalletLimit": {
+			WalletID:      "d4a6607a-1af7-4571-bdff-2672be72ba0e",
+			Period:        repository.SpendLimitPeriodWeekly,
+			WindowType:    repository.SpendLimitWindowRolling,
+			Amount:        money.MustParseDecimal("1000.00"),
+			ExpectedError: false,
+		},
+		"SuccessCreateCardTransactionLimit": {
+			CardID:        "cb21fe95-37e7-4e67-aac3-1b633fe1036d",
+			Period:        repository.SpendLimitPeriodTransaction,
+			Amount:        money.MustParseDecimal("100.00"),
+			ExpectedError: false,
+		},
+		"FailedExistingCalendarDailyLimit": {
+			WalletID:      "d4a6607a-1af7-4571-bdff-2672be72ba0e",
+			Period:        repository.SpendLimitPeriodDaily,
+			Amount:        money.MustParseDecimal("1000.00"),
+			ExpectedError: true,
+		},
+		"FailedMissingOwner": {
+			Period:        repository.SpendLimitPeriodDaily,
+			Amount:        money.MustParseDecimal("1000.00"),
+			ExpectedError: true,
+		},
+		"FailedCardOfAnotherWallet": {
+			WalletID:      "370a9739-b90b-4264-81a2-f8d0d3236011",
+			CardID:        "cb21fe95-37e7-4e67-aac3-1b633fe1036d",
+			Period:        repository.SpendLimitPeriodDaily,
+			Amount:        money.MustParseDecimal("1000.00"),
+			ExpectedError: true,
+		},
+		"FailedZeroAmount": {
+			WalletID:      "d4a6607a-1af7-4571-bdff-2672be72ba0e",
+			Period:        repository.SpendLimitPeriodYearly,
+			ExpectedError: true,
+		},
+		"FailedUnknownPeriod": {
+			WalletID:      "d4a6607a-1af7-4571-bdff-2672be72ba0e",
+			Period:        "quarterly",
+			Amount:        money.MustParseDecimal("1000.00"),
+			ExpectedError: true,
+		},
+	}
+
+	for v, tc := range cases {
+		t.Run(v, func(t *testing.T) {
+			ctx := context.Background()
+			svc := NewSpendLimitService(Log, DB)
+
+			payload := CreateSpendLimitRequest{
+				Period:     tc.Period,
+				WindowType: tc.WindowType,
+				Amount:     tc.Amount,
+			}
+			if tc.WalletID != "" {
+				payload.WalletID = uuid.MustParse(tc.WalletID)
+			}
+			if tc.CardID != "" {
+				payload.CardID = uuid.MustParse(tc.CardID)
+			}
+
+			spendLimit, err := svc.CreateSpendLimit(ctx, &payload)
+
+			if tc.ExpectedError {
+				assert.Error(t, err)
+				assert.Nil(t, spendLimit)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e"), spendLimit.WalletID)
+				assert.NotEmpty(t, spendLimit.WindowType)
+			}
+		})
+	}
+}
+
+func TestLimitEngineIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	reinitializeDB()
+
+	ctx := context.Background()
+	svc := NewTransactionService(Log, DB, newTestConverter(t), Notifier, newTestFraudEngine(t))
+	spendLimitSvc := NewSpendLimitService(Log, DB)
+	cardSvc := NewCardService(Log, DB)
+
+	topUpTeamWallet(t, ctx, "d4a6607a-1af7-4571-bdff-2672be72ba0e", "933efe12-2219-42df-bd51-a2e84888432d", money.MustParseDecimal("1000.00"))
+
+	card, err := cardSvc.CreateCard(ctx, &CreateCardRequest{
+		CardNo:      "4012888888881881",
+		ExpiryMonth: "12",
+		ExpiryYear:  fmt.Sprintf("%d", time.Now().Year()+1),
+		CVV:         "123",
+		WalletID:    uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e"),
+	})
+	assert.NoError(t, err)
+
+	_, err = spendLimitSvc.CreateSpendLimit(ctx, &CreateSpendLimitRequest{
+		CardID: card.ID,
+		Period: repository.SpendLimitPeriodTransaction,
+		Amount: money.MustParseDecimal("50.00"),
+	})
+	assert.NoError(t, err)
+
+	_, err = spendLimitSvc.CreateSpendLimit(ctx, &CreateSpendLimitRequest{
+		WalletID:   uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e"),
+		Period:     repository.SpendLimitPeriodDaily,
+		WindowType: repository.SpendLimitWindowRolling,
+		Amount:     money.MustParseDecimal("100.00"),
+	})
+	assert.NoError(t, err)
+
+	// NOTE: Each step is cumulative, declined steps do not count towards the spend
+	steps := []struct {
+		Amount        money.Decimal
+		ExpectedError bool
+	}{
+		{Amount: money.MustParseDecimal("60.00"), ExpectedError: true},
+		{Amount: money.MustParseDecimal("50.00"), ExpectedError: false},
+		{Amount: money.MustParseDecimal("40.00"), ExpectedError: false},
+		{Amount: money.MustParseDecimal("20.00"), ExpectedError: true},
+		{Amount: money.MustParseDecimal("10.00"), ExpectedError: false},
+	}
+
+	for _, step := range steps {
+		transaction, err := svc.CreateCardTransaction(ctx, &CreateCardTransactionRequest{
+			CardID:   card.ID,
+			Amount:   step.Amount,
+			Merchant: "ACME",
+		})
+
+		if step.ExpectedError {
+			assert.Error(t, err)
+			assert.Nil(t, transaction)
+		} else {
+			assert.NoError(t, err)
+			assert.NotNil(t, transaction)
+		}
+	}
+}
+
+func TestSyncCalendarSpendLimitIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	reinitializeDB()
+
+	ctx := context.Background()
+	svc := NewSpendLimitService(Log, DB)
+	walletRepo := postgre.CreateWalletRepository(Log, DB)
+	walletID := uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e")
+
+	spendLimits, err := svc.GetSpendLimits(ctx, &GetSpendLimitsRequest{WalletID: walletID, Limit: 100})
+	assert.NoError(t, err)
+
+	var dailyLimit *repository.SpendLimit
+	for i := range spendLimits {
+		if spendLimits[i].CardID == uuid.Nil && spendLimits[i].Period == repository.SpendLimitPeriodDaily && spendLimits[i].WindowType == repository.SpendLimitWindowCalendar {
+			dailyLimit = &spendLimits[i]
+		}
+	}
+	if !assert.NotNil(t, dailyLimit) {
+		return
+	}
+
+	_, err = svc.UpdateSpendLimit(ctx, dailyLimit.ID, &UpdateSpendLimitRequest{Amount: money.MustParseDecimal("2000.00")})
+	assert.NoError(t, err)
+
+	wallet, err := walletRepo.GetWalletByID(ctx, walletID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, wallet.DailyLimit.Cmp(money.MustParseDecimal("2000.00")))
+
+	_, err = svc.DeleteSpendLimit(ctx, dailyLimit.ID)
+	assert.NoError(t, err)
+
+	wallet, err = walletRepo.GetWalletByID(ctx, walletID)
+	assert.NoError(t, err)
+	assert.True(t, wallet.DailyLimit.IsZero())
+}
@@ -7,8 +7,10 @@ import (
 	"github.com/google/uuid"
 	"gitlab.com/renodesper/spenmo-test/repository"
 	"gitlab.com/renodesper/spenmo-test/repository/postgre"
+	ctxUtil "gitlab.com/renodesper/spenmo-test/util/ctx"
 	"gitlab.com/renodesper/spenmo-test/util/errors"
 	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/tracing"
 )
 
 type (
@@ -16,6 +18,7 @@ type (
 	TeamService interface {
 		GetAllTeams(ctx context.Context, sortBy string, sort string, skip int, limit int) ([]repository.Team, error)
 		GetTeam(ctx context.Context, teamID uuid.UUID) (*repository.Team, error)
+		GetTeamsByIDs(ctx context.Context, teamIDs []uuid.UUID) ([]repository.Team, error)
 		CreateTeam(ctx context.Context, payload *CreateTeamRequest) (*repository.Team, error)
 		UpdateTeam(ctx context.Context, teamID uuid.UUID, payload *UpdateTeamRequest) (*repository.Team, error)
 		DeleteTeam(ctx context.Context, teamID uuid.UUID) (*repository.Team, error)
@@ -24,34 +27,42 @@ type (
 	TeamSvc struct {
 		Log           logger.Logger
 		Team          postgre.TeamRepository
+		TeamMember    postgre.TeamMemberRepository
 		TeamMemberSvc TeamMemberService
 		WalletSvc     WalletService
 	}
 
 	CreateTeamRequest struct {
-		Name string
+		Name     string
+		Timezone string
 	}
 
 	UpdateTeamRequest struct {
-		Name string
+		Name     string
+		Timezone string
 	}
 )
 
 // NewTeamService creates team service
 func NewTeamService(log logger.Logger, db *pg.DB) TeamService {
 	teamRepo := postgre.CreateTeamRepository(log, db)
+	teamMemberRepo := postgre.CreateTeamMemberRepository(log, db)
 	teamMemberSvc := NewTeamMemberService(log, db)
 	walletSvc := NewWalletService(log, db)
 
 	return &TeamSvc{
 		Log:           log,
 		Team:          teamRepo,
+		TeamMember:    teamMemberRepo,
 		TeamMemberSvc: teamMemberSvc,
 		WalletSvc:     walletSvc,
 	}
 }
 
 func (s *TeamSvc) GetAllTeams(ctx context.Context, sortBy string, sort string, skip int, limit int) ([]repository.Team, error) {
+	ctx, span := tracing.Start(ctx, "TeamService.GetAllTeams")
+	defer span.End()
+
 	var teams []repository.Team
 
 	teams, err := s.Team.GetAllTeams(ctx, sortBy, sort, skip, limit)
@@ -67,11 +78,31 @@ func (s *TeamSvc) GetAllTeams(ctx context.Context, sortBy string, sort string, s
 }
 
 func (s *TeamSvc) GetTeam(ctx context.Context, teamID uuid.UUID) (*repository.Team, error) {
+	ctx, span := tracing.Start(ctx, "TeamService.GetTeam")
+	defer span.End()
+
 	team, err := s.Team.GetTeamByID(ctx, teamID)
 	return team, err
 }
 
+func (s *TeamSvc) GetTeamsByIDs(ctx context.Context, teamIDs []uuid.UUID) ([]repository.Team, error) {
+	ctx, span := tracing.Start(ctx, "TeamService.GetTeamsByIDs")
+	defer span.End()
+
+	teams, err := s.Team.GetTeamsByIDs(ctx, teamIDs)
+	return teams, err
+}
+
 func (s *TeamSvc) CreateTeam(ctx context.Context, payload *CreateTeamRequest) (*repository.Team, error) {
+	ctx, span := tracing.Start(ctx, "TeamService.CreateTeam")
+	defer span.End()
+
+	// NOTE: The creator becomes the first admin, only admins grant the role
+	creatorID := ctxUtil.GetUserID(ctx)
+	if creatorID == uuid.Nil {
+		return nil, errors.Unauthenticated
+	}
+
 	if payload.Name != "" {
 		team, _ := s.Team.GetTeamByName(ctx, payload.Name)
 
@@ -86,11 +117,41 @@ func (s *TeamSvc) CreateTeam(ctx context.Context, payload *CreateTeamRequest) (*
 		ID:   ID,
 		Name: payload.Name,
 	}
+
+	if payload.Timezone != "" {
+		timezone, err := newTimezone(payload.Timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		teamPayload.Timezone = timezone
+	}
 	team, err := s.Team.CreateTeam(ctx, &teamPayload)
-	return team, err
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.TeamMember.CreateTeamMember(ctx, &repository.TeamMember{
+		ID:     uuid.New(),
+		TeamID: team.ID,
+		UserID: creatorID,
+		Role:   repository.TeamMemberRoleAdmin,
+	})
+	if err != nil {
+		if _, er := s.Team.DeleteTeam(ctx, team.ID); er != nil {
+			s.Log.Warn(er)
+		}
+
+		return nil, err
+	}
+
+	return team, nil
 }
 
 func (s *TeamSvc) UpdateTeam(ctx context.Context, teamID uuid.UUID, payload *UpdateTeamRequest) (*repository.Team, error) {
+	ctx, span := tracing.Start(ctx, "TeamService.UpdateTeam")
+	defer span.End()
+
 	team, _ := s.Team.GetTeamByID(ctx, teamID)
 
 	if team == nil {
@@ -103,11 +164,23 @@ func (s *TeamSvc) UpdateTeam(ctx context.Context, teamID uuid.UUID, payload *Upd
 		teamPayload["name"] = payload.Name
 	}
 
+	if payload.Timezone != "" {
+		timezone, err := newTimezone(payload.Timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		teamPayload["timezone"] = timezone
+	}
+
 	team, err := s.Team.UpdateTeam(ctx, teamID, teamPayload)
 	return team, err
 }
 
 func (s *TeamSvc) DeleteTeam(ctx context.Context, teamID uuid.UUID) (*repository.Team, error) {
+	ctx, span := tracing.Start(ctx, "TeamService.DeleteTeam")
+	defer span.End()
+
 	team, err := s.Team.DeleteTeam(ctx, teamID)
 	if err != nil {
 		return nil, err
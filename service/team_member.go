@@ -10,13 +10,18 @@ import (
 	e "gitlab.com/renodesper/spenmo-test/util/error"
 	"gitlab.com/renodesper/spenmo-test/util/errors"
 	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/tracing"
 )
 
 type (
 	// TeamMemberService ...
 	TeamMemberService interface {
 		GetTeamMembers(ctx context.Context, payload *GetTeamMembersRequest) ([]repository.TeamMember, error)
+		GetTeamMembersByTeamIDs(ctx context.Context, teamIDs []uuid.UUID) ([]repository.TeamMember, error)
+		GetTeamMembersByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]repository.TeamMember, error)
+		GetTeamApprovers(ctx context.Context, teamID uuid.UUID) ([]repository.TeamMember, error)
 		CreateTeamMember(ctx context.Context, payload *CreateTeamMemberRequest) (*repository.TeamMember, error)
+		UpdateTeamMember(ctx context.Context, payload *UpdateTeamMemberRequest) (*repository.TeamMember, error)
 		DeleteTeamMember(ctx context.Context, teamID uuid.UUID, userID uuid.UUID) (*repository.TeamMember, error)
 		DeleteTeamMembersByTeamID(ctx context.Context, teamID uuid.UUID) ([]repository.TeamMember, error)
 		DeleteTeamMembersByUserID(ctx context.Context, userID uuid.UUID) ([]repository.TeamMember, error)
@@ -30,6 +35,13 @@ type (
 	CreateTeamMemberRequest struct {
 		TeamID uuid.UUID
 		UserID uuid.UUID
+		Role   string
+	}
+
+	UpdateTeamMemberRequest struct {
+		TeamID uuid.UUID
+		UserID uuid.UUID
+		Role   string
 	}
 
 	GetTeamMembersRequest struct {
@@ -53,11 +65,42 @@ func NewTeamMemberService(log logger.Logger, db *pg.DB) TeamMemberService {
 }
 
 func (s *TeamMemberSvc) GetTeamMembers(ctx context.Context, payload *GetTeamMembersRequest) ([]repository.TeamMember, error) {
+	ctx, span := tracing.Start(ctx, "TeamMemberService.GetTeamMembers")
+	defer span.End()
+
 	teamMembers, err := s.TeamMember.GetTeamMembers(ctx, payload.TeamID, payload.UserID, payload.SortBy, payload.Sort, payload.Skip, payload.Limit)
 	return teamMembers, err
 }
 
+func (s *TeamMemberSvc) GetTeamMembersByTeamIDs(ctx context.Context, teamIDs []uuid.UUID) ([]repository.TeamMember, error) {
+	ctx, span := tracing.Start(ctx, "TeamMemberService.GetTeamMembersByTeamIDs")
+	defer span.End()
+
+	teamMembers, err := s.TeamMember.GetTeamMembersByTeamIDs(ctx, teamIDs)
+	return teamMembers, err
+}
+
+func (s *TeamMemberSvc) GetTeamMembersByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]repository.TeamMember, error) {
+	ctx, span := tracing.Start(ctx, "TeamMemberService.GetTeamMembersByUserIDs")
+	defer span.End()
+
+	teamMembers, err := s.TeamMember.GetTeamMembersByUserIDs(ctx, userIDs)
+	return teamMembers, err
+}
+
+// GetTeamApprovers returns the members reviewing the fund requests of a team
+func (s *TeamMemberSvc) GetTeamApprovers(ctx context.Context, teamID uuid.UUID) ([]repository.TeamMember, error) {
+	ctx, span := tracing.Start(ctx, "TeamMemberService.GetTeamApprovers")
+	defer span.End()
+
+	teamMembers, err := s.TeamMember.GetTeamMembersByRole(ctx, teamID, repository.TeamMemberRoleAdmin)
+	return teamMembers, err
+}
+
 func (s *TeamMemberSvc) CreateTeamMember(ctx context.Context, teamMemberPayload *CreateTeamMemberRequest) (*repository.TeamMember, error) {
+	ctx, span := tracing.Start(ctx, "TeamMemberService.CreateTeamMember")
+	defer span.End()
+
 	if teamMemberPayload.TeamID == uuid.Nil {
 		return nil, errors.MissingTeamID
 	}
@@ -76,28 +119,129 @@ func (s *TeamMemberSvc) CreateTeamMember(ctx context.Context, teamMemberPayload
 		return nil, errors.FailedTeamMemberExist
 	}
 
+	role, err := newTeamMemberRole(teamMemberPayload.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: Only an admin of the team can add another admin
+	if role == repository.TeamMemberRoleAdmin {
+		if _, err := requireTeamAdmin(ctx, s.TeamMember, teamMemberPayload.TeamID); err != nil {
+			return nil, err
+		}
+	}
+
 	ID := uuid.New()
 
 	teamPayload := repository.TeamMember{
 		ID:     ID,
 		TeamID: teamMemberPayload.TeamID,
 		UserID: teamMemberPayload.UserID,
+		Role:   role,
 	}
 	teamMember, err := s.TeamMember.CreateTeamMember(ctx, &teamPayload)
 	return teamMember, err
 }
 
+func (s *TeamMemberSvc) UpdateTeamMember(ctx context.Context, teamMemberPayload *UpdateTeamMemberRequest) (*repository.TeamMember, error) {
+	ctx, span := tracing.Start(ctx, "TeamMemberService.UpdateTeamMember")
+	defer span.End()
+
+	if teamMemberPayload.TeamID == uuid.Nil {
+		return nil, errors.MissingTeamID
+	}
+
+	if teamMemberPayload.UserID == uuid.Nil {
+		return nil, errors.MissingUserID
+	}
+
+	// NOTE: Role is the only field of a member, keep it when it is not sent
+	if teamMemberPayload.Role == "" {
+		teamMember, err := s.TeamMember.GetTeamMember(ctx, teamMemberPayload.TeamID, teamMemberPayload.UserID)
+		if err != nil {
+			if er, ok := err.(e.Error); ok && er.Code == errors.FailedNoRows.Code {
+				return nil, errors.FailedTeamMemberNotFound
+			}
+
+			return nil, err
+		}
+
+		return teamMember, nil
+	}
+
+	role, err := newTeamMemberRole(teamMemberPayload.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := requireTeamAdmin(ctx, s.TeamMember, teamMemberPayload.TeamID); err != nil {
+		return nil, err
+	}
+
+	if role != repository.TeamMemberRoleAdmin {
+		if err := s.keepTeamAdmin(ctx, teamMemberPayload.TeamID, teamMemberPayload.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	teamMember, err := s.TeamMember.UpdateTeamMember(ctx, teamMemberPayload.TeamID, teamMemberPayload.UserID, map[string]interface{}{"role": role})
+	return teamMember, err
+}
+
 func (s *TeamMemberSvc) DeleteTeamMember(ctx context.Context, teamID uuid.UUID, userID uuid.UUID) (*repository.TeamMember, error) {
+	ctx, span := tracing.Start(ctx, "TeamMemberService.DeleteTeamMember")
+	defer span.End()
+
+	if _, err := requireTeamAdmin(ctx, s.TeamMember, teamID); err != nil {
+		return nil, err
+	}
+
+	if err := s.keepTeamAdmin(ctx, teamID, userID); err != nil {
+		return nil, err
+	}
+
 	teamMember, err := s.TeamMember.DeleteTeamMember(ctx, teamID, userID)
 	return teamMember, err
 }
 
 func (s *TeamMemberSvc) DeleteTeamMembersByTeamID(ctx context.Context, teamID uuid.UUID) ([]repository.TeamMember, error) {
+	ctx, span := tracing.Start(ctx, "TeamMemberService.DeleteTeamMembersByTeamID")
+	defer span.End()
+
 	teamMembers, err := s.TeamMember.DeleteTeamMembersByTeamID(ctx, teamID)
 	return teamMembers, err
 }
 
 func (s *TeamMemberSvc) DeleteTeamMembersByUserID(ctx context.Context, userID uuid.UUID) ([]repository.TeamMember, error) {
+	ctx, span := tracing.Start(ctx, "TeamMemberService.DeleteTeamMembersByUserID")
+	defer span.End()
+
 	teamMembers, err := s.TeamMember.DeleteTeamMembersByUserID(ctx, userID)
 	return teamMembers, err
 }
+
+// keepTeamAdmin refuses to remove or demote the member when they are the
+// last admin of the team, nobody could grant the role again
+func (s *TeamMemberSvc) keepTeamAdmin(ctx context.Context, teamID uuid.UUID, userID uuid.UUID) error {
+	admins, err := s.TeamMember.GetTeamMembersByRole(ctx, teamID, repository.TeamMemberRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	if len(admins) == 1 && admins[0].UserID == userID {
+		return errors.LastTeamAdmin
+	}
+
+	return nil
+}
+
+func newTeamMemberRole(role string) (string, error) {
+	switch role {
+	case "":
+		return repository.TeamMemberRoleMember, nil
+	case repository.TeamMemberRoleMember, repository.TeamMemberRoleAdmin:
+		return role, nil
+	default:
+		return "", errors.InvalidRole
+	}
+}
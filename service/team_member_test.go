@@ -6,6 +6,10 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	ctxUtil "gitlab.com/renodesper/spenmo-test/util/ctx"
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
 )
 
 func TestGetTeamMembersIntegration(t *testing.T) {
@@ -93,6 +97,51 @@ func TestGetTeamMembersIntegration(t *testing.T) {
 	}
 }
 
+func TestGetTeamMembersByTeamIDsIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	cases := map[string]struct {
+		TeamIDs         []string
+		ExpectedUserIDs []string
+	}{
+		"SuccessGetTeamMembers": {
+			TeamIDs:         []string{"933efe12-2219-42df-bd51-a2e84888432d", uuid.New().String()},
+			ExpectedUserIDs: []string{"8e159833-5078-4b0a-80a0-363d82bafd60"},
+		},
+		"SuccessUnknownTeam": {
+			TeamIDs: []string{uuid.New().String()},
+		},
+		"SuccessNoTeam": {},
+	}
+
+	for v, tc := range cases {
+		t.Run(v, func(t *testing.T) {
+			ctx := context.Background()
+			svc := NewTeamMemberService(Log, DB)
+
+			var teamIDs []uuid.UUID
+			for _, ID := range tc.TeamIDs {
+				teamIDs = append(teamIDs, uuid.MustParse(ID))
+			}
+
+			teamMembers, err := svc.GetTeamMembersByTeamIDs(ctx, teamIDs)
+			assert.NoError(t, err)
+
+			var userIDs []string
+			for _, teamMember := range teamMembers {
+				assert.Contains(t, tc.TeamIDs, teamMember.TeamID.String())
+				userIDs = append(userIDs, teamMember.UserID.String())
+			}
+
+			for _, userID := range tc.ExpectedUserIDs {
+				assert.Contains(t, userIDs, userID)
+			}
+		})
+	}
+}
+
 func TestCreateTeamMemberIntegration(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
@@ -101,15 +150,46 @@ func TestCreateTeamMemberIntegration(t *testing.T) {
 	reinitializeDB()
 
 	cases := map[string]struct {
+		CallerID      string
 		TeamID        string
 		UserID        string
+		Role          string
+		ExpectedRole  string
 		ExpectedError bool
 	}{
 		"SuccessCreateTeamMember": {
 			TeamID:        "933efe12-2219-42df-bd51-a2e84888432d",
 			UserID:        "bafdd20b-a2a5-41ca-b4a2-26fccbd029dd",
+			ExpectedRole:  repository.TeamMemberRoleMember,
+			ExpectedError: false,
+		},
+		"SuccessCreateTeamAdmin": {
+			CallerID:      "8e159833-5078-4b0a-80a0-363d82bafd60",
+			TeamID:        "933efe12-2219-42df-bd51-a2e84888432d",
+			UserID:        "0e49e11c-660c-43c5-954e-ef9e89b45833",
+			Role:          repository.TeamMemberRoleAdmin,
+			ExpectedRole:  repository.TeamMemberRoleAdmin,
 			ExpectedError: false,
 		},
+		"FailedInvalidRole": {
+			TeamID:        "933efe12-2219-42df-bd51-a2e84888432d",
+			UserID:        "2b1f3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d",
+			Role:          "owner",
+			ExpectedError: true,
+		},
+		"FailedCreateTeamAdminByMember": {
+			CallerID:      "51241303-ebe0-4c2b-98be-44d93439f6d9",
+			TeamID:        "933efe12-2219-42df-bd51-a2e84888432d",
+			UserID:        "2b1f3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d",
+			Role:          repository.TeamMemberRoleAdmin,
+			ExpectedError: true,
+		},
+		"FailedCreateTeamAdminUnauthenticated": {
+			TeamID:        "933efe12-2219-42df-bd51-a2e84888432d",
+			UserID:        "2b1f3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d",
+			Role:          repository.TeamMemberRoleAdmin,
+			ExpectedError: true,
+		},
 		"FailedMissingTeamID": {
 			TeamID:        "",
 			UserID:        "bafdd20b-a2a5-41ca-b4a2-26fccbd029dd",
@@ -130,6 +210,9 @@ func TestCreateTeamMemberIntegration(t *testing.T) {
 	for v, tc := range cases {
 		t.Run(v, func(t *testing.T) {
 			ctx := context.Background()
+			if tc.CallerID != "" {
+				ctx = ctxUtil.SetUserID(ctx, uuid.MustParse(tc.CallerID))
+			}
 			svc := NewTeamMemberService(Log, DB)
 
 			payload := CreateTeamMemberRequest{}
@@ -155,6 +238,7 @@ func TestCreateTeamMemberIntegration(t *testing.T) {
 			}
 
 			payload.UserID = userID
+			payload.Role = tc.Role
 
 			teamMember, err := svc.CreateTeamMember(ctx, &payload)
 
@@ -164,11 +248,63 @@ func TestCreateTeamMemberIntegration(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.NotNil(t, teamMember)
+				assert.Equal(t, tc.ExpectedRole, teamMember.Role)
 			}
 		})
 	}
 }
 
+func TestUpdateTeamMemberIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	reinitializeDB()
+
+	teamID := uuid.MustParse("933efe12-2219-42df-bd51-a2e84888432d")
+	adminID := uuid.MustParse("8e159833-5078-4b0a-80a0-363d82bafd60")
+	memberID := uuid.MustParse("51241303-ebe0-4c2b-98be-44d93439f6d9")
+	otherMemberID := uuid.MustParse("e0294804-ac72-4f2f-be54-2fb18a135709")
+
+	svc := NewTeamMemberService(Log, DB)
+
+	t.Run("FailedPromoteByMember", func(t *testing.T) {
+		ctx := ctxUtil.SetUserID(context.Background(), memberID)
+
+		teamMember, err := svc.UpdateTeamMember(ctx, &UpdateTeamMemberRequest{TeamID: teamID, UserID: otherMemberID, Role: repository.TeamMemberRoleAdmin})
+		assert.Equal(t, errors.Forbidden, err)
+		assert.Nil(t, teamMember)
+
+		teamMember, err = svc.UpdateTeamMember(ctx, &UpdateTeamMemberRequest{TeamID: teamID, UserID: memberID, Role: repository.TeamMemberRoleAdmin})
+		assert.Equal(t, errors.Forbidden, err)
+		assert.Nil(t, teamMember)
+	})
+
+	t.Run("SuccessKeepRoleWhenNotSent", func(t *testing.T) {
+		ctx := ctxUtil.SetUserID(context.Background(), memberID)
+
+		teamMember, err := svc.UpdateTeamMember(ctx, &UpdateTeamMemberRequest{TeamID: teamID, UserID: adminID})
+		assert.NoError(t, err)
+		assert.Equal(t, repository.TeamMemberRoleAdmin, teamMember.Role)
+	})
+
+	t.Run("FailedDemoteLastAdmin", func(t *testing.T) {
+		ctx := ctxUtil.SetUserID(context.Background(), adminID)
+
+		teamMember, err := svc.UpdateTeamMember(ctx, &UpdateTeamMemberRequest{TeamID: teamID, UserID: adminID, Role: repository.TeamMemberRoleMember})
+		assert.Equal(t, errors.LastTeamAdmin, err)
+		assert.Nil(t, teamMember)
+	})
+
+	t.Run("SuccessPromoteByAdmin", func(t *testing.T) {
+		ctx := ctxUtil.SetUserID(context.Background(), adminID)
+
+		teamMember, err := svc.UpdateTeamMember(ctx, &UpdateTeamMemberRequest{TeamID: teamID, UserID: otherMemberID, Role: repository.TeamMemberRoleAdmin})
+		assert.NoError(t, err)
+		assert.Equal(t, repository.TeamMemberRoleAdmin, teamMember.Role)
+	})
+}
+
 func TestDeleteTeamMemberIntegration(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
@@ -176,36 +312,54 @@ func TestDeleteTeamMemberIntegration(t *testing.T) {
 
 	reinitializeDB()
 
+	adminID := "8e159833-5078-4b0a-80a0-363d82bafd60"
+
 	cases := map[string]struct {
+		CallerID      string
 		TeamID        string
 		UserID        string
-		ExpectedError bool
+		ExpectedError error
 	}{
 		"SuccessDeleteTeamMember": {
+			CallerID: adminID,
+			TeamID:   "933efe12-2219-42df-bd51-a2e84888432d",
+			UserID:   "51241303-ebe0-4c2b-98be-44d93439f6d9",
+		},
+		"FailedLastTeamAdmin": {
+			CallerID:      adminID,
 			TeamID:        "933efe12-2219-42df-bd51-a2e84888432d",
-			UserID:        "8e159833-5078-4b0a-80a0-363d82bafd60",
-			ExpectedError: false,
+			UserID:        adminID,
+			ExpectedError: errors.LastTeamAdmin,
+		},
+		"FailedNotTeamAdmin": {
+			CallerID:      "e0294804-ac72-4f2f-be54-2fb18a135709",
+			TeamID:        "933efe12-2219-42df-bd51-a2e84888432d",
+			UserID:        adminID,
+			ExpectedError: errors.Forbidden,
 		},
 		"FailedTeamMemberNotFound1": {
 			TeamID:        "933efe12-2219-42df-bd51-a2e84888432e",
+			CallerID:      adminID,
 			UserID:        "8e159833-5078-4b0a-80a0-363d82bafd61",
-			ExpectedError: true,
+			ExpectedError: errors.Forbidden,
 		},
 		"FailedTeamMemberNotFound2": {
+			CallerID:      adminID,
 			TeamID:        "",
-			UserID:        "8e159833-5078-4b0a-80a0-363d82bafd60",
-			ExpectedError: true,
+			UserID:        adminID,
+			ExpectedError: errors.Forbidden,
 		},
 		"FailedTeamMemberNotFound3": {
+			CallerID:      adminID,
 			TeamID:        "933efe12-2219-42df-bd51-a2e84888432d",
 			UserID:        "",
-			ExpectedError: true,
+			ExpectedError: errors.FailedTeamMemberDelete,
 		},
 	}
 
 	for v, tc := range cases {
 		t.Run(v, func(t *testing.T) {
-			ctx := context.Background()
+			ctx := ctxUtil.SetUserID(context.Background(), uuid.MustParse(tc.CallerID))
 			svc := NewTeamMemberService(Log, DB)
 
 			var err error
@@ -227,8 +381,10 @@ func TestDeleteTeamMemberIntegration(t *testing.T) {
 			}
 
 			teamMember, err := svc.DeleteTeamMember(ctx, teamID, userID)
-			if tc.ExpectedError {
-				assert.Error(t, err)
+			if tc.ExpectedError != nil {
+				if assert.Error(t, err) {
+					assert.Equal(t, tc.ExpectedError.(e.Error).Code, err.(e.Error).Code)
+				}
 				assert.Nil(t, teamMember)
 			} else {
 				assert.NoError(t, err)
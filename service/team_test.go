@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	ctxUtil "gitlab.com/renodesper/spenmo-test/util/ctx"
 )
 
 func TestGetAllTeamsIntegration(t *testing.T) {
@@ -120,23 +121,32 @@ func TestCreateTeamIntegration(t *testing.T) {
 
 	reinitializeDB()
 
+	creatorID := uuid.MustParse("8e159833-5078-4b0a-80a0-363d82bafd60")
+
 	cases := map[string]struct {
 		Name          string
+		CallerID      uuid.UUID
 		ExpectedError bool
 	}{
 		"SuccessCreateTeam": {
 			Name:          "team100",
+			CallerID:      creatorID,
 			ExpectedError: false,
 		},
 		"FailedTeamExist": {
 			Name:          "team100",
+			CallerID:      creatorID,
+			ExpectedError: true,
+		},
+		"FailedUnauthenticated": {
+			Name:          "team200",
 			ExpectedError: true,
 		},
 	}
 
 	for v, tc := range cases {
 		t.Run(v, func(t *testing.T) {
-			ctx := context.Background()
+			ctx := ctxUtil.SetUserID(context.Background(), tc.CallerID)
 			svc := NewTeamService(Log, DB)
 
 			payload := CreateTeamRequest{
@@ -154,6 +164,13 @@ func TestCreateTeamIntegration(t *testing.T) {
 				team, err := svc.GetTeam(ctx, team.ID)
 				assert.NoError(t, err)
 				assert.NotEmpty(t, team)
+
+				// NOTE: The creator is the first admin of the team
+				admins, err := NewTeamMemberService(Log, DB).GetTeamApprovers(ctx, team.ID)
+				assert.NoError(t, err)
+				if assert.Len(t, admins, 1) {
+					assert.Equal(t, creatorID, admins[0].UserID)
+				}
 			}
 		})
 	}
@@ -0,0 +1,450 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/repository/postgre"
+	"gitlab.com/renodesper/spenmo-test/util/card"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/fx"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+	"gitlab.com/renodesper/spenmo-test/util/notifier"
+	"gitlab.com/renodesper/spenmo-test/util/tracing"
+)
+
+type (
+	// TransactionService ...
+	TransactionService interface {
+		GetTransactions(ctx context.Context, payload *GetTransactionsRequest) ([]repository.Transaction, error)
+		GetTransaction(ctx context.Context, transactionID uuid.UUID) (*repository.Transaction, error)
+		UpdateTransaction(ctx context.Context, transactionID uuid.UUID, payload *UpdateTransactionRequest) (*repository.Transaction, error)
+		CreateTransfer(ctx context.Context, payload *CreateTransferRequest) ([]repository.Transaction, error)
+		CreateCardTransaction(ctx context.Context, payload *CreateCardTransactionRequest) (*repository.Transaction, error)
+	}
+
+	TransactionSvc struct {
+		Log         logger.Logger
+		Transaction postgre.TransactionRepository
+		Wallet      postgre.WalletRepository
+		Card        postgre.CardRepository
+		Converter   *fx.Converter
+		Limit       *LimitEngine
+		Budget      *BudgetChecker
+		Fraud       *FraudEngine
+	}
+
+	GetTransactionsRequest struct {
+		WalletID       uuid.UUID
+		CardID         uuid.UUID
+		Category       string
+		Tag            string
+		MissingReceipt bool
+		SortBy         string
+		Sort           string
+		Skip           int
+		Limit          int
+	}
+
+	// UpdateTransactionRequest replaces the expense metadata of a
+	// transaction, an empty value clears it
+	UpdateTransactionRequest struct {
+		Memo     string
+		Category string
+		Tags     []string
+	}
+
+	// CreateTransferRequest moves Amount, expressed in the currency of the
+	// receiving wallet, from one wallet to another. The sending wallet is
+	// debited the converted amount when both currencies differ.
+	CreateTransferRequest struct {
+		FromWalletID uuid.UUID
+		ToWalletID   uuid.UUID
+		Amount       money.Decimal
+		Currency     string
+		Description  string
+		// Guard runs after the limits in the transaction posting the debit,
+		// it lets internal callers record the transfer atomically
+		Guard postgre.TransactionGuard
+	}
+
+	// CreateCardTransactionRequest charges a card with Amount expressed in
+	// the currency of the merchant.
+	CreateCardTransactionRequest struct {
+		CardID      uuid.UUID
+		Amount      money.Decimal
+		Currency    string
+		Merchant    string
+		MerchantID  string
+		MCC         string
+		Country     string
+		Description string
+	}
+)
+
+const TRANSACTION_TAGS_MAXIMUM = 10
+
+// NewTransactionService creates transaction service
+func NewTransactionService(log logger.Logger, db *pg.DB, converter *fx.Converter, notify notifier.Notifier, fraud *FraudEngine) TransactionService {
+	transactionRepo := postgre.CreateTransactionRepository(log, db)
+	walletRepo := postgre.CreateWalletRepository(log, db)
+	cardRepo := postgre.CreateCardRepository(log, db)
+
+	return &TransactionSvc{
+		Log:         log,
+		Transaction: transactionRepo,
+		Wallet:      walletRepo,
+		Card:        cardRepo,
+		Converter:   converter,
+		Limit:       NewLimitEngine(log, db),
+		Budget:      NewBudgetChecker(log, db, notify),
+		Fraud:       fraud,
+	}
+}
+
+func (s *TransactionSvc) GetTransactions(ctx context.Context, payload *GetTransactionsRequest) ([]repository.Transaction, error) {
+	ctx, span := tracing.Start(ctx, "TransactionService.GetTransactions")
+	defer span.End()
+
+	category := strings.ToLower(strings.TrimSpace(payload.Category))
+	tag := strings.ToLower(strings.TrimSpace(payload.Tag))
+
+	transactions, err := s.Transaction.GetTransactions(ctx, payload.WalletID, payload.CardID, category, tag, payload.MissingReceipt, payload.SortBy, payload.Sort, payload.Skip, payload.Limit)
+	return transactions, err
+}
+
+func (s *TransactionSvc) GetTransaction(ctx context.Context, transactionID uuid.UUID) (*repository.Transaction, error) {
+	ctx, span := tracing.Start(ctx, "TransactionService.GetTransaction")
+	defer span.End()
+
+	transaction, err := s.Transaction.GetTransactionByID(ctx, transactionID)
+	return transaction, err
+}
+
+func (s *TransactionSvc) UpdateTransaction(ctx context.Context, transactionID uuid.UUID, payload *UpdateTransactionRequest) (*repository.Transaction, error) {
+	ctx, span := tracing.Start(ctx, "TransactionService.UpdateTransaction")
+	defer span.End()
+
+	tags, err := normalizeTags(payload.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionPayload := map[string]interface{}{
+		"memo":     strings.TrimSpace(payload.Memo),
+		"category": strings.ToLower(strings.TrimSpace(payload.Category)),
+		"tags":     pg.Array(tags),
+	}
+
+	transaction, err := s.Transaction.UpdateTransaction(ctx, transactionID, transactionPayload)
+	return transaction, err
+}
+
+func (s *TransactionSvc) CreateTransfer(ctx context.Context, payload *CreateTransferRequest) ([]repository.Transaction, error) {
+	ctx, span := tracing.Start(ctx, "TransactionService.CreateTransfer")
+	defer span.End()
+
+	if payload.Amount.Sign() <= 0 {
+		return nil, errors.InvalidAmount
+	}
+
+	if payload.FromWalletID == payload.ToWalletID {
+		return nil, errors.InvalidTransfer
+	}
+
+	fromWallet, err := s.getActiveWallet(ctx, payload.FromWalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	toWallet, err := s.getActiveWallet(ctx, payload.ToWalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.Currency == "" {
+		payload.Currency = toWallet.Currency
+	}
+
+	credit, err := money.New(payload.Amount, payload.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	if credit.Currency != toWallet.Currency {
+		return nil, errors.CurrencyMismatch
+	}
+
+	conversion, err := s.Converter.Convert(ctx, credit, fromWallet.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	referenceID := uuid.New()
+
+	debitPayload := repository.Transaction{
+		ID:                   uuid.New(),
+		ReferenceID:          referenceID,
+		WalletID:             fromWallet.ID,
+		CounterpartyWalletID: toWallet.ID,
+		Type:                 repository.TransactionTypeTransfer,
+		Direction:            repository.TransactionDirectionDebit,
+		Amount:               conversion.Result.Amount,
+		Currency:             conversion.Result.Currency,
+		Description:          payload.Description,
+	}
+	setConversion(&debitPayload, conversion)
+
+	creditPayload := repository.Transaction{
+		ID:                   uuid.New(),
+		ReferenceID:          referenceID,
+		WalletID:             toWallet.ID,
+		CounterpartyWalletID: fromWallet.ID,
+		Type:                 repository.TransactionTypeTransfer,
+		Direction:            repository.TransactionDirectionCredit,
+		Amount:               credit.Amount,
+		Currency:             credit.Currency,
+		Description:          payload.Description,
+	}
+
+	limitGuard, err := s.Limit.Guard(ctx, fromWallet, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	guard := postgre.ChainTransactionGuards(limitGuard, payload.Guard)
+
+	transactions, err := s.Transaction.CreateTransfer(ctx, &debitPayload, &creditPayload, guard)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range transactions {
+		s.checkBudgets(ctx, &transactions[i])
+	}
+
+	return transactions, nil
+}
+
+func (s *TransactionSvc) CreateCardTransaction(ctx context.Context, payload *CreateCardTransactionRequest) (*repository.Transaction, error) {
+	ctx, span := tracing.Start(ctx, "TransactionService.CreateCardTransaction")
+	defer span.End()
+
+	if payload.Amount.Sign() <= 0 {
+		return nil, errors.InvalidAmount
+	}
+
+	existingCard, err := s.Card.GetCardByID(ctx, payload.CardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingCard.IsDeleted {
+		return nil, errors.FailedCardNotFound
+	}
+
+	if existingCard.IsClosed {
+		return nil, errors.ClosedCard
+	}
+
+	if existingCard.IsFrozen {
+		return nil, errors.FrozenCard
+	}
+
+	err = card.IsExpiryValid(existingCard.ExpiryMonth, existingCard.ExpiryYear)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := s.getActiveWallet(ctx, existingCard.WalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.Currency == "" {
+		payload.Currency = existingCard.Currency
+	}
+
+	amount, err := money.New(payload.Amount, payload.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	conversion, err := s.Converter.Convert(ctx, amount, wallet.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionPayload := repository.Transaction{
+		ID:          uuid.New(),
+		ReferenceID: uuid.New(),
+		WalletID:    wallet.ID,
+		CardID:      existingCard.ID,
+		Type:        repository.TransactionTypeCard,
+		Direction:   repository.TransactionDirectionDebit,
+		Amount:      conversion.Result.Amount,
+		Currency:    conversion.Result.Currency,
+		Merchant:    payload.Merchant,
+		MerchantID:  payload.MerchantID,
+		MCC:         payload.MCC,
+		Country:     strings.ToUpper(payload.Country),
+		Description: payload.Description,
+	}
+	setConversion(&transactionPayload, conversion)
+
+	err = checkSpendControls(wallet, existingCard, &transactionPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	limitGuard, err := s.Limit.Guard(ctx, wallet, existingCard.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var verdict *FraudVerdict
+	guard := postgre.ChainTransactionGuards(s.cardTypeGuard(existingCard), limitGuard, s.fraudGuard(existingCard, &verdict))
+
+	transaction, err := s.Transaction.CreateCardTransaction(ctx, &transactionPayload, guard)
+	if err != nil {
+		// NOTE: The card is frozen once the card row is no longer locked
+		if verdict != nil {
+			return nil, s.Fraud.Decline(ctx, existingCard, verdict)
+		}
+
+		return nil, err
+	}
+
+	s.checkBudgets(ctx, transaction)
+
+	return transaction, nil
+}
+
+func (s *TransactionSvc) getActiveWallet(ctx context.Context, walletID uuid.UUID) (*repository.Wallet, error) {
+	wallet, err := s.Wallet.GetWalletByID(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if wallet.IsDeleted {
+		return nil, errors.FailedWalletNotFound
+	}
+
+	return wallet, nil
+}
+
+// checkBudgets runs once the transaction is posted, a failure is only logged
+// since the money has already moved
+func (s *TransactionSvc) checkBudgets(ctx context.Context, transaction *repository.Transaction) {
+	if err := s.Budget.Check(ctx, transaction); err != nil {
+		s.Log.Errorw("unable to check budgets", "transactionId", transaction.ID, "error", err)
+	}
+}
+
+// cardTypeGuard closes a single use card and binds a merchant locked card to
+// the merchant of its first capture. The card row is locked so concurrent
+// captures cannot both go through.
+func (s *TransactionSvc) cardTypeGuard(existingCard *repository.Card) postgre.TransactionGuard {
+	if existingCard.Type != repository.CardTypeSingleUse && existingCard.Type != repository.CardTypeMerchantLocked {
+		return nil
+	}
+
+	return func(ctx context.Context, tx *pg.Tx, transaction *repository.Transaction) error {
+		lockedCard, err := s.Card.GetCardForUpdate(ctx, tx, existingCard.ID)
+		if err != nil {
+			return err
+		}
+
+		if lockedCard.IsClosed {
+			return errors.ClosedCard
+		}
+
+		switch lockedCard.Type {
+		case repository.CardTypeSingleUse:
+			return s.Card.UpdateCardInTx(ctx, tx, lockedCard.ID, map[string]interface{}{"is_closed": true})
+		case repository.CardTypeMerchantLocked:
+			merchant := transactionMerchant(transaction)
+			if merchant == "" {
+				return errors.MissingMerchant
+			}
+
+			if lockedCard.LockedMerchant == "" {
+				return s.Card.UpdateCardInTx(ctx, tx, lockedCard.ID, map[string]interface{}{"locked_merchant": merchant})
+			}
+
+			if lockedCard.LockedMerchant != merchant {
+				return errors.MerchantLockedCard
+			}
+		}
+
+		return nil
+	}
+}
+
+// fraudGuard locks the card row and runs the fraud rules, the concurrent
+// authorizations of a card wait for each other so none of them is evaluated
+// against counters missing the others. The declining rule is kept in verdict.
+func (s *TransactionSvc) fraudGuard(existingCard *repository.Card, verdict **FraudVerdict) postgre.TransactionGuard {
+	return func(ctx context.Context, tx *pg.Tx, transaction *repository.Transaction) error {
+		if _, err := s.Card.GetCardForUpdate(ctx, tx, existingCard.ID); err != nil {
+			return err
+		}
+
+		declined, err := s.Fraud.Check(ctx, existingCard, transaction)
+		if err != nil {
+			return err
+		}
+
+		if declined != nil {
+			*verdict = declined
+			return errors.FraudRuleTriggered
+		}
+
+		return nil
+	}
+}
+
+// normalizeTags lowercases the tags and drops the duplicates so filtering on
+// a tag does not depend on how it was typed
+func normalizeTags(tags []string) ([]string, error) {
+	if len(tags) > TRANSACTION_TAGS_MAXIMUM {
+		return nil, errors.InvalidTags
+	}
+
+	normalized := []string{}
+	seen := map[string]bool{}
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			return nil, errors.InvalidTags
+		}
+
+		if seen[tag] {
+			continue
+		}
+
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	return normalized, nil
+}
+
+// setConversion stores the rate used on a converted transaction so the amount can be reproduced later
+func setConversion(transaction *repository.Transaction, conversion *fx.Conversion) {
+	if conversion.Source.Currency == conversion.Result.Currency {
+		return
+	}
+
+	originalAmount := conversion.Source.Amount
+	transaction.OriginalAmount = &originalAmount
+	transaction.OriginalCurrency = conversion.Source.Currency
+	transaction.FxRate = conversion.Rate
+	transaction.FxMarkupBps = conversion.MarkupBps
+	transaction.FxRounding = string(conversion.Rounding)
+}
@@ -0,0 +1,358 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/util/fx"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+	"gitlab.com/renodesper/spenmo-test/util/velocity/memory"
+)
+
+func newTestConverter(t *testing.T) *fx.Converter {
+	provider, err := fx.NewStaticRateProvider("SGD", map[string]string{
+		"USD": "0.74",
+	})
+	assert.NoError(t, err)
+
+	return fx.NewConverter(provider, 0, money.RoundHalfUp)
+}
+
+func newTestFraudEngine(t *testing.T, rules ...FraudRule) *FraudEngine {
+	fraud, err := NewFraudEngine(Log, DB, memory.CreateStore(), rules)
+	assert.NoError(t, err)
+
+	return fraud
+}
+
+func topUpTeamWallet(t *testing.T, ctx context.Context, walletID string, teamID string, amount money.Decimal) {
+	walletSvc := NewWalletService(Log, DB)
+
+	payload := UpdateWalletRequest{
+		BalanceIncrease: amount,
+		TeamID:          uuid.MustParse(teamID),
+	}
+
+	_, err := walletSvc.UpdateWallet(ctx, uuid.MustParse(walletID), &payload)
+	assert.NoError(t, err)
+}
+
+func TestNormalizeTags(t *testing.T) {
+	cases := map[string]struct {
+		Tags          []string
+		ExpectedTags  []string
+		ExpectedError bool
+	}{
+		"SuccessNormalize": {
+			Tags:         []string{" Travel", "travel", "Q3"},
+			ExpectedTags: []string{"travel", "q3"},
+		},
+		"SuccessEmpty": {
+			Tags:         nil,
+			ExpectedTags: []string{},
+		},
+		"FailedBlankTag": {
+			Tags:          []string{"travel", " "},
+			ExpectedError: true,
+		},
+		"FailedTooManyTags": {
+			Tags:          []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"},
+			ExpectedError: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tags, err := normalizeTags(tc.Tags)
+			if tc.ExpectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ExpectedTags, tags)
+		})
+	}
+}
+
+func TestCreateTransferIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	reinitializeDB()
+
+	ctx := context.Background()
+	svc := NewTransactionService(Log, DB, newTestConverter(t), Notifier, newTestFraudEngine(t))
+	walletSvc := NewWalletService(Log, DB)
+
+	topUpTeamWallet(t, ctx, "d4a6607a-1af7-4571-bdff-2672be72ba0e", "933efe12-2219-42df-bd51-a2e84888432d", money.MustParseDecimal("1000.00"))
+
+	usdWallet, err := walletSvc.CreateWallet(ctx, &CreateWalletRequest{
+		UserID:   uuid.MustParse("8e159833-5078-4b0a-80a0-363d82bafd60"),
+		Currency: "USD",
+	})
+	assert.NoError(t, err)
+
+	cases := map[string]struct {
+		FromWalletID          string
+		ToWalletID            string
+		Amount                money.Decimal
+		Currency              string
+		ExpectedDebitAmount   money.Decimal
+		ExpectedDebitCurrency string
+		ExpectedError         bool
+	}{
+		"SuccessCreateTransfer": {
+			FromWalletID:          "d4a6607a-1af7-4571-bdff-2672be72ba0e",
+			ToWalletID:            "370a9739-b90b-4264-81a2-f8d0d3236011",
+			Amount:                money.MustParseDecimal("100.00"),
+			ExpectedDebitAmount:   money.MustParseDecimal("100.00"),
+			ExpectedDebitCurrency: "SGD",
+			ExpectedError:         false,
+		},
+		"SuccessCreateConvertedTransfer": {
+			FromWalletID:          "d4a6607a-1af7-4571-bdff-2672be72ba0e",
+			ToWalletID:            usdWallet.ID.String(),
+			Amount:                money.MustParseDecimal("10.00"),
+			Currency:              "USD",
+			ExpectedDebitAmount:   money.MustParseDecimal("13.51"),
+			ExpectedDebitCurrency: "SGD",
+			ExpectedError:         false,
+		},
+		"FailedInvalidAmount": {
+			FromWalletID:  "d4a6607a-1af7-4571-bdff-2672be72ba0e",
+			ToWalletID:    "370a9739-b90b-4264-81a2-f8d0d3236011",
+			Amount:        money.MustParseDecimal("0.00"),
+			ExpectedError: true,
+		},
+		"FailedInvalidTransfer": {
+			FromWalletID:  "d4a6607a-1af7-4571-bdff-2672be72ba0e",
+			ToWalletID:    "d4a6607a-1af7-4571-bdff-2672be72ba0e",
+			Amount:        money.MustParseDecimal("10.00"),
+			ExpectedError: true,
+		},
+		"FailedCurrencyMismatch": {
+			FromWalletID:  "d4a6607a-1af7-4571-bdff-2672be72ba0e",
+			ToWalletID:    "370a9739-b90b-4264-81a2-f8d0d3236011",
+			Amount:        money.MustParseDecimal("10.00"),
+			Currency:      "USD",
+			ExpectedError: true,
+		},
+		"FailedNotEnoughBalance": {
+			FromWalletID:  "370a9739-b90b-4264-81a2-f8d0d3236011",
+			ToWalletID:    "d4a6607a-1af7-4571-bdff-2672be72ba0e",
+			Amount:        money.MustParseDecimal("10000.00"),
+			ExpectedError: true,
+		},
+		"FailedWalletNotFound": {
+			FromWalletID:  "d4a6607a-1af7-4571-bdff-2672be72ba0e",
+			ToWalletID:    "d4a6607a-1af7-4571-bdff-2672be72ba0f",
+			Amount:        money.MustParseDecimal("10.00"),
+			ExpectedError: true,
+		},
+	}
+
+	for v, tc := range cases {
+		t.Run(v, func(t *testing.T) {
+			payload := CreateTransferRequest{
+				FromWalletID: uuid.MustParse(tc.FromWalletID),
+				ToWalletID:   uuid.MustParse(tc.ToWalletID),
+				Amount:       tc.Amount,
+				Currency:     tc.Currency,
+			}
+
+			transactions, err := svc.CreateTransfer(ctx, &payload)
+
+			if tc.ExpectedError {
+				assert.Error(t, err)
+				assert.Empty(t, transactions)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, transactions, 2)
+
+				debit, credit := transactions[0], transactions[1]
+				assert.Equal(t, repository.TransactionDirectionDebit, debit.Direction)
+				assert.Zero(t, tc.ExpectedDebitAmount.Cmp(debit.Amount))
+				assert.Equal(t, tc.ExpectedDebitCurrency, debit.Currency)
+				assert.Equal(t, repository.TransactionDirectionCredit, credit.Direction)
+				assert.Zero(t, tc.Amount.Cmp(credit.Amount))
+				assert.Equal(t, debit.ReferenceID, credit.ReferenceID)
+
+				if debit.Currency != credit.Currency {
+					assert.NotEmpty(t, debit.FxRate)
+					if assert.NotNil(t, debit.OriginalAmount) {
+						assert.Zero(t, credit.Amount.Cmp(*debit.OriginalAmount))
+					}
+					assert.Equal(t, credit.Currency, debit.OriginalCurrency)
+				} else {
+					assert.Nil(t, debit.OriginalAmount)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateCardTransactionIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	reinitializeDB()
+
+	ctx := context.Background()
+	svc := NewTransactionService(Log, DB, newTestConverter(t), Notifier, newTestFraudEngine(t))
+	cardSvc := NewCardService(Log, DB)
+
+	topUpTeamWallet(t, ctx, "d4a6607a-1af7-4571-bdff-2672be72ba0e", "933efe12-2219-42df-bd51-a2e84888432d", money.MustParseDecimal("1000.00"))
+
+	activeCard, err := cardSvc.CreateCard(ctx, &CreateCardRequest{
+		CardNo:      "4012888888881881",
+		ExpiryMonth: "12",
+		ExpiryYear:  fmt.Sprintf("%d", time.Now().Year()+1),
+		CVV:         "123",
+		WalletID:    uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e"),
+	})
+	assert.NoError(t, err)
+
+	cases := map[string]struct {
+		CardID         string
+		Amount         money.Decimal
+		Currency       string
+		ExpectedAmount money.Decimal
+		ExpectedError  bool
+	}{
+		"SuccessCreateCardTransaction": {
+			CardID:         activeCard.ID.String(),
+			Amount:         money.MustParseDecimal("10.00"),
+			ExpectedAmount: money.MustParseDecimal("10.00"),
+			ExpectedError:  false,
+		},
+		"SuccessCreateConvertedCardTransaction": {
+			CardID:         activeCard.ID.String(),
+			Amount:         money.MustParseDecimal("10.00"),
+			Currency:       "USD",
+			ExpectedAmount: money.MustParseDecimal("13.51"),
+			ExpectedError:  false,
+		},
+		"FailedExpiredCard": {
+			CardID:        "cb21fe95-37e7-4e67-aac3-1b633fe1036d",
+			Amount:        money.MustParseDecimal("10.00"),
+			ExpectedError: true,
+		},
+		"FailedCardNotFound": {
+			CardID:        "cb21fe95-37e7-4e67-aac3-1b633fe1036e",
+			Amount:        money.MustParseDecimal("10.00"),
+			ExpectedError: true,
+		},
+		"FailedNotEnoughBalance": {
+			CardID:        activeCard.ID.String(),
+			Amount:        money.MustParseDecimal("10000.00"),
+			ExpectedError: true,
+		},
+	}
+
+	for v, tc := range cases {
+		t.Run(v, func(t *testing.T) {
+			payload := CreateCardTransactionRequest{
+				CardID:   uuid.MustParse(tc.CardID),
+				Amount:   tc.Amount,
+				Currency: tc.Currency,
+				Merchant: "ACME",
+			}
+
+			transaction, err := svc.CreateCardTransaction(ctx, &payload)
+
+			if tc.ExpectedError {
+				assert.Error(t, err)
+				assert.Nil(t, transaction)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, transaction)
+				assert.Zero(t, tc.ExpectedAmount.Cmp(transaction.Amount))
+				assert.Equal(t, repository.TransactionTypeCard, transaction.Type)
+
+				stored, err := svc.GetTransaction(ctx, transaction.ID)
+				assert.NoError(t, err)
+				assert.Equal(t, transaction.FxRate, stored.FxRate)
+			}
+		})
+	}
+}
+
+func TestCardTypeIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	reinitializeDB()
+
+	ctx := context.Background()
+	svc := NewTransactionService(Log, DB, newTestConverter(t), Notifier, newTestFraudEngine(t))
+	cardSvc := NewCardService(Log, DB)
+
+	topUpTeamWallet(t, ctx, "d4a6607a-1af7-4571-bdff-2672be72ba0e", "933efe12-2219-42df-bd51-a2e84888432d", money.MustParseDecimal("1000.00"))
+
+	singleUseCard, err := cardSvc.CreateCard(ctx, &CreateCardRequest{
+		CardNo:      "4012888888881881",
+		ExpiryMonth: "12",
+		ExpiryYear:  fmt.Sprintf("%d", time.Now().Year()+1),
+		CVV:         "123",
+		Type:        repository.CardTypeSingleUse,
+		WalletID:    uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e"),
+	})
+	assert.NoError(t, err)
+
+	merchantLockedCard, err := cardSvc.CreateCard(ctx, &CreateCardRequest{
+		CardNo:      "5555555555554444",
+		ExpiryMonth: "12",
+		ExpiryYear:  fmt.Sprintf("%d", time.Now().Year()+1),
+		CVV:         "123",
+		Type:        repository.CardTypeMerchantLocked,
+		WalletID:    uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e"),
+	})
+	assert.NoError(t, err)
+
+	// NOTE: Each step is cumulative
+	steps := []struct {
+		CardID        uuid.UUID
+		Merchant      string
+		ExpectedError bool
+	}{
+		{CardID: singleUseCard.ID, Merchant: "ACME", ExpectedError: false},
+		{CardID: singleUseCard.ID, Merchant: "ACME", ExpectedError: true},
+		{CardID: merchantLockedCard.ID, Merchant: "", ExpectedError: true},
+		{CardID: merchantLockedCard.ID, Merchant: "ACME", ExpectedError: false},
+		{CardID: merchantLockedCard.ID, Merchant: "acme", ExpectedError: false},
+		{CardID: merchantLockedCard.ID, Merchant: "Globex", ExpectedError: true},
+	}
+
+	for _, step := range steps {
+		transaction, err := svc.CreateCardTransaction(ctx, &CreateCardTransactionRequest{
+			CardID:   step.CardID,
+			Amount:   money.MustParseDecimal("10.00"),
+			Merchant: step.Merchant,
+		})
+
+		if step.ExpectedError {
+			assert.Error(t, err)
+			assert.Nil(t, transaction)
+		} else {
+			assert.NoError(t, err)
+			assert.NotNil(t, transaction)
+		}
+	}
+
+	closedCard, err := cardSvc.GetCard(ctx, singleUseCard.ID)
+	assert.NoError(t, err)
+	assert.True(t, closedCard.IsClosed)
+
+	lockedCard, err := cardSvc.GetCard(ctx, merchantLockedCard.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", lockedCard.LockedMerchant)
+}
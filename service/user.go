@@ -9,6 +9,7 @@ import (
 	"gitlab.com/renodesper/spenmo-test/repository/postgre"
 	"gitlab.com/renodesper/spenmo-test/util/errors"
 	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/tracing"
 )
 
 type (
@@ -16,6 +17,7 @@ type (
 	UserService interface {
 		GetAllUsers(ctx context.Context, sortBy string, sort string, skip int, limit int) ([]repository.User, error)
 		GetUser(ctx context.Context, userID uuid.UUID) (*repository.User, error)
+		GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID) ([]repository.User, error)
 		CreateUser(ctx context.Context, payload *CreateUserRequest) (*repository.User, error)
 		UpdateUser(ctx context.Context, userID uuid.UUID, payload *UpdateUserRequest) (*repository.User, error)
 		DeleteUser(ctx context.Context, userID uuid.UUID) (*repository.User, error)
@@ -54,6 +56,9 @@ func NewUserService(log logger.Logger, db *pg.DB) UserService {
 }
 
 func (s *UserSvc) GetAllUsers(ctx context.Context, sortBy string, sort string, skip int, limit int) ([]repository.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetAllUsers")
+	defer span.End()
+
 	var users []repository.User
 
 	users, err := s.User.GetAllUsers(ctx, sortBy, sort, skip, limit)
@@ -69,11 +74,25 @@ func (s *UserSvc) GetAllUsers(ctx context.Context, sortBy string, sort string, s
 }
 
 func (s *UserSvc) GetUser(ctx context.Context, userID uuid.UUID) (*repository.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUser")
+	defer span.End()
+
 	user, err := s.User.GetUserByID(ctx, userID)
 	return user, err
 }
 
+func (s *UserSvc) GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID) ([]repository.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUsersByIDs")
+	defer span.End()
+
+	users, err := s.User.GetUsersByIDs(ctx, userIDs)
+	return users, err
+}
+
 func (s *UserSvc) CreateUser(ctx context.Context, payload *CreateUserRequest) (*repository.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.CreateUser")
+	defer span.End()
+
 	if payload.Email != "" {
 		user, _ := s.User.GetUserByEmail(ctx, payload.Email)
 
@@ -94,6 +113,9 @@ func (s *UserSvc) CreateUser(ctx context.Context, payload *CreateUserRequest) (*
 }
 
 func (s *UserSvc) UpdateUser(ctx context.Context, userID uuid.UUID, payload *UpdateUserRequest) (*repository.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateUser")
+	defer span.End()
+
 	if payload.Email != "" {
 		user, _ := s.User.GetUserByEmail(ctx, payload.Email)
 
@@ -117,6 +139,9 @@ func (s *UserSvc) UpdateUser(ctx context.Context, userID uuid.UUID, payload *Upd
 }
 
 func (s *UserSvc) DeleteUser(ctx context.Context, userID uuid.UUID) (*repository.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.DeleteUser")
+	defer span.End()
+
 	user, err := s.User.DeleteUser(ctx, userID)
 	if err != nil {
 		return nil, err
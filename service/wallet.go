@@ -2,13 +2,18 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-pg/pg/v10"
 	"github.com/google/uuid"
 	"gitlab.com/renodesper/spenmo-test/repository"
 	"gitlab.com/renodesper/spenmo-test/repository/postgre"
+	e "gitlab.com/renodesper/spenmo-test/util/error"
 	"gitlab.com/renodesper/spenmo-test/util/errors"
 	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+	"gitlab.com/renodesper/spenmo-test/util/statement"
+	"gitlab.com/renodesper/spenmo-test/util/tracing"
 )
 
 type (
@@ -17,17 +22,23 @@ type (
 		GetAllWallets(ctx context.Context, sortBy string, sort string, skip int, limit int) ([]repository.Wallet, error)
 		GetWallets(ctx context.Context, payload *GetWalletsRequest) ([]repository.Wallet, error)
 		GetWallet(ctx context.Context, walletID uuid.UUID) (*repository.Wallet, error)
+		GetWalletsByIDs(ctx context.Context, walletIDs []uuid.UUID) ([]repository.Wallet, error)
+		GetWalletsByTeamIDs(ctx context.Context, teamIDs []uuid.UUID) ([]repository.Wallet, error)
+		GetWalletsByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]repository.Wallet, error)
 		CreateWallet(ctx context.Context, payload *CreateWalletRequest) (*repository.Wallet, error)
 		UpdateWallet(ctx context.Context, walletID uuid.UUID, payload *UpdateWalletRequest) (*repository.Wallet, error)
 		DeleteWalletByID(ctx context.Context, walletID uuid.UUID) (*repository.Wallet, error)
 		DeleteWalletsByUserID(ctx context.Context, userID uuid.UUID) ([]repository.Wallet, error)
 		DeleteWalletsByTeamID(ctx context.Context, teamID uuid.UUID) ([]repository.Wallet, error)
+		GetStatement(ctx context.Context, payload *GetStatementRequest) (*statement.Statement, error)
 	}
 
 	WalletSvc struct {
-		Log     logger.Logger
-		Wallet  postgre.WalletRepository
-		CardSvc CardService
+		Log         logger.Logger
+		Wallet      postgre.WalletRepository
+		Transaction postgre.TransactionRepository
+		SpendLimit  postgre.SpendLimitRepository
+		CardSvc     CardService
 	}
 
 	GetWalletsRequest struct {
@@ -40,20 +51,30 @@ type (
 	}
 
 	CreateWalletRequest struct {
-		TeamID uuid.UUID
-		UserID uuid.UUID
+		TeamID   uuid.UUID
+		UserID   uuid.UUID
+		Currency string
 	}
 
 	UpdateWalletRequest struct {
-		BalanceIncrease uint64 `json:"balanceIncrease"`
-		BalanceDecrease uint64 `json:"balanceDecrease"`
-		DailyLimit      uint64
-		MonthlyLimit    uint64
+		BalanceIncrease money.Decimal `json:"balanceIncrease"`
+		BalanceDecrease money.Decimal `json:"balanceDecrease"`
+		DailyLimit      money.Decimal
+		MonthlyLimit    money.Decimal
+		Currency        string
 		TeamID          uuid.UUID
 		UserID          uuid.UUID
 	}
+
+	// GetStatementRequest covers the transactions created in [From, To)
+	GetStatementRequest struct {
+		WalletID uuid.UUID
+		From     time.Time
+		To       time.Time
+	}
 )
 
+// NOTE: The limits are in the minor unit of the wallet currency
 const WALLET_DAILY_LIMIT_DEFAULT = 500000
 const WALLET_MONTHLY_LIMIT_DEFAULT = 500000
 const WALLET_LIMIT_MINIMUM = 10000
@@ -61,16 +82,23 @@ const WALLET_LIMIT_MINIMUM = 10000
 // NewWalletService creates user service
 func NewWalletService(log logger.Logger, db *pg.DB) WalletService {
 	walletRepo := postgre.CreateWalletRepository(log, db)
+	transactionRepo := postgre.CreateTransactionRepository(log, db)
+	spendLimitRepo := postgre.CreateSpendLimitRepository(log, db)
 	cardSvc := NewCardService(log, db)
 
 	return &WalletSvc{
-		Log:     log,
-		Wallet:  walletRepo,
-		CardSvc: cardSvc,
+		Log:         log,
+		Wallet:      walletRepo,
+		Transaction: transactionRepo,
+		SpendLimit:  spendLimitRepo,
+		CardSvc:     cardSvc,
 	}
 }
 
 func (s *WalletSvc) GetAllWallets(ctx context.Context, sortBy string, sort string, skip int, limit int) ([]repository.Wallet, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.GetAllWallets")
+	defer span.End()
+
 	var wallets []repository.Wallet
 
 	wallets, err := s.Wallet.GetAllWallets(ctx, sortBy, sort, skip, limit)
@@ -86,22 +114,65 @@ func (s *WalletSvc) GetAllWallets(ctx context.Context, sortBy string, sort strin
 }
 
 func (s *WalletSvc) GetWallets(ctx context.Context, payload *GetWalletsRequest) ([]repository.Wallet, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.GetWallets")
+	defer span.End()
+
 	wallets, err := s.Wallet.GetWallets(ctx, payload.TeamID, payload.UserID, payload.SortBy, payload.Sort, payload.Skip, payload.Limit)
 	return wallets, err
 }
 
 func (s *WalletSvc) GetWallet(ctx context.Context, walletID uuid.UUID) (*repository.Wallet, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.GetWallet")
+	defer span.End()
+
 	wallet, err := s.Wallet.GetWalletByID(ctx, walletID)
 	return wallet, err
 }
 
+func (s *WalletSvc) GetWalletsByIDs(ctx context.Context, walletIDs []uuid.UUID) ([]repository.Wallet, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.GetWalletsByIDs")
+	defer span.End()
+
+	wallets, err := s.Wallet.GetWalletsByIDs(ctx, walletIDs)
+	return wallets, err
+}
+
+func (s *WalletSvc) GetWalletsByTeamIDs(ctx context.Context, teamIDs []uuid.UUID) ([]repository.Wallet, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.GetWalletsByTeamIDs")
+	defer span.End()
+
+	wallets, err := s.Wallet.GetWalletsByTeamIDs(ctx, teamIDs)
+	return wallets, err
+}
+
+func (s *WalletSvc) GetWalletsByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]repository.Wallet, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.GetWalletsByUserIDs")
+	defer span.End()
+
+	wallets, err := s.Wallet.GetWalletsByUserIDs(ctx, userIDs)
+	return wallets, err
+}
+
 func (s *WalletSvc) CreateWallet(ctx context.Context, payload *CreateWalletRequest) (*repository.Wallet, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.CreateWallet")
+	defer span.End()
+
 	ID := uuid.New()
 
+	if payload.Currency == "" {
+		payload.Currency = money.DefaultCurrency
+	}
+
+	currency, err := money.GetCurrency(payload.Currency)
+	if err != nil {
+		return nil, err
+	}
+
 	walletPayload := repository.Wallet{
 		ID:           ID,
-		DailyLimit:   WALLET_DAILY_LIMIT_DEFAULT,
-		MonthlyLimit: WALLET_MONTHLY_LIMIT_DEFAULT,
+		DailyLimit:   currency.FromMinorUnits(WALLET_DAILY_LIMIT_DEFAULT),
+		MonthlyLimit: currency.FromMinorUnits(WALLET_MONTHLY_LIMIT_DEFAULT),
+		Currency:     currency.Code,
 	}
 
 	if payload.TeamID == uuid.Nil && payload.UserID == uuid.Nil {
@@ -117,10 +188,28 @@ func (s *WalletSvc) CreateWallet(ctx context.Context, payload *CreateWalletReque
 	}
 
 	wallet, err := s.Wallet.CreateWallet(ctx, &walletPayload)
-	return wallet, err
+	if err != nil {
+		return nil, err
+	}
+
+	limits := map[string]money.Decimal{
+		repository.SpendLimitPeriodDaily:   wallet.DailyLimit,
+		repository.SpendLimitPeriodMonthly: wallet.MonthlyLimit,
+	}
+	for period, amount := range limits {
+		err := setCalendarSpendLimit(ctx, s.SpendLimit, wallet.ID, uuid.Nil, period, money.Money{Amount: amount, Currency: wallet.Currency})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return wallet, nil
 }
 
 func (s *WalletSvc) UpdateWallet(ctx context.Context, walletID uuid.UUID, payload *UpdateWalletRequest) (*repository.Wallet, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.UpdateWallet")
+	defer span.End()
+
 	if payload.TeamID == uuid.Nil && payload.UserID == uuid.Nil {
 		return nil, errors.MissingWalletOwner
 	}
@@ -138,50 +227,138 @@ func (s *WalletSvc) UpdateWallet(ctx context.Context, walletID uuid.UUID, payloa
 		return nil, errors.InvalidWalletOwner
 	}
 
+	// NOTE: Amounts and limits are expressed in the wallet currency, other currencies need an explicit conversion
+	if payload.Currency != "" {
+		currency, err := money.GetCurrency(payload.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		if currency.Code != existingWallet.Currency {
+			return nil, errors.CurrencyMismatch
+		}
+	}
+
 	var walletPayload = make(map[string]interface{})
 
-	if payload.BalanceIncrease > 0 && payload.BalanceDecrease > 0 {
+	if payload.BalanceIncrease.Sign() < 0 || payload.BalanceDecrease.Sign() < 0 {
+		return nil, errors.InvalidAmount
+	}
+
+	if payload.BalanceIncrease.Sign() > 0 && payload.BalanceDecrease.Sign() > 0 {
 		return nil, errors.InvalidWalletBalanceOperation
 	}
 
-	if payload.BalanceIncrease > 0 {
-		walletPayload["balance"] = existingWallet.Balance + payload.BalanceIncrease
+	balance := money.Money{Amount: existingWallet.Balance, Currency: existingWallet.Currency}
+
+	// NOTE: Balance changes are recorded as adjustments so the ledger always adds up to the balance
+	var adjustment *repository.Transaction
+
+	if payload.BalanceIncrease.Sign() > 0 {
+		increase, err := money.New(payload.BalanceIncrease, existingWallet.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		balance, err = balance.Add(increase)
+		if err != nil {
+			return nil, err
+		}
+
+		adjustment = newAdjustment(existingWallet, repository.TransactionDirectionCredit, increase)
 	}
 
-	if payload.BalanceDecrease > 0 {
-		balance := existingWallet.Balance - payload.BalanceDecrease
+	if payload.BalanceDecrease.Sign() > 0 {
+		decrease, err := money.New(payload.BalanceDecrease, existingWallet.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		balance, err = balance.Sub(decrease)
+		if err != nil {
+			if er := err.(e.Error); er.Code == errors.InsufficientAmount.Code {
+				return nil, errors.NotEnoughBalance
+			}
 
-		if balance > existingWallet.Balance {
-			return nil, errors.NotEnoughBalance
+			return nil, err
 		}
 
-		walletPayload["balance"] = balance
+		adjustment = newAdjustment(existingWallet, repository.TransactionDirectionDebit, decrease)
 	}
 
+	limits := map[string]money.Money{}
+
 	// NOTE: Only for team
 	if payload.TeamID != uuid.Nil {
-		if payload.DailyLimit > 0 && payload.DailyLimit < WALLET_LIMIT_MINIMUM {
-			return nil, errors.InvalidDailyLimit
+		currency, err := money.GetCurrency(existingWallet.Currency)
+		if err != nil {
+			return nil, err
 		}
 
-		if payload.MonthlyLimit > 0 && payload.MonthlyLimit < WALLET_LIMIT_MINIMUM {
-			return nil, errors.InvalidMonthlyLimit
+		minimum := currency.FromMinorUnits(WALLET_LIMIT_MINIMUM)
+
+		if !payload.DailyLimit.IsZero() {
+			dailyLimit, err := money.New(payload.DailyLimit, existingWallet.Currency)
+			if err != nil {
+				return nil, err
+			}
+
+			if dailyLimit.Amount.Cmp(minimum) < 0 {
+				return nil, errors.InvalidDailyLimit
+			}
+
+			walletPayload["daily_limit"] = dailyLimit.Amount
+			limits[repository.SpendLimitPeriodDaily] = dailyLimit
 		}
 
-		if payload.DailyLimit >= WALLET_LIMIT_MINIMUM {
-			walletPayload["daily_limit"] = payload.DailyLimit
+		if !payload.MonthlyLimit.IsZero() {
+			monthlyLimit, err := money.New(payload.MonthlyLimit, existingWallet.Currency)
+			if err != nil {
+				return nil, err
+			}
+
+			if monthlyLimit.Amount.Cmp(minimum) < 0 {
+				return nil, errors.InvalidMonthlyLimit
+			}
+
+			walletPayload["monthly_limit"] = monthlyLimit.Amount
+			limits[repository.SpendLimitPeriodMonthly] = monthlyLimit
 		}
+	}
 
-		if payload.MonthlyLimit >= WALLET_LIMIT_MINIMUM {
-			walletPayload["monthly_limit"] = payload.MonthlyLimit
+	for period, amount := range limits {
+		err := setCalendarSpendLimit(ctx, s.SpendLimit, walletID, uuid.Nil, period, amount)
+		if err != nil {
+			return nil, err
 		}
 	}
 
 	wallet, err := s.Wallet.UpdateWallet(ctx, walletID, walletPayload)
-	return wallet, err
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: The adjustment is applied last, a request failing before it can be
+	// retried without moving the balance twice
+	if adjustment != nil {
+		_, err := s.Transaction.CreateAdjustment(ctx, adjustment)
+		if err != nil {
+			return nil, err
+		}
+
+		wallet, err = s.Wallet.GetWalletByID(ctx, walletID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return wallet, nil
 }
 
 func (s *WalletSvc) DeleteWalletByID(ctx context.Context, walletID uuid.UUID) (*repository.Wallet, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.DeleteWalletByID")
+	defer span.End()
+
 	wallet, err := s.Wallet.DeleteWalletByID(ctx, walletID)
 	if err != nil {
 		return nil, err
@@ -196,6 +373,9 @@ func (s *WalletSvc) DeleteWalletByID(ctx context.Context, walletID uuid.UUID) (*
 }
 
 func (s *WalletSvc) DeleteWalletsByUserID(ctx context.Context, userID uuid.UUID) ([]repository.Wallet, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.DeleteWalletsByUserID")
+	defer span.End()
+
 	wallets, err := s.Wallet.DeleteWalletsByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -210,6 +390,9 @@ func (s *WalletSvc) DeleteWalletsByUserID(ctx context.Context, userID uuid.UUID)
 }
 
 func (s *WalletSvc) DeleteWalletsByTeamID(ctx context.Context, teamID uuid.UUID) ([]repository.Wallet, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.DeleteWalletsByTeamID")
+	defer span.End()
+
 	wallets, err := s.Wallet.DeleteWalletsByTeamID(ctx, teamID)
 	if err != nil {
 		return nil, err
@@ -224,6 +407,9 @@ func (s *WalletSvc) DeleteWalletsByTeamID(ctx context.Context, teamID uuid.UUID)
 }
 
 func (s *WalletSvc) DeleteCardsByWalletID(ctx context.Context, wallets []repository.Wallet) error {
+	ctx, span := tracing.Start(ctx, "WalletService.DeleteCardsByWalletID")
+	defer span.End()
+
 	deleteCardsError := errors.FailedCardsDelete
 
 	for _, wallet := range wallets {
@@ -235,3 +421,69 @@ func (s *WalletSvc) DeleteCardsByWalletID(ctx context.Context, wallets []reposit
 
 	return deleteCardsError
 }
+
+// GetStatement prepares the statement of a wallet, the entries are only read
+// from the database once the statement is written.
+func (s *WalletSvc) GetStatement(ctx context.Context, payload *GetStatementRequest) (*statement.Statement, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.GetStatement")
+	defer span.End()
+
+	if !payload.From.Before(payload.To) {
+		return nil, errors.InvalidStatementPeriod
+	}
+
+	wallet, err := s.Wallet.GetWalletByID(ctx, payload.WalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	openingBalance, err := s.Transaction.GetBalanceAt(ctx, wallet.ID, payload.From)
+	if err != nil {
+		return nil, err
+	}
+
+	return &statement.Statement{
+		WalletID:       wallet.ID.String(),
+		Currency:       wallet.Currency,
+		From:           payload.From,
+		To:             payload.To,
+		OpeningBalance: openingBalance,
+		Entries: func(fn func(statement.Entry) error) error {
+			return s.Transaction.ForEachTransaction(ctx, wallet.ID, payload.From, payload.To, func(transaction *repository.Transaction) error {
+				return fn(newStatementEntry(transaction))
+			})
+		},
+	}, nil
+}
+
+func newAdjustment(wallet *repository.Wallet, direction string, amount money.Money) *repository.Transaction {
+	return &repository.Transaction{
+		ID:          uuid.New(),
+		ReferenceID: uuid.New(),
+		WalletID:    wallet.ID,
+		Type:        repository.TransactionTypeAdjustment,
+		Direction:   direction,
+		Amount:      amount.Amount,
+		Currency:    amount.Currency,
+	}
+}
+
+func newStatementEntry(transaction *repository.Transaction) statement.Entry {
+	amount := transaction.Amount
+	if transaction.Direction == repository.TransactionDirectionDebit {
+		amount = amount.Neg()
+	}
+
+	description := transaction.Description
+	if description == "" {
+		description = transaction.Merchant
+	}
+
+	return statement.Entry{
+		Date:        transaction.CreatedAt,
+		Reference:   transaction.ReferenceID.String(),
+		Type:        transaction.Type,
+		Description: description,
+		Amount:      amount,
+	}
+}
@@ -1,11 +1,15 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+	"gitlab.com/renodesper/spenmo-test/util/statement"
 )
 
 func TestGetAllWalletsIntegration(t *testing.T) {
@@ -214,6 +218,7 @@ func TestCreateWalletIntegration(t *testing.T) {
 	cases := map[string]struct {
 		TeamID        string
 		UserID        string
+		Currency      string
 		ExpectedError bool
 	}{
 		"SuccessCreateWalletByTeamID": {
@@ -224,6 +229,16 @@ func TestCreateWalletIntegration(t *testing.T) {
 			UserID:        "0e49e11c-660c-43c5-954e-ef9e89b45833",
 			ExpectedError: false,
 		},
+		"SuccessCreateWalletWithCurrency": {
+			TeamID:        "933efe12-2219-42df-bd51-a2e84888432d",
+			Currency:      "usd",
+			ExpectedError: false,
+		},
+		"FailedInvalidCurrency": {
+			TeamID:        "933efe12-2219-42df-bd51-a2e84888432d",
+			Currency:      "XYZ",
+			ExpectedError: true,
+		},
 		"FailedMissingWalletOwner": {
 			TeamID:        "",
 			UserID:        "",
@@ -236,7 +251,9 @@ func TestCreateWalletIntegration(t *testing.T) {
 			ctx := context.Background()
 			svc := NewWalletService(Log, DB)
 
-			payload := CreateWalletRequest{}
+			payload := CreateWalletRequest{
+				Currency: tc.Currency,
+			}
 
 			var err error
 			var teamID uuid.UUID
@@ -291,37 +308,37 @@ func TestUpdateWalletIntegration(t *testing.T) {
 		ID              string
 		TeamID          string
 		UserID          string
-		BalanceIncrease uint64
-		BalanceDecrease uint64
-		DailyLimit      uint64
-		MonthlyLimit    uint64
+		BalanceIncrease money.Decimal
+		BalanceDecrease money.Decimal
+		DailyLimit      money.Decimal
+		MonthlyLimit    money.Decimal
 		ExpectedError   bool
 	}{
 		"SuccessUpdateUser": {
 			ID:              "d4a6607a-1af7-4571-bdff-2672be72ba0e",
 			TeamID:          "933efe12-2219-42df-bd51-a2e84888432d",
 			UserID:          "",
-			BalanceIncrease: 1000,
-			DailyLimit:      200000,
-			MonthlyLimit:    300000,
+			BalanceIncrease: money.NewDecimal(1000, 0),
+			DailyLimit:      money.NewDecimal(200000, 0),
+			MonthlyLimit:    money.NewDecimal(300000, 0),
 			ExpectedError:   false,
 		},
 		"FailedMissingWalletOwner": {
 			ID:              "d4a6607a-1af7-4571-bdff-2672be72ba0e",
 			TeamID:          "",
 			UserID:          "",
-			BalanceIncrease: 1000,
-			DailyLimit:      200000,
-			MonthlyLimit:    300000,
+			BalanceIncrease: money.NewDecimal(1000, 0),
+			DailyLimit:      money.NewDecimal(200000, 0),
+			MonthlyLimit:    money.NewDecimal(300000, 0),
 			ExpectedError:   true,
 		},
 		"FailedInvalidWalletOwner": {
 			ID:              "d4a6607a-1af7-4571-bdff-2672be72ba0e",
 			TeamID:          "933efe12-2219-42df-bd51-a2e84888432d",
 			UserID:          "0e49e11c-660c-43c5-954e-ef9e89b45833",
-			BalanceIncrease: 1000,
-			DailyLimit:      200000,
-			MonthlyLimit:    300000,
+			BalanceIncrease: money.NewDecimal(1000, 0),
+			DailyLimit:      money.NewDecimal(200000, 0),
+			MonthlyLimit:    money.NewDecimal(300000, 0),
 			ExpectedError:   true,
 		},
 	}
@@ -378,9 +395,11 @@ func TestUpdateWalletIntegration(t *testing.T) {
 				assert.NoError(t, err)
 				assert.NotEmpty(t, wallet)
 
-				assert.Equal(t, tc.BalanceIncrease, updatedWallet.Balance-currentBalance)
-				assert.Equal(t, tc.DailyLimit, wallet.DailyLimit)
-				assert.Equal(t, tc.MonthlyLimit, wallet.MonthlyLimit)
+				increase, err := updatedWallet.Balance.Sub(currentBalance)
+				assert.NoError(t, err)
+				assert.Zero(t, tc.BalanceIncrease.Cmp(increase))
+				assert.Zero(t, tc.DailyLimit.Cmp(wallet.DailyLimit))
+				assert.Zero(t, tc.MonthlyLimit.Cmp(wallet.MonthlyLimit))
 			}
 		})
 	}
@@ -389,65 +408,65 @@ func TestUpdateWalletIntegration(t *testing.T) {
 		ID              string
 		TeamID          string
 		UserID          string
-		BalanceIncrease uint64
-		BalanceDecrease uint64
-		DailyLimit      uint64
-		MonthlyLimit    uint64
+		BalanceIncrease money.Decimal
+		BalanceDecrease money.Decimal
+		DailyLimit      money.Decimal
+		MonthlyLimit    money.Decimal
 		ExpectedError   bool
 	}{
 		"FailedWalletNotFound": {
 			ID:              "d4a6607a-1af7-4571-bdff-2672be72ba0f",
 			TeamID:          "933efe12-2219-42df-bd51-a2e84888432d",
 			UserID:          "",
-			BalanceIncrease: 1000,
-			DailyLimit:      200000,
-			MonthlyLimit:    300000,
+			BalanceIncrease: money.NewDecimal(1000, 0),
+			DailyLimit:      money.NewDecimal(200000, 0),
+			MonthlyLimit:    money.NewDecimal(300000, 0),
 			ExpectedError:   true,
 		},
 		"FailedInvalidWalletOwner": {
 			ID:              "d4a6607a-1af7-4571-bdff-2672be72ba0e",
 			TeamID:          "933efe12-2219-42df-bd51-a2e84888432e",
 			UserID:          "",
-			BalanceIncrease: 1000,
-			DailyLimit:      200000,
-			MonthlyLimit:    300000,
+			BalanceIncrease: money.NewDecimal(1000, 0),
+			DailyLimit:      money.NewDecimal(200000, 0),
+			MonthlyLimit:    money.NewDecimal(300000, 0),
 			ExpectedError:   true,
 		},
 		"FailedInvalidWalletBalanceOperation": {
 			ID:              "d4a6607a-1af7-4571-bdff-2672be72ba0e",
 			TeamID:          "933efe12-2219-42df-bd51-a2e84888432d",
 			UserID:          "",
-			BalanceIncrease: 1000,
-			BalanceDecrease: 1000,
-			DailyLimit:      200000,
-			MonthlyLimit:    300000,
+			BalanceIncrease: money.NewDecimal(1000, 0),
+			BalanceDecrease: money.NewDecimal(1000, 0),
+			DailyLimit:      money.NewDecimal(200000, 0),
+			MonthlyLimit:    money.NewDecimal(300000, 0),
 			ExpectedError:   true,
 		},
 		"FailedNotEnoughBalance": {
 			ID:              "d4a6607a-1af7-4571-bdff-2672be72ba0e",
 			TeamID:          "933efe12-2219-42df-bd51-a2e84888432d",
 			UserID:          "",
-			BalanceDecrease: 10000,
-			DailyLimit:      200000,
-			MonthlyLimit:    300000,
+			BalanceDecrease: money.NewDecimal(10000, 0),
+			DailyLimit:      money.NewDecimal(200000, 0),
+			MonthlyLimit:    money.NewDecimal(300000, 0),
 			ExpectedError:   true,
 		},
 		"FailedInvalidDailyLimit": {
 			ID:              "d4a6607a-1af7-4571-bdff-2672be72ba0e",
 			TeamID:          "933efe12-2219-42df-bd51-a2e84888432d",
 			UserID:          "",
-			BalanceDecrease: 1000,
-			DailyLimit:      5000,
-			MonthlyLimit:    300000,
+			BalanceDecrease: money.NewDecimal(1000, 0),
+			DailyLimit:      money.MustParseDecimal("50.00"),
+			MonthlyLimit:    money.NewDecimal(300000, 0),
 			ExpectedError:   true,
 		},
 		"FailedInvalidMonthlyLimit": {
 			ID:              "d4a6607a-1af7-4571-bdff-2672be72ba0e",
 			TeamID:          "933efe12-2219-42df-bd51-a2e84888432d",
 			UserID:          "",
-			BalanceDecrease: 1000,
-			DailyLimit:      300000,
-			MonthlyLimit:    5000,
+			BalanceDecrease: money.NewDecimal(1000, 0),
+			DailyLimit:      money.NewDecimal(300000, 0),
+			MonthlyLimit:    money.MustParseDecimal("50.00"),
 			ExpectedError:   true,
 		},
 	}
@@ -655,3 +674,76 @@ func TestDeleteWalletsByTeamIDIntegration(t *testing.T) {
 		})
 	}
 }
+
+func TestGetStatementIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	reinitializeDB()
+
+	ctx := context.Background()
+	svc := NewWalletService(Log, DB)
+
+	walletID := "d4a6607a-1af7-4571-bdff-2672be72ba0e"
+	teamID := "933efe12-2219-42df-bd51-a2e84888432d"
+
+	topUpTeamWallet(t, ctx, walletID, teamID, money.MustParseDecimal("100.00"))
+	start := time.Now()
+	topUpTeamWallet(t, ctx, walletID, teamID, money.MustParseDecimal("50.00"))
+
+	_, err := svc.UpdateWallet(ctx, uuid.MustParse(walletID), &UpdateWalletRequest{
+		BalanceDecrease: money.MustParseDecimal("20.50"),
+		TeamID:          uuid.MustParse(teamID),
+	})
+	assert.NoError(t, err)
+
+	cases := map[string]struct {
+		From                   time.Time
+		To                     time.Time
+		ExpectedOpeningBalance string
+		ExpectedCSV            []string
+		ExpectedError          bool
+	}{
+		"SuccessGetStatement": {
+			From:                   start,
+			To:                     time.Now().Add(time.Hour),
+			ExpectedOpeningBalance: "100.00",
+			ExpectedCSV:            []string{",,50.00,150.00,SGD", ",20.50,,129.50,SGD", "Closing balance,20.50,50.00,129.50,SGD"},
+			ExpectedError:          false,
+		},
+		"FailedInvalidStatementPeriod": {
+			From:          time.Now(),
+			To:            start,
+			ExpectedError: true,
+		},
+	}
+
+	for v, tc := range cases {
+		t.Run(v, func(t *testing.T) {
+			payload := GetStatementRequest{
+				WalletID: uuid.MustParse(walletID),
+				From:     tc.From,
+				To:       tc.To,
+			}
+
+			walletStatement, err := svc.GetStatement(ctx, &payload)
+
+			if tc.ExpectedError {
+				assert.Error(t, err)
+				assert.Nil(t, walletStatement)
+			} else {
+				assert.NoError(t, err)
+				assert.Zero(t, money.MustParseDecimal(tc.ExpectedOpeningBalance).Cmp(walletStatement.OpeningBalance))
+
+				var b bytes.Buffer
+				err = walletStatement.Write(statement.NewCSVWriter(&b))
+				assert.NoError(t, err)
+
+				for _, line := range tc.ExpectedCSV {
+					assert.Contains(t, b.String(), line)
+				}
+			}
+		})
+	}
+}
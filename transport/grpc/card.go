@@ -0,0 +1,356 @@
+package grpc
+
+import (
+	"context"
+
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	"gitlab.com/renodesper/spenmo-test/endpoint"
+	m "gitlab.com/renodesper/spenmo-test/middleware"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/transport/grpc/pb"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/validation"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type cardServer struct {
+	pb.UnimplementedCardServiceServer
+
+	getAllCards           grpctransport.Handler
+	getCard               grpctransport.Handler
+	createCard            grpctransport.Handler
+	updateCard            grpctransport.Handler
+	deleteCard            grpctransport.Handler
+	deleteCardsByWalletID grpctransport.Handler
+	freezeCard            grpctransport.Handler
+	unfreezeCard          grpctransport.Handler
+	getCardAudits         grpctransport.Handler
+}
+
+func newCardServer(endpoints endpoint.Set, middlewares m.Factory, opts []grpctransport.ServerOption) pb.CardServiceServer {
+	return &cardServer{
+		getAllCards:           newHandler(middlewares("GetAllCards"), endpoints.GetAllCardsEndpoint, decodeGetAllCardsRequest, encodeGetAllCardsResponse, opts),
+		getCard:               newHandler(middlewares("GetCard"), endpoints.GetCardEndpoint, decodeGetCardRequest, encodeCardResponse, opts),
+		createCard:            newHandler(middlewares("CreateCard"), endpoints.CreateCardEndpoint, decodeCreateCardRequest, encodeCardResponse, opts),
+		updateCard:            newHandler(middlewares("UpdateCard"), endpoints.UpdateCardEndpoint, decodeUpdateCardRequest, encodeCardResponse, opts),
+		deleteCard:            newHandler(middlewares("DeleteCardByID"), endpoints.DeleteCardByIDEndpoint, decodeDeleteCardRequest, encodeCardResponse, opts),
+		deleteCardsByWalletID: newHandler(middlewares("DeleteCardsByWalletID"), endpoints.DeleteCardsByWalletIDEndpoint, decodeDeleteCardsByWalletIDRequest, encodeCardsResponse, opts),
+		freezeCard:            newHandler(middlewares("FreezeCard"), endpoints.FreezeCardEndpoint, decodeFreezeCardRequest, encodeCardResponse, opts),
+		unfreezeCard:          newHandler(middlewares("UnfreezeCard"), endpoints.UnfreezeCardEndpoint, decodeFreezeCardRequest, encodeCardResponse, opts),
+		getCardAudits:         newHandler(middlewares("GetCardAudits"), endpoints.GetCardAuditsEndpoint, decodeGetCardAuditsRequest, encodeGetCardAuditsResponse, opts),
+	}
+}
+
+func (s *cardServer) GetAllCards(ctx context.Context, req *pb.GetAllCardsRequest) (*pb.GetAllCardsResponse, error) {
+	res, err := serve(ctx, s.getAllCards, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.GetAllCardsResponse), nil
+}
+
+func (s *cardServer) GetCard(ctx context.Context, req *pb.GetCardRequest) (*pb.Card, error) {
+	res, err := serve(ctx, s.getCard, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.Card), nil
+}
+
+func (s *cardServer) CreateCard(ctx context.Context, req *pb.CreateCardRequest) (*pb.Card, error) {
+	res, err := serve(ctx, s.createCard, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.Card), nil
+}
+
+func (s *cardServer) UpdateCard(ctx context.Context, req *pb.UpdateCardRequest) (*pb.Card, error) {
+	res, err := serve(ctx, s.updateCard, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.Card), nil
+}
+
+func (s *cardServer) DeleteCard(ctx context.Context, req *pb.DeleteCardRequest) (*pb.Card, error) {
+	res, err := serve(ctx, s.deleteCard, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.Card), nil
+}
+
+func (s *cardServer) DeleteCardsByWalletID(ctx context.Context, req *pb.DeleteCardsByWalletIDRequest) (*pb.Cards, error) {
+	res, err := serve(ctx, s.deleteCardsByWalletID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.Cards), nil
+}
+
+func (s *cardServer) FreezeCard(ctx context.Context, req *pb.FreezeCardRequest) (*pb.Card, error) {
+	res, err := serve(ctx, s.freezeCard, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.Card), nil
+}
+
+func (s *cardServer) UnfreezeCard(ctx context.Context, req *pb.FreezeCardRequest) (*pb.Card, error) {
+	res, err := serve(ctx, s.unfreezeCard, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.Card), nil
+}
+
+func (s *cardServer) GetCardAudits(ctx context.Context, req *pb.GetCardAuditsRequest) (*pb.GetCardAuditsResponse, error) {
+	res, err := serve(ctx, s.getCardAudits, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.GetCardAuditsResponse), nil
+}
+
+func decodeGetAllCardsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.GetAllCardsRequest)
+
+	var req endpoint.GetAllCardsRequest
+	req.SortBy, req.Sort, req.Skip, req.Limit = decodePagination(r.Pagination)
+
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func decodeGetCardRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.GetCardRequest)
+
+	ID, err := parseID(r.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint.GetCardRequest{ID: ID}, nil
+}
+
+func decodeCreateCardRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.CreateCardRequest)
+
+	walletID, err := parseID(r.WalletId)
+	if err != nil {
+		return nil, err
+	}
+
+	req := endpoint.CreateCardRequest{
+		CardNo:      r.CardNo,
+		ExpiryMonth: r.ExpiryMonth,
+		ExpiryYear:  r.ExpiryYear,
+		CVV:         r.Cvv,
+		Currency:    r.Currency,
+		Type:        r.Type,
+		WalletID:    walletID,
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func decodeUpdateCardRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.UpdateCardRequest)
+
+	ID, err := parseID(r.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	walletID, err := parseOptionalID(r.WalletId)
+	if err != nil {
+		return nil, err
+	}
+
+	req := endpoint.UpdateCardRequest{
+		ID:          ID,
+		WalletID:    walletID,
+		CardNo:      r.CardNo,
+		ExpiryMonth: r.ExpiryMonth,
+		ExpiryYear:  r.ExpiryYear,
+		CVV:         r.Cvv,
+	}
+
+	if req.DailyLimit, err = parseDecimal(r.DailyLimit); err != nil {
+		return nil, err
+	}
+	if req.MonthlyLimit, err = parseDecimal(r.MonthlyLimit); err != nil {
+		return nil, err
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func decodeDeleteCardRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.DeleteCardRequest)
+
+	ID, err := parseID(r.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint.DeleteCardByIDRequest{ID: ID}, nil
+}
+
+func decodeDeleteCardsByWalletIDRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.DeleteCardsByWalletIDRequest)
+
+	walletID, err := parseID(r.WalletId)
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint.DeleteCardsByWalletIDRequest{WalletID: walletID}, nil
+}
+
+func decodeFreezeCardRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.FreezeCardRequest)
+
+	ID, err := parseID(r.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint.FreezeCardRequest{ID: ID, Reason: r.Reason}, nil
+}
+
+func decodeGetCardAuditsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.GetCardAuditsRequest)
+
+	ID, err := parseID(r.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	req := endpoint.GetCardAuditsRequest{
+		ID:    ID,
+		Skip:  int(r.Skip),
+		Limit: 10,
+	}
+	if r.Limit != 0 {
+		req.Limit = int(r.Limit)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func encodeGetAllCardsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res := response.(map[string]interface{})
+	cards, _ := res["cards"].([]repository.Card)
+
+	return &pb.GetAllCardsResponse{
+		Cards:      encodeCards(cards),
+		Pagination: encodePagination(res),
+	}, nil
+}
+
+func encodeGetCardAuditsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res := response.(map[string]interface{})
+	audits, _ := res["audits"].([]repository.CardAudit)
+
+	pbAudits := make([]*pb.CardAudit, 0, len(audits))
+	for i := range audits {
+		audit, err := encodeCardAudit(&audits[i])
+		if err != nil {
+			return nil, err
+		}
+
+		pbAudits = append(pbAudits, audit)
+	}
+
+	return &pb.GetCardAuditsResponse{
+		Audits:     pbAudits,
+		Pagination: encodePagination(res),
+	}, nil
+}
+
+func encodeCardsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	return &pb.Cards{Cards: encodeCards(response.([]repository.Card))}, nil
+}
+
+func encodeCardResponse(_ context.Context, response interface{}) (interface{}, error) {
+	return encodeCard(response.(*repository.Card)), nil
+}
+
+func encodeCards(cards []repository.Card) []*pb.Card {
+	pbCards := make([]*pb.Card, 0, len(cards))
+	for i := range cards {
+		pbCards = append(pbCards, encodeCard(&cards[i]))
+	}
+
+	return pbCards
+}
+
+func encodeCard(card *repository.Card) *pb.Card {
+	return &pb.Card{
+		Id:             encodeID(card.ID),
+		CardNo:         card.CardNo,
+		ExpiryMonth:    card.ExpiryMonth,
+		ExpiryYear:     card.ExpiryYear,
+		Cvv:            card.CVV,
+		DailyLimit:     card.DailyLimit.String(),
+		MonthlyLimit:   card.MonthlyLimit.String(),
+		Currency:       card.Currency,
+		Type:           card.Type,
+		LockedMerchant: card.LockedMerchant,
+		WalletId:       encodeID(card.WalletID),
+		IsFrozen:       card.IsFrozen,
+		IsClosed:       card.IsClosed,
+		IsDeleted:      card.IsDeleted,
+		CreatedAt:      encodeTime(card.CreatedAt),
+		UpdatedAt:      encodeTime(card.UpdatedAt),
+	}
+}
+
+func encodeCardAudit(audit *repository.CardAudit) (*pb.CardAudit, error) {
+	pbAudit := &pb.CardAudit{
+		Id:        encodeID(audit.ID),
+		CardId:    encodeID(audit.CardID),
+		Action:    audit.Action,
+		Rule:      audit.Rule,
+		Reason:    audit.Reason,
+		UserId:    encodeID(audit.UserID),
+		CreatedAt: encodeTime(audit.CreatedAt),
+	}
+
+	if len(audit.Details) > 0 {
+		details, err := structpb.NewStruct(audit.Details)
+		if err != nil {
+			return nil, errors.UnencodableResponse.AppendError(err)
+		}
+
+		pbAudit.Details = details
+	}
+
+	return pbAudit, nil
+}
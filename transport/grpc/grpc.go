@@ -0,0 +1,263 @@
+package grpc
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	kitendpoint "github.com/go-kit/kit/endpoint"
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	"github.com/google/uuid"
+	"github.com/iancoleman/strcase"
+	"github.com/spf13/viper"
+	"gitlab.com/renodesper/spenmo-test/endpoint"
+	m "gitlab.com/renodesper/spenmo-test/middleware"
+	"gitlab.com/renodesper/spenmo-test/middleware/metric"
+	"gitlab.com/renodesper/spenmo-test/middleware/recover"
+	"gitlab.com/renodesper/spenmo-test/middleware/tracing"
+	"gitlab.com/renodesper/spenmo-test/transport/grpc/pb"
+	ctxUtil "gitlab.com/renodesper/spenmo-test/util/ctx"
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+	"gitlab.com/renodesper/spenmo-test/util/ratelimit"
+	"gitlab.com/renodesper/spenmo-test/util/validation"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var validate = validation.New()
+
+// errorDomain is set on the ErrorInfo detail of every status returned, its
+// reason holds the code of the error
+const errorDomain = "spenmo"
+
+// NewGRPCServer serves the endpoints of users, teams, team members, wallets
+// and cards, limiter is nil when the calls are not rate limited and metrics is
+// nil when they are not measured
+func NewGRPCServer(endpoints endpoint.Set, log logger.Logger, limiter *ratelimit.Limiter, metrics *metric.Metrics) *grpc.Server {
+	// NOTE: Will be executed on the request metadata before the request is decoded
+	serverOpts := []grpctransport.ServerOption{
+		grpctransport.ServerBefore(ctxUtil.ExtractGRPCRequestID, ctxUtil.ExtractGRPCUserID),
+	}
+
+	// NOTE: Middlewares
+	publicMiddlewares := prepareMiddleware(log, metrics)
+
+	interceptors := []grpc.UnaryServerInterceptor{traced()}
+	if limiter != nil {
+		interceptors = append(interceptors, rateLimit(log, limiter))
+	}
+
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptors...))
+	pb.RegisterUserServiceServer(server, newUserServer(endpoints, publicMiddlewares, serverOpts))
+	pb.RegisterTeamServiceServer(server, newTeamServer(endpoints, publicMiddlewares, serverOpts))
+	pb.RegisterTeamMemberServiceServer(server, newTeamMemberServer(endpoints, publicMiddlewares, serverOpts))
+	pb.RegisterWalletServiceServer(server, newWalletServer(endpoints, publicMiddlewares, serverOpts))
+	pb.RegisterCardServiceServer(server, newCardServer(endpoints, publicMiddlewares, serverOpts))
+
+	return server
+}
+
+// newHandler wraps the endpoint with the middlewares, as the HTTP transport
+// does
+func newHandler(middlewares m.Middlewares, ep kitendpoint.Endpoint, dec grpctransport.DecodeRequestFunc, enc grpctransport.EncodeResponseFunc, opts []grpctransport.ServerOption) grpctransport.Handler {
+	return grpctransport.NewServer(m.Chain(middlewares)(ep), dec, enc, opts...)
+}
+
+// serve runs the handler and converts its error to a gRPC status
+func serve(ctx context.Context, handler grpctransport.Handler, req interface{}) (interface{}, error) {
+	_, res, err := handler.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res, nil
+}
+
+// encodeError maps the HTTP status of the error to a gRPC code, the code of
+// the error is kept as the reason of an ErrorInfo detail
+func encodeError(err error) error {
+	er, ok := err.(e.Error)
+	if !ok {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	st := status.New(codeFromStatus(er.Status), er.Message)
+
+	info := &errdetails.ErrorInfo{
+		Reason: er.Code,
+		Domain: errorDomain,
+	}
+	if viper.GetString("app.env") != "production" && er.Trace != "" {
+		info.Metadata = map[string]string{"trace": er.Trace}
+	}
+
+	if detailed, err := st.WithDetails(info); err == nil {
+		st = detailed
+	}
+
+	// NOTE: The field details of a validation error are sent as BadRequest
+	if len(er.Details) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, detail := range er.Details {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       detail.Field,
+				Description: detail.Message,
+			})
+		}
+
+		if detailed, err := st.WithDetails(badRequest); err == nil {
+			st = detailed
+		}
+	}
+
+	return st.Err()
+}
+
+func codeFromStatus(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusPreconditionFailed, http.StatusUnprocessableEntity:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+
+	if httpStatus >= 400 && httpStatus < 500 {
+		return codes.InvalidArgument
+	}
+
+	return codes.Internal
+}
+
+func prepareMiddleware(log logger.Logger, metrics *metric.Metrics) m.Factory {
+	return func(method string) m.Middlewares {
+		publicMiddlewares := m.Middlewares{
+			Before: []kitendpoint.Middleware{
+				tracing.CreateMiddleware(log, "grpc", method),
+				recover.CreateMiddleware(log),
+			},
+			After: []kitendpoint.Middleware{},
+		}
+
+		// NOTE: Metric
+		if metrics != nil {
+			publicMiddlewares.Before = append(publicMiddlewares.Before, metric.CreateMiddleware(log, metrics, "grpc", method))
+		}
+
+		return publicMiddlewares
+	}
+}
+
+func parseID(s string) (uuid.UUID, error) {
+	ID, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, errors.UnparsableUUID.AppendError(err)
+	}
+
+	return ID, nil
+}
+
+// parseOptionalID returns uuid.Nil when s is empty
+func parseOptionalID(s string) (uuid.UUID, error) {
+	if s == "" {
+		return uuid.Nil, nil
+	}
+
+	return parseID(s)
+}
+
+// parseDecimal returns zero when s is empty
+func parseDecimal(s string) (money.Decimal, error) {
+	if s == "" {
+		return money.Decimal{}, nil
+	}
+
+	d, err := money.ParseDecimal(s)
+	if err != nil {
+		return money.Decimal{}, errors.InvalidRequest.AppendError(err)
+	}
+
+	return d, nil
+}
+
+// decodePagination sets the defaults of the HTTP transport on the fields left
+// empty
+func decodePagination(p *pb.Pagination) (sortBy string, sort string, skip int, limit int) {
+	sortBy = "created_at"
+	sort = "DESC"
+	limit = 10
+
+	if p == nil {
+		return sortBy, sort, skip, limit
+	}
+
+	if p.SortBy != "" {
+		sortBy = strcase.ToSnake(p.SortBy)
+	}
+	if p.Sort != "" {
+		sort = p.Sort
+	}
+	if p.Skip != 0 {
+		skip = int(p.Skip)
+	}
+	if p.Limit != 0 {
+		limit = int(p.Limit)
+	}
+
+	return sortBy, sort, skip, limit
+}
+
+// encodePagination reads the pagination of the list responses of the
+// endpoints
+func encodePagination(response map[string]interface{}) *pb.Pagination {
+	pagination, _ := response["pagination"].(map[string]interface{})
+
+	sortBy, _ := pagination["sortBy"].(string)
+	sort, _ := pagination["sort"].(string)
+	skip, _ := pagination["skip"].(int)
+	limit, _ := pagination["limit"].(int)
+
+	return &pb.Pagination{
+		SortBy: sortBy,
+		Sort:   sort,
+		Skip:   int32(skip),
+		Limit:  int32(limit),
+	}
+}
+
+func encodeID(ID uuid.UUID) string {
+	if ID == uuid.Nil {
+		return ""
+	}
+
+	return ID.String()
+}
+
+func encodeTime(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
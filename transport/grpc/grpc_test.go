@@ -0,0 +1,152 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/endpoint"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/transport/grpc/pb"
+	ctxUtil "gitlab.com/renodesper/spenmo-test/util/ctx"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger/noop"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestCodeFromStatus(t *testing.T) {
+	cases := map[string]struct {
+		Status       int
+		ExpectedCode codes.Code
+	}{
+		"BadRequest":       {Status: http.StatusBadRequest, ExpectedCode: codes.InvalidArgument},
+		"Unauthorized":     {Status: http.StatusUnauthorized, ExpectedCode: codes.Unauthenticated},
+		"Forbidden":        {Status: http.StatusForbidden, ExpectedCode: codes.PermissionDenied},
+		"NotFound":         {Status: http.StatusNotFound, ExpectedCode: codes.NotFound},
+		"Conflict":         {Status: http.StatusConflict, ExpectedCode: codes.AlreadyExists},
+		"Unprocessable":    {Status: http.StatusUnprocessableEntity, ExpectedCode: codes.FailedPrecondition},
+		"TooManyRequests":  {Status: http.StatusTooManyRequests, ExpectedCode: codes.ResourceExhausted},
+		"OtherClientError": {Status: http.StatusTeapot, ExpectedCode: codes.InvalidArgument},
+		"ServerError":      {Status: http.StatusInternalServerError, ExpectedCode: codes.Internal},
+		"NoStatus":         {Status: 0, ExpectedCode: codes.Internal},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.ExpectedCode, codeFromStatus(tc.Status))
+		})
+	}
+}
+
+func TestGRPCServer(t *testing.T) {
+	userID := uuid.MustParse("8e159833-5078-4b0a-80a0-363d82bafd60")
+	walletID := uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e")
+	createdAt := time.Date(2021, 7, 1, 8, 0, 0, 0, time.UTC)
+
+	endpoints := endpoint.Set{
+		GetUserByIDEndpoint: func(ctx context.Context, request interface{}) (interface{}, error) {
+			req := request.(endpoint.GetUserRequest)
+			if req.ID != ctxUtil.GetUserID(ctx) {
+				return nil, errors.FailedUserNotFound
+			}
+
+			return &repository.User{ID: req.ID, Email: "admin@spenmo.com", CreatedAt: createdAt}, nil
+		},
+		GetAllWalletsEndpoint: func(ctx context.Context, request interface{}) (interface{}, error) {
+			req := request.(endpoint.GetAllWalletsRequest)
+
+			wallets := []repository.Wallet{{ID: walletID, Balance: money.MustParseDecimal("12.50"), Currency: "SGD"}}
+			return map[string]interface{}{
+				"wallets": wallets,
+				"pagination": map[string]interface{}{
+					"sortBy": req.SortBy,
+					"sort":   req.Sort,
+					"skip":   req.Skip,
+					"limit":  req.Limit,
+				},
+			}, nil
+		},
+	}
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := NewGRPCServer(endpoints, noop.CreateLogger(), nil, nil)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithInsecure(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	users := pb.NewUserServiceClient(conn)
+	wallets := pb.NewWalletServiceClient(conn)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-user-id", userID.String())
+
+	t.Run("SuccessGetUser", func(t *testing.T) {
+		user, err := users.GetUser(ctx, &pb.GetUserRequest{Id: userID.String()})
+		assert.NoError(t, err)
+		assert.Equal(t, userID.String(), user.Id)
+		assert.Equal(t, "admin@spenmo.com", user.Email)
+		assert.Equal(t, createdAt, user.CreatedAt.AsTime())
+	})
+
+	t.Run("SuccessGetAllWallets", func(t *testing.T) {
+		res, err := wallets.GetAllWallets(ctx, &pb.GetAllWalletsRequest{Pagination: &pb.Pagination{SortBy: "updatedAt", Limit: 5}})
+		assert.NoError(t, err)
+		assert.Equal(t, &pb.Pagination{SortBy: "updated_at", Sort: "DESC", Limit: 5}, res.Pagination)
+
+		if assert.Len(t, res.Wallets, 1) {
+			assert.Equal(t, walletID.String(), res.Wallets[0].Id)
+			assert.Equal(t, "12.50", res.Wallets[0].Balance)
+			assert.Empty(t, res.Wallets[0].TeamId)
+		}
+	})
+
+	t.Run("FailedNotFound", func(t *testing.T) {
+		_, err := users.GetUser(ctx, &pb.GetUserRequest{Id: uuid.New().String()})
+		assertStatus(t, err, codes.NotFound, errors.FailedUserNotFound.Code)
+	})
+
+	t.Run("FailedInvalidID", func(t *testing.T) {
+		_, err := users.GetUser(ctx, &pb.GetUserRequest{Id: "not-a-uuid"})
+		assertStatus(t, err, codes.InvalidArgument, errors.UnparsableUUID.Code)
+	})
+
+	t.Run("FailedInvalidRequest", func(t *testing.T) {
+		_, err := users.CreateUser(ctx, &pb.CreateUserRequest{Email: "not-an-email", Name: "Admin"})
+		assertStatus(t, err, codes.InvalidArgument, errors.InvalidRequest.Code)
+	})
+}
+
+func assertStatus(t *testing.T, err error, code codes.Code, reason string) {
+	st, ok := status.FromError(err)
+	if !assert.True(t, ok) {
+		return
+	}
+
+	assert.Equal(t, code, st.Code())
+
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			assert.Equal(t, errorDomain, info.Domain)
+			assert.Equal(t, reason, info.Reason)
+			return
+		}
+	}
+
+	t.Errorf("status %v has no ErrorInfo detail", st)
+}
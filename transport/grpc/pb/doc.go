@@ -0,0 +1,5 @@
+// Package pb holds the protobuf messages and gRPC services of the API, the
+// Go code is generated from the .proto files of this directory
+package pb
+
+//go:generate protoc --proto_path=. --go_out=paths=source_relative:. --go-grpc_out=paths=source_relative:. common.proto user.proto team.proto team_member.proto wallet.proto card.proto
@@ -0,0 +1,281 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// TeamMemberServiceClient is the client API for TeamMemberService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TeamMemberServiceClient interface {
+	GetTeamMembers(ctx context.Context, in *GetTeamMembersRequest, opts ...grpc.CallOption) (*GetTeamMembersResponse, error)
+	CreateTeamMember(ctx context.Context, in *CreateTeamMemberRequest, opts ...grpc.CallOption) (*TeamMember, error)
+	UpdateTeamMember(ctx context.Context, in *UpdateTeamMemberRequest, opts ...grpc.CallOption) (*TeamMember, error)
+	DeleteTeamMember(ctx context.Context, in *DeleteTeamMemberRequest, opts ...grpc.CallOption) (*TeamMember, error)
+	DeleteTeamMembersByTeamID(ctx context.Context, in *DeleteTeamMembersByTeamIDRequest, opts ...grpc.CallOption) (*TeamMembers, error)
+	DeleteTeamMembersByUserID(ctx context.Context, in *DeleteTeamMembersByUserIDRequest, opts ...grpc.CallOption) (*TeamMembers, error)
+}
+
+type teamMemberServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTeamMemberServiceClient(cc grpc.ClientConnInterface) TeamMemberServiceClient {
+	return &teamMemberServiceClient{cc}
+}
+
+func (c *teamMemberServiceClient) GetTeamMembers(ctx context.Context, in *GetTeamMembersRequest, opts ...grpc.CallOption) (*GetTeamMembersResponse, error) {
+	out := new(GetTeamMembersResponse)
+	err := c.cc.Invoke(ctx, "/spenmo.v1.TeamMemberService/GetTeamMembers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamMemberServiceClient) CreateTeamMember(ctx context.Context, in *CreateTeamMemberRequest, opts ...grpc.CallOption) (*TeamMember, error) {
+	out := new(TeamMember)
+	err := c.cc.Invoke(ctx, "/spenmo.v1.TeamMemberService/CreateTeamMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamMemberServiceClient) UpdateTeamMember(ctx context.Context, in *UpdateTeamMemberRequest, opts ...grpc.CallOption) (*TeamMember, error) {
+	out := new(TeamMember)
+	err := c.cc.Invoke(ctx, "/spenmo.v1.TeamMemberService/UpdateTeamMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamMemberServiceClient) DeleteTeamMember(ctx context.Context, in *DeleteTeamMemberRequest, opts ...grpc.CallOption) (*TeamMember, error) {
+	out := new(TeamMember)
+	err := c.cc.Invoke(ctx, "/spenmo.v1.TeamMemberService/DeleteTeamMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamMemberServiceClient) DeleteTeamMembersByTeamID(ctx context.Context, in *DeleteTeamMembersByTeamIDRequest, opts ...grpc.CallOption) (*TeamMembers, error) {
+	out := new(TeamMembers)
+	err := c.cc.Invoke(ctx, "/spenmo.v1.TeamMemberService/DeleteTeamMembersByTeamID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamMemberServiceClient) DeleteTeamMembersByUserID(ctx context.Context, in *DeleteTeamMembersByUserIDRequest, opts ...grpc.CallOption) (*TeamMembers, error) {
+	out := new(TeamMembers)
+	err := c.cc.Invoke(ctx, "/spenmo.v1.TeamMemberService/DeleteTeamMembersByUserID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TeamMemberServiceServer is the server API for TeamMemberService service.
+// All implementations must embed UnimplementedTeamMemberServiceServer
+// for forward compatibility
+type TeamMemberServiceServer interface {
+	GetTeamMembers(context.Context, *GetTeamMembersRequest) (*GetTeamMembersResponse, error)
+	CreateTeamMember(context.Context, *CreateTeamMemberRequest) (*TeamMember, error)
+	UpdateTeamMember(context.Context, *UpdateTeamMemberRequest) (*TeamMember, error)
+	DeleteTeamMember(context.Context, *DeleteTeamMemberRequest) (*TeamMember, error)
+	DeleteTeamMembersByTeamID(context.Context, *DeleteTeamMembersByTeamIDRequest) (*TeamMembers, error)
+	DeleteTeamMembersByUserID(context.Context, *DeleteTeamMembersByUserIDRequest) (*TeamMembers, error)
+	mustEmbedUnimplementedTeamMemberServiceServer()
+}
+
+// UnimplementedTeamMemberServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedTeamMemberServiceServer struct {
+}
+
+func (UnimplementedTeamMemberServiceServer) GetTeamMembers(context.Context, *GetTeamMembersRequest) (*GetTeamMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTeamMembers not implemented")
+}
+func (UnimplementedTeamMemberServiceServer) CreateTeamMember(context.Context, *CreateTeamMemberRequest) (*TeamMember, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTeamMember not implemented")
+}
+func (UnimplementedTeamMemberServiceServer) UpdateTeamMember(context.Context, *UpdateTeamMemberRequest) (*TeamMember, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTeamMember not implemented")
+}
+func (UnimplementedTeamMemberServiceServer) DeleteTeamMember(context.Context, *DeleteTeamMemberRequest) (*TeamMember, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTeamMember not implemented")
+}
+func (UnimplementedTeamMemberServiceServer) DeleteTeamMembersByTeamID(context.Context, *DeleteTeamMembersByTeamIDRequest) (*TeamMembers, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTeamMembersByTeamID not implemented")
+}
+func (UnimplementedTeamMemberServiceServer) DeleteTeamMembersByUserID(context.Context, *DeleteTeamMembersByUserIDRequest) (*TeamMembers, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTeamMembersByUserID not implemented")
+}
+func (UnimplementedTeamMemberServiceServer) mustEmbedUnimplementedTeamMemberServiceServer() {}
+
+// UnsafeTeamMemberServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TeamMemberServiceServer will
+// result in compilation errors.
+type UnsafeTeamMemberServiceServer interface {
+	mustEmbedUnimplementedTeamMemberServiceServer()
+}
+
+func RegisterTeamMemberServiceServer(s grpc.ServiceRegistrar, srv TeamMemberServiceServer) {
+	s.RegisterService(&TeamMemberService_ServiceDesc, srv)
+}
+
+func _TeamMemberService_GetTeamMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTeamMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamMemberServiceServer).GetTeamMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spenmo.v1.TeamMemberService/GetTeamMembers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamMemberServiceServer).GetTeamMembers(ctx, req.(*GetTeamMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamMemberService_CreateTeamMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTeamMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamMemberServiceServer).CreateTeamMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spenmo.v1.TeamMemberService/CreateTeamMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamMemberServiceServer).CreateTeamMember(ctx, req.(*CreateTeamMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamMemberService_UpdateTeamMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTeamMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamMemberServiceServer).UpdateTeamMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spenmo.v1.TeamMemberService/UpdateTeamMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamMemberServiceServer).UpdateTeamMember(ctx, req.(*UpdateTeamMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamMemberService_DeleteTeamMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTeamMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamMemberServiceServer).DeleteTeamMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spenmo.v1.TeamMemberService/DeleteTeamMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamMemberServiceServer).DeleteTeamMember(ctx, req.(*DeleteTeamMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamMemberService_DeleteTeamMembersByTeamID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTeamMembersByTeamIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamMemberServiceServer).DeleteTeamMembersByTeamID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spenmo.v1.TeamMemberService/DeleteTeamMembersByTeamID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamMemberServiceServer).DeleteTeamMembersByTeamID(ctx, req.(*DeleteTeamMembersByTeamIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamMemberService_DeleteTeamMembersByUserID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTeamMembersByUserIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamMemberServiceServer).DeleteTeamMembersByUserID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spenmo.v1.TeamMemberService/DeleteTeamMembersByUserID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamMemberServiceServer).DeleteTeamMembersByUserID(ctx, req.(*DeleteTeamMembersByUserIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TeamMemberService_ServiceDesc is the grpc.ServiceDesc for TeamMemberService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TeamMemberService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spenmo.v1.TeamMemberService",
+	HandlerType: (*TeamMemberServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTeamMembers",
+			Handler:    _TeamMemberService_GetTeamMembers_Handler,
+		},
+		{
+			MethodName: "CreateTeamMember",
+			Handler:    _TeamMemberService_CreateTeamMember_Handler,
+		},
+		{
+			MethodName: "UpdateTeamMember",
+			Handler:    _TeamMemberService_UpdateTeamMember_Handler,
+		},
+		{
+			MethodName: "DeleteTeamMember",
+			Handler:    _TeamMemberService_DeleteTeamMember_Handler,
+		},
+		{
+			MethodName: "DeleteTeamMembersByTeamID",
+			Handler:    _TeamMemberService_DeleteTeamMembersByTeamID_Handler,
+		},
+		{
+			MethodName: "DeleteTeamMembersByUserID",
+			Handler:    _TeamMemberService_DeleteTeamMembersByUserID_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "team_member.proto",
+}
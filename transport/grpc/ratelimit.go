@@ -0,0 +1,82 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/ratelimit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// rateLimit counts the calls of every client per full method, the limit is
+// sent in the x-ratelimit-* header metadata of every call
+func rateLimit(log logger.Logger, limiter *ratelimit.Limiter) grpc.UnaryServerInterceptor {
+	trustProxy := viper.GetBool("ratelimit.trustProxy")
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		userID, _ := uuid.Parse(firstMetadata(md, "x-user-id"))
+		client := ratelimit.ClientKey(firstMetadata(md, "x-api-key"), userID, peerIP(ctx, md, trustProxy))
+
+		res, err := limiter.Allow(ctx, info.FullMethod, client)
+		if err != nil {
+			log.Warnw("unable to count rate limited call", "client", client, "error", err)
+		}
+
+		header := metadata.Pairs(
+			"x-ratelimit-limit", strconv.Itoa(res.Limit),
+			"x-ratelimit-remaining", strconv.Itoa(res.Remaining),
+			"x-ratelimit-reset", strconv.FormatInt(res.Reset.Unix(), 10),
+		)
+		if !res.Allowed {
+			header.Set("retry-after", strconv.Itoa(res.RetryAfter))
+		}
+		_ = grpc.SetHeader(ctx, header)
+
+		if !res.Allowed {
+			return nil, encodeError(errors.LimitExceeded)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// peerIP is the address of the peer, or the first address of x-forwarded-for
+// when the service runs behind a trusted proxy
+func peerIP(ctx context.Context, md metadata.MD, trustProxy bool) string {
+	if trustProxy {
+		if forwarded := firstMetadata(md, "x-forwarded-for"); forwarded != "" {
+			return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+		}
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
+
+func firstMetadata(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
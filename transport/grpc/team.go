@@ -0,0 +1,174 @@
+package grpc
+
+import (
+	"context"
+
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	"gitlab.com/renodesper/spenmo-test/endpoint"
+	m "gitlab.com/renodesper/spenmo-test/middleware"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/transport/grpc/pb"
+	"gitlab.com/renodesper/spenmo-test/util/validation"
+)
+
+type teamServer struct {
+	pb.UnimplementedTeamServiceServer
+
+	getAllTeams grpctransport.Handler
+	getTeam     grpctransport.Handler
+	createTeam  grpctransport.Handler
+	updateTeam  grpctransport.Handler
+	deleteTeam  grpctransport.Handler
+}
+
+func newTeamServer(endpoints endpoint.Set, middlewares m.Factory, opts []grpctransport.ServerOption) pb.TeamServiceServer {
+	return &teamServer{
+		getAllTeams: newHandler(middlewares("GetAllTeams"), endpoints.GetAllTeamsEndpoint, decodeGetAllTeamsRequest, encodeGetAllTeamsResponse, opts),
+		getTeam:     newHandler(middlewares("GetTeam"), endpoints.GetTeamEndpoint, decodeGetTeamRequest, encodeTeamResponse, opts),
+		createTeam:  newHandler(middlewares("CreateTeam"), endpoints.CreateTeamEndpoint, decodeCreateTeamRequest, encodeTeamResponse, opts),
+		updateTeam:  newHandler(middlewares("UpdateTeam"), endpoints.UpdateTeamEndpoint, decodeUpdateTeamRequest, encodeTeamResponse, opts),
+		deleteTeam:  newHandler(middlewares("DeleteTeam"), endpoints.DeleteTeamEndpoint, decodeDeleteTeamRequest, encodeTeamResponse, opts),
+	}
+}
+
+func (s *teamServer) GetAllTeams(ctx context.Context, req *pb.GetAllTeamsRequest) (*pb.GetAllTeamsResponse, error) {
+	res, err := serve(ctx, s.getAllTeams, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.GetAllTeamsResponse), nil
+}
+
+func (s *teamServer) GetTeam(ctx context.Context, req *pb.GetTeamRequest) (*pb.Team, error) {
+	res, err := serve(ctx, s.getTeam, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.Team), nil
+}
+
+func (s *teamServer) CreateTeam(ctx context.Context, req *pb.CreateTeamRequest) (*pb.Team, error) {
+	res, err := serve(ctx, s.createTeam, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.Team), nil
+}
+
+func (s *teamServer) UpdateTeam(ctx context.Context, req *pb.UpdateTeamRequest) (*pb.Team, error) {
+	res, err := serve(ctx, s.updateTeam, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.Team), nil
+}
+
+func (s *teamServer) DeleteTeam(ctx context.Context, req *pb.DeleteTeamRequest) (*pb.Team, error) {
+	res, err := serve(ctx, s.deleteTeam, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.Team), nil
+}
+
+func decodeGetAllTeamsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.GetAllTeamsRequest)
+
+	var req endpoint.GetAllTeamsRequest
+	req.SortBy, req.Sort, req.Skip, req.Limit = decodePagination(r.Pagination)
+
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func decodeGetTeamRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.GetTeamRequest)
+
+	ID, err := parseID(r.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint.GetTeamRequest{ID: ID}, nil
+}
+
+func decodeCreateTeamRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.CreateTeamRequest)
+
+	req := endpoint.CreateTeamRequest{
+		Name:     r.Name,
+		Timezone: r.Timezone,
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func decodeUpdateTeamRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.UpdateTeamRequest)
+
+	ID, err := parseID(r.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	req := endpoint.UpdateTeamRequest{
+		ID:       ID,
+		Name:     r.Name,
+		Timezone: r.Timezone,
+	}
+
+	return req, nil
+}
+
+func decodeDeleteTeamRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.DeleteTeamRequest)
+
+	ID, err := parseID(r.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint.DeleteTeamRequest{ID: ID}, nil
+}
+
+func encodeGetAllTeamsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res := response.(map[string]interface{})
+	teams, _ := res["teams"].([]repository.Team)
+
+	pbTeams := make([]*pb.Team, 0, len(teams))
+	for i := range teams {
+		pbTeams = append(pbTeams, encodeTeam(&teams[i]))
+	}
+
+	return &pb.GetAllTeamsResponse{
+		Teams:      pbTeams,
+		Pagination: encodePagination(res),
+	}, nil
+}
+
+func encodeTeamResponse(_ context.Context, response interface{}) (interface{}, error) {
+	return encodeTeam(response.(*repository.Team)), nil
+}
+
+func encodeTeam(team *repository.Team) *pb.Team {
+	return &pb.Team{
+		Id:        encodeID(team.ID),
+		Name:      team.Name,
+		Timezone:  team.Timezone,
+		IsDeleted: team.IsDeleted,
+		CreatedAt: encodeTime(team.CreatedAt),
+		UpdatedAt: encodeTime(team.UpdatedAt),
+	}
+}
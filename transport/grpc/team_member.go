@@ -0,0 +1,243 @@
+package grpc
+
+import (
+	"context"
+
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	"gitlab.com/renodesper/spenmo-test/endpoint"
+	m "gitlab.com/renodesper/spenmo-test/middleware"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/transport/grpc/pb"
+	"gitlab.com/renodesper/spenmo-test/util/validation"
+)
+
+type teamMemberServer struct {
+	pb.UnimplementedTeamMemberServiceServer
+
+	getTeamMembers            grpctransport.Handler
+	createTeamMember          grpctransport.Handler
+	updateTeamMember          grpctransport.Handler
+	deleteTeamMember          grpctransport.Handler
+	deleteTeamMembersByTeamID grpctransport.Handler
+	deleteTeamMembersByUserID grpctransport.Handler
+}
+
+func newTeamMemberServer(endpoints endpoint.Set, middlewares m.Factory, opts []grpctransport.ServerOption) pb.TeamMemberServiceServer {
+	return &teamMemberServer{
+		getTeamMembers:            newHandler(middlewares("GetTeamMembers"), endpoints.GetTeamMembersEndpoint, decodeGetTeamMembersRequest, encodeGetTeamMembersResponse, opts),
+		createTeamMember:          newHandler(middlewares("CreateTeamMember"), endpoints.CreateTeamMemberEndpoint, decodeCreateTeamMemberRequest, encodeTeamMemberResponse, opts),
+		updateTeamMember:          newHandler(middlewares("UpdateTeamMember"), endpoints.UpdateTeamMemberEndpoint, decodeUpdateTeamMemberRequest, encodeTeamMemberResponse, opts),
+		deleteTeamMember:          newHandler(middlewares("DeleteTeamMember"), endpoints.DeleteTeamMemberEndpoint, decodeDeleteTeamMemberRequest, encodeTeamMemberResponse, opts),
+		deleteTeamMembersByTeamID: newHandler(middlewares("DeleteTeamMembersByTeamID"), endpoints.DeleteTeamMembersByTeamIDEndpoint, decodeDeleteTeamMembersByTeamIDRequest, encodeTeamMembersResponse, opts),
+		deleteTeamMembersByUserID: newHandler(middlewares("DeleteTeamMembersByUserID"), endpoints.DeleteTeamMembersByUserIDEndpoint, decodeDeleteTeamMembersByUserIDRequest, encodeTeamMembersResponse, opts),
+	}
+}
+
+func (s *teamMemberServer) GetTeamMembers(ctx context.Context, req *pb.GetTeamMembersRequest) (*pb.GetTeamMembersResponse, error) {
+	res, err := serve(ctx, s.getTeamMembers, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.GetTeamMembersResponse), nil
+}
+
+func (s *teamMemberServer) CreateTeamMember(ctx context.Context, req *pb.CreateTeamMemberRequest) (*pb.TeamMember, error) {
+	res, err := serve(ctx, s.createTeamMember, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.TeamMember), nil
+}
+
+func (s *teamMemberServer) UpdateTeamMember(ctx context.Context, req *pb.UpdateTeamMemberRequest) (*pb.TeamMember, error) {
+	res, err := serve(ctx, s.updateTeamMember, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.TeamMember), nil
+}
+
+func (s *teamMemberServer) DeleteTeamMember(ctx context.Context, req *pb.DeleteTeamMemberRequest) (*pb.TeamMember, error) {
+	res, err := serve(ctx, s.deleteTeamMember, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.TeamMember), nil
+}
+
+func (s *teamMemberServer) DeleteTeamMembersByTeamID(ctx context.Context, req *pb.DeleteTeamMembersByTeamIDRequest) (*pb.TeamMembers, error) {
+	res, err := serve(ctx, s.deleteTeamMembersByTeamID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.TeamMembers), nil
+}
+
+func (s *teamMemberServer) DeleteTeamMembersByUserID(ctx context.Context, req *pb.DeleteTeamMembersByUserIDRequest) (*pb.TeamMembers, error) {
+	res, err := serve(ctx, s.deleteTeamMembersByUserID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.TeamMembers), nil
+}
+
+func decodeGetTeamMembersRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.GetTeamMembersRequest)
+
+	teamID, err := parseOptionalID(r.TeamId)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := parseOptionalID(r.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	req := endpoint.GetTeamMembersRequest{
+		TeamID: teamID,
+		UserID: userID,
+	}
+	req.SortBy, req.Sort, req.Skip, req.Limit = decodePagination(r.Pagination)
+
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func decodeCreateTeamMemberRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.CreateTeamMemberRequest)
+
+	teamID, err := parseID(r.TeamId)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := parseID(r.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	req := endpoint.CreateTeamMemberRequest{
+		TeamID: teamID,
+		UserID: userID,
+		Role:   r.Role,
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func decodeUpdateTeamMemberRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.UpdateTeamMemberRequest)
+
+	teamID, err := parseID(r.TeamId)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := parseID(r.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	req := endpoint.UpdateTeamMemberRequest{
+		TeamID: teamID,
+		UserID: userID,
+		Role:   r.Role,
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func decodeDeleteTeamMemberRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.DeleteTeamMemberRequest)
+
+	teamID, err := parseID(r.TeamId)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := parseID(r.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint.DeleteTeamMemberRequest{TeamID: teamID, UserID: userID}, nil
+}
+
+func decodeDeleteTeamMembersByTeamIDRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.DeleteTeamMembersByTeamIDRequest)
+
+	teamID, err := parseID(r.TeamId)
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint.DeleteTeamMembersByTeamIDRequest{TeamID: teamID}, nil
+}
+
+func decodeDeleteTeamMembersByUserIDRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.DeleteTeamMembersByUserIDRequest)
+
+	userID, err := parseID(r.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint.DeleteTeamMembersByUserIDRequest{UserID: userID}, nil
+}
+
+func encodeGetTeamMembersResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res := response.(map[string]interface{})
+	teamMembers, _ := res["teamMembers"].([]repository.TeamMember)
+
+	return &pb.GetTeamMembersResponse{
+		TeamMembers: encodeTeamMembers(teamMembers),
+		Pagination:  encodePagination(res),
+	}, nil
+}
+
+func encodeTeamMembersResponse(_ context.Context, response interface{}) (interface{}, error) {
+	return &pb.TeamMembers{TeamMembers: encodeTeamMembers(response.([]repository.TeamMember))}, nil
+}
+
+func encodeTeamMemberResponse(_ context.Context, response interface{}) (interface{}, error) {
+	return encodeTeamMember(response.(*repository.TeamMember)), nil
+}
+
+func encodeTeamMembers(teamMembers []repository.TeamMember) []*pb.TeamMember {
+	pbTeamMembers := make([]*pb.TeamMember, 0, len(teamMembers))
+	for i := range teamMembers {
+		pbTeamMembers = append(pbTeamMembers, encodeTeamMember(&teamMembers[i]))
+	}
+
+	return pbTeamMembers
+}
+
+func encodeTeamMember(teamMember *repository.TeamMember) *pb.TeamMember {
+	return &pb.TeamMember{
+		Id:        encodeID(teamMember.ID),
+		TeamId:    encodeID(teamMember.TeamID),
+		UserId:    encodeID(teamMember.UserID),
+		Role:      teamMember.Role,
+		IsDeleted: teamMember.IsDeleted,
+		CreatedAt: encodeTime(teamMember.CreatedAt),
+		UpdatedAt: encodeTime(teamMember.UpdatedAt),
+	}
+}
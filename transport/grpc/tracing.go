@@ -0,0 +1,59 @@
+package grpc
+
+import (
+	"context"
+
+	"gitlab.com/renodesper/spenmo-test/util/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// metadataCarrier lets the propagator read traceparent from the metadata
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	return firstMetadata(metadata.MD(c), key)
+}
+
+func (c metadataCarrier) Set(key string, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// traced starts the server span of every call, as a child of the span sent by
+// the caller in the traceparent metadata
+func traced() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+
+		ctx, span := tracing.Start(ctx, info.FullMethod,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.RPCSystemGRPC, semconv.RPCMethodKey.String(info.FullMethod)),
+		)
+		defer span.End()
+
+		res, err := handler(ctx, req)
+
+		st, _ := status.FromError(err)
+		span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int64(int64(st.Code())))
+		if err != nil {
+			span.SetStatus(codes.Error, st.Message())
+		}
+
+		return res, err
+	}
+}
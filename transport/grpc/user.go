@@ -0,0 +1,174 @@
+package grpc
+
+import (
+	"context"
+
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	"gitlab.com/renodesper/spenmo-test/endpoint"
+	m "gitlab.com/renodesper/spenmo-test/middleware"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/transport/grpc/pb"
+	"gitlab.com/renodesper/spenmo-test/util/validation"
+)
+
+type userServer struct {
+	pb.UnimplementedUserServiceServer
+
+	getAllUsers grpctransport.Handler
+	getUser     grpctransport.Handler
+	createUser  grpctransport.Handler
+	updateUser  grpctransport.Handler
+	deleteUser  grpctransport.Handler
+}
+
+func newUserServer(endpoints endpoint.Set, middlewares m.Factory, opts []grpctransport.ServerOption) pb.UserServiceServer {
+	return &userServer{
+		getAllUsers: newHandler(middlewares("GetAllUsers"), endpoints.GetAllUsersEndpoint, decodeGetAllUsersRequest, encodeGetAllUsersResponse, opts),
+		getUser:     newHandler(middlewares("GetUserByID"), endpoints.GetUserByIDEndpoint, decodeGetUserRequest, encodeUserResponse, opts),
+		createUser:  newHandler(middlewares("CreateUser"), endpoints.CreateUserEndpoint, decodeCreateUserRequest, encodeUserResponse, opts),
+		updateUser:  newHandler(middlewares("UpdateUser"), endpoints.UpdateUserEndpoint, decodeUpdateUserRequest, encodeUserResponse, opts),
+		deleteUser:  newHandler(middlewares("DeleteUserByID"), endpoints.DeleteUserByIDEndpoint, decodeDeleteUserRequest, encodeUserResponse, opts),
+	}
+}
+
+func (s *userServer) GetAllUsers(ctx context.Context, req *pb.GetAllUsersRequest) (*pb.GetAllUsersResponse, error) {
+	res, err := serve(ctx, s.getAllUsers, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.GetAllUsersResponse), nil
+}
+
+func (s *userServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	res, err := serve(ctx, s.getUser, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.User), nil
+}
+
+func (s *userServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.User, error) {
+	res, err := serve(ctx, s.createUser, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.User), nil
+}
+
+func (s *userServer) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.User, error) {
+	res, err := serve(ctx, s.updateUser, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.User), nil
+}
+
+func (s *userServer) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*pb.User, error) {
+	res, err := serve(ctx, s.deleteUser, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.User), nil
+}
+
+func decodeGetAllUsersRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.GetAllUsersRequest)
+
+	var req endpoint.GetAllUsersRequest
+	req.SortBy, req.Sort, req.Skip, req.Limit = decodePagination(r.Pagination)
+
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func decodeGetUserRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.GetUserRequest)
+
+	ID, err := parseID(r.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint.GetUserRequest{ID: ID}, nil
+}
+
+func decodeCreateUserRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.CreateUserRequest)
+
+	req := endpoint.CreateUserRequest{
+		Email: r.Email,
+		Name:  r.Name,
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func decodeUpdateUserRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.UpdateUserRequest)
+
+	ID, err := parseID(r.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	req := endpoint.UpdateUserRequest{
+		ID:    ID,
+		Email: r.Email,
+		Name:  r.Name,
+	}
+
+	return req, nil
+}
+
+func decodeDeleteUserRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.DeleteUserRequest)
+
+	ID, err := parseID(r.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint.DeleteUserRequest{ID: ID}, nil
+}
+
+func encodeGetAllUsersResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res := response.(map[string]interface{})
+	users, _ := res["users"].([]repository.User)
+
+	pbUsers := make([]*pb.User, 0, len(users))
+	for i := range users {
+		pbUsers = append(pbUsers, encodeUser(&users[i]))
+	}
+
+	return &pb.GetAllUsersResponse{
+		Users:      pbUsers,
+		Pagination: encodePagination(res),
+	}, nil
+}
+
+func encodeUserResponse(_ context.Context, response interface{}) (interface{}, error) {
+	return encodeUser(response.(*repository.User)), nil
+}
+
+func encodeUser(user *repository.User) *pb.User {
+	return &pb.User{
+		Id:        encodeID(user.ID),
+		Email:     user.Email,
+		Name:      user.Name,
+		IsDeleted: user.IsDeleted,
+		CreatedAt: encodeTime(user.CreatedAt),
+		UpdatedAt: encodeTime(user.UpdatedAt),
+	}
+}
@@ -0,0 +1,269 @@
+package grpc
+
+import (
+	"context"
+
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	"gitlab.com/renodesper/spenmo-test/endpoint"
+	m "gitlab.com/renodesper/spenmo-test/middleware"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/transport/grpc/pb"
+	"gitlab.com/renodesper/spenmo-test/util/validation"
+)
+
+type walletServer struct {
+	pb.UnimplementedWalletServiceServer
+
+	getAllWallets         grpctransport.Handler
+	getWallet             grpctransport.Handler
+	createWallet          grpctransport.Handler
+	updateWallet          grpctransport.Handler
+	deleteWallet          grpctransport.Handler
+	deleteWalletsByTeamID grpctransport.Handler
+	deleteWalletsByUserID grpctransport.Handler
+}
+
+func newWalletServer(endpoints endpoint.Set, middlewares m.Factory, opts []grpctransport.ServerOption) pb.WalletServiceServer {
+	return &walletServer{
+		getAllWallets:         newHandler(middlewares("GetAllWallets"), endpoints.GetAllWalletsEndpoint, decodeGetAllWalletsRequest, encodeGetAllWalletsResponse, opts),
+		getWallet:             newHandler(middlewares("GetWallet"), endpoints.GetWalletEndpoint, decodeGetWalletRequest, encodeWalletResponse, opts),
+		createWallet:          newHandler(middlewares("CreateWallet"), endpoints.CreateWalletEndpoint, decodeCreateWalletRequest, encodeWalletResponse, opts),
+		updateWallet:          newHandler(middlewares("UpdateWallet"), endpoints.UpdateWalletEndpoint, decodeUpdateWalletRequest, encodeWalletResponse, opts),
+		deleteWallet:          newHandler(middlewares("DeleteWalletByID"), endpoints.DeleteWalletByIDEndpoint, decodeDeleteWalletRequest, encodeWalletResponse, opts),
+		deleteWalletsByTeamID: newHandler(middlewares("DeleteWalletsByTeamID"), endpoints.DeleteWalletsByTeamIDEndpoint, decodeDeleteWalletsByTeamIDRequest, encodeWalletsResponse, opts),
+		deleteWalletsByUserID: newHandler(middlewares("DeleteWalletsByUserID"), endpoints.DeleteWalletsByUserIDEndpoint, decodeDeleteWalletsByUserIDRequest, encodeWalletsResponse, opts),
+	}
+}
+
+func (s *walletServer) GetAllWallets(ctx context.Context, req *pb.GetAllWalletsRequest) (*pb.GetAllWalletsResponse, error) {
+	res, err := serve(ctx, s.getAllWallets, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.GetAllWalletsResponse), nil
+}
+
+func (s *walletServer) GetWallet(ctx context.Context, req *pb.GetWalletRequest) (*pb.Wallet, error) {
+	res, err := serve(ctx, s.getWallet, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.Wallet), nil
+}
+
+func (s *walletServer) CreateWallet(ctx context.Context, req *pb.CreateWalletRequest) (*pb.Wallet, error) {
+	res, err := serve(ctx, s.createWallet, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.Wallet), nil
+}
+
+func (s *walletServer) UpdateWallet(ctx context.Context, req *pb.UpdateWalletRequest) (*pb.Wallet, error) {
+	res, err := serve(ctx, s.updateWallet, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.Wallet), nil
+}
+
+func (s *walletServer) DeleteWallet(ctx context.Context, req *pb.DeleteWalletRequest) (*pb.Wallet, error) {
+	res, err := serve(ctx, s.deleteWallet, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.Wallet), nil
+}
+
+func (s *walletServer) DeleteWalletsByTeamID(ctx context.Context, req *pb.DeleteWalletsByTeamIDRequest) (*pb.Wallets, error) {
+	res, err := serve(ctx, s.deleteWalletsByTeamID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.Wallets), nil
+}
+
+func (s *walletServer) DeleteWalletsByUserID(ctx context.Context, req *pb.DeleteWalletsByUserIDRequest) (*pb.Wallets, error) {
+	res, err := serve(ctx, s.deleteWalletsByUserID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*pb.Wallets), nil
+}
+
+func decodeGetAllWalletsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.GetAllWalletsRequest)
+
+	var req endpoint.GetAllWalletsRequest
+	req.SortBy, req.Sort, req.Skip, req.Limit = decodePagination(r.Pagination)
+
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func decodeGetWalletRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.GetWalletRequest)
+
+	ID, err := parseID(r.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint.GetWalletRequest{ID: ID}, nil
+}
+
+func decodeCreateWalletRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.CreateWalletRequest)
+
+	teamID, err := parseOptionalID(r.TeamId)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := parseOptionalID(r.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	req := endpoint.CreateWalletRequest{
+		TeamID:   teamID,
+		UserID:   userID,
+		Currency: r.Currency,
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func decodeUpdateWalletRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.UpdateWalletRequest)
+
+	ID, err := parseID(r.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	teamID, err := parseOptionalID(r.TeamId)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := parseOptionalID(r.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	req := endpoint.UpdateWalletRequest{
+		ID:       ID,
+		TeamID:   teamID,
+		UserID:   userID,
+		Currency: r.Currency,
+	}
+
+	if req.BalanceIncrease, err = parseDecimal(r.BalanceIncrease); err != nil {
+		return nil, err
+	}
+	if req.BalanceDecrease, err = parseDecimal(r.BalanceDecrease); err != nil {
+		return nil, err
+	}
+	if req.DailyLimit, err = parseDecimal(r.DailyLimit); err != nil {
+		return nil, err
+	}
+	if req.MonthlyLimit, err = parseDecimal(r.MonthlyLimit); err != nil {
+		return nil, err
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func decodeDeleteWalletRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.DeleteWalletRequest)
+
+	ID, err := parseID(r.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint.DeleteWalletByIDRequest{ID: ID}, nil
+}
+
+func decodeDeleteWalletsByTeamIDRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.DeleteWalletsByTeamIDRequest)
+
+	teamID, err := parseID(r.TeamId)
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint.DeleteWalletsByTeamIDRequest{TeamID: teamID}, nil
+}
+
+func decodeDeleteWalletsByUserIDRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	r := grpcReq.(*pb.DeleteWalletsByUserIDRequest)
+
+	userID, err := parseID(r.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint.DeleteWalletsByUserIDRequest{UserID: userID}, nil
+}
+
+func encodeGetAllWalletsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res := response.(map[string]interface{})
+	wallets, _ := res["wallets"].([]repository.Wallet)
+
+	return &pb.GetAllWalletsResponse{
+		Wallets:    encodeWallets(wallets),
+		Pagination: encodePagination(res),
+	}, nil
+}
+
+func encodeWalletsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	return &pb.Wallets{Wallets: encodeWallets(response.([]repository.Wallet))}, nil
+}
+
+func encodeWalletResponse(_ context.Context, response interface{}) (interface{}, error) {
+	return encodeWallet(response.(*repository.Wallet)), nil
+}
+
+func encodeWallets(wallets []repository.Wallet) []*pb.Wallet {
+	pbWallets := make([]*pb.Wallet, 0, len(wallets))
+	for i := range wallets {
+		pbWallets = append(pbWallets, encodeWallet(&wallets[i]))
+	}
+
+	return pbWallets
+}
+
+func encodeWallet(wallet *repository.Wallet) *pb.Wallet {
+	return &pb.Wallet{
+		Id:           encodeID(wallet.ID),
+		Balance:      wallet.Balance.String(),
+		DailyLimit:   wallet.DailyLimit.String(),
+		MonthlyLimit: wallet.MonthlyLimit.String(),
+		Currency:     wallet.Currency,
+		TeamId:       encodeID(wallet.TeamID),
+		UserId:       encodeID(wallet.UserID),
+		IsDeleted:    wallet.IsDeleted,
+		CreatedAt:    encodeTime(wallet.CreatedAt),
+		UpdatedAt:    encodeTime(wallet.UpdatedAt),
+	}
+}
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-zoo/bone"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/endpoint"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/validation"
+)
+
+// decodeGetTeamSpendRequest reads from and to as inclusive dates, the last 30
+// days are used when they are omitted
+func decodeGetTeamSpendRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req endpoint.GetTeamSpendRequest
+
+	IDStr := bone.GetValue(r, "id")
+	ID, err := uuid.Parse(IDStr)
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+	req.ID = ID
+
+	now := time.Now().UTC()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, 0, -29)
+
+	fromParam := r.URL.Query().Get("from")
+	if fromParam != "" {
+		from, err = time.Parse(dateLayout, fromParam)
+		if err != nil {
+			return nil, errors.UnparsableDate.AppendError(err)
+		}
+	}
+	req.From = from
+
+	toParam := r.URL.Query().Get("to")
+	if toParam != "" {
+		to, err = time.Parse(dateLayout, toParam)
+		if err != nil {
+			return nil, errors.UnparsableDate.AppendError(err)
+		}
+	}
+	req.To = to.AddDate(0, 0, 1)
+
+	req.GroupBy = r.URL.Query().Get("groupBy")
+	req.Interval = r.URL.Query().Get("interval")
+
+	validate = validation.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
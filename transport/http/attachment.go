@@ -0,0 +1,98 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-zoo/bone"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/endpoint"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+)
+
+// receiptFormField is the multipart field holding the receipt file
+const receiptFormField = "file"
+
+func decodeGetReceiptsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req endpoint.GetReceiptsRequest
+
+	IDStr := bone.GetValue(r, "id")
+	ID, err := uuid.Parse(IDStr)
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+
+	req.TransactionID = ID
+
+	return req, nil
+}
+
+func decodeGetReceiptRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req endpoint.GetReceiptRequest
+
+	transactionID, err := uuid.Parse(bone.GetValue(r, "id"))
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+
+	ID, err := uuid.Parse(bone.GetValue(r, "receiptId"))
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+
+	req.TransactionID = transactionID
+	req.ID = ID
+
+	return req, nil
+}
+
+// decodeCreateReceiptRequest streams the file part of a multipart upload to
+// the service, which enforces the size and type of the receipt. The parts
+// before the file are skipped.
+func decodeCreateReceiptRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req endpoint.CreateReceiptRequest
+
+	IDStr := bone.GetValue(r, "id")
+	ID, err := uuid.Parse(IDStr)
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, errors.InvalidRequest.AppendError(err)
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, errors.MissingReceipt
+		}
+		if err != nil {
+			return nil, errors.InvalidRequest.AppendError(err)
+		}
+
+		if part.FormName() == receiptFormField {
+			req.TransactionID = ID
+			req.FileName = part.FileName()
+			req.Content = part
+
+			return req, nil
+		}
+	}
+}
+
+func encodeReceiptResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res := response.(endpoint.GetReceiptResponse)
+	defer res.Content.Close()
+
+	w.Header().Set("Content-Type", res.Attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(res.Attachment.Size, 10))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", res.Attachment.FileName))
+
+	_, err := io.Copy(w, res.Content)
+	return err
+}
@@ -0,0 +1,103 @@
+package http
+
+import (
+	"bytes"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-zoo/bone"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/endpoint"
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+)
+
+func TestDecodeCreateReceiptRequest(t *testing.T) {
+	newMultipart := func(fields map[string]string, file string) (string, *bytes.Buffer) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+
+		for name, value := range fields {
+			_ = writer.WriteField(name, value)
+		}
+
+		if file != "" {
+			part, _ := writer.CreateFormFile(receiptFormField, "lunch.pdf")
+			_, _ = part.Write([]byte(file))
+		}
+
+		_ = writer.Close()
+		return writer.FormDataContentType(), body
+	}
+
+	fileContentType, fileBody := newMultipart(map[string]string{"note": "skipped"}, "%PDF-1.4")
+	emptyContentType, emptyBody := newMultipart(map[string]string{"note": "no file"}, "")
+
+	cases := map[string]struct {
+		Path            string
+		ContentType     string
+		Body            *bytes.Buffer
+		ExpectedContent string
+		ExpectedError   e.Error
+	}{
+		"SuccessDecode": {
+			Path:            "/transactions/8e159833-5078-4b0a-80a0-363d82bafd60/receipts",
+			ContentType:     fileContentType,
+			Body:            fileBody,
+			ExpectedContent: "%PDF-1.4",
+		},
+		"FailedMissingFile": {
+			Path:          "/transactions/8e159833-5078-4b0a-80a0-363d82bafd60/receipts",
+			ContentType:   emptyContentType,
+			Body:          emptyBody,
+			ExpectedError: errors.MissingReceipt,
+		},
+		"FailedNotMultipart": {
+			Path:          "/transactions/8e159833-5078-4b0a-80a0-363d82bafd60/receipts",
+			ContentType:   "application/json",
+			Body:          bytes.NewBufferString("{}"),
+			ExpectedError: errors.InvalidRequest,
+		},
+		"FailedInvalidID": {
+			Path:          "/transactions/not-a-uuid/receipts",
+			ContentType:   "application/json",
+			Body:          bytes.NewBufferString("{}"),
+			ExpectedError: errors.UnparsableUUID,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var decoded interface{}
+			var err error
+
+			router := bone.New()
+			router.Post("/transactions/:id/receipts", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				decoded, err = decodeCreateReceiptRequest(r.Context(), r)
+				if err == nil {
+					// NOTE: The file part is only readable while the request is served
+					content, _ := ioutil.ReadAll(decoded.(endpoint.CreateReceiptRequest).Content)
+					assert.Equal(t, tc.ExpectedContent, string(content))
+				}
+			}))
+
+			req := httptest.NewRequest("POST", tc.Path, tc.Body)
+			req.Header.Set("Content-Type", tc.ContentType)
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			if tc.ExpectedError.Code != "" {
+				if assert.Error(t, err) {
+					assert.Equal(t, tc.ExpectedError.Code, err.(e.Error).Code)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "lunch.pdf", decoded.(endpoint.CreateReceiptRequest).FileName)
+			assert.Equal(t, "8e159833-5078-4b0a-80a0-363d82bafd60", decoded.(endpoint.CreateReceiptRequest).TransactionID.String())
+		})
+	}
+}
@@ -5,12 +5,12 @@ import (
 	"net/http"
 	"strconv"
 
-	"github.com/go-playground/validator"
 	"github.com/go-zoo/bone"
 	"github.com/google/uuid"
 	"github.com/iancoleman/strcase"
 	"gitlab.com/renodesper/spenmo-test/endpoint"
 	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/validation"
 )
 
 func decodeCreateCardRequest(_ context.Context, r *http.Request) (interface{}, error) {
@@ -21,9 +21,9 @@ func decodeCreateCardRequest(_ context.Context, r *http.Request) (interface{}, e
 	}
 	defer r.Body.Close()
 
-	validate = validator.New()
+	validate = validation.New()
 	if err := validate.Struct(req); err != nil {
-		return nil, errors.InvalidRequest
+		return nil, validation.Error(err)
 	}
 
 	return req, nil
@@ -61,9 +61,9 @@ func decodeGetAllCardsRequest(_ context.Context, r *http.Request) (interface{},
 	}
 	req.Limit = limit
 
-	validate = validator.New()
+	validate = validation.New()
 	if err := validate.Struct(req); err != nil {
-		return nil, errors.InvalidRequest
+		return nil, validation.Error(err)
 	}
 
 	return req, nil
@@ -76,7 +76,7 @@ func decodeGetCardRequest(_ context.Context, r *http.Request) (interface{}, erro
 
 	ID, err := uuid.Parse(IDStr)
 	if err != nil {
-		return nil, err
+		return nil, errors.UnparsableUUID.AppendError(err)
 	}
 
 	req.ID = ID
@@ -90,7 +90,7 @@ func decodeUpdateCardRequest(_ context.Context, r *http.Request) (interface{}, e
 	IDStr := bone.GetValue(r, "id")
 	ID, err := uuid.Parse(IDStr)
 	if err != nil {
-		return nil, err
+		return nil, errors.UnparsableUUID.AppendError(err)
 	}
 
 	req.ID = ID
@@ -100,9 +100,9 @@ func decodeUpdateCardRequest(_ context.Context, r *http.Request) (interface{}, e
 	}
 	defer r.Body.Close()
 
-	validate = validator.New()
+	validate = validation.New()
 	if err := validate.Struct(req); err != nil {
-		return nil, errors.InvalidRequest
+		return nil, validation.Error(err)
 	}
 
 	return req, nil
@@ -114,7 +114,7 @@ func decodeDeleteCardByIDRequest(_ context.Context, r *http.Request) (interface{
 	IDStr := bone.GetValue(r, "id")
 	ID, err := uuid.Parse(IDStr)
 	if err != nil {
-		return nil, err
+		return nil, errors.UnparsableUUID.AppendError(err)
 	}
 
 	req.ID = ID
@@ -128,10 +128,65 @@ func decodeDeleteCardsByWalletIDRequest(_ context.Context, r *http.Request) (int
 	walletIDStr := bone.GetValue(r, "walletId")
 	walletID, err := uuid.Parse(walletIDStr)
 	if err != nil {
-		return nil, err
+		return nil, errors.UnparsableUUID.AppendError(err)
 	}
 
 	req.WalletID = walletID
 
 	return req, nil
 }
+
+func decodeFreezeCardRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req endpoint.FreezeCardRequest
+
+	IDStr := bone.GetValue(r, "id")
+	ID, err := uuid.Parse(IDStr)
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+
+	// NOTE: The reason is optional, so is the body
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, errors.UnparsableJSON
+		}
+	}
+	defer r.Body.Close()
+
+	req.ID = ID
+
+	return req, nil
+}
+
+func decodeGetCardAuditsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	skip := 0
+	limit := 10
+
+	var req endpoint.GetCardAuditsRequest
+
+	IDStr := bone.GetValue(r, "id")
+	ID, err := uuid.Parse(IDStr)
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+	req.ID = ID
+
+	skipParam := r.URL.Query().Get("skip")
+	if skipParam != "" {
+		skip, _ = strconv.Atoi(skipParam)
+	}
+	req.Skip = skip
+
+	limitParam := r.URL.Query().Get("limit")
+	if limitParam != "" {
+		limit, _ = strconv.Atoi(limitParam)
+	}
+	req.Limit = limit
+
+	validate = validation.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
@@ -0,0 +1,60 @@
+package http
+
+import (
+	"bytes"
+	"net/http"
+
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+)
+
+// downloadBufferSize holds the start of a download, enough for the heading of
+// a file and its first rows
+const downloadBufferSize = 32 << 10
+
+// downloadWriter holds back the start of a download so a failure while the
+// first rows are fetched is still answered with its own status. Once the body
+// is sent the status cannot change anymore.
+type downloadWriter struct {
+	http.ResponseWriter
+	buf     bytes.Buffer
+	started bool
+}
+
+func (w *downloadWriter) Write(b []byte) (int, error) {
+	if !w.started && w.buf.Len()+len(b) <= downloadBufferSize {
+		return w.buf.Write(b)
+	}
+
+	if err := w.flush(); err != nil {
+		return 0, err
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *downloadWriter) flush() error {
+	w.started = true
+	_, err := w.buf.WriteTo(w.ResponseWriter)
+	return err
+}
+
+// endDownload sends what is left of a download. An error raised before the
+// body started is returned so it is encoded as usual, afterwards the
+// connection is aborted so the client sees a truncated download instead of a
+// file ending with an error document.
+func endDownload(log logger.Logger, w *downloadWriter, name string, err error) error {
+	if err == nil {
+		err = w.flush()
+	}
+	if err == nil {
+		return nil
+	}
+
+	if !w.started {
+		w.Header().Del("Content-Disposition")
+		return err
+	}
+
+	log.Errorw("unable to stream "+name, "error", err)
+	panic(http.ErrAbortHandler)
+}
@@ -0,0 +1,72 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/endpoint"
+	"gitlab.com/renodesper/spenmo-test/util/logger/noop"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+	"gitlab.com/renodesper/spenmo-test/util/statement"
+)
+
+func TestEncodeStatementResponse(t *testing.T) {
+	newResponse := func(rows int, err error) endpoint.GetWalletStatementResponse {
+		return endpoint.GetWalletStatementResponse{
+			Format: statement.FormatCSV,
+			Statement: &statement.Statement{
+				WalletID: "d4a6607a-1af7-4571-bdff-2672be72ba0e",
+				Currency: "SGD",
+				From:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:       time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				Entries: func(fn func(statement.Entry) error) error {
+					for i := 0; i < rows; i++ {
+						entry := statement.Entry{Reference: fmt.Sprintf("ref-%d", i), Type: "card", Amount: money.MustParseDecimal("-1.00")}
+						if err := fn(entry); err != nil {
+							return err
+						}
+					}
+					return err
+				},
+			},
+		}
+	}
+
+	encode := encodeStatementResponse(noop.CreateLogger())
+	failure := fmt.Errorf("connection reset")
+
+	t.Run("SuccessEncode", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		err := encode(context.Background(), rr, newResponse(3, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Header().Get("Content-Disposition"), "attachment")
+		assert.Contains(t, rr.Body.String(), "Closing balance")
+	})
+
+	t.Run("FailedBeforeBody", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		err := encode(context.Background(), rr, newResponse(3, failure))
+		assert.Equal(t, failure, err)
+		assert.Empty(t, rr.Header().Get("Content-Disposition"))
+		assert.Empty(t, rr.Body.String())
+	})
+
+	t.Run("FailedMidStream", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			_ = encode(context.Background(), rr, newResponse(5000, failure))
+		})
+		assert.True(t, strings.HasPrefix(rr.Body.String(), "Date,"))
+		assert.NotContains(t, rr.Body.String(), "Closing balance")
+	})
+}
@@ -0,0 +1,131 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/endpoint"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/export"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/validation"
+)
+
+// decodeGetTeamExportRequest reads from and to as inclusive dates, the
+// previous month is exported by default
+func decodeGetTeamExportRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req endpoint.GetTeamExportRequest
+
+	IDStr := bone.GetValue(r, "id")
+	ID, err := uuid.Parse(IDStr)
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+	req.ID = ID
+
+	walletIDStr := r.URL.Query().Get("walletId")
+	if walletIDStr != "" {
+		walletID, err := uuid.Parse(walletIDStr)
+		if err != nil {
+			return nil, errors.UnparsableUUID.AppendError(err)
+		}
+		req.WalletID = walletID
+	}
+
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(now.Year(), now.Month(), 0, 0, 0, 0, 0, time.UTC)
+
+	fromParam := r.URL.Query().Get("from")
+	if fromParam != "" {
+		from, err = time.Parse(dateLayout, fromParam)
+		if err != nil {
+			return nil, errors.UnparsableDate.AppendError(err)
+		}
+	}
+	req.From = from
+
+	toParam := r.URL.Query().Get("to")
+	if toParam != "" {
+		to, err = time.Parse(dateLayout, toParam)
+		if err != nil {
+			return nil, errors.UnparsableDate.AppendError(err)
+		}
+	}
+	req.To = to.AddDate(0, 0, 1)
+
+	req.Format = strings.ToLower(r.URL.Query().Get("format"))
+	if err := export.IsFormatValid(req.Format); err != nil {
+		return nil, err
+	}
+
+	validate = validation.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+// encodeExportResponse streams the export as a file download, a failure past
+// its first rows aborts the download (see downloadWriter)
+func encodeExportResponse(log logger.Logger) httptransport.EncodeResponseFunc {
+	return func(_ context.Context, w http.ResponseWriter, response interface{}) error {
+		res := response.(endpoint.GetTeamExportResponse)
+		download := &downloadWriter{ResponseWriter: w}
+
+		writer, err := export.NewWriter(res.Format, download)
+		if err != nil {
+			return err
+		}
+
+		w.Header().Set("Content-Type", export.ContentType(res.Format))
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", res.Export.Filename(res.Format)))
+
+		return endDownload(log, download, "export", res.Export.Write(writer))
+	}
+}
+
+func decodeGetChartOfAccountsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req endpoint.GetChartOfAccountsRequest
+
+	IDStr := bone.GetValue(r, "id")
+	ID, err := uuid.Parse(IDStr)
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+
+	req.TeamID = ID
+
+	return req, nil
+}
+
+func decodeSetChartOfAccountsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req endpoint.SetChartOfAccountsRequest
+
+	IDStr := bone.GetValue(r, "id")
+	ID, err := uuid.Parse(IDStr)
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.UnparsableJSON
+	}
+	defer r.Body.Close()
+
+	req.TeamID = ID
+
+	validate = validation.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
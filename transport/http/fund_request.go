@@ -0,0 +1,165 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-zoo/bone"
+	"github.com/google/uuid"
+	"github.com/iancoleman/strcase"
+	"gitlab.com/renodesper/spenmo-test/endpoint"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/validation"
+)
+
+func decodeGetFundRequestsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	sortBy := "created_at"
+	sort := "DESC"
+	skip := 0
+	limit := 10
+
+	var req endpoint.GetFundRequestsRequest
+
+	teamIDStr := r.URL.Query().Get("teamId")
+	if teamIDStr != "" {
+		teamID, err := uuid.Parse(teamIDStr)
+		if err != nil {
+			return nil, errors.UnparsableUUID.AppendError(err)
+		}
+		req.TeamID = teamID
+	}
+
+	requesterIDStr := r.URL.Query().Get("requesterId")
+	if requesterIDStr != "" {
+		requesterID, err := uuid.Parse(requesterIDStr)
+		if err != nil {
+			return nil, errors.UnparsableUUID.AppendError(err)
+		}
+		req.RequesterID = requesterID
+	}
+
+	req.Status = r.URL.Query().Get("status")
+
+	sortByParam := r.URL.Query().Get("sortBy")
+	if sortByParam != "" {
+		sortBy = strcase.ToSnake(sortByParam)
+	}
+	req.SortBy = sortBy
+
+	sortParam := r.URL.Query().Get("sort")
+	if sortParam != "" {
+		sort = sortParam
+	}
+	req.Sort = sort
+
+	skipParam := r.URL.Query().Get("skip")
+	if skipParam != "" {
+		skip, _ = strconv.Atoi(skipParam)
+	}
+	req.Skip = skip
+
+	limitParam := r.URL.Query().Get("limit")
+	if limitParam != "" {
+		limit, _ = strconv.Atoi(limitParam)
+	}
+	req.Limit = limit
+
+	validate = validation.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func decodeGetFundRequestRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	IDStr := bone.GetValue(r, "id")
+
+	var req endpoint.GetFundRequestRequest
+
+	ID, err := uuid.Parse(IDStr)
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+
+	req.ID = ID
+
+	return req, nil
+}
+
+func decodeCreateFundRequestRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req endpoint.CreateFundRequestRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.UnparsableJSON
+	}
+	defer r.Body.Close()
+
+	validate = validation.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func decodeApproveFundRequestRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req endpoint.ApproveFundRequestRequest
+
+	IDStr := bone.GetValue(r, "id")
+	ID, err := uuid.Parse(IDStr)
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.UnparsableJSON
+	}
+	defer r.Body.Close()
+
+	req.ID = ID
+
+	validate = validation.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func decodeRejectFundRequestRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req endpoint.RejectFundRequestRequest
+
+	IDStr := bone.GetValue(r, "id")
+	ID, err := uuid.Parse(IDStr)
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+
+	// NOTE: The note is optional, so is the body
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, errors.UnparsableJSON
+		}
+	}
+	defer r.Body.Close()
+
+	req.ID = ID
+
+	return req, nil
+}
+
+func decodeCancelFundRequestRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req endpoint.CancelFundRequestRequest
+
+	IDStr := bone.GetValue(r, "id")
+	ID, err := uuid.Parse(IDStr)
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+
+	req.ID = ID
+
+	return req, nil
+}
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-zoo/bone"
+	"github.com/google/uuid"
+	"github.com/iancoleman/strcase"
+	"gitlab.com/renodesper/spenmo-test/endpoint"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/validation"
+)
+
+func decodeGetFundingSchedulesRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	sortBy := "created_at"
+	sort := "DESC"
+	skip := 0
+	limit := 10
+
+	var req endpoint.GetFundingSchedulesRequest
+
+	teamIDStr := r.URL.Query().Get("teamId")
+	if teamIDStr != "" {
+		teamID, err := uuid.Parse(teamIDStr)
+		if err != nil {
+			return nil, errors.UnparsableUUID.AppendError(err)
+		}
+		req.TeamID = teamID
+	}
+
+	sortByParam := r.URL.Query().Get("sortBy")
+	if sortByParam != "" {
+		sortBy = strcase.ToSnake(sortByParam)
+	}
+	req.SortBy = sortBy
+
+	sortParam := r.URL.Query().Get("sort")
+	if sortParam != "" {
+		sort = sortParam
+	}
+	req.Sort = sort
+
+	skipParam := r.URL.Query().Get("skip")
+	if skipParam != "" {
+		skip, _ = strconv.Atoi(skipParam)
+	}
+	req.Skip = skip
+
+	limitParam := r.URL.Query().Get("limit")
+	if limitParam != "" {
+		limit, _ = strconv.Atoi(limitParam)
+	}
+	req.Limit = limit
+
+	validate = validation.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func decodeGetFundingScheduleRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	IDStr := bone.GetValue(r, "id")
+
+	var req endpoint.GetFundingScheduleRequest
+
+	ID, err := uuid.Parse(IDStr)
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+
+	req.ID = ID
+
+	return req, nil
+}
+
+func decodeCreateFundingScheduleRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req endpoint.CreateFundingScheduleRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.UnparsableJSON
+	}
+	defer r.Body.Close()
+
+	validate = validation.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func decodeUpdateFundingScheduleRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req endpoint.UpdateFundingScheduleRequest
+
+	IDStr := bone.GetValue(r, "id")
+	ID, err := uuid.Parse(IDStr)
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.UnparsableJSON
+	}
+	defer r.Body.Close()
+
+	req.ID = ID
+
+	validate = validation.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func decodeDeleteFundingScheduleRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req endpoint.DeleteFundingScheduleRequest
+
+	IDStr := bone.GetValue(r, "id")
+	ID, err := uuid.Parse(IDStr)
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+
+	req.ID = ID
+
+	return req, nil
+}
+
+func decodeGetFundingRunsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	skip := 0
+	limit := 10
+
+	var req endpoint.GetFundingRunsRequest
+
+	IDStr := bone.GetValue(r, "id")
+	ID, err := uuid.Parse(IDStr)
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+	req.ID = ID
+
+	skipParam := r.URL.Query().Get("skip")
+	if skipParam != "" {
+		skip, _ = strconv.Atoi(skipParam)
+	}
+	req.Skip = skip
+
+	limitParam := r.URL.Query().Get("limit")
+	if limitParam != "" {
+		limit, _ = strconv.Atoi(limitParam)
+	}
+	req.Limit = limit
+
+	validate = validation.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
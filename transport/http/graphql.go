@@ -0,0 +1,45 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"gitlab.com/renodesper/spenmo-test/endpoint"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/validation"
+)
+
+// decodeGraphQLRequest reads the query from the JSON body of a POST or from
+// the query string of a GET, where variables is a JSON object
+func decodeGraphQLRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req endpoint.GraphQLRequest
+
+	if r.Method == http.MethodGet {
+		req.Query = r.URL.Query().Get("query")
+		req.OperationName = r.URL.Query().Get("operationName")
+
+		if variables := r.URL.Query().Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				return nil, errors.UnparsableJSON
+			}
+		}
+	} else {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, errors.UnparsableJSON
+		}
+		defer r.Body.Close()
+	}
+
+	validate = validation.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+// encodeGraphQLResponse writes the result as is, GraphQL clients expect data
+// and errors at the top level
+func encodeGraphQLResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	return json.NewEncoder(w).Encode(response)
+}
@@ -2,28 +2,28 @@ package http
 
 import (
 	"context"
+	"math"
 	"net/http"
-	"time"
+	"strconv"
+	"strings"
 
 	kitendpoint "github.com/go-kit/kit/endpoint"
-	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	httptransport "github.com/go-kit/kit/transport/http"
 	"github.com/go-playground/validator"
 	"github.com/go-zoo/bone"
 	jsoniter "github.com/json-iterator/go"
-	"github.com/juju/ratelimit"
-	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/viper"
 	"gitlab.com/renodesper/spenmo-test/endpoint"
 	m "gitlab.com/renodesper/spenmo-test/middleware"
 	"gitlab.com/renodesper/spenmo-test/middleware/metric"
-	"gitlab.com/renodesper/spenmo-test/middleware/ratelimiter"
 	"gitlab.com/renodesper/spenmo-test/middleware/recover"
+	"gitlab.com/renodesper/spenmo-test/middleware/tracing"
 	ctxUtil "gitlab.com/renodesper/spenmo-test/util/ctx"
 	e "gitlab.com/renodesper/spenmo-test/util/error"
 	"gitlab.com/renodesper/spenmo-test/util/errors"
 	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/ratelimit"
 	resp "gitlab.com/renodesper/spenmo-test/util/response"
 )
 
@@ -32,13 +32,34 @@ var (
 	validate *validator.Validate
 )
 
-// NewHTTPHandler ...
-func NewHTTPHandler(endpoints endpoint.Set, log logger.Logger) http.Handler {
+const dateLayout = "2006-01-02"
+
+// NewHTTPHandler serves the endpoints, limiter is nil when the requests are
+// not rate limited and metrics is nil when they are not measured
+func NewHTTPHandler(endpoints endpoint.Set, log logger.Logger, limiter *ratelimit.Limiter, metrics *metric.Metrics) http.Handler {
+	r := newRouter(endpoints, log, metrics)
+
+	var handler http.Handler = r
+	if limiter != nil {
+		handler = rateLimit(log, limiter, handler)
+	}
+	if metrics != nil {
+		handler = instrument(metrics, r, handler)
+	}
+
+	return traced(r, handler)
+}
+
+// newRouter routes every endpoint, the requests are not limited, measured or
+// traced before they reach the endpoint middlewares
+func newRouter(endpoints endpoint.Set, log logger.Logger, metrics *metric.Metrics) *bone.Mux {
 	r := bone.New()
 
 	// NOTE: Will be executed on the HTTP request object before the request is decoded
 	serverRequestOpts := []httptransport.RequestFunc{
+		httptransport.PopulateRequestContext,
 		ctxUtil.ExtractRequestID,
+		ctxUtil.ExtractUserID,
 	}
 
 	// NOTE: Will be executed on the HTTP response writer after the endpoint is invoked, but before anything written to the client
@@ -53,101 +74,247 @@ func NewHTTPHandler(endpoints endpoint.Set, log logger.Logger) http.Handler {
 	}
 
 	// NOTE: Middlewares
-	publicMiddlewares := prepareMiddleware(log)
+	publicMiddlewares := prepareMiddleware(log, metrics)
 
 	// NOTE: Routes
 	r.NotFound(http.HandlerFunc(notFound))
 
-	GetHealthCheckEndpoint := m.Chain(publicMiddlewares)(endpoints.GetHealthCheckEndpoint)
+	GetHealthCheckEndpoint := m.Chain(publicMiddlewares("GetHealthCheck"))(endpoints.GetHealthCheckEndpoint)
 	r.Get("/health", httptransport.NewServer(GetHealthCheckEndpoint, decodeNothing, encodeResponse, serverOpts...))
 
-	GetAllUsersEndpoint := m.Chain(publicMiddlewares)(endpoints.GetAllUsersEndpoint)
+	GetAllUsersEndpoint := m.Chain(publicMiddlewares("GetAllUsers"))(endpoints.GetAllUsersEndpoint)
 	r.Get("/users", httptransport.NewServer(GetAllUsersEndpoint, decodeGetAllUsersRequest, encodeResponse, serverOpts...))
 
-	GetUserByIDEndpoint := m.Chain(publicMiddlewares)(endpoints.GetUserByIDEndpoint)
+	GetUserByIDEndpoint := m.Chain(publicMiddlewares("GetUserByID"))(endpoints.GetUserByIDEndpoint)
 	r.Get("/users/:id", httptransport.NewServer(GetUserByIDEndpoint, decodeGetUserRequest, encodeResponse, serverOpts...))
 
-	CreateUserEndpoint := m.Chain(publicMiddlewares)(endpoints.CreateUserEndpoint)
+	CreateUserEndpoint := m.Chain(publicMiddlewares("CreateUser"))(endpoints.CreateUserEndpoint)
 	r.Post("/users", httptransport.NewServer(CreateUserEndpoint, decodeCreateUserRequest, encodeResponse, serverOpts...))
 
-	UpdateUserEndpoint := m.Chain(publicMiddlewares)(endpoints.UpdateUserEndpoint)
+	UpdateUserEndpoint := m.Chain(publicMiddlewares("UpdateUser"))(endpoints.UpdateUserEndpoint)
 	r.Put("/users/:id", httptransport.NewServer(UpdateUserEndpoint, decodeUpdateUserRequest, encodeResponse, serverOpts...))
 
-	DeleteUserByIDEndpoint := m.Chain(publicMiddlewares)(endpoints.DeleteUserByIDEndpoint)
+	DeleteUserByIDEndpoint := m.Chain(publicMiddlewares("DeleteUserByID"))(endpoints.DeleteUserByIDEndpoint)
 	r.Delete("/users/:id", httptransport.NewServer(DeleteUserByIDEndpoint, decodeDeleteUserRequest, encodeResponse, serverOpts...))
 
-	GetAllTeamsEndpoint := m.Chain(publicMiddlewares)(endpoints.GetAllTeamsEndpoint)
+	GetAllTeamsEndpoint := m.Chain(publicMiddlewares("GetAllTeams"))(endpoints.GetAllTeamsEndpoint)
 	r.Get("/teams", httptransport.NewServer(GetAllTeamsEndpoint, decodeGetAllTeamsRequest, encodeResponse, serverOpts...))
 
-	GetTeamEndpoint := m.Chain(publicMiddlewares)(endpoints.GetTeamEndpoint)
+	GetTeamEndpoint := m.Chain(publicMiddlewares("GetTeam"))(endpoints.GetTeamEndpoint)
 	r.Get("/teams/:id", httptransport.NewServer(GetTeamEndpoint, decodeGetTeamRequest, encodeResponse, serverOpts...))
 
-	CreateTeamEndpoint := m.Chain(publicMiddlewares)(endpoints.CreateTeamEndpoint)
+	CreateTeamEndpoint := m.Chain(publicMiddlewares("CreateTeam"))(endpoints.CreateTeamEndpoint)
 	r.Post("/teams", httptransport.NewServer(CreateTeamEndpoint, decodeCreateTeamRequest, encodeResponse, serverOpts...))
 
-	UpdateTeamEndpoint := m.Chain(publicMiddlewares)(endpoints.UpdateTeamEndpoint)
+	UpdateTeamEndpoint := m.Chain(publicMiddlewares("UpdateTeam"))(endpoints.UpdateTeamEndpoint)
 	r.Put("/teams/:id", httptransport.NewServer(UpdateTeamEndpoint, decodeUpdateTeamRequest, encodeResponse, serverOpts...))
 
-	DeleteTeamEndpoint := m.Chain(publicMiddlewares)(endpoints.DeleteTeamEndpoint)
+	DeleteTeamEndpoint := m.Chain(publicMiddlewares("DeleteTeam"))(endpoints.DeleteTeamEndpoint)
 	r.Delete("/teams/:id", httptransport.NewServer(DeleteTeamEndpoint, decodeDeleteTeamRequest, encodeResponse, serverOpts...))
 
-	GetTeamMembersEndpoint := m.Chain(publicMiddlewares)(endpoints.GetTeamMembersEndpoint)
+	GetTeamSpendEndpoint := m.Chain(publicMiddlewares("GetTeamSpend"))(endpoints.GetTeamSpendEndpoint)
+	r.Get("/teams/:id/spend", httptransport.NewServer(GetTeamSpendEndpoint, decodeGetTeamSpendRequest, encodeResponse, serverOpts...))
+
+	GetTeamExportEndpoint := m.Chain(publicMiddlewares("GetTeamExport"))(endpoints.GetTeamExportEndpoint)
+	r.Get("/teams/:id/export", httptransport.NewServer(GetTeamExportEndpoint, decodeGetTeamExportRequest, encodeExportResponse(log), serverOpts...))
+
+	GetChartOfAccountsEndpoint := m.Chain(publicMiddlewares("GetChartOfAccounts"))(endpoints.GetChartOfAccountsEndpoint)
+	r.Get("/teams/:id/chart-of-accounts", httptransport.NewServer(GetChartOfAccountsEndpoint, decodeGetChartOfAccountsRequest, encodeResponse, serverOpts...))
+
+	SetChartOfAccountsEndpoint := m.Chain(publicMiddlewares("SetChartOfAccounts"))(endpoints.SetChartOfAccountsEndpoint)
+	r.Put("/teams/:id/chart-of-accounts", httptransport.NewServer(SetChartOfAccountsEndpoint, decodeSetChartOfAccountsRequest, encodeResponse, serverOpts...))
+
+	GetTeamMembersEndpoint := m.Chain(publicMiddlewares("GetTeamMembers"))(endpoints.GetTeamMembersEndpoint)
 	r.Get("/team-members", httptransport.NewServer(GetTeamMembersEndpoint, decodeGetTeamMembersRequest, encodeResponse, serverOpts...))
 
-	CreateTeamMemberEndpoint := m.Chain(publicMiddlewares)(endpoints.CreateTeamMemberEndpoint)
+	CreateTeamMemberEndpoint := m.Chain(publicMiddlewares("CreateTeamMember"))(endpoints.CreateTeamMemberEndpoint)
 	r.Post("/team-members", httptransport.NewServer(CreateTeamMemberEndpoint, decodeCreateTeamMemberRequest, encodeResponse, serverOpts...))
 
-	DeleteTeamMemberEndpoint := m.Chain(publicMiddlewares)(endpoints.DeleteTeamMemberEndpoint)
+	UpdateTeamMemberEndpoint := m.Chain(publicMiddlewares("UpdateTeamMember"))(endpoints.UpdateTeamMemberEndpoint)
+	r.Put("/team-members", httptransport.NewServer(UpdateTeamMemberEndpoint, decodeUpdateTeamMemberRequest, encodeResponse, serverOpts...))
+
+	DeleteTeamMemberEndpoint := m.Chain(publicMiddlewares("DeleteTeamMember"))(endpoints.DeleteTeamMemberEndpoint)
 	r.Delete("/team-members", httptransport.NewServer(DeleteTeamMemberEndpoint, decodeDeleteTeamMemberRequest, encodeResponse, serverOpts...))
 
-	DeleteTeamMembersByTeamIDEndpoint := m.Chain(publicMiddlewares)(endpoints.DeleteTeamMembersByTeamIDEndpoint)
+	DeleteTeamMembersByTeamIDEndpoint := m.Chain(publicMiddlewares("DeleteTeamMembersByTeamID"))(endpoints.DeleteTeamMembersByTeamIDEndpoint)
 	r.Delete("/team-members/teams/:teamId", httptransport.NewServer(DeleteTeamMembersByTeamIDEndpoint, decodeDeleteTeamMembersByTeamIDRequest, encodeResponse, serverOpts...))
 
-	DeleteTeamMembersByUserIDEndpoint := m.Chain(publicMiddlewares)(endpoints.DeleteTeamMembersByUserIDEndpoint)
+	DeleteTeamMembersByUserIDEndpoint := m.Chain(publicMiddlewares("DeleteTeamMembersByUserID"))(endpoints.DeleteTeamMembersByUserIDEndpoint)
 	r.Delete("/team-members/users/:userId", httptransport.NewServer(DeleteTeamMembersByUserIDEndpoint, decodeDeleteTeamMembersByUserIDRequest, encodeResponse, serverOpts...))
 
-	GetAllWalletsEndpoint := m.Chain(publicMiddlewares)(endpoints.GetAllWalletsEndpoint)
+	GetAllWalletsEndpoint := m.Chain(publicMiddlewares("GetAllWallets"))(endpoints.GetAllWalletsEndpoint)
 	r.Get("/wallets", httptransport.NewServer(GetAllWalletsEndpoint, decodeGetAllWalletsRequest, encodeResponse, serverOpts...))
 
-	GetWalletEndpoint := m.Chain(publicMiddlewares)(endpoints.GetWalletEndpoint)
+	GetWalletEndpoint := m.Chain(publicMiddlewares("GetWallet"))(endpoints.GetWalletEndpoint)
 	r.Get("/wallets/:id", httptransport.NewServer(GetWalletEndpoint, decodeGetWalletRequest, encodeResponse, serverOpts...))
 
-	CreateWalletEndpoint := m.Chain(publicMiddlewares)(endpoints.CreateWalletEndpoint)
+	CreateWalletEndpoint := m.Chain(publicMiddlewares("CreateWallet"))(endpoints.CreateWalletEndpoint)
 	r.Post("/wallets", httptransport.NewServer(CreateWalletEndpoint, decodeCreateWalletRequest, encodeResponse, serverOpts...))
 
-	UpdateWalletEndpoint := m.Chain(publicMiddlewares)(endpoints.UpdateWalletEndpoint)
+	UpdateWalletEndpoint := m.Chain(publicMiddlewares("UpdateWallet"))(endpoints.UpdateWalletEndpoint)
 	r.Put("/wallets/:id", httptransport.NewServer(UpdateWalletEndpoint, decodeUpdateWalletRequest, encodeResponse, serverOpts...))
 
-	DeleteWalletByIDEndpoint := m.Chain(publicMiddlewares)(endpoints.DeleteWalletByIDEndpoint)
+	DeleteWalletByIDEndpoint := m.Chain(publicMiddlewares("DeleteWalletByID"))(endpoints.DeleteWalletByIDEndpoint)
 	r.Delete("/wallets/:id", httptransport.NewServer(DeleteWalletByIDEndpoint, decodeDeleteWalletByIDRequest, encodeResponse, serverOpts...))
 
-	DeleteWalletsByTeamIDEndpoint := m.Chain(publicMiddlewares)(endpoints.DeleteWalletsByTeamIDEndpoint)
+	DeleteWalletsByTeamIDEndpoint := m.Chain(publicMiddlewares("DeleteWalletsByTeamID"))(endpoints.DeleteWalletsByTeamIDEndpoint)
 	r.Delete("/wallets/teams/:teamId", httptransport.NewServer(DeleteWalletsByTeamIDEndpoint, decodeDeleteWalletsByTeamIDRequest, encodeResponse, serverOpts...))
 
-	DeleteWalletsByUserIDEndpoint := m.Chain(publicMiddlewares)(endpoints.DeleteWalletsByUserIDEndpoint)
+	DeleteWalletsByUserIDEndpoint := m.Chain(publicMiddlewares("DeleteWalletsByUserID"))(endpoints.DeleteWalletsByUserIDEndpoint)
 	r.Delete("/wallets/users/:userId", httptransport.NewServer(DeleteWalletsByUserIDEndpoint, decodeDeleteWallestByUserIDRequest, encodeResponse, serverOpts...))
 
-	GetAllCardsEndpoint := m.Chain(publicMiddlewares)(endpoints.GetAllCardsEndpoint)
+	GetWalletStatementEndpoint := m.Chain(publicMiddlewares("GetWalletStatement"))(endpoints.GetWalletStatementEndpoint)
+	r.Get("/wallets/:id/statements", httptransport.NewServer(GetWalletStatementEndpoint, decodeGetWalletStatementRequest, encodeStatementResponse(log), serverOpts...))
+
+	GetWalletControlsEndpoint := m.Chain(publicMiddlewares("GetWalletControls"))(endpoints.GetWalletControlsEndpoint)
+	r.Get("/wallets/:id/controls", httptransport.NewServer(GetWalletControlsEndpoint, decodeGetSpendControlsRequest, encodeResponse, serverOpts...))
+
+	SetWalletControlsEndpoint := m.Chain(publicMiddlewares("SetWalletControls"))(endpoints.SetWalletControlsEndpoint)
+	r.Put("/wallets/:id/controls", httptransport.NewServer(SetWalletControlsEndpoint, decodeSetSpendControlsRequest, encodeResponse, serverOpts...))
+
+	DeleteWalletControlsEndpoint := m.Chain(publicMiddlewares("DeleteWalletControls"))(endpoints.DeleteWalletControlsEndpoint)
+	r.Delete("/wallets/:id/controls", httptransport.NewServer(DeleteWalletControlsEndpoint, decodeDeleteSpendControlsRequest, encodeResponse, serverOpts...))
+
+	GetAllCardsEndpoint := m.Chain(publicMiddlewares("GetAllCards"))(endpoints.GetAllCardsEndpoint)
 	r.Get("/cards", httptransport.NewServer(GetAllCardsEndpoint, decodeGetAllCardsRequest, encodeResponse, serverOpts...))
 
-	GetCardEndpoint := m.Chain(publicMiddlewares)(endpoints.GetCardEndpoint)
+	GetCardEndpoint := m.Chain(publicMiddlewares("GetCard"))(endpoints.GetCardEndpoint)
 	r.Get("/cards/:id", httptransport.NewServer(GetCardEndpoint, decodeGetCardRequest, encodeResponse, serverOpts...))
 
-	CreateCardEndpoint := m.Chain(publicMiddlewares)(endpoints.CreateCardEndpoint)
+	CreateCardEndpoint := m.Chain(publicMiddlewares("CreateCard"))(endpoints.CreateCardEndpoint)
 	r.Post("/cards", httptransport.NewServer(CreateCardEndpoint, decodeCreateCardRequest, encodeResponse, serverOpts...))
 
-	UpdateCardEndpoint := m.Chain(publicMiddlewares)(endpoints.UpdateCardEndpoint)
+	UpdateCardEndpoint := m.Chain(publicMiddlewares("UpdateCard"))(endpoints.UpdateCardEndpoint)
 	r.Put("/cards/:id", httptransport.NewServer(UpdateCardEndpoint, decodeUpdateCardRequest, encodeResponse, serverOpts...))
 
-	DeleteCardByIDEndpoint := m.Chain(publicMiddlewares)(endpoints.DeleteCardByIDEndpoint)
+	DeleteCardByIDEndpoint := m.Chain(publicMiddlewares("DeleteCardByID"))(endpoints.DeleteCardByIDEndpoint)
 	r.Delete("/cards/:id", httptransport.NewServer(DeleteCardByIDEndpoint, decodeDeleteCardByIDRequest, encodeResponse, serverOpts...))
 
-	DeleteCardsByWalletIDEndpoint := m.Chain(publicMiddlewares)(endpoints.DeleteCardsByWalletIDEndpoint)
+	DeleteCardsByWalletIDEndpoint := m.Chain(publicMiddlewares("DeleteCardsByWalletID"))(endpoints.DeleteCardsByWalletIDEndpoint)
 	r.Delete("/cards/wallets/:walletId", httptransport.NewServer(DeleteCardsByWalletIDEndpoint, decodeDeleteCardsByWalletIDRequest, encodeResponse, serverOpts...))
 
+	FreezeCardEndpoint := m.Chain(publicMiddlewares("FreezeCard"))(endpoints.FreezeCardEndpoint)
+	r.Post("/cards/:id/freeze", httptransport.NewServer(FreezeCardEndpoint, decodeFreezeCardRequest, encodeResponse, serverOpts...))
+
+	UnfreezeCardEndpoint := m.Chain(publicMiddlewares("UnfreezeCard"))(endpoints.UnfreezeCardEndpoint)
+	r.Post("/cards/:id/unfreeze", httptransport.NewServer(UnfreezeCardEndpoint, decodeFreezeCardRequest, encodeResponse, serverOpts...))
+
+	GetCardAuditsEndpoint := m.Chain(publicMiddlewares("GetCardAudits"))(endpoints.GetCardAuditsEndpoint)
+	r.Get("/cards/:id/audits", httptransport.NewServer(GetCardAuditsEndpoint, decodeGetCardAuditsRequest, encodeResponse, serverOpts...))
+
+	GetCardControlsEndpoint := m.Chain(publicMiddlewares("GetCardControls"))(endpoints.GetCardControlsEndpoint)
+	r.Get("/cards/:id/controls", httptransport.NewServer(GetCardControlsEndpoint, decodeGetSpendControlsRequest, encodeResponse, serverOpts...))
+
+	SetCardControlsEndpoint := m.Chain(publicMiddlewares("SetCardControls"))(endpoints.SetCardControlsEndpoint)
+	r.Put("/cards/:id/controls", httptransport.NewServer(SetCardControlsEndpoint, decodeSetSpendControlsRequest, encodeResponse, serverOpts...))
+
+	DeleteCardControlsEndpoint := m.Chain(publicMiddlewares("DeleteCardControls"))(endpoints.DeleteCardControlsEndpoint)
+	r.Delete("/cards/:id/controls", httptransport.NewServer(DeleteCardControlsEndpoint, decodeDeleteSpendControlsRequest, encodeResponse, serverOpts...))
+
+	GetTransactionsEndpoint := m.Chain(publicMiddlewares("GetTransactions"))(endpoints.GetTransactionsEndpoint)
+	r.Get("/transactions", httptransport.NewServer(GetTransactionsEndpoint, decodeGetTransactionsRequest, encodeResponse, serverOpts...))
+
+	GetTransactionEndpoint := m.Chain(publicMiddlewares("GetTransaction"))(endpoints.GetTransactionEndpoint)
+	r.Get("/transactions/:id", httptransport.NewServer(GetTransactionEndpoint, decodeGetTransactionRequest, encodeResponse, serverOpts...))
+
+	UpdateTransactionEndpoint := m.Chain(publicMiddlewares("UpdateTransaction"))(endpoints.UpdateTransactionEndpoint)
+	r.Put("/transactions/:id", httptransport.NewServer(UpdateTransactionEndpoint, decodeUpdateTransactionRequest, encodeResponse, serverOpts...))
+
+	GetReceiptsEndpoint := m.Chain(publicMiddlewares("GetReceipts"))(endpoints.GetReceiptsEndpoint)
+	r.Get("/transactions/:id/receipts", httptransport.NewServer(GetReceiptsEndpoint, decodeGetReceiptsRequest, encodeResponse, serverOpts...))
+
+	GetReceiptEndpoint := m.Chain(publicMiddlewares("GetReceipt"))(endpoints.GetReceiptEndpoint)
+	r.Get("/transactions/:id/receipts/:receiptId", httptransport.NewServer(GetReceiptEndpoint, decodeGetReceiptRequest, encodeReceiptResponse, serverOpts...))
+
+	CreateReceiptEndpoint := m.Chain(publicMiddlewares("CreateReceipt"))(endpoints.CreateReceiptEndpoint)
+	r.Post("/transactions/:id/receipts", httptransport.NewServer(CreateReceiptEndpoint, decodeCreateReceiptRequest, encodeResponse, serverOpts...))
+
+	CreateTransferEndpoint := m.Chain(publicMiddlewares("CreateTransfer"))(endpoints.CreateTransferEndpoint)
+	r.Post("/transfers", httptransport.NewServer(CreateTransferEndpoint, decodeCreateTransferRequest, encodeResponse, serverOpts...))
+
+	CreateCardTransactionEndpoint := m.Chain(publicMiddlewares("CreateCardTransaction"))(endpoints.CreateCardTransactionEndpoint)
+	r.Post("/cards/:id/transactions", httptransport.NewServer(CreateCardTransactionEndpoint, decodeCreateCardTransactionRequest, encodeResponse, serverOpts...))
+
+	GetBudgetsEndpoint := m.Chain(publicMiddlewares("GetBudgets"))(endpoints.GetBudgetsEndpoint)
+	r.Get("/budgets", httptransport.NewServer(GetBudgetsEndpoint, decodeGetBudgetsRequest, encodeResponse, serverOpts...))
+
+	GetBudgetEndpoint := m.Chain(publicMiddlewares("GetBudget"))(endpoints.GetBudgetEndpoint)
+	r.Get("/budgets/:id", httptransport.NewServer(GetBudgetEndpoint, decodeGetBudgetRequest, encodeResponse, serverOpts...))
+
+	CreateBudgetEndpoint := m.Chain(publicMiddlewares("CreateBudget"))(endpoints.CreateBudgetEndpoint)
+	r.Post("/budgets", httptransport.NewServer(CreateBudgetEndpoint, decodeCreateBudgetRequest, encodeResponse, serverOpts...))
+
+	UpdateBudgetEndpoint := m.Chain(publicMiddlewares("UpdateBudget"))(endpoints.UpdateBudgetEndpoint)
+	r.Put("/budgets/:id", httptransport.NewServer(UpdateBudgetEndpoint, decodeUpdateBudgetRequest, encodeResponse, serverOpts...))
+
+	DeleteBudgetEndpoint := m.Chain(publicMiddlewares("DeleteBudget"))(endpoints.DeleteBudgetEndpoint)
+	r.Delete("/budgets/:id", httptransport.NewServer(DeleteBudgetEndpoint, decodeDeleteBudgetRequest, encodeResponse, serverOpts...))
+
+	GetBudgetAlertsEndpoint := m.Chain(publicMiddlewares("GetBudgetAlerts"))(endpoints.GetBudgetAlertsEndpoint)
+	r.Get("/budgets/:id/alerts", httptransport.NewServer(GetBudgetAlertsEndpoint, decodeGetBudgetAlertsRequest, encodeResponse, serverOpts...))
+
+	GetSpendLimitsEndpoint := m.Chain(publicMiddlewares("GetSpendLimits"))(endpoints.GetSpendLimitsEndpoint)
+	r.Get("/spend-limits", httptransport.NewServer(GetSpendLimitsEndpoint, decodeGetSpendLimitsRequest, encodeResponse, serverOpts...))
+
+	GetSpendLimitEndpoint := m.Chain(publicMiddlewares("GetSpendLimit"))(endpoints.GetSpendLimitEndpoint)
+	r.Get("/spend-limits/:id", httptransport.NewServer(GetSpendLimitEndpoint, decodeGetSpendLimitRequest, encodeResponse, serverOpts...))
+
+	CreateSpendLimitEndpoint := m.Chain(publicMiddlewares("CreateSpendLimit"))(endpoints.CreateSpendLimitEndpoint)
+	r.Post("/spend-limits", httptransport.NewServer(CreateSpendLimitEndpoint, decodeCreateSpendLimitRequest, encodeResponse, serverOpts...))
+
+	UpdateSpendLimitEndpoint := m.Chain(publicMiddlewares("UpdateSpendLimit"))(endpoints.UpdateSpendLimitEndpoint)
+	r.Put("/spend-limits/:id", httptransport.NewServer(UpdateSpendLimitEndpoint, decodeUpdateSpendLimitRequest, encodeResponse, serverOpts...))
+
+	DeleteSpendLimitEndpoint := m.Chain(publicMiddlewares("DeleteSpendLimit"))(endpoints.DeleteSpendLimitEndpoint)
+	r.Delete("/spend-limits/:id", httptransport.NewServer(DeleteSpendLimitEndpoint, decodeDeleteSpendLimitRequest, encodeResponse, serverOpts...))
+
+	GetFundingSchedulesEndpoint := m.Chain(publicMiddlewares("GetFundingSchedules"))(endpoints.GetFundingSchedulesEndpoint)
+	r.Get("/funding-schedules", httptransport.NewServer(GetFundingSchedulesEndpoint, decodeGetFundingSchedulesRequest, encodeResponse, serverOpts...))
+
+	GetFundingScheduleEndpoint := m.Chain(publicMiddlewares("GetFundingSchedule"))(endpoints.GetFundingScheduleEndpoint)
+	r.Get("/funding-schedules/:id", httptransport.NewServer(GetFundingScheduleEndpoint, decodeGetFundingScheduleRequest, encodeResponse, serverOpts...))
+
+	CreateFundingScheduleEndpoint := m.Chain(publicMiddlewares("CreateFundingSchedule"))(endpoints.CreateFundingScheduleEndpoint)
+	r.Post("/funding-schedules", httptransport.NewServer(CreateFundingScheduleEndpoint, decodeCreateFundingScheduleRequest, encodeResponse, serverOpts...))
+
+	UpdateFundingScheduleEndpoint := m.Chain(publicMiddlewares("UpdateFundingSchedule"))(endpoints.UpdateFundingScheduleEndpoint)
+	r.Put("/funding-schedules/:id", httptransport.NewServer(UpdateFundingScheduleEndpoint, decodeUpdateFundingScheduleRequest, encodeResponse, serverOpts...))
+
+	DeleteFundingScheduleEndpoint := m.Chain(publicMiddlewares("DeleteFundingSchedule"))(endpoints.DeleteFundingScheduleEndpoint)
+	r.Delete("/funding-schedules/:id", httptransport.NewServer(DeleteFundingScheduleEndpoint, decodeDeleteFundingScheduleRequest, encodeResponse, serverOpts...))
+
+	GetFundingRunsEndpoint := m.Chain(publicMiddlewares("GetFundingRuns"))(endpoints.GetFundingRunsEndpoint)
+	r.Get("/funding-schedules/:id/runs", httptransport.NewServer(GetFundingRunsEndpoint, decodeGetFundingRunsRequest, encodeResponse, serverOpts...))
+
+	GetFundRequestsEndpoint := m.Chain(publicMiddlewares("GetFundRequests"))(endpoints.GetFundRequestsEndpoint)
+	r.Get("/fund-requests", httptransport.NewServer(GetFundRequestsEndpoint, decodeGetFundRequestsRequest, encodeResponse, serverOpts...))
+
+	GetFundRequestEndpoint := m.Chain(publicMiddlewares("GetFundRequest"))(endpoints.GetFundRequestEndpoint)
+	r.Get("/fund-requests/:id", httptransport.NewServer(GetFundRequestEndpoint, decodeGetFundRequestRequest, encodeResponse, serverOpts...))
+
+	CreateFundRequestEndpoint := m.Chain(publicMiddlewares("CreateFundRequest"))(endpoints.CreateFundRequestEndpoint)
+	r.Post("/fund-requests", httptransport.NewServer(CreateFundRequestEndpoint, decodeCreateFundRequestRequest, encodeResponse, serverOpts...))
+
+	ApproveFundRequestEndpoint := m.Chain(publicMiddlewares("ApproveFundRequest"))(endpoints.ApproveFundRequestEndpoint)
+	r.Post("/fund-requests/:id/approve", httptransport.NewServer(ApproveFundRequestEndpoint, decodeApproveFundRequestRequest, encodeResponse, serverOpts...))
+
+	RejectFundRequestEndpoint := m.Chain(publicMiddlewares("RejectFundRequest"))(endpoints.RejectFundRequestEndpoint)
+	r.Post("/fund-requests/:id/reject", httptransport.NewServer(RejectFundRequestEndpoint, decodeRejectFundRequestRequest, encodeResponse, serverOpts...))
+
+	CancelFundRequestEndpoint := m.Chain(publicMiddlewares("CancelFundRequest"))(endpoints.CancelFundRequestEndpoint)
+	r.Post("/fund-requests/:id/cancel", httptransport.NewServer(CancelFundRequestEndpoint, decodeCancelFundRequestRequest, encodeResponse, serverOpts...))
+
+	GraphQLEndpoint := m.Chain(publicMiddlewares("GraphQL"))(endpoints.GraphQLEndpoint)
+	r.Get("/graphql", httptransport.NewServer(GraphQLEndpoint, decodeGraphQLRequest, encodeGraphQLResponse, serverOpts...))
+	r.Post("/graphql", httptransport.NewServer(GraphQLEndpoint, decodeGraphQLRequest, encodeGraphQLResponse, serverOpts...))
+
 	// NOTE: Prometheus metrics endpoint
 	r.Get("/metrics", promhttp.Handler())
 
+	// NOTE: API documentation
+	r.Get("/openapi.json", http.HandlerFunc(serveOpenAPI))
+	r.Get("/docs", http.HandlerFunc(serveSwaggerUI))
+
 	return r
 }
 
@@ -164,20 +331,44 @@ func encodeResponse(ctx context.Context, w http.ResponseWriter, response interfa
 	})
 }
 
+// encodeError answers with the status declared by the error, errors that are
+// not an e.Error are reported as an internal error
 func encodeError(ctx context.Context, err error, w http.ResponseWriter) {
-	er := err.(e.Error)
+	er, ok := err.(e.Error)
+	if !ok {
+		er = errors.UnexpectedError.Wrap(err)
+	}
 	if er.Status == 0 {
-		er.Status = 500
+		er.Status = http.StatusInternalServerError
 	}
 
 	if viper.GetString("app.env") == "production" {
 		er = er.WithoutStackTrace()
 	}
 
-	requestID := ctxUtil.GetRequestID(ctx)
+	accept, _ := ctx.Value(httptransport.ContextKeyRequestAccept).(string)
+	path, _ := ctx.Value(httptransport.ContextKeyRequestPath).(string)
+
+	writeError(w, er, accept, path, ctxUtil.GetRequestID(ctx))
+}
+
+func notFound(w http.ResponseWriter, r *http.Request) {
+	writeError(w, errors.StatusNotFound.WithoutStackTrace(), r.Header.Get("Accept"), r.URL.Path, r.Header.Get("X-Request-Id"))
+}
+
+// writeError writes er as an ErrorResponse, or as a problem document when the
+// client asks for it
+func writeError(w http.ResponseWriter, er e.Error, accept string, path string, requestID string) {
+	if acceptsProblem(accept) {
+		w.Header().Set("Content-Type", resp.ProblemContentType)
+		w.WriteHeader(er.Status)
+
+		_ = json.NewEncoder(w).Encode(resp.NewProblemResponse(er, path, requestID))
+		return
+	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusBadRequest)
+	w.WriteHeader(er.Status)
 
 	_ = json.NewEncoder(w).Encode(&resp.ErrorResponse{
 		Errors: []e.Error{er},
@@ -185,43 +376,51 @@ func encodeError(ctx context.Context, err error, w http.ResponseWriter) {
 	})
 }
 
-func notFound(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusNotFound)
+// acceptsProblem tells whether the Accept header prefers problem documents
+// over plain JSON, wildcards only match the default ErrorResponse
+func acceptsProblem(accept string) bool {
+	problemQ, jsonQ := 0.0, 0.0
+
+	for _, mediaRange := range strings.Split(accept, ",") {
+		params := strings.Split(mediaRange, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+
+		q := 1.0
+		for _, param := range params[1:] {
+			kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+			if len(kv) == 2 && strings.ToLower(kv[0]) == "q" {
+				if value, err := strconv.ParseFloat(kv[1], 64); err == nil {
+					q = value
+				}
+			}
+		}
+
+		switch mediaType {
+		case resp.ProblemContentType:
+			problemQ = math.Max(problemQ, q)
+		case "application/json":
+			jsonQ = math.Max(jsonQ, q)
+		}
+	}
 
-	_ = json.NewEncoder(w).Encode(&resp.ErrorResponse{
-		Errors: []e.Error{errors.StatusNotFound.WithoutStackTrace()},
-		Meta:   resp.PopulateMeta(r.Header.Get("X-Request-Id")),
-	})
+	return problemQ > 0 && problemQ >= jsonQ
 }
 
-func prepareMiddleware(log logger.Logger) m.Middlewares {
-	// NOTE: Rate Limiter
-	ratelimitBucket := ratelimit.NewBucket(1*time.Second, 5)
-
-	// NOTE: Metric
-	fieldKeys := []string{"method", "error"}
-	requestCount := kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
-		Namespace: "spenmo",
-		Subsystem: "test",
-		Name:      "request_count",
-		Help:      "Number of requests received",
-	}, fieldKeys)
-	requestLatency := kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
-		Namespace: "spenmo",
-		Subsystem: "test",
-		Name:      "request_latency_microseconds",
-		Help:      "Total duration of requests in microseconds",
-	}, fieldKeys)
-
-	publicMiddlewares := m.Middlewares{
-		Before: []kitendpoint.Middleware{
-			recover.CreateMiddleware(log),
-			ratelimiter.CreateMiddleware(log, ratelimitBucket),
-			metric.CreateMiddleware(log, requestCount, requestLatency),
-		},
-		After: []kitendpoint.Middleware{},
+func prepareMiddleware(log logger.Logger, metrics *metric.Metrics) m.Factory {
+	return func(method string) m.Middlewares {
+		publicMiddlewares := m.Middlewares{
+			Before: []kitendpoint.Middleware{
+				tracing.CreateMiddleware(log, "http", method),
+				recover.CreateMiddleware(log),
+			},
+			After: []kitendpoint.Middleware{},
+		}
+
+		// NOTE: Metric
+		if metrics != nil {
+			publicMiddlewares.Before = append(publicMiddlewares.Before, metric.CreateMiddleware(log, metrics, "http", method))
+		}
+
+		return publicMiddlewares
 	}
-
-	return publicMiddlewares
 }
@@ -1,11 +1,19 @@
 package http
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
 	"github.com/stretchr/testify/assert"
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	resp "gitlab.com/renodesper/spenmo-test/util/response"
 )
 
 func TestNotFoundHandler(t *testing.T) {
@@ -20,3 +28,160 @@ func TestNotFoundHandler(t *testing.T) {
 
 	assert.Equal(t, http.StatusNotFound, rr.Code)
 }
+
+func TestNotFoundHandlerProblem(t *testing.T) {
+	req := httptest.NewRequest("GET", "/not-found", nil)
+	req.Header.Set("Accept", resp.ProblemContentType)
+	req.Header.Set("X-Request-Id", "d4a6607a-1af7-4571-bdff-2672be72ba0e")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(notFound)
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, resp.ProblemContentType, rr.Header().Get("Content-Type"))
+
+	var problem resp.ProblemResponse
+	if assert.NoError(t, json.NewDecoder(rr.Body).Decode(&problem)) {
+		assert.Equal(t, resp.ProblemResponse{
+			Type:      "urn:spenmo:error:" + errors.StatusNotFound.Code,
+			Title:     "Not Found",
+			Status:    http.StatusNotFound,
+			Detail:    errors.StatusNotFound.Message,
+			Instance:  "/not-found",
+			Code:      errors.StatusNotFound.Code,
+			RequestID: "d4a6607a-1af7-4571-bdff-2672be72ba0e",
+		}, problem)
+	}
+}
+
+func TestEncodeError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"SuccessNotFound", errors.FailedWalletNotFound, http.StatusNotFound, errors.FailedWalletNotFound.Code},
+		{"SuccessTooManyRequests", errors.LimitExceeded, http.StatusTooManyRequests, errors.LimitExceeded.Code},
+		{"SuccessInternalServerError", errors.FailedWalletFetch.AppendError(fmt.Errorf("connection refused")), http.StatusInternalServerError, errors.FailedWalletFetch.Code},
+		{"SuccessBadRequest", errors.UnparsableUUID, http.StatusBadRequest, errors.UnparsableUUID.Code},
+		{"SuccessWithoutStatus", e.Error{Code: "XX0000", Message: "no status"}, http.StatusInternalServerError, "XX0000"},
+		{"SuccessPlainError", fmt.Errorf("boom"), http.StatusInternalServerError, errors.UnexpectedError.Code},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			encodeError(context.Background(), tt.err, rr)
+
+			assert.Equal(t, tt.status, rr.Code)
+			assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+			var res resp.ErrorResponse
+			if assert.NoError(t, json.NewDecoder(rr.Body).Decode(&res)) && assert.Len(t, res.Errors, 1) {
+				assert.Equal(t, tt.status, res.Errors[0].Status)
+				assert.Equal(t, tt.code, res.Errors[0].Code)
+			}
+		})
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	tests := []struct {
+		name    string
+		route   string
+		decode  func(context.Context, *http.Request) (interface{}, error)
+		method  string
+		url     string
+		body    string
+		code    string
+		details []e.Detail
+	}{
+		{"FailedUnparsableWalletID", "/wallets/:id", decodeGetWalletRequest, http.MethodGet, "/wallets/not-a-uuid", "", errors.UnparsableUUID.Code, nil},
+		{"FailedUnparsableUserID", "/users/:id", decodeGetUserRequest, http.MethodGet, "/users/not-a-uuid", "", errors.UnparsableUUID.Code, nil},
+		{"FailedUnparsableTeamID", "/teams/:id", decodeGetTeamRequest, http.MethodGet, "/teams/not-a-uuid", "", errors.UnparsableUUID.Code, nil},
+		{"FailedUnparsableCardID", "/cards/:id", decodeGetCardRequest, http.MethodGet, "/cards/not-a-uuid", "", errors.UnparsableUUID.Code, nil},
+		{"FailedInvalidStatementFormat", "/wallets/:id/statement", decodeGetWalletStatementRequest, http.MethodGet, "/wallets/00000000-0000-0000-0000-000000000001/statement?format=doc", "", errors.InvalidStatementFormat.Code, nil},
+		{"FailedInvalidEmail", "/users", decodeCreateUserRequest, http.MethodPost, "/users", `{"email": "admin", "name": "Admin"}`, errors.InvalidRequest.Code, []e.Detail{
+			{Field: "email", Rule: "email", Message: "email must be a valid email address"},
+		}},
+		{"FailedLimitOutOfRange", "/teams", decodeGetAllTeamsRequest, http.MethodGet, "/teams?limit=1000", "", errors.InvalidRequest.Code, []e.Detail{
+			{Field: "limit", Rule: "max", Message: "limit must be at most 100"},
+		}},
+		{"FailedInvalidExpiry", "/cards", decodeCreateCardRequest, http.MethodPost, "/cards", `{"cardNo": "4111111111111111", "expiryMonth": "00", "expiryYear": "2030", "cvv": "123", "walletId": "00000000-0000-0000-0000-000000000001"}`, errors.InvalidRequest.Code, []e.Detail{
+			{Field: "expiryMonth", Rule: "expiry_month", Message: "expiryMonth must be a month between 01 and 12"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := bone.New()
+			r.Register(tt.method, tt.route, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				_, err := tt.decode(req.Context(), req)
+				if assert.Error(t, err) {
+					encodeError(req.Context(), err, w)
+				}
+			}))
+
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+			var res resp.ErrorResponse
+			if assert.NoError(t, json.NewDecoder(rr.Body).Decode(&res)) && assert.Len(t, res.Errors, 1) {
+				assert.Equal(t, tt.code, res.Errors[0].Code)
+				assert.Equal(t, tt.details, res.Errors[0].Details)
+				assert.Equal(t, tt.details, res.Errors[0].Details)
+			}
+		})
+	}
+}
+
+func TestEncodeErrorProblem(t *testing.T) {
+	er := errors.InvalidRequest.WithDetails(e.Detail{Field: "email", Rule: "email", Message: "email must be a valid email address"})
+
+	ctx := context.WithValue(context.Background(), httptransport.ContextKeyRequestAccept, resp.ProblemContentType)
+	ctx = context.WithValue(ctx, httptransport.ContextKeyRequestPath, "/users")
+
+	rr := httptest.NewRecorder()
+	encodeError(ctx, er, rr)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, resp.ProblemContentType, rr.Header().Get("Content-Type"))
+
+	var problem resp.ProblemResponse
+	if assert.NoError(t, json.NewDecoder(rr.Body).Decode(&problem)) {
+		assert.Equal(t, "urn:spenmo:error:AU1001", problem.Type)
+		assert.Equal(t, "Bad Request", problem.Title)
+		assert.Equal(t, http.StatusBadRequest, problem.Status)
+		assert.Equal(t, "/users", problem.Instance)
+		assert.Equal(t, er.Details, problem.Details)
+	}
+}
+
+func TestAcceptsProblem(t *testing.T) {
+	tests := []struct {
+		accept   string
+		expected bool
+	}{
+		{"", false},
+		{"*/*", false},
+		{"application/json", false},
+		{"application/problem+json", true},
+		{"Application/Problem+JSON", true},
+		{"application/json, application/problem+json", true},
+		{"application/problem+json;q=0.5, application/json", false},
+		{"application/problem+json, application/json;q=0.9", true},
+		{"application/problem+json;q=0", false},
+		{"text/html, application/problem+json;q=0.8, */*;q=0.1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			assert.Equal(t, tt.expected, acceptsProblem(tt.accept))
+		})
+	}
+}
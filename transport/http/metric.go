@@ -0,0 +1,55 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-zoo/bone"
+	"gitlab.com/renodesper/spenmo-test/middleware/metric"
+)
+
+// statusRecorder keeps the status written by the handler, a handler that
+// never calls WriteHeader answers 200
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusRecorder) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusRecorder) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// instrument measures every request by the route pattern it matched, so the
+// ids in the path do not become labels. Unknown routes are reported as
+// NotFound.
+func instrument(metrics *metric.Metrics, r *bone.Mux, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		route := r.GetRequestRoute(req)
+		rec := &statusRecorder{ResponseWriter: w}
+
+		defer func(begin time.Time) {
+			if rec.status == 0 {
+				rec.status = http.StatusOK
+			}
+
+			metrics.HTTPDuration.With(
+				"method", req.Method,
+				"route", route,
+				"status", strconv.Itoa(rec.status),
+			).Observe(time.Since(begin).Seconds())
+		}(time.Now())
+
+		next.ServeHTTP(rec, req)
+	})
+}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/middleware/metric"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger/noop"
+)
+
+func TestMetrics(t *testing.T) {
+	// NOTE: The collectors are not registered, so the test can build them again
+	requestCount := stdprometheus.NewCounterVec(stdprometheus.CounterOpts{Name: "request_count"}, []string{"transport", "method", "error"})
+	httpDuration := stdprometheus.NewHistogramVec(stdprometheus.HistogramOpts{Name: "http_request_duration_seconds"}, []string{"method", "route", "status"})
+	metrics := &metric.Metrics{
+		RequestCount:   kitprometheus.NewCounter(requestCount),
+		RequestLatency: kitprometheus.NewSummary(stdprometheus.NewSummaryVec(stdprometheus.SummaryOpts{Name: "request_latency_seconds"}, []string{"transport", "method", "error"})),
+		HTTPDuration:   kitprometheus.NewHistogram(httpDuration),
+	}
+
+	handler := NewHTTPHandler(stubEndpoints(), noop.CreateLogger(), nil, metrics)
+	serve := func(method string, path string) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(method, path, nil))
+	}
+
+	serve(http.MethodGet, "/wallets/d4a6607a-1af7-4571-bdff-2672be72ba0e")
+	serve(http.MethodGet, "/wallets/933efe12-2219-42df-bd51-a2e84888432d")
+	serve(http.MethodDelete, "/cards/d4a6607a-1af7-4571-bdff-2672be72ba0e")
+	serve(http.MethodGet, "/wallets/not-an-id")
+	serve(http.MethodGet, "/not-found")
+	serve(http.MethodGet, "/health")
+
+	t.Run("SuccessEndpointLabels", func(t *testing.T) {
+		assert.Equal(t, float64(2), testutil.ToFloat64(requestCount.WithLabelValues("http", "GetWallet", errors.StatusNotFound.Code)))
+		assert.Equal(t, float64(1), testutil.ToFloat64(requestCount.WithLabelValues("http", "DeleteCardByID", errors.StatusNotFound.Code)))
+		assert.Equal(t, float64(1), testutil.ToFloat64(requestCount.WithLabelValues("http", "GetHealthCheck", errors.StatusNotFound.Code)))
+
+		// NOTE: Requests that fail to decode never reach the endpoint
+		assert.Len(t, series(t, requestCount), 3)
+	})
+
+	t.Run("SuccessRouteLabels", func(t *testing.T) {
+		assert.ElementsMatch(t, []map[string]string{
+			{"method": http.MethodGet, "route": "/wallets/:id", "status": "404"},
+			{"method": http.MethodGet, "route": "/wallets/:id", "status": "400"},
+			{"method": http.MethodDelete, "route": "/cards/:id", "status": "404"},
+			{"method": http.MethodGet, "route": "NotFound", "status": "404"},
+			{"method": http.MethodGet, "route": "/health", "status": "404"},
+		}, series(t, httpDuration))
+	})
+}
+
+// series lists the labels of every series collected by c
+func series(t *testing.T, c stdprometheus.Collector) []map[string]string {
+	registry := stdprometheus.NewRegistry()
+	registry.MustRegister(c)
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var labels []map[string]string
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			lvs := map[string]string{}
+			for _, pair := range m.GetLabel() {
+				lvs[pair.GetName()] = pair.GetValue()
+			}
+			labels = append(labels, lvs)
+		}
+	}
+
+	return labels
+}
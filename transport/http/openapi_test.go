@@ -0,0 +1,359 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	kitendpoint "github.com/go-kit/kit/endpoint"
+	"github.com/go-zoo/bone"
+	"github.com/google/uuid"
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/endpoint"
+	"gitlab.com/renodesper/spenmo-test/repository"
+	"gitlab.com/renodesper/spenmo-test/service"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger/noop"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+	resp "gitlab.com/renodesper/spenmo-test/util/response"
+)
+
+var (
+	routeParam = regexp.MustCompile(`:(\w+)`)
+	specParam  = regexp.MustCompile(`\{\w+\}`)
+)
+
+func TestOpenAPI(t *testing.T) {
+	openapi3.DefineStringFormat("uuid", `^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+	doc, err := openapi3.NewLoader().LoadFromData(openAPISpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		assert.NoError(t, doc.Validate(context.Background()))
+	})
+
+	handler := newRouter(stubEndpoints(), noop.CreateLogger(), nil)
+	routes := registeredRoutes(handler)
+
+	t.Run("RoutesDocumented", func(t *testing.T) {
+		for _, route := range routes {
+			assert.NotNil(t, findOperation(doc, route.Method, route.Path), "%s %s is not in openapi.json", route.Method, route.Path)
+		}
+	})
+
+	t.Run("OperationsRouted", func(t *testing.T) {
+		registered := map[string]bool{}
+		for _, route := range routes {
+			registered[route.Method+" "+route.Path] = true
+		}
+
+		for path, pathItem := range doc.Paths {
+			for method := range pathItem.Operations() {
+				assert.True(t, registered[method+" "+path], "%s %s is in openapi.json but not routed", method, path)
+			}
+		}
+	})
+
+	// NOTE: The stub endpoints fail, so every route answers with its error or with the error of its decoder
+	t.Run("ResponsesMatchSchema", func(t *testing.T) {
+		for _, accept := range []string{"", resp.ProblemContentType} {
+			for _, route := range routes {
+				path := specParam.ReplaceAllString(route.Path, "d4a6607a-1af7-4571-bdff-2672be72ba0e")
+
+				var body []byte
+				if op := findOperation(doc, route.Method, route.Path); op != nil && op.RequestBody != nil && op.RequestBody.Value.Content.Get("application/json") != nil {
+					body = []byte(`{}`)
+				}
+
+				req := httptest.NewRequest(route.Method, path, bytes.NewReader(body))
+				if accept != "" {
+					req.Header.Set("Accept", accept)
+				}
+				rr := httptest.NewRecorder()
+				handler.ServeHTTP(rr, req)
+
+				assert.NoError(t, validateResponse(doc, route.Method, route.Path, rr), "%s %s %s", route.Method, route.Path, accept)
+			}
+		}
+	})
+
+	t.Run("SuccessResponsesMatchSchema", func(t *testing.T) {
+		fixtures := successFixtures()
+
+		for _, route := range routes {
+			op := findOperation(doc, route.Method, route.Path)
+			if op == nil || route.Path == "/openapi.json" {
+				continue
+			}
+
+			success := op.Responses.Get(http.StatusOK)
+			if success == nil || success.Value.Content.Get("application/json") == nil {
+				continue
+			}
+
+			key := route.Method + " " + route.Path
+			fixture, ok := fixtures[key]
+			if !assert.True(t, ok, "%s has no fixture", key) {
+				continue
+			}
+
+			encode := encodeResponse
+			if route.Path == "/graphql" {
+				encode = encodeGraphQLResponse
+			}
+
+			rr := httptest.NewRecorder()
+			rr.Header().Set("Content-Type", "application/json")
+			if err := encode(context.Background(), rr, fixture); err != nil {
+				t.Fatal(err)
+			}
+
+			assert.NoError(t, validateResponse(doc, route.Method, route.Path, rr), key)
+		}
+	})
+}
+
+// registeredRoutes lists the routes of the router with their parameters
+// written as in openapi.json
+func registeredRoutes(r *bone.Mux) []*bone.Route {
+	var routes []*bone.Route
+	for _, rs := range r.Routes {
+		for _, route := range rs {
+			routes = append(routes, &bone.Route{Method: route.Method, Path: routeParam.ReplaceAllString(route.Path, "{$1}")})
+		}
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].Path+routes[i].Method < routes[j].Path+routes[j].Method
+	})
+
+	return routes
+}
+
+func findOperation(doc *openapi3.T, method string, path string) *openapi3.Operation {
+	pathItem := doc.Paths.Find(path)
+	if pathItem == nil {
+		return nil
+	}
+
+	return pathItem.GetOperation(method)
+}
+
+// validateResponse checks the status and the content type are documented,
+// and the JSON body against its schema
+func validateResponse(doc *openapi3.T, method string, path string, rr *httptest.ResponseRecorder) error {
+	op := findOperation(doc, method, path)
+	if op == nil {
+		return fmt.Errorf("operation is not documented")
+	}
+
+	response := op.Responses.Get(rr.Code)
+	if response == nil {
+		response = op.Responses.Default()
+	}
+	if response == nil {
+		return fmt.Errorf("status %d is not documented", rr.Code)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(rr.Header().Get("Content-Type"))
+	if err != nil {
+		return err
+	}
+
+	content := response.Value.Content.Get(mediaType)
+	if content == nil {
+		return fmt.Errorf("content type %s of status %d is not documented", mediaType, rr.Code)
+	}
+
+	if (mediaType != "application/json" && mediaType != resp.ProblemContentType) || content.Schema == nil {
+		return nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &value); err != nil {
+		return err
+	}
+
+	return content.Schema.Value.VisitJSON(value, openapi3.MultiErrors())
+}
+
+// stubEndpoints fails on every endpoint of the set
+func stubEndpoints() endpoint.Set {
+	var endpoints endpoint.Set
+
+	stub := kitendpoint.Endpoint(func(ctx context.Context, request interface{}) (interface{}, error) {
+		return nil, errors.StatusNotFound
+	})
+
+	v := reflect.ValueOf(&endpoints).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if v.Field(i).Type() == reflect.TypeOf(stub) {
+			v.Field(i).Set(reflect.ValueOf(stub))
+		}
+	}
+
+	return endpoints
+}
+
+// successFixtures holds a response per route, as returned by its endpoint
+func successFixtures() map[string]interface{} {
+	ID := uuid.MustParse("d4a6607a-1af7-4571-bdff-2672be72ba0e")
+	teamID := uuid.MustParse("933efe12-2219-42df-bd51-a2e84888432d")
+	userID := uuid.MustParse("8e159833-5078-4b0a-80a0-363d82bafd60")
+	now := time.Date(2021, 7, 1, 8, 0, 0, 0, time.UTC)
+	amount := money.MustParseDecimal("100.50")
+
+	controls := &repository.SpendControls{BlockedMCCs: []string{"7995"}, AllowedCountries: []string{"SG"}}
+	user := repository.User{ID: userID, Email: "admin@spenmo.com", Name: "Admin", CreatedAt: now, UpdatedAt: now}
+	team := repository.Team{ID: teamID, Name: "Spenmo", Timezone: "Asia/Singapore", CreatedAt: now, UpdatedAt: now}
+	teamMember := repository.TeamMember{ID: ID, TeamID: teamID, UserID: userID, Role: repository.TeamMemberRoleAdmin, CreatedAt: now, UpdatedAt: now}
+	wallet := repository.Wallet{ID: ID, Balance: amount, DailyLimit: amount, MonthlyLimit: amount, Currency: "SGD", TeamID: teamID, Controls: controls, CreatedAt: now, UpdatedAt: now}
+	card := repository.Card{ID: ID, CardNo: "4111111111111111", ExpiryMonth: "12", ExpiryYear: "2025", CVV: "123", DailyLimit: amount, MonthlyLimit: amount, Currency: "SGD", Type: repository.CardTypeStandard, WalletID: ID, CreatedAt: now, UpdatedAt: now}
+	audit := repository.CardAudit{ID: ID, CardID: ID, Action: repository.CardAuditActionDeclined, Rule: "mcc_blocked", UserID: userID, Details: map[string]interface{}{"mcc": "7995"}, CreatedAt: now}
+	transaction := repository.Transaction{ID: ID, ReferenceID: ID, WalletID: ID, CardID: ID, Type: repository.TransactionTypeCard, Direction: repository.TransactionDirectionDebit, Amount: amount, Currency: "SGD", OriginalAmount: &amount, OriginalCurrency: "USD", FxRate: "1.35", FxMarkupBps: 100, Merchant: "Grab", MCC: "4121", Country: "SG", Tags: []string{"travel"}, CreatedAt: now, UpdatedAt: now}
+	attachment := repository.Attachment{ID: ID, TransactionID: ID, Kind: repository.AttachmentKindReceipt, FileName: "receipt.pdf", ContentType: "application/pdf", Size: 1024, UploadedBy: userID, CreatedAt: now}
+	budget := repository.Budget{ID: ID, Name: "Travel", WalletID: ID, Amount: amount, Currency: "SGD", Period: repository.BudgetPeriodMonthly, Thresholds: []int{80, 100}, CreatedAt: now, UpdatedAt: now}
+	alert := repository.BudgetAlert{ID: ID, BudgetID: ID, TransactionID: ID, Threshold: 80, PeriodStart: now, Spent: amount, Amount: amount, Currency: "SGD", CreatedAt: now}
+	spendLimit := repository.SpendLimit{ID: ID, WalletID: ID, Period: repository.SpendLimitPeriodDaily, WindowType: repository.SpendLimitWindowRolling, Amount: amount, Currency: "SGD", CreatedAt: now, UpdatedAt: now}
+	schedule := repository.FundingSchedule{ID: ID, TeamID: teamID, SourceWalletID: ID, TargetWalletID: ID, Mode: repository.FundingModeTopUp, Amount: amount, Currency: "SGD", DayOfMonth: 1, NextRunAt: now, CreatedAt: now, UpdatedAt: now}
+	run := repository.FundingRun{ID: ID, ScheduleID: ID, Period: now, Status: repository.FundingRunStatusSucceeded, Amount: amount, Currency: "SGD", TransactionID: ID, CreatedAt: now}
+	fundRequest := repository.FundRequest{ID: ID, TeamID: teamID, WalletID: ID, RequesterID: userID, Amount: amount, Currency: "SGD", Reason: "Travel", Status: repository.FundRequestStatusPending, CreatedAt: now, UpdatedAt: now}
+	chart := repository.ChartOfAccounts{ID: ID, TeamID: teamID, WalletAccount: "1000", ExpenseAccount: "6000", IncomeAccount: "4000", TransferAccount: "1100", Categories: map[string]string{"travel": "6100"}, CreatedAt: now, UpdatedAt: now}
+	spend := &service.TeamSpend{TeamID: teamID, GroupBy: "card", Interval: "day", From: now, To: now, Series: []service.SpendSeries{{Key: ID.String(), Currency: "SGD", Total: amount, Count: 1, Points: []service.SpendPoint{{Bucket: now, Amount: amount, Count: 1}}}}}
+
+	graphQLResult := &gql.Result{
+		Data: map[string]interface{}{
+			"team": map[string]interface{}{"id": teamID.String(), "members": []interface{}{}},
+			"user": nil,
+		},
+		Errors: []gqlerrors.FormattedError{{
+			Message:    errors.FailedNoRows.Message,
+			Locations:  []location.SourceLocation{{Line: 1, Column: 20}},
+			Path:       []interface{}{"user"},
+			Extensions: map[string]interface{}{"code": errors.FailedNoRows.Code, "status": errors.FailedNoRows.Status},
+		}},
+	}
+
+	list := func(key string, items interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			key: items,
+			"pagination": map[string]interface{}{
+				"sortBy": "created_at",
+				"sort":   "DESC",
+				"skip":   0,
+				"limit":  10,
+			},
+		}
+	}
+	page := func(key string, items interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			key: items,
+			"pagination": map[string]interface{}{
+				"skip":  0,
+				"limit": 10,
+			},
+		}
+	}
+
+	return map[string]interface{}{
+		"GET /health": endpoint.HealthCheckResponse{Version: "1.0"},
+
+		"GET /users":         list("users", []repository.User{user}),
+		"POST /users":        &user,
+		"GET /users/{id}":    &user,
+		"PUT /users/{id}":    &user,
+		"DELETE /users/{id}": &user,
+
+		"GET /teams":                          list("teams", []repository.Team{team}),
+		"POST /teams":                         &team,
+		"GET /teams/{id}":                     &team,
+		"PUT /teams/{id}":                     &team,
+		"DELETE /teams/{id}":                  &team,
+		"GET /teams/{id}/spend":               spend,
+		"GET /teams/{id}/chart-of-accounts":   &chart,
+		"PUT /teams/{id}/chart-of-accounts":   &chart,
+		"GET /team-members":                   list("teamMembers", []repository.TeamMember{teamMember}),
+		"POST /team-members":                  &teamMember,
+		"PUT /team-members":                   &teamMember,
+		"DELETE /team-members":                &teamMember,
+		"DELETE /team-members/teams/{teamId}": []repository.TeamMember{teamMember},
+		"DELETE /team-members/users/{userId}": []repository.TeamMember{teamMember},
+
+		"GET /wallets":                   list("wallets", []repository.Wallet{wallet}),
+		"POST /wallets":                  &wallet,
+		"GET /wallets/{id}":              &wallet,
+		"PUT /wallets/{id}":              &wallet,
+		"DELETE /wallets/{id}":           &wallet,
+		"DELETE /wallets/teams/{teamId}": []repository.Wallet{wallet},
+		"DELETE /wallets/users/{userId}": []repository.Wallet{},
+		"GET /wallets/{id}/controls":     controls,
+		"PUT /wallets/{id}/controls":     controls,
+		"DELETE /wallets/{id}/controls":  &repository.SpendControls{},
+
+		"GET /cards":                       list("cards", []repository.Card{card}),
+		"POST /cards":                      &card,
+		"GET /cards/{id}":                  &card,
+		"PUT /cards/{id}":                  &card,
+		"DELETE /cards/{id}":               &card,
+		"DELETE /cards/wallets/{walletId}": []repository.Card{card},
+		"POST /cards/{id}/freeze":          &card,
+		"POST /cards/{id}/unfreeze":        &card,
+		"GET /cards/{id}/audits":           page("audits", []repository.CardAudit{audit}),
+		"GET /cards/{id}/controls":         controls,
+		"PUT /cards/{id}/controls":         controls,
+		"DELETE /cards/{id}/controls":      &repository.SpendControls{},
+		"POST /cards/{id}/transactions":    &transaction,
+
+		"GET /transactions":                list("transactions", []repository.Transaction{transaction}),
+		"GET /transactions/{id}":           &transaction,
+		"PUT /transactions/{id}":           &transaction,
+		"GET /transactions/{id}/receipts":  []repository.Attachment{attachment},
+		"POST /transactions/{id}/receipts": &attachment,
+		"POST /transfers":                  []repository.Transaction{transaction, transaction},
+
+		"GET /budgets":             list("budgets", []repository.Budget{budget}),
+		"POST /budgets":            &budget,
+		"GET /budgets/{id}":        &budget,
+		"PUT /budgets/{id}":        &budget,
+		"DELETE /budgets/{id}":     &budget,
+		"GET /budgets/{id}/alerts": []repository.BudgetAlert{alert},
+
+		"GET /spend-limits":         list("spendLimits", []repository.SpendLimit{spendLimit}),
+		"POST /spend-limits":        &spendLimit,
+		"GET /spend-limits/{id}":    &spendLimit,
+		"PUT /spend-limits/{id}":    &spendLimit,
+		"DELETE /spend-limits/{id}": &spendLimit,
+
+		"GET /funding-schedules":           list("fundingSchedules", []repository.FundingSchedule{schedule}),
+		"POST /funding-schedules":          &schedule,
+		"GET /funding-schedules/{id}":      &schedule,
+		"PUT /funding-schedules/{id}":      &schedule,
+		"DELETE /funding-schedules/{id}":   &schedule,
+		"GET /funding-schedules/{id}/runs": page("fundingRuns", []repository.FundingRun{run}),
+
+		"GET /fund-requests":               list("fundRequests", []repository.FundRequest{fundRequest}),
+		"POST /fund-requests":              &fundRequest,
+		"GET /fund-requests/{id}":          &fundRequest,
+		"POST /fund-requests/{id}/approve": &fundRequest,
+		"POST /fund-requests/{id}/reject":  &fundRequest,
+		"POST /fund-requests/{id}/cancel":  &fundRequest,
+
+		"GET /graphql":  graphQLResult,
+		"POST /graphql": graphQLResult,
+	}
+}
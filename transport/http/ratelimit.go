@@ -0,0 +1,66 @@
+package http
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/ratelimit"
+)
+
+// rateLimit counts the requests of every client before they are routed, so
+// the unknown routes count too. The limit of the route group is sent in the
+// X-RateLimit-* headers of every response.
+func rateLimit(log logger.Logger, limiter *ratelimit.Limiter, next http.Handler) http.Handler {
+	trustProxy := viper.GetBool("ratelimit.trustProxy")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// NOTE: Prometheus scrapes are not limited
+		if r.URL.Path == "/metrics" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		userID, _ := uuid.Parse(r.Header.Get("X-User-Id"))
+		client := ratelimit.ClientKey(r.Header.Get("X-Api-Key"), userID, clientIP(r, trustProxy))
+
+		res, err := limiter.Allow(r.Context(), r.URL.Path, client)
+		if err != nil {
+			log.Warnw("unable to count rate limited request", "client", client, "error", err)
+		}
+
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(res.Reset.Unix(), 10))
+
+		if !res.Allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(res.RetryAfter))
+			writeError(w, errors.LimitExceeded.WithoutStackTrace(), r.Header.Get("Accept"), r.URL.Path, r.Header.Get("X-Request-Id"))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// clientIP is the address of the peer, or the first address of
+// X-Forwarded-For when the service runs behind a trusted proxy
+func clientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
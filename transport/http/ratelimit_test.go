@@ -0,0 +1,100 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger/noop"
+	"gitlab.com/renodesper/spenmo-test/util/ratelimit"
+	"gitlab.com/renodesper/spenmo-test/util/ratelimit/memory"
+	resp "gitlab.com/renodesper/spenmo-test/util/response"
+)
+
+func TestRateLimit(t *testing.T) {
+	limiter, err := ratelimit.NewLimiter(memory.CreateStore(), ratelimit.Limit{Requests: 2, Period: time.Hour}, []ratelimit.Group{
+		{Name: "graphql", Prefixes: []string{"/graphql"}, Limit: ratelimit.Limit{Requests: 1, Period: time.Hour}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := rateLimit(noop.CreateLogger(), limiter, ok)
+
+	serve := func(path string, header map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = "10.0.0.1:52000"
+		for key, value := range header {
+			req.Header.Set(key, value)
+		}
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("SuccessHeaders", func(t *testing.T) {
+		rr := serve("/users", nil)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "2", rr.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, "1", rr.Header().Get("X-RateLimit-Remaining"))
+
+		reset, err := strconv.ParseInt(rr.Header().Get("X-RateLimit-Reset"), 10, 64)
+		assert.NoError(t, err)
+		assert.True(t, reset > time.Now().Unix())
+	})
+
+	t.Run("FailedLimitExceeded", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve("/teams", nil).Code)
+
+		rr := serve("/users", map[string]string{"Accept": resp.ProblemContentType})
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+		assert.Equal(t, "0", rr.Header().Get("X-RateLimit-Remaining"))
+		assert.NotEmpty(t, rr.Header().Get("Retry-After"))
+		assert.Equal(t, resp.ProblemContentType, rr.Header().Get("Content-Type"))
+
+		var problem resp.ProblemResponse
+		if assert.NoError(t, json.NewDecoder(rr.Body).Decode(&problem)) {
+			assert.Equal(t, errors.LimitExceeded.Code, problem.Code)
+		}
+	})
+
+	t.Run("SuccessOtherClients", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve("/users", map[string]string{"X-User-Id": "8e159833-5078-4b0a-80a0-363d82bafd60"}).Code)
+		assert.Equal(t, http.StatusOK, serve("/users", map[string]string{"X-Api-Key": "secret"}).Code)
+
+		// NOTE: X-Forwarded-For is ignored unless the proxy is trusted
+		assert.Equal(t, http.StatusTooManyRequests, serve("/users", map[string]string{"X-Forwarded-For": "10.0.0.2"}).Code)
+	})
+
+	t.Run("SuccessGroup", func(t *testing.T) {
+		rr := serve("/graphql", nil)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "1", rr.Header().Get("X-RateLimit-Limit"))
+
+		assert.Equal(t, http.StatusTooManyRequests, serve("/graphql", nil).Code)
+	})
+
+	t.Run("SuccessMetricsNotLimited", func(t *testing.T) {
+		rr := serve("/metrics", nil)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Header().Get("X-RateLimit-Limit"))
+	})
+}
+
+func TestClientIP(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	req.RemoteAddr = "10.0.0.1:52000"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+
+	assert.Equal(t, "10.0.0.1", clientIP(req, false))
+	assert.Equal(t, "203.0.113.7", clientIP(req, true))
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-zoo/bone"
+	"github.com/google/uuid"
+	"gitlab.com/renodesper/spenmo-test/endpoint"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/validation"
+)
+
+func decodeGetSpendControlsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	IDStr := bone.GetValue(r, "id")
+
+	var req endpoint.GetSpendControlsRequest
+
+	ID, err := uuid.Parse(IDStr)
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+
+	req.ID = ID
+
+	return req, nil
+}
+
+func decodeSetSpendControlsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req endpoint.SetSpendControlsRequest
+
+	IDStr := bone.GetValue(r, "id")
+	ID, err := uuid.Parse(IDStr)
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.UnparsableJSON
+	}
+	defer r.Body.Close()
+
+	req.ID = ID
+
+	validate = validation.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func decodeDeleteSpendControlsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req endpoint.DeleteSpendControlsRequest
+
+	IDStr := bone.GetValue(r, "id")
+	ID, err := uuid.Parse(IDStr)
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+
+	req.ID = ID
+
+	return req, nil
+}
@@ -0,0 +1,141 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-zoo/bone"
+	"github.com/google/uuid"
+	"github.com/iancoleman/strcase"
+	"gitlab.com/renodesper/spenmo-test/endpoint"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/validation"
+)
+
+func decodeGetSpendLimitsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	sortBy := "created_at"
+	sort := "DESC"
+	skip := 0
+	limit := 10
+
+	var req endpoint.GetSpendLimitsRequest
+
+	walletIDStr := r.URL.Query().Get("walletId")
+	if walletIDStr != "" {
+		walletID, err := uuid.Parse(walletIDStr)
+		if err != nil {
+			return nil, errors.UnparsableUUID.AppendError(err)
+		}
+		req.WalletID = walletID
+	}
+
+	cardIDStr := r.URL.Query().Get("cardId")
+	if cardIDStr != "" {
+		cardID, err := uuid.Parse(cardIDStr)
+		if err != nil {
+			return nil, errors.UnparsableUUID.AppendError(err)
+		}
+		req.CardID = cardID
+	}
+
+	sortByParam := r.URL.Query().Get("sortBy")
+	if sortByParam != "" {
+		sortBy = strcase.ToSnake(sortByParam)
+	}
+	req.SortBy = sortBy
+
+	sortParam := r.URL.Query().Get("sort")
+	if sortParam != "" {
+		sort = sortParam
+	}
+	req.Sort = sort
+
+	skipParam := r.URL.Query().Get("skip")
+	if skipParam != "" {
+		skip, _ = strconv.Atoi(skipParam)
+	}
+	req.Skip = skip
+
+	limitParam := r.URL.Query().Get("limit")
+	if limitParam != "" {
+		limit, _ = strconv.Atoi(limitParam)
+	}
+	req.Limit = limit
+
+	validate = validation.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func decodeGetSpendLimitRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	IDStr := bone.GetValue(r, "id")
+
+	var req endpoint.GetSpendLimitRequest
+
+	ID, err := uuid.Parse(IDStr)
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+
+	req.ID = ID
+
+	return req, nil
+}
+
+func decodeCreateSpendLimitRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req endpoint.CreateSpendLimitRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.UnparsableJSON
+	}
+	defer r.Body.Close()
+
+	validate = validation.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func decodeUpdateSpendLimitRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req endpoint.UpdateSpendLimitRequest
+
+	IDStr := bone.GetValue(r, "id")
+	ID, err := uuid.Parse(IDStr)
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.UnparsableJSON
+	}
+	defer r.Body.Close()
+
+	req.ID = ID
+
+	validate = validation.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func decodeDeleteSpendLimitRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req endpoint.DeleteSpendLimitRequest
+
+	IDStr := bone.GetValue(r, "id")
+	ID, err := uuid.Parse(IDStr)
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+
+	req.ID = ID
+
+	return req, nil
+}
@@ -5,12 +5,12 @@ import (
 	"net/http"
 	"strconv"
 
-	"github.com/go-playground/validator"
 	"github.com/go-zoo/bone"
 	"github.com/google/uuid"
 	"github.com/iancoleman/strcase"
 	"gitlab.com/renodesper/spenmo-test/endpoint"
 	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/validation"
 )
 
 func decodeCreateTeamMemberRequest(_ context.Context, r *http.Request) (interface{}, error) {
@@ -21,9 +21,25 @@ func decodeCreateTeamMemberRequest(_ context.Context, r *http.Request) (interfac
 	}
 	defer r.Body.Close()
 
-	validate = validator.New()
+	validate = validation.New()
 	if err := validate.Struct(req); err != nil {
-		return nil, errors.InvalidRequest
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+func decodeUpdateTeamMemberRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req endpoint.UpdateTeamMemberRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.UnparsableJSON
+	}
+	defer r.Body.Close()
+
+	validate = validation.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
 	}
 
 	return req, nil
@@ -79,9 +95,9 @@ func decodeGetTeamMembersRequest(_ context.Context, r *http.Request) (interface{
 	}
 	req.Limit = limit
 
-	validate = validator.New()
+	validate = validation.New()
 	if err := validate.Struct(req); err != nil {
-		return nil, errors.InvalidRequest
+		return nil, validation.Error(err)
 	}
 
 	return req, nil
@@ -95,9 +111,9 @@ func decodeDeleteTeamMemberRequest(_ context.Context, r *http.Request) (interfac
 	}
 	defer r.Body.Close()
 
-	validate = validator.New()
+	validate = validation.New()
 	if err := validate.Struct(req); err != nil {
-		return nil, errors.InvalidRequest
+		return nil, validation.Error(err)
 	}
 
 	return req, nil
@@ -109,7 +125,7 @@ func decodeDeleteTeamMembersByTeamIDRequest(_ context.Context, r *http.Request)
 	teamIDStr := bone.GetValue(r, "teamId")
 	teamID, err := uuid.Parse(teamIDStr)
 	if err != nil {
-		return nil, err
+		return nil, errors.UnparsableUUID.AppendError(err)
 	}
 
 	req.TeamID = teamID
@@ -123,7 +139,7 @@ func decodeDeleteTeamMembersByUserIDRequest(_ context.Context, r *http.Request)
 	userIDStr := bone.GetValue(r, "userId")
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		return nil, err
+		return nil, errors.UnparsableUUID.AppendError(err)
 	}
 
 	req.UserID = userID
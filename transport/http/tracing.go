@@ -0,0 +1,43 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/go-zoo/bone"
+	"gitlab.com/renodesper/spenmo-test/util/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traced starts the server span of every request, as a child of the span sent
+// by the caller in traceparent. The span is named by the route pattern it
+// matched, so the ids in the path do not split the operations.
+func traced(r *bone.Mux, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// NOTE: Prometheus scrapes are not traced
+		if req.URL.Path == "/metrics" {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		route := r.GetRequestRoute(req)
+		ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+
+		ctx, span := tracing.Start(ctx, req.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest("", route, req)...),
+		)
+		defer span.End()
+
+		rec := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, req.WithContext(ctx))
+
+		if rec.status == 0 {
+			rec.status = http.StatusOK
+		}
+		span.SetAttributes(semconv.HTTPAttributesFromHTTPStatusCode(rec.status)...)
+		span.SetStatus(semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(rec.status, trace.SpanKindServer))
+	})
+}
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger/noop"
+	"gitlab.com/renodesper/spenmo-test/util/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	tracing.Install(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+
+	handler := NewHTTPHandler(stubEndpoints(), noop.CreateLogger(), nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/wallets/d4a6607a-1af7-4571-bdff-2672be72ba0e", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if !assert.Len(t, spans, 2) {
+		return
+	}
+
+	endpointSpan, serverSpan := spans[0], spans[1]
+
+	t.Run("SuccessServerSpan", func(t *testing.T) {
+		assert.Equal(t, "GET /wallets/:id", serverSpan.Name())
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", serverSpan.SpanContext().TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", serverSpan.Parent().SpanID().String())
+		assert.True(t, serverSpan.Parent().IsRemote())
+
+		// NOTE: Client errors do not fail the server span
+		assert.Equal(t, codes.Unset, serverSpan.Status().Code)
+	})
+
+	t.Run("SuccessEndpointSpan", func(t *testing.T) {
+		assert.Equal(t, "endpoint.GetWallet", endpointSpan.Name())
+		assert.Equal(t, serverSpan.SpanContext().SpanID(), endpointSpan.Parent().SpanID())
+		assert.Equal(t, codes.Error, endpointSpan.Status().Code)
+
+		attrs := map[string]string{}
+		for _, attr := range endpointSpan.Attributes() {
+			attrs[string(attr.Key)] = attr.Value.Emit()
+		}
+		assert.Equal(t, errors.StatusNotFound.Code, attrs[string(tracing.ErrorCodeKey)])
+		assert.NotEmpty(t, attrs["request.id"])
+	})
+}
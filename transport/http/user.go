@@ -5,12 +5,12 @@ import (
 	"net/http"
 	"strconv"
 
-	"github.com/go-playground/validator"
 	"github.com/go-zoo/bone"
 	"github.com/google/uuid"
 	"github.com/iancoleman/strcase"
 	"gitlab.com/renodesper/spenmo-test/endpoint"
 	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/validation"
 )
 
 func decodeCreateUserRequest(_ context.Context, r *http.Request) (interface{}, error) {
@@ -21,9 +21,9 @@ func decodeCreateUserRequest(_ context.Context, r *http.Request) (interface{}, e
 	}
 	defer r.Body.Close()
 
-	validate = validator.New()
+	validate = validation.New()
 	if err := validate.Struct(req); err != nil {
-		return nil, errors.InvalidRequest
+		return nil, validation.Error(err)
 	}
 
 	return req, nil
@@ -61,9 +61,9 @@ func decodeGetAllUsersRequest(_ context.Context, r *http.Request) (interface{},
 	}
 	req.Limit = limit
 
-	validate = validator.New()
+	validate = validation.New()
 	if err := validate.Struct(req); err != nil {
-		return nil, errors.InvalidRequest
+		return nil, validation.Error(err)
 	}
 
 	return req, nil
@@ -76,7 +76,7 @@ func decodeGetUserRequest(_ context.Context, r *http.Request) (interface{}, erro
 
 	ID, err := uuid.Parse(IDStr)
 	if err != nil {
-		return nil, err
+		return nil, errors.UnparsableUUID.AppendError(err)
 	}
 
 	req.ID = ID
@@ -90,7 +90,7 @@ func decodeUpdateUserRequest(_ context.Context, r *http.Request) (interface{}, e
 	IDStr := bone.GetValue(r, "id")
 	ID, err := uuid.Parse(IDStr)
 	if err != nil {
-		return nil, err
+		return nil, errors.UnparsableUUID.AppendError(err)
 	}
 
 	req.ID = ID
@@ -100,9 +100,9 @@ func decodeUpdateUserRequest(_ context.Context, r *http.Request) (interface{}, e
 	}
 	defer r.Body.Close()
 
-	validate = validator.New()
+	validate = validation.New()
 	if err := validate.Struct(req); err != nil {
-		return nil, errors.InvalidRequest
+		return nil, validation.Error(err)
 	}
 
 	return req, nil
@@ -114,7 +114,7 @@ func decodeDeleteUserRequest(_ context.Context, r *http.Request) (interface{}, e
 	IDStr := bone.GetValue(r, "id")
 	ID, err := uuid.Parse(IDStr)
 	if err != nil {
-		return nil, err
+		return nil, errors.UnparsableUUID.AppendError(err)
 	}
 
 	req.ID = ID
@@ -2,15 +2,21 @@ package http
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
-	"github.com/go-playground/validator"
+	httptransport "github.com/go-kit/kit/transport/http"
 	"github.com/go-zoo/bone"
 	"github.com/google/uuid"
 	"github.com/iancoleman/strcase"
 	"gitlab.com/renodesper/spenmo-test/endpoint"
 	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/statement"
+	"gitlab.com/renodesper/spenmo-test/util/validation"
 )
 
 func decodeCreateWalletRequest(_ context.Context, r *http.Request) (interface{}, error) {
@@ -21,9 +27,9 @@ func decodeCreateWalletRequest(_ context.Context, r *http.Request) (interface{},
 	}
 	defer r.Body.Close()
 
-	validate = validator.New()
+	validate = validation.New()
 	if err := validate.Struct(req); err != nil {
-		return nil, errors.InvalidRequest
+		return nil, validation.Error(err)
 	}
 
 	return req, nil
@@ -61,9 +67,9 @@ func decodeGetAllWalletsRequest(_ context.Context, r *http.Request) (interface{}
 	}
 	req.Limit = limit
 
-	validate = validator.New()
+	validate = validation.New()
 	if err := validate.Struct(req); err != nil {
-		return nil, errors.InvalidRequest
+		return nil, validation.Error(err)
 	}
 
 	return req, nil
@@ -76,7 +82,7 @@ func decodeGetWalletRequest(_ context.Context, r *http.Request) (interface{}, er
 
 	ID, err := uuid.Parse(IDStr)
 	if err != nil {
-		return nil, err
+		return nil, errors.UnparsableUUID.AppendError(err)
 	}
 
 	req.ID = ID
@@ -90,7 +96,7 @@ func decodeUpdateWalletRequest(_ context.Context, r *http.Request) (interface{},
 	IDStr := bone.GetValue(r, "id")
 	ID, err := uuid.Parse(IDStr)
 	if err != nil {
-		return nil, err
+		return nil, errors.UnparsableUUID.AppendError(err)
 	}
 
 	req.ID = ID
@@ -100,9 +106,9 @@ func decodeUpdateWalletRequest(_ context.Context, r *http.Request) (interface{},
 	}
 	defer r.Body.Close()
 
-	validate = validator.New()
+	validate = validation.New()
 	if err := validate.Struct(req); err != nil {
-		return nil, errors.InvalidRequest
+		return nil, validation.Error(err)
 	}
 
 	return req, nil
@@ -114,7 +120,7 @@ func decodeDeleteWalletByIDRequest(_ context.Context, r *http.Request) (interfac
 	IDStr := bone.GetValue(r, "id")
 	ID, err := uuid.Parse(IDStr)
 	if err != nil {
-		return nil, err
+		return nil, errors.UnparsableUUID.AppendError(err)
 	}
 
 	req.ID = ID
@@ -128,7 +134,7 @@ func decodeDeleteWalletsByTeamIDRequest(_ context.Context, r *http.Request) (int
 	teamIDStr := bone.GetValue(r, "teamId")
 	teamID, err := uuid.Parse(teamIDStr)
 	if err != nil {
-		return nil, err
+		return nil, errors.UnparsableUUID.AppendError(err)
 	}
 
 	req.TeamID = teamID
@@ -142,10 +148,76 @@ func decodeDeleteWallestByUserIDRequest(_ context.Context, r *http.Request) (int
 	userIDStr := bone.GetValue(r, "userId")
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		return nil, err
+		return nil, errors.UnparsableUUID.AppendError(err)
 	}
 
 	req.UserID = userID
 
 	return req, nil
 }
+
+// decodeGetWalletStatementRequest reads from and to as inclusive dates, the
+// current month is used when they are omitted
+func decodeGetWalletStatementRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req endpoint.GetWalletStatementRequest
+
+	IDStr := bone.GetValue(r, "id")
+	ID, err := uuid.Parse(IDStr)
+	if err != nil {
+		return nil, errors.UnparsableUUID.AppendError(err)
+	}
+	req.ID = ID
+
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	fromParam := r.URL.Query().Get("from")
+	if fromParam != "" {
+		from, err = time.Parse(dateLayout, fromParam)
+		if err != nil {
+			return nil, errors.UnparsableDate.AppendError(err)
+		}
+	}
+	req.From = from
+
+	toParam := r.URL.Query().Get("to")
+	if toParam != "" {
+		to, err = time.Parse(dateLayout, toParam)
+		if err != nil {
+			return nil, errors.UnparsableDate.AppendError(err)
+		}
+	}
+	req.To = to.AddDate(0, 0, 1)
+
+	req.Format = strings.ToLower(r.URL.Query().Get("format"))
+	if err := statement.IsFormatValid(req.Format); err != nil {
+		return nil, err
+	}
+
+	validate = validation.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, validation.Error(err)
+	}
+
+	return req, nil
+}
+
+// encodeStatementResponse streams the statement as a file download, a failure
+// past its first rows aborts the download (see downloadWriter)
+func encodeStatementResponse(log logger.Logger) httptransport.EncodeResponseFunc {
+	return func(_ context.Context, w http.ResponseWriter, response interface{}) error {
+		res := response.(endpoint.GetWalletStatementResponse)
+		download := &downloadWriter{ResponseWriter: w}
+
+		writer, err := statement.NewWriter(res.Format, download)
+		if err != nil {
+			return err
+		}
+
+		w.Header().Set("Content-Type", statement.ContentType(res.Format))
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", res.Statement.Filename(res.Format)))
+
+		return endDownload(log, download, "statement", res.Statement.Write(writer))
+	}
+}
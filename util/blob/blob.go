@@ -0,0 +1,16 @@
+package blob
+
+import (
+	"context"
+	"io"
+)
+
+type (
+	// Store keeps opaque files under a key. Keys are slash separated paths
+	// chosen by the caller, putting an existing key replaces its content.
+	Store interface {
+		Put(ctx context.Context, key string, content io.Reader) error
+		Get(ctx context.Context, key string) (io.ReadCloser, error)
+		Delete(ctx context.Context, key string) error
+	}
+)
@@ -0,0 +1,107 @@
+package local
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gitlab.com/renodesper/spenmo-test/util/blob"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+)
+
+type Local struct {
+	dir string
+}
+
+// CreateStore creates blob store writing every file under dir, the files are
+// not shared between instances unless dir is
+func CreateStore(dir string) (blob.Store, error) {
+	err := os.MkdirAll(dir, 0o750)
+	if err != nil {
+		return nil, errors.FailedBlobWrite.AppendError(err)
+	}
+
+	return &Local{dir: dir}, nil
+}
+
+// Put implements Store.Put. The content is written to a temporary file first
+// so a failed upload never leaves a partial file under key.
+func (l *Local) Put(ctx context.Context, key string, content io.Reader) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o750)
+	if err != nil {
+		return errors.FailedBlobWrite.AppendError(err)
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return errors.FailedBlobWrite.AppendError(err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.FailedBlobWrite.AppendError(err)
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return errors.FailedBlobWrite.AppendError(err)
+	}
+
+	return nil
+}
+
+// Get implements Store.Get
+func (l *Local) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.FailedBlobNotFound
+		}
+
+		return nil, errors.FailedBlobRead.AppendError(err)
+	}
+
+	return file, nil
+}
+
+// Delete implements Store.Delete, deleting a missing key is not an error
+func (l *Local) Delete(ctx context.Context, key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.FailedBlobDelete.AppendError(err)
+	}
+
+	return nil
+}
+
+// path maps key to a file under dir, keys escaping dir are rejected
+func (l *Local) path(key string) (string, error) {
+	clean := filepath.Clean("/" + filepath.FromSlash(key))
+	if key == "" || strings.HasSuffix(key, "/") || clean == string(filepath.Separator) {
+		return "", errors.InvalidBlobKey
+	}
+
+	return filepath.Join(l.dir, clean), nil
+}
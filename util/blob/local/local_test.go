@@ -0,0 +1,68 @@
+package local
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	store, err := CreateStore(dir)
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.Put(ctx, "receipts/a/b.pdf", strings.NewReader("first")))
+	assert.NoError(t, store.Put(ctx, "receipts/a/b.pdf", strings.NewReader("second")))
+
+	file, err := store.Get(ctx, "receipts/a/b.pdf")
+	if assert.NoError(t, err) {
+		content, err := ioutil.ReadAll(file)
+		assert.NoError(t, err)
+		assert.Equal(t, "second", string(content))
+		assert.NoError(t, file.Close())
+	}
+
+	// NOTE: Only the file itself is left behind, the temporary upload is gone
+	entries, err := ioutil.ReadDir(filepath.Join(dir, "receipts", "a"))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	assert.NoError(t, store.Delete(ctx, "receipts/a/b.pdf"))
+	assert.NoError(t, store.Delete(ctx, "receipts/a/b.pdf"))
+
+	_, err = store.Get(ctx, "receipts/a/b.pdf")
+	if assert.Error(t, err) {
+		assert.Equal(t, errors.FailedBlobNotFound.Code, err.(e.Error).Code)
+	}
+}
+
+func TestStoreKey(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	store, err := CreateStore(filepath.Join(dir, "blobs"))
+	assert.NoError(t, err)
+
+	// NOTE: A key never escapes the directory of the store
+	assert.NoError(t, store.Put(ctx, "../../outside", strings.NewReader("content")))
+	_, err = ioutil.ReadFile(filepath.Join(dir, "blobs", "outside"))
+	assert.NoError(t, err)
+
+	tests := []string{"", "/", "receipts/"}
+	for _, key := range tests {
+		t.Run(key, func(t *testing.T) {
+			err := store.Put(ctx, key, strings.NewReader("content"))
+			if assert.Error(t, err) {
+				assert.Equal(t, errors.InvalidBlobKey.Code, err.(e.Error).Code)
+			}
+		})
+	}
+}
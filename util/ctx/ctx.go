@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
 )
 
 type CtxKey int
@@ -12,6 +13,8 @@ type CtxKey int
 const (
 	// CtxRequestID ...
 	CtxRequestID CtxKey = iota
+	// CtxUserID ...
+	CtxUserID
 )
 
 // GetRequestID ...
@@ -33,3 +36,49 @@ func ExtractRequestID(ctx context.Context, r *http.Request) context.Context {
 	ctx = SetRequestID(ctx, r.Header.Get("X-Request-Id"))
 	return ctx
 }
+
+// GetUserID returns the caller, uuid.Nil when the request is anonymous
+func GetUserID(ctx context.Context) uuid.UUID {
+	res, _ := ctx.Value(CtxUserID).(uuid.UUID)
+	return res
+}
+
+// SetUserID ...
+func SetUserID(ctx context.Context, val uuid.UUID) context.Context {
+	return context.WithValue(ctx, CtxUserID, val)
+}
+
+// ExtractUserID reads the caller set by the gateway in X-User-Id
+func ExtractUserID(ctx context.Context, r *http.Request) context.Context {
+	userID, err := uuid.Parse(r.Header.Get("X-User-Id"))
+	if err != nil {
+		return ctx
+	}
+
+	return SetUserID(ctx, userID)
+}
+
+// ExtractGRPCRequestID reads the request id from the x-request-id metadata
+func ExtractGRPCRequestID(ctx context.Context, md metadata.MD) context.Context {
+	return SetRequestID(ctx, firstMetadata(md, "x-request-id"))
+}
+
+// ExtractGRPCUserID reads the caller set by the gateway in the x-user-id
+// metadata
+func ExtractGRPCUserID(ctx context.Context, md metadata.MD) context.Context {
+	userID, err := uuid.Parse(firstMetadata(md, "x-user-id"))
+	if err != nil {
+		return ctx
+	}
+
+	return SetUserID(ctx, userID)
+}
+
+func firstMetadata(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
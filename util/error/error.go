@@ -9,12 +9,20 @@ import (
 type (
 	// Error ...
 	Error struct {
-		Status      int    `json:"status,omitempty"`
-		Code        string `json:"code,omitempty"`
-		Message     string `json:"message,omitempty"`
-		Trace       string `json:"trace,omitempty"`
-		RedirectURL string `json:"redirect_url,omitempty"`
-		Meta        Meta   `json:"meta,omitempty"`
+		Status      int      `json:"status,omitempty"`
+		Code        string   `json:"code,omitempty"`
+		Message     string   `json:"message,omitempty"`
+		Trace       string   `json:"trace,omitempty"`
+		RedirectURL string   `json:"redirect_url,omitempty"`
+		Meta        Meta     `json:"meta,omitempty"`
+		Details     []Detail `json:"details,omitempty"`
+	}
+
+	// Detail points at the field of the request that caused the error
+	Detail struct {
+		Field   string `json:"field"`
+		Rule    string `json:"rule"`
+		Message string `json:"message"`
 	}
 
 	// Meta ...
@@ -59,6 +67,12 @@ func (e Error) WithoutStackTrace() Error {
 	return e
 }
 
+// WithDetails ...
+func (e Error) WithDetails(details ...Detail) Error {
+	e.Details = append(append([]Detail{}, e.Details...), details...)
+	return e
+}
+
 // AppendError ...
 func (e Error) AppendError(err error) Error {
 	return Error{
@@ -0,0 +1,14 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+
+	"gitlab.com/renodesper/spenmo-test/util/error"
+)
+
+var (
+	InvalidSpendGroupBy  = error.NewError(http.StatusBadRequest, "AN1000", fmt.Errorf("groupBy must be one of card, user, mcc or day"))
+	InvalidSpendInterval = error.NewError(http.StatusBadRequest, "AN1001", fmt.Errorf("interval must be one of day, week or month"))
+	InvalidSpendPeriod   = error.NewError(http.StatusBadRequest, "AN1002", fmt.Errorf("spend period must start before it ends"))
+)
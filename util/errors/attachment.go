@@ -0,0 +1,19 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+
+	"gitlab.com/renodesper/spenmo-test/util/error"
+)
+
+var (
+	MissingReceipt         = error.NewError(http.StatusBadRequest, "AT1000", fmt.Errorf("receipt file is required"))
+	ReceiptTooLarge        = error.NewError(http.StatusRequestEntityTooLarge, "AT1001", fmt.Errorf("receipt exceeds the maximum size"))
+	UnsupportedReceiptType = error.NewError(http.StatusUnsupportedMediaType, "AT1002", fmt.Errorf("receipt must be a JPEG, PNG or PDF file"))
+	InvalidBlobKey         = error.NewError(http.StatusInternalServerError, "AT1003", fmt.Errorf("invalid blob key"))
+	FailedBlobNotFound     = error.NewError(http.StatusNotFound, "AT1004", fmt.Errorf("blob cannot be found"))
+	FailedBlobWrite        = error.NewError(http.StatusInternalServerError, "AT1005", fmt.Errorf("unable to write blob"))
+	FailedBlobRead         = error.NewError(http.StatusInternalServerError, "AT1006", fmt.Errorf("unable to read blob"))
+	FailedBlobDelete       = error.NewError(http.StatusInternalServerError, "AT1007", fmt.Errorf("unable to delete blob"))
+)
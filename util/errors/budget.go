@@ -0,0 +1,16 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+
+	"gitlab.com/renodesper/spenmo-test/util/error"
+)
+
+var (
+	MissingBudgetOwner     = error.NewError(http.StatusBadRequest, "BU1000", fmt.Errorf("missing budget wallet or card"))
+	InvalidBudgetOwner     = error.NewError(http.StatusBadRequest, "BU1001", fmt.Errorf("card does not belong to the budget wallet"))
+	InvalidBudgetAmount    = error.NewError(http.StatusBadRequest, "BU1002", fmt.Errorf("budget amount must be positive"))
+	InvalidBudgetPeriod    = error.NewError(http.StatusBadRequest, "BU1003", fmt.Errorf("invalid budget period"))
+	InvalidBudgetThreshold = error.NewError(http.StatusBadRequest, "BU1004", fmt.Errorf("budget thresholds must be distinct percentages between 1 and 1000"))
+)
@@ -15,4 +15,8 @@ var (
 	InvalidCVV         = error.NewError(http.StatusBadRequest, "CA1004", fmt.Errorf("invalid cvv"))
 	FailedCardsDelete  = error.NewError(http.StatusInternalServerError, "CA1005", fmt.Errorf("failed to delete cards"))
 	InvalidCardOwner   = error.NewError(http.StatusBadRequest, "CA1006", fmt.Errorf("invalid card owner"))
+	InvalidCardType    = error.NewError(http.StatusBadRequest, "CA1007", fmt.Errorf("invalid card type"))
+	ClosedCard         = error.NewError(http.StatusUnprocessableEntity, "CA1008", fmt.Errorf("card is closed"))
+	MerchantLockedCard = error.NewError(http.StatusUnprocessableEntity, "CA1009", fmt.Errorf("card is locked to another merchant"))
+	MissingMerchant    = error.NewError(http.StatusBadRequest, "CA1010", fmt.Errorf("missing merchant on a merchant locked card"))
 )
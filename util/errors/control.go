@@ -0,0 +1,15 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+
+	"gitlab.com/renodesper/spenmo-test/util/error"
+)
+
+var (
+	SpendControlDeclined        = error.NewError(http.StatusUnprocessableEntity, "CO1000", fmt.Errorf("transaction declined by spend controls"))
+	InvalidSpendControlMCC      = error.NewError(http.StatusBadRequest, "CO1001", fmt.Errorf("invalid MCC, expected 4 digits or a range such as 7800-7802"))
+	InvalidSpendControlCountry  = error.NewError(http.StatusBadRequest, "CO1002", fmt.Errorf("invalid country, expected an ISO 3166-1 alpha-2 code"))
+	InvalidSpendControlMerchant = error.NewError(http.StatusBadRequest, "CO1003", fmt.Errorf("invalid merchant ID"))
+)
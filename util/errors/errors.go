@@ -8,10 +8,13 @@ import (
 )
 
 var (
-	UnexpectedPanic  = error.NewError(http.StatusInternalServerError, "ER9999", fmt.Errorf("unexpected panic"))
-	StatusNotFound   = error.NewError(http.StatusNotFound, "ER9998", fmt.Errorf(http.StatusText(http.StatusNotFound)))
-	StatusBadRequest = error.NewError(http.StatusBadRequest, "ER9997", fmt.Errorf(http.StatusText(http.StatusBadRequest)))
-	LimitExceeded    = error.NewError(http.StatusTooManyRequests, "ER9996", fmt.Errorf("limit exceeded"))
+	UnexpectedPanic      = error.NewError(http.StatusInternalServerError, "ER9999", fmt.Errorf("unexpected panic"))
+	StatusNotFound       = error.NewError(http.StatusNotFound, "ER9998", fmt.Errorf(http.StatusText(http.StatusNotFound)))
+	StatusBadRequest     = error.NewError(http.StatusBadRequest, "ER9997", fmt.Errorf(http.StatusText(http.StatusBadRequest)))
+	LimitExceeded        = error.NewError(http.StatusTooManyRequests, "ER9996", fmt.Errorf("limit exceeded"))
+	UnencodableResponse  = error.NewError(http.StatusInternalServerError, "ER9995", fmt.Errorf("failed to encode response"))
+	UnsupportedOperation = error.NewError(http.StatusNotImplemented, "ER9994", fmt.Errorf("operation is not supported"))
+	UnexpectedError      = error.NewError(http.StatusInternalServerError, "ER9993", fmt.Errorf("unexpected error"))
 )
 
 var (
@@ -19,6 +22,9 @@ var (
 	UnparsableJSON     = error.NewError(http.StatusBadRequest, "AU1002", fmt.Errorf("failed on parsing JSON"))
 	UnreadableResponse = error.NewError(http.StatusBadRequest, "AU1003", fmt.Errorf("failed to read response"))
 	UnparsableUUID     = error.NewError(http.StatusBadRequest, "AU1004", fmt.Errorf("failed to parse UUID"))
+	UnparsableDate     = error.NewError(http.StatusBadRequest, "AU1005", fmt.Errorf("failed to parse date, expected YYYY-MM-DD"))
+	Unauthenticated    = error.NewError(http.StatusUnauthorized, "AU1006", fmt.Errorf("caller is not authenticated"))
+	Forbidden          = error.NewError(http.StatusForbidden, "AU1007", fmt.Errorf("caller is not allowed to access this resource"))
 )
 
 var (
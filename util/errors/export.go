@@ -0,0 +1,17 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+
+	"gitlab.com/renodesper/spenmo-test/util/error"
+)
+
+var (
+	InvalidExportPeriod = error.NewError(http.StatusBadRequest, "EX1000", fmt.Errorf("export period must start before it ends"))
+	InvalidExportFormat = error.NewError(http.StatusBadRequest, "EX1001", fmt.Errorf("export format must be journal, qbo or xero"))
+	MissingExportWallet = error.NewError(http.StatusBadRequest, "EX1002", fmt.Errorf("bank statement formats export a single wallet"))
+	InvalidExportWallet = error.NewError(http.StatusBadRequest, "EX1003", fmt.Errorf("wallet must belong to the team"))
+	FailedExportWrite   = error.NewError(http.StatusInternalServerError, "EX1004", fmt.Errorf("unable to write export"))
+	InvalidAccount      = error.NewError(http.StatusBadRequest, "EX1005", fmt.Errorf("category and account cannot be blank"))
+)
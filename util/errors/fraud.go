@@ -0,0 +1,16 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+
+	"gitlab.com/renodesper/spenmo-test/util/error"
+)
+
+var (
+	FraudRuleTriggered = error.NewError(http.StatusUnprocessableEntity, "FR1000", fmt.Errorf("transaction declined by fraud rules"))
+	InvalidFraudRule   = error.NewError(http.StatusInternalServerError, "FR1001", fmt.Errorf("invalid fraud rule"))
+	FrozenCard         = error.NewError(http.StatusUnprocessableEntity, "FR1002", fmt.Errorf("card is frozen"))
+	CardNotFrozen      = error.NewError(http.StatusBadRequest, "FR1003", fmt.Errorf("card is not frozen"))
+	CardAlreadyFrozen  = error.NewError(http.StatusBadRequest, "FR1004", fmt.Errorf("card is already frozen"))
+)
@@ -0,0 +1,16 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+
+	"gitlab.com/renodesper/spenmo-test/util/error"
+)
+
+var (
+	InvalidFundRequestAmount = error.NewError(http.StatusBadRequest, "RQ1000", fmt.Errorf("fund request amount must be positive"))
+	InvalidFundRequestWallet = error.NewError(http.StatusBadRequest, "RQ1001", fmt.Errorf("wallet must belong to the requester or to the team"))
+	InvalidFundRequestSource = error.NewError(http.StatusBadRequest, "RQ1002", fmt.Errorf("source wallet must be a wallet of the team"))
+	FundRequestNotPending    = error.NewError(http.StatusConflict, "RQ1003", fmt.Errorf("fund request is no longer pending"))
+	FundRequestSelfReview    = error.NewError(http.StatusForbidden, "RQ1004", fmt.Errorf("requester cannot review their own fund request"))
+)
@@ -0,0 +1,19 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+
+	"gitlab.com/renodesper/spenmo-test/util/error"
+)
+
+var (
+	InvalidCronExpression     = error.NewError(http.StatusBadRequest, "FU1000", fmt.Errorf("invalid cron expression, expected 5 fields such as 0 9 1 * *"))
+	InvalidDayOfMonth         = error.NewError(http.StatusBadRequest, "FU1001", fmt.Errorf("day of month must be between 1 and 31"))
+	MissingFundingSchedule    = error.NewError(http.StatusBadRequest, "FU1002", fmt.Errorf("expected exactly one of cron or day of month"))
+	InvalidFundingMode        = error.NewError(http.StatusBadRequest, "FU1003", fmt.Errorf("invalid funding mode, expected top_up or reset"))
+	InvalidFundingAmount      = error.NewError(http.StatusBadRequest, "FU1004", fmt.Errorf("funding amount must be positive"))
+	InvalidFundingTarget      = error.NewError(http.StatusBadRequest, "FU1005", fmt.Errorf("target wallet must be another wallet of the source wallet team or its members"))
+	InvalidFundingSource      = error.NewError(http.StatusBadRequest, "FU1006", fmt.Errorf("source wallet must be a team wallet"))
+	FundingRunAlreadyExecuted = error.NewError(http.StatusConflict, "FU1007", fmt.Errorf("funding run is already executed for this period"))
+)
@@ -0,0 +1,15 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+
+	"gitlab.com/renodesper/spenmo-test/util/error"
+)
+
+var (
+	RateNotFound        = error.NewError(http.StatusBadRequest, "FX1000", fmt.Errorf("exchange rate cannot be found"))
+	InvalidRate         = error.NewError(http.StatusInternalServerError, "FX1001", fmt.Errorf("invalid exchange rate"))
+	FailedRatesLoad     = error.NewError(http.StatusInternalServerError, "FX1002", fmt.Errorf("unable to load exchange rates"))
+	InvalidRoundingMode = error.NewError(http.StatusInternalServerError, "FX1003", fmt.Errorf("invalid rounding mode"))
+)
@@ -0,0 +1,13 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+
+	"gitlab.com/renodesper/spenmo-test/util/error"
+)
+
+var (
+	QueryTooDeep    = error.NewError(http.StatusBadRequest, "GQ1000", fmt.Errorf("query exceeds the maximum depth"))
+	QueryTooComplex = error.NewError(http.StatusBadRequest, "GQ1001", fmt.Errorf("query exceeds the maximum complexity"))
+)
@@ -0,0 +1,18 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+
+	"gitlab.com/renodesper/spenmo-test/util/error"
+)
+
+var (
+	SpendLimitExceeded      = error.NewError(http.StatusUnprocessableEntity, "LI1000", fmt.Errorf("spend limit exceeded"))
+	MissingSpendLimitOwner  = error.NewError(http.StatusBadRequest, "LI1001", fmt.Errorf("missing spend limit wallet or card"))
+	InvalidSpendLimitOwner  = error.NewError(http.StatusBadRequest, "LI1002", fmt.Errorf("card does not belong to the spend limit wallet"))
+	InvalidSpendLimitPeriod = error.NewError(http.StatusBadRequest, "LI1003", fmt.Errorf("invalid spend limit period"))
+	InvalidSpendLimitWindow = error.NewError(http.StatusBadRequest, "LI1004", fmt.Errorf("invalid spend limit window"))
+	InvalidSpendLimitAmount = error.NewError(http.StatusBadRequest, "LI1005", fmt.Errorf("spend limit amount must be positive"))
+	InvalidTimezone         = error.NewError(http.StatusBadRequest, "LI1006", fmt.Errorf("invalid timezone, expected an IANA name such as Asia/Singapore"))
+)
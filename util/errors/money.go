@@ -0,0 +1,17 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+
+	"gitlab.com/renodesper/spenmo-test/util/error"
+)
+
+var (
+	InvalidCurrency    = error.NewError(http.StatusBadRequest, "MO1000", fmt.Errorf("invalid currency"))
+	CurrencyMismatch   = error.NewError(http.StatusBadRequest, "MO1001", fmt.Errorf("currency mismatch, explicit conversion is required"))
+	AmountOverflow     = error.NewError(http.StatusBadRequest, "MO1002", fmt.Errorf("amount overflow"))
+	InsufficientAmount = error.NewError(http.StatusBadRequest, "MO1003", fmt.Errorf("insufficient amount"))
+	InvalidDecimal     = error.NewError(http.StatusBadRequest, "MO1004", fmt.Errorf("invalid decimal amount"))
+	InvalidAmountScale = error.NewError(http.StatusBadRequest, "MO1005", fmt.Errorf("amount has more decimal places than the currency allows"))
+)
@@ -62,3 +62,95 @@ var (
 	FailedCardFetch    = error.NewError(http.StatusInternalServerError, "PG2506", fmt.Errorf("unable to fetch card"))
 	FailedCardsFetch   = error.NewError(http.StatusInternalServerError, "PG2507", fmt.Errorf("unable to fetch cards"))
 )
+
+var (
+	FailedTransactionNotFound = error.NewError(http.StatusNotFound, "PG2602", fmt.Errorf("transaction cannot be found"))
+	FailedTransactionCreate   = error.NewError(http.StatusInternalServerError, "PG2603", fmt.Errorf("unable to create transaction"))
+	FailedTransactionUpdate   = error.NewError(http.StatusInternalServerError, "PG2604", fmt.Errorf("unable to update transaction"))
+	FailedTransactionFetch    = error.NewError(http.StatusInternalServerError, "PG2606", fmt.Errorf("unable to fetch transaction"))
+	FailedTransactionsFetch   = error.NewError(http.StatusInternalServerError, "PG2607", fmt.Errorf("unable to fetch transactions"))
+)
+
+var (
+	FailedSpendFetch = error.NewError(http.StatusInternalServerError, "PG2707", fmt.Errorf("unable to fetch spend"))
+)
+
+var (
+	FailedBudgetNotFound = error.NewError(http.StatusNotFound, "PG2802", fmt.Errorf("budget cannot be found"))
+	FailedBudgetCreate   = error.NewError(http.StatusInternalServerError, "PG2803", fmt.Errorf("unable to create budget"))
+	FailedBudgetUpdate   = error.NewError(http.StatusInternalServerError, "PG2804", fmt.Errorf("unable to update budget"))
+	FailedBudgetDelete   = error.NewError(http.StatusInternalServerError, "PG2805", fmt.Errorf("unable to delete budget"))
+	FailedBudgetFetch    = error.NewError(http.StatusInternalServerError, "PG2806", fmt.Errorf("unable to fetch budget"))
+	FailedBudgetsFetch   = error.NewError(http.StatusInternalServerError, "PG2807", fmt.Errorf("unable to fetch budgets"))
+)
+
+var (
+	FailedBudgetAlertCreate = error.NewError(http.StatusInternalServerError, "PG2903", fmt.Errorf("unable to create budget alert"))
+	FailedBudgetAlertsFetch = error.NewError(http.StatusInternalServerError, "PG2907", fmt.Errorf("unable to fetch budget alerts"))
+)
+
+var (
+	FailedSpendLimitExist    = error.NewError(http.StatusBadRequest, "PG3001", fmt.Errorf("spend limit is already exist"))
+	FailedSpendLimitNotFound = error.NewError(http.StatusNotFound, "PG3002", fmt.Errorf("spend limit cannot be found"))
+	FailedSpendLimitCreate   = error.NewError(http.StatusInternalServerError, "PG3003", fmt.Errorf("unable to create spend limit"))
+	FailedSpendLimitUpdate   = error.NewError(http.StatusInternalServerError, "PG3004", fmt.Errorf("unable to update spend limit"))
+	FailedSpendLimitDelete   = error.NewError(http.StatusInternalServerError, "PG3005", fmt.Errorf("unable to delete spend limit"))
+	FailedSpendLimitFetch    = error.NewError(http.StatusInternalServerError, "PG3006", fmt.Errorf("unable to fetch spend limit"))
+	FailedSpendLimitsFetch   = error.NewError(http.StatusInternalServerError, "PG3007", fmt.Errorf("unable to fetch spend limits"))
+)
+
+var (
+	FailedCardAuditCreate = error.NewError(http.StatusInternalServerError, "PG3103", fmt.Errorf("unable to create card audit"))
+	FailedCardAuditsFetch = error.NewError(http.StatusInternalServerError, "PG3107", fmt.Errorf("unable to fetch card audits"))
+)
+
+var (
+	FailedVelocityEventCreate  = error.NewError(http.StatusInternalServerError, "PG3203", fmt.Errorf("unable to record velocity event"))
+	FailedVelocityEventsFetch  = error.NewError(http.StatusInternalServerError, "PG3207", fmt.Errorf("unable to fetch velocity events"))
+	FailedVelocityMemberCreate = error.NewError(http.StatusInternalServerError, "PG3213", fmt.Errorf("unable to add velocity member"))
+	FailedVelocityMemberFetch  = error.NewError(http.StatusInternalServerError, "PG3216", fmt.Errorf("unable to fetch velocity member"))
+)
+
+var (
+	FailedFundingScheduleNotFound = error.NewError(http.StatusNotFound, "PG3302", fmt.Errorf("funding schedule cannot be found"))
+	FailedFundingScheduleCreate   = error.NewError(http.StatusInternalServerError, "PG3303", fmt.Errorf("unable to create funding schedule"))
+	FailedFundingScheduleUpdate   = error.NewError(http.StatusInternalServerError, "PG3304", fmt.Errorf("unable to update funding schedule"))
+	FailedFundingScheduleDelete   = error.NewError(http.StatusInternalServerError, "PG3305", fmt.Errorf("unable to delete funding schedule"))
+	FailedFundingScheduleFetch    = error.NewError(http.StatusInternalServerError, "PG3306", fmt.Errorf("unable to fetch funding schedule"))
+	FailedFundingSchedulesFetch   = error.NewError(http.StatusInternalServerError, "PG3307", fmt.Errorf("unable to fetch funding schedules"))
+)
+
+var (
+	FailedFundingRunCreate = error.NewError(http.StatusInternalServerError, "PG3403", fmt.Errorf("unable to create funding run"))
+	FailedFundingRunsFetch = error.NewError(http.StatusInternalServerError, "PG3407", fmt.Errorf("unable to fetch funding runs"))
+)
+
+var (
+	FailedFundRequestNotFound = error.NewError(http.StatusNotFound, "PG3502", fmt.Errorf("fund request cannot be found"))
+	FailedFundRequestCreate   = error.NewError(http.StatusInternalServerError, "PG3503", fmt.Errorf("unable to create fund request"))
+	FailedFundRequestUpdate   = error.NewError(http.StatusInternalServerError, "PG3504", fmt.Errorf("unable to update fund request"))
+	FailedFundRequestFetch    = error.NewError(http.StatusInternalServerError, "PG3506", fmt.Errorf("unable to fetch fund request"))
+	FailedFundRequestsFetch   = error.NewError(http.StatusInternalServerError, "PG3507", fmt.Errorf("unable to fetch fund requests"))
+)
+
+var (
+	FailedAttachmentNotFound = error.NewError(http.StatusNotFound, "PG3602", fmt.Errorf("attachment cannot be found"))
+	FailedAttachmentCreate   = error.NewError(http.StatusInternalServerError, "PG3603", fmt.Errorf("unable to create attachment"))
+	FailedAttachmentFetch    = error.NewError(http.StatusInternalServerError, "PG3606", fmt.Errorf("unable to fetch attachment"))
+	FailedAttachmentsFetch   = error.NewError(http.StatusInternalServerError, "PG3607", fmt.Errorf("unable to fetch attachments"))
+)
+
+var (
+	FailedChartOfAccountsNotFound = error.NewError(http.StatusNotFound, "PG3702", fmt.Errorf("chart of accounts cannot be found"))
+	FailedChartOfAccountsSet      = error.NewError(http.StatusInternalServerError, "PG3703", fmt.Errorf("unable to set chart of accounts"))
+	FailedChartOfAccountsFetch    = error.NewError(http.StatusInternalServerError, "PG3706", fmt.Errorf("unable to fetch chart of accounts"))
+)
+
+var (
+	FailedWalletDriftCorrect = error.NewError(http.StatusInternalServerError, "PG3803", fmt.Errorf("unable to correct wallet drift"))
+	FailedWalletDriftsFetch  = error.NewError(http.StatusInternalServerError, "PG3807", fmt.Errorf("unable to fetch wallet drifts"))
+)
+
+var (
+	FailedRateLimitIncrement = error.NewError(http.StatusInternalServerError, "PG3904", fmt.Errorf("unable to count rate limited request"))
+)
@@ -0,0 +1,14 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+
+	"gitlab.com/renodesper/spenmo-test/util/error"
+)
+
+var (
+	InvalidStatementPeriod = error.NewError(http.StatusBadRequest, "ST1000", fmt.Errorf("statement period must start before it ends"))
+	InvalidStatementFormat = error.NewError(http.StatusBadRequest, "ST1001", fmt.Errorf("statement format must be csv or pdf"))
+	FailedStatementWrite   = error.NewError(http.StatusInternalServerError, "ST1002", fmt.Errorf("unable to write statement"))
+)
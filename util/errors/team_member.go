@@ -10,4 +10,6 @@ import (
 var (
 	MissingTeamID = error.NewError(http.StatusBadRequest, "TM1000", fmt.Errorf("missing teamId"))
 	MissingUserID = error.NewError(http.StatusBadRequest, "TM1001", fmt.Errorf("missing userId"))
+	InvalidRole   = error.NewError(http.StatusBadRequest, "TM1002", fmt.Errorf("invalid role, expected member or admin"))
+	LastTeamAdmin = error.NewError(http.StatusBadRequest, "TM1003", fmt.Errorf("the last admin of a team cannot be removed or demoted"))
 )
@@ -0,0 +1,14 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+
+	"gitlab.com/renodesper/spenmo-test/util/error"
+)
+
+var (
+	InvalidAmount   = error.NewError(http.StatusBadRequest, "TR1000", fmt.Errorf("amount must be greater than zero"))
+	InvalidTransfer = error.NewError(http.StatusBadRequest, "TR1001", fmt.Errorf("cannot transfer to the same wallet"))
+	InvalidTags     = error.NewError(http.StatusBadRequest, "TR1002", fmt.Errorf("tags must be at most 10 non empty values"))
+)
@@ -0,0 +1,219 @@
+package export
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+type (
+	// Export describes the transactions of a team, or of a single wallet of
+	// the team, over [From, To). Entries are pulled lazily so large periods
+	// are streamed rather than loaded at once.
+	Export struct {
+		TeamID   string
+		WalletID string
+		From     time.Time
+		To       time.Time
+		Accounts Accounts
+		Entries  func(fn func(Entry) error) error
+	}
+
+	// Entry is a single transaction leg seen from the wallet. Amount is
+	// always positive, Direction tells whether the wallet was debited or
+	// credited. Account is the contra account of the wallet and is filled
+	// in by Export.Write.
+	Entry struct {
+		Date        time.Time
+		Reference   string
+		WalletID    string
+		Type        string
+		Direction   string
+		Payee       string
+		Description string
+		Memo        string
+		Category    string
+		Amount      money.Decimal
+		Currency    string
+		Account     string
+	}
+
+	// Accounts maps transactions to the chart of accounts of a team. The
+	// category of a transaction wins over its type and direction.
+	Accounts struct {
+		Wallet     string
+		Expense    string
+		Income     string
+		Transfer   string
+		Categories map[string]string
+	}
+
+	// Writer renders an export in a specific format
+	Writer interface {
+		Begin(x *Export) error
+		Entry(entry Entry) error
+		End() error
+	}
+)
+
+const (
+	FormatQBO     = "qbo"
+	FormatXero    = "xero"
+	FormatJournal = "journal"
+
+	DirectionDebit  = "debit"
+	DirectionCredit = "credit"
+
+	TypeTransfer = "transfer"
+)
+
+// DefaultAccounts is used for a team without a chart of accounts
+var DefaultAccounts = Accounts{
+	Wallet:   "Spenmo Wallet",
+	Expense:  "Uncategorized Expense",
+	Income:   "Uncategorized Income",
+	Transfer: "Wallet Transfers",
+}
+
+const dateLayout = "2006-01-02"
+
+// NewWriter returns the writer for format, defaulting to the journal
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch strings.ToLower(format) {
+	case FormatJournal, "":
+		return NewJournalWriter(w), nil
+	case FormatQBO:
+		return NewQBOWriter(w), nil
+	case FormatXero:
+		return NewXeroWriter(w), nil
+	default:
+		return nil, errors.InvalidExportFormat
+	}
+}
+
+// IsFormatValid ...
+func IsFormatValid(format string) error {
+	switch strings.ToLower(format) {
+	case FormatJournal, FormatQBO, FormatXero, "":
+		return nil
+	default:
+		return errors.InvalidExportFormat
+	}
+}
+
+// IsBankFormat tells whether format is a bank statement import, which always
+// covers a single wallet
+func IsBankFormat(format string) bool {
+	format = strings.ToLower(format)
+	return format == FormatQBO || format == FormatXero
+}
+
+// ContentType returns the MIME type of format
+func ContentType(format string) string {
+	return "text/csv"
+}
+
+// Filename returns a download name such as export-<team>-2021-01-01-2021-01-31-journal.csv
+func (x *Export) Filename(format string) string {
+	if format == "" {
+		format = FormatJournal
+	}
+
+	owner := x.TeamID
+	if x.WalletID != "" {
+		owner = x.WalletID
+	}
+
+	from, to := x.Period()
+	return "export-" + owner + "-" + from + "-" + to + "-" + strings.ToLower(format) + ".csv"
+}
+
+// Period returns the first and last day covered by the export
+func (x *Export) Period() (string, string) {
+	return x.From.Format(dateLayout), x.To.Add(-time.Nanosecond).Format(dateLayout)
+}
+
+// Write renders the export, resolving the contra account of every entry as
+// entries are streamed to w
+func (x *Export) Write(w Writer) error {
+	if err := w.Begin(x); err != nil {
+		return wrap(err)
+	}
+
+	err := x.Entries(func(entry Entry) error {
+		entry.Account = x.Accounts.Resolve(entry)
+		return wrap(w.Entry(entry))
+	})
+	if err != nil {
+		return err
+	}
+
+	return wrap(w.End())
+}
+
+// Resolve returns the contra account of entry, blank accounts fall back to
+// DefaultAccounts
+func (a Accounts) Resolve(entry Entry) string {
+	if account := a.Categories[strings.ToLower(entry.Category)]; account != "" {
+		return account
+	}
+
+	switch {
+	case entry.Type == TypeTransfer:
+		return fallback(a.Transfer, DefaultAccounts.Transfer)
+	case entry.Direction == DirectionDebit:
+		return fallback(a.Expense, DefaultAccounts.Expense)
+	default:
+		return fallback(a.Income, DefaultAccounts.Income)
+	}
+}
+
+// WalletAccount returns the account holding the wallet balances
+func (a Accounts) WalletAccount() string {
+	return fallback(a.Wallet, DefaultAccounts.Wallet)
+}
+
+// description picks the most specific text describing an entry
+func description(entry Entry) string {
+	for _, text := range []string{entry.Memo, entry.Description, entry.Payee} {
+		if text != "" {
+			return text
+		}
+	}
+
+	return entry.Type
+}
+
+// signedAmount is negative when the wallet was debited
+func signedAmount(entry Entry) money.Decimal {
+	if entry.Direction == DirectionDebit {
+		return entry.Amount.Neg()
+	}
+
+	return entry.Amount
+}
+
+func fallback(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
+
+// wrap keeps application errors and flags anything else as a write failure
+func wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := err.(e.Error); ok {
+		return err
+	}
+
+	return errors.FailedExportWrite.AppendError(err)
+}
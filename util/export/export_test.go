@@ -0,0 +1,124 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+func newTestExport(entries []Entry) *Export {
+	return &Export{
+		TeamID: "933efe12-2219-42df-bd51-a2e84888432d",
+		From:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+		Accounts: Accounts{
+			Wallet:     "1010 Wallet",
+			Expense:    "6000 General Expense",
+			Categories: map[string]string{"meals": "6100 Meals"},
+		},
+		Entries: func(fn func(Entry) error) error {
+			for _, entry := range entries {
+				if err := fn(entry); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+var testEntries = []Entry{
+	{Date: time.Date(2021, 1, 5, 10, 0, 0, 0, time.UTC), Reference: "ref-1", Type: "adjustment", Direction: DirectionCredit, Description: "Top up", Amount: money.MustParseDecimal("50.00"), Currency: "SGD"},
+	{Date: time.Date(2021, 1, 6, 10, 0, 0, 0, time.UTC), Reference: "ref-2", Type: "card", Direction: DirectionDebit, Payee: "ACME, Inc.", Category: "Meals", Memo: "Client lunch", Amount: money.MustParseDecimal("20.50"), Currency: "SGD"},
+	{Date: time.Date(2021, 1, 7, 10, 0, 0, 0, time.UTC), Reference: "ref-3", Type: "card", Direction: DirectionDebit, Payee: "Taxi", Amount: money.MustParseDecimal("8.00"), Currency: "SGD"},
+	{Date: time.Date(2021, 1, 8, 10, 0, 0, 0, time.UTC), Reference: "ref-4", Type: "transfer", Direction: DirectionDebit, Description: "Allowance", Amount: money.MustParseDecimal("5.00"), Currency: "SGD"},
+}
+
+func TestWriteJournal(t *testing.T) {
+	x := newTestExport(testEntries)
+
+	var b bytes.Buffer
+	err := x.Write(NewJournalWriter(&b))
+	assert.NoError(t, err)
+
+	expected := strings.Join([]string{
+		"Date,Reference,Account,Description,Debit,Credit,Currency",
+		"2021-01-05,ref-1,1010 Wallet,Top up,50.00,,SGD",
+		"2021-01-05,ref-1,Uncategorized Income,Top up,,50.00,SGD",
+		"2021-01-06,ref-2,6100 Meals,Client lunch,20.50,,SGD",
+		"2021-01-06,ref-2,1010 Wallet,Client lunch,,20.50,SGD",
+		"2021-01-07,ref-3,6000 General Expense,Taxi,8.00,,SGD",
+		"2021-01-07,ref-3,1010 Wallet,Taxi,,8.00,SGD",
+		"2021-01-08,ref-4,Wallet Transfers,Allowance,5.00,,SGD",
+		"2021-01-08,ref-4,1010 Wallet,Allowance,,5.00,SGD",
+		"",
+	}, "\n")
+	assert.Equal(t, expected, b.String())
+	assert.Equal(t, "export-933efe12-2219-42df-bd51-a2e84888432d-2021-01-01-2021-01-31-journal.csv", x.Filename(""))
+}
+
+func TestWriteQBO(t *testing.T) {
+	x := newTestExport(testEntries[:2])
+	x.WalletID = "d4a6607a-1af7-4571-bdff-2672be72ba0e"
+
+	var b bytes.Buffer
+	err := x.Write(NewQBOWriter(&b))
+	assert.NoError(t, err)
+
+	expected := strings.Join([]string{
+		"Date,Description,Amount",
+		"01/05/2021,Top up,50.00",
+		"01/06/2021,Client lunch,-20.50",
+		"",
+	}, "\n")
+	assert.Equal(t, expected, b.String())
+	assert.Equal(t, "export-d4a6607a-1af7-4571-bdff-2672be72ba0e-2021-01-01-2021-01-31-qbo.csv", x.Filename(FormatQBO))
+}
+
+func TestWriteXero(t *testing.T) {
+	x := newTestExport(testEntries[:2])
+
+	var b bytes.Buffer
+	err := x.Write(NewXeroWriter(&b))
+	assert.NoError(t, err)
+
+	expected := strings.Join([]string{
+		"*Date,*Amount,Payee,Description,Reference,Account Code",
+		"05/01/2021,50.00,,Top up,ref-1,Uncategorized Income",
+		`06/01/2021,-20.50,"ACME, Inc.",Client lunch,ref-2,6100 Meals`,
+		"",
+	}, "\n")
+	assert.Equal(t, expected, b.String())
+}
+
+func TestNewWriter(t *testing.T) {
+	cases := map[string]struct {
+		Format        string
+		ExpectedError bool
+	}{
+		"SuccessDefault": {Format: ""},
+		"SuccessJournal": {Format: "journal"},
+		"SuccessQBO":     {Format: "QBO"},
+		"SuccessXero":    {Format: "xero"},
+		"FailedUnknown":  {Format: "ofx", ExpectedError: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewWriter(tc.Format, &bytes.Buffer{})
+			if tc.ExpectedError {
+				assert.Equal(t, errors.InvalidExportFormat, err)
+				assert.Equal(t, errors.InvalidExportFormat, IsFormatValid(tc.Format))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NoError(t, IsFormatValid(tc.Format))
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+type (
+	// JournalWriter renders an export as a generic double-entry journal, each
+	// entry becomes a balanced pair of lines between the wallet account and
+	// its contra account
+	JournalWriter struct {
+		w       *csv.Writer
+		account string
+	}
+)
+
+var journalHeader = []string{"Date", "Reference", "Account", "Description", "Debit", "Credit", "Currency"}
+
+// NewJournalWriter ...
+func NewJournalWriter(w io.Writer) *JournalWriter {
+	return &JournalWriter{w: csv.NewWriter(w)}
+}
+
+// Begin ...
+func (j *JournalWriter) Begin(x *Export) error {
+	j.account = x.Accounts.WalletAccount()
+	return j.w.Write(journalHeader)
+}
+
+// Entry ...
+func (j *JournalWriter) Entry(entry Entry) error {
+	debitAccount, creditAccount := j.account, entry.Account
+	if entry.Direction == DirectionDebit {
+		debitAccount, creditAccount = entry.Account, j.account
+	}
+
+	date := entry.Date.Format(dateLayout)
+	text := description(entry)
+	amount := entry.Amount.String()
+
+	err := j.w.Write([]string{date, entry.Reference, debitAccount, text, amount, "", entry.Currency})
+	if err != nil {
+		return err
+	}
+
+	return j.w.Write([]string{date, entry.Reference, creditAccount, text, "", amount, entry.Currency})
+}
+
+// End ...
+func (j *JournalWriter) End() error {
+	j.w.Flush()
+	return j.w.Error()
+}
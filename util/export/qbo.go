@@ -0,0 +1,43 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+type (
+	// QBOWriter renders an export as the 3 column bank transactions CSV
+	// imported by QuickBooks Online, money leaving the wallet is negative
+	QBOWriter struct {
+		w *csv.Writer
+	}
+)
+
+var qboHeader = []string{"Date", "Description", "Amount"}
+
+const qboDateLayout = "01/02/2006"
+
+// NewQBOWriter ...
+func NewQBOWriter(w io.Writer) *QBOWriter {
+	return &QBOWriter{w: csv.NewWriter(w)}
+}
+
+// Begin ...
+func (q *QBOWriter) Begin(_ *Export) error {
+	return q.w.Write(qboHeader)
+}
+
+// Entry ...
+func (q *QBOWriter) Entry(entry Entry) error {
+	return q.w.Write([]string{
+		entry.Date.Format(qboDateLayout),
+		description(entry),
+		signedAmount(entry).String(),
+	})
+}
+
+// End ...
+func (q *QBOWriter) End() error {
+	q.w.Flush()
+	return q.w.Error()
+}
@@ -0,0 +1,46 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+type (
+	// XeroWriter renders an export as a precoded Xero bank statement CSV, the
+	// account code lets Xero suggest the reconciliation of every line
+	XeroWriter struct {
+		w *csv.Writer
+	}
+)
+
+var xeroHeader = []string{"*Date", "*Amount", "Payee", "Description", "Reference", "Account Code"}
+
+const xeroDateLayout = "02/01/2006"
+
+// NewXeroWriter ...
+func NewXeroWriter(w io.Writer) *XeroWriter {
+	return &XeroWriter{w: csv.NewWriter(w)}
+}
+
+// Begin ...
+func (x *XeroWriter) Begin(_ *Export) error {
+	return x.w.Write(xeroHeader)
+}
+
+// Entry ...
+func (x *XeroWriter) Entry(entry Entry) error {
+	return x.w.Write([]string{
+		entry.Date.Format(xeroDateLayout),
+		signedAmount(entry).String(),
+		entry.Payee,
+		description(entry),
+		entry.Reference,
+		entry.Account,
+	})
+}
+
+// End ...
+func (x *XeroWriter) End() error {
+	x.w.Flush()
+	return x.w.Error()
+}
@@ -0,0 +1,90 @@
+package fx
+
+import (
+	"context"
+	"math/big"
+
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+type (
+	// Converter converts money between currencies using a RateProvider.
+	// The markup is charged on top of the mid-market rate, so the converted
+	// amount is what the payer is debited for the source amount.
+	Converter struct {
+		Provider  RateProvider
+		MarkupBps uint64
+		Rounding  money.RoundingMode
+	}
+
+	// Conversion records everything needed to reproduce a converted amount
+	Conversion struct {
+		Source    money.Money        `json:"source"`
+		Result    money.Money        `json:"result"`
+		Rate      string             `json:"rate"`
+		MarkupBps uint64             `json:"markupBps"`
+		Rounding  money.RoundingMode `json:"rounding"`
+		Provider  string             `json:"provider"`
+	}
+)
+
+// NewConverter ...
+func NewConverter(provider RateProvider, markupBps uint64, rounding money.RoundingMode) *Converter {
+	return &Converter{
+		Provider:  provider,
+		MarkupBps: markupBps,
+		Rounding:  rounding,
+	}
+}
+
+// Convert converts amount into the to currency
+func (c *Converter) Convert(ctx context.Context, amount money.Money, to string) (*Conversion, error) {
+	target, err := money.GetCurrency(to)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := money.GetCurrency(amount.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	if source.Code == target.Code {
+		return &Conversion{
+			Source:   amount,
+			Result:   amount,
+			Rate:     big.NewRat(1, 1).FloatString(RatePrecision),
+			Rounding: c.Rounding,
+		}, nil
+	}
+
+	rate, err := c.Provider.GetRate(ctx, source.Code, target.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := Apply(amount.Amount, target, rate.Value, c.MarkupBps, c.Rounding)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Conversion{
+		Source:    amount,
+		Result:    money.Money{Amount: result, Currency: target.Code},
+		Rate:      rate.String(),
+		MarkupBps: c.MarkupBps,
+		Rounding:  c.Rounding,
+		Provider:  rate.Source,
+	}, nil
+}
+
+// Apply converts an amount into target, rounded to the minor units
+// of target. It is exported so a stored conversion can be recomputed.
+func Apply(amount money.Decimal, target money.Currency, rate *big.Rat, markupBps uint64, rounding money.RoundingMode) (money.Decimal, error) {
+	result := new(big.Rat).Mul(amount.Rat(), rate)
+
+	markup := new(big.Rat).SetFrac(new(big.Int).SetUint64(10000+markupBps), big.NewInt(10000))
+	result.Mul(result, markup)
+
+	return money.NewDecimalFromRat(result, int32(target.MinorUnits), rounding)
+}
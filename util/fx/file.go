@@ -0,0 +1,112 @@
+package fx
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+type (
+	// FileRateProvider serves rates from a JSON file so the service can run
+	// offline. The file is reloaded whenever its modification time changes.
+	//
+	//	{"base": "USD", "asOf": "2021-10-12T00:00:00Z", "rates": {"SGD": "1.3512"}}
+	FileRateProvider struct {
+		Path string
+
+		mu      sync.RWMutex
+		table   *Table
+		modTime time.Time
+	}
+
+	rateFile struct {
+		Base  string            `json:"base"`
+		AsOf  time.Time         `json:"asOf"`
+		Rates map[string]string `json:"rates"`
+	}
+)
+
+// NewFileRateProvider loads the rate file once to fail fast on a bad file
+func NewFileRateProvider(path string) (RateProvider, error) {
+	p := &FileRateProvider{
+		Path: path,
+	}
+
+	if err := p.reload(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// GetRate ...
+func (p *FileRateProvider) GetRate(ctx context.Context, from string, to string) (*Rate, error) {
+	if err := p.reload(); err != nil {
+		return nil, err
+	}
+
+	p.mu.RLock()
+	table := p.table
+	p.mu.RUnlock()
+
+	value, err := table.Rate(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Rate{
+		From:   from,
+		To:     to,
+		Value:  value,
+		Source: "file",
+		AsOf:   table.AsOf,
+	}, nil
+}
+
+func (p *FileRateProvider) reload() error {
+	info, err := os.Stat(p.Path)
+	if err != nil {
+		return errors.FailedRatesLoad.AppendError(err)
+	}
+
+	p.mu.RLock()
+	upToDate := p.table != nil && info.ModTime().Equal(p.modTime)
+	p.mu.RUnlock()
+
+	if upToDate {
+		return nil
+	}
+
+	content, err := os.ReadFile(p.Path)
+	if err != nil {
+		return errors.FailedRatesLoad.AppendError(err)
+	}
+
+	var file rateFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return errors.FailedRatesLoad.AppendError(err)
+	}
+
+	asOf := file.AsOf
+	if asOf.IsZero() {
+		asOf = info.ModTime().UTC()
+	}
+
+	table, err := NewTable(file.Base, file.Rates, asOf)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.table = table
+	p.modTime = info.ModTime()
+	p.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package fx
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+type (
+	// RateProvider returns the mid-market rate used to convert one unit of
+	// the from currency into the to currency.
+	RateProvider interface {
+		GetRate(ctx context.Context, from string, to string) (*Rate, error)
+	}
+
+	// Rate ...
+	Rate struct {
+		From   string    `json:"from"`
+		To     string    `json:"to"`
+		Value  *big.Rat  `json:"-"`
+		Source string    `json:"source"`
+		AsOf   time.Time `json:"asOf"`
+	}
+
+	// Table holds the rates of every currency against a single base currency,
+	// cross rates are derived from the base.
+	Table struct {
+		Base  string
+		Rates map[string]*big.Rat
+		AsOf  time.Time
+	}
+)
+
+// RatePrecision is the number of decimal places kept for a rate. Rates are
+// quantized before they are used so the stored rate reproduces the amount.
+const RatePrecision = 10
+
+// String returns the rate as a decimal string with RatePrecision digits
+func (r *Rate) String() string {
+	return r.Value.FloatString(RatePrecision)
+}
+
+// ParseRate parses a positive decimal rate such as "1.3512"
+func ParseRate(value string) (*big.Rat, error) {
+	rate, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok || rate.Sign() <= 0 {
+		return nil, errors.InvalidRate
+	}
+
+	return rate, nil
+}
+
+// NewTable builds a rate table from decimal strings keyed by currency code
+func NewTable(base string, rates map[string]string, asOf time.Time) (*Table, error) {
+	table := &Table{
+		Base:  strings.ToUpper(base),
+		Rates: map[string]*big.Rat{},
+		AsOf:  asOf,
+	}
+
+	for code, value := range rates {
+		rate, err := ParseRate(value)
+		if err != nil {
+			return nil, errors.InvalidRate.AppendError(fmt.Errorf("%s: %q", code, value))
+		}
+
+		table.Rates[strings.ToUpper(code)] = rate
+	}
+	table.Rates[table.Base] = big.NewRat(1, 1)
+
+	return table, nil
+}
+
+// Rate returns the rate from -> to, quantized to RatePrecision
+func (t *Table) Rate(from string, to string) (*big.Rat, error) {
+	from = strings.ToUpper(from)
+	to = strings.ToUpper(to)
+
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+
+	fromRate, ok := t.Rates[from]
+	if !ok {
+		return nil, errors.RateNotFound
+	}
+
+	toRate, ok := t.Rates[to]
+	if !ok {
+		return nil, errors.RateNotFound
+	}
+
+	rate, err := money.NewDecimalFromRat(new(big.Rat).Quo(toRate, fromRate), RatePrecision, money.RoundHalfUp)
+	if err != nil {
+		return nil, errors.InvalidRate.AppendError(err)
+	}
+
+	return rate.Rat(), nil
+}
@@ -0,0 +1,154 @@
+package fx
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+func TestStaticRateProvider(t *testing.T) {
+	provider, err := NewStaticRateProvider("usd", map[string]string{
+		"sgd": "1.35",
+		"EUR": "0.9",
+	})
+	assert.NoError(t, err)
+
+	cases := map[string]struct {
+		From          string
+		To            string
+		ExpectedRate  string
+		ExpectedError error
+	}{
+		"SuccessFromBase": {
+			From:         "USD",
+			To:           "SGD",
+			ExpectedRate: "1.3500000000",
+		},
+		"SuccessToBase": {
+			From:         "SGD",
+			To:           "USD",
+			ExpectedRate: "0.7407407407",
+		},
+		"SuccessCrossRate": {
+			From:         "EUR",
+			To:           "SGD",
+			ExpectedRate: "1.5000000000",
+		},
+		"FailedRateNotFound": {
+			From:          "USD",
+			To:            "JPY",
+			ExpectedError: errors.RateNotFound,
+		},
+	}
+
+	for v, tc := range cases {
+		t.Run(v, func(t *testing.T) {
+			rate, err := provider.GetRate(context.Background(), tc.From, tc.To)
+
+			if tc.ExpectedError != nil {
+				assert.Equal(t, tc.ExpectedError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.ExpectedRate, rate.String())
+			}
+		})
+	}
+
+	_, err = NewStaticRateProvider("USD", map[string]string{"SGD": "-1"})
+	assert.Error(t, err)
+}
+
+func TestFileRateProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	err := os.WriteFile(path, []byte(`{"base": "SGD", "rates": {"USD": "0.74", "JPY": "82"}}`), 0600)
+	assert.NoError(t, err)
+
+	provider, err := NewFileRateProvider(path)
+	assert.NoError(t, err)
+
+	rate, err := provider.GetRate(context.Background(), "SGD", "JPY")
+	assert.NoError(t, err)
+	assert.Equal(t, "82.0000000000", rate.String())
+	assert.Equal(t, "file", rate.Source)
+
+	_, err = NewFileRateProvider(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestConvert(t *testing.T) {
+	provider, err := NewStaticRateProvider("USD", map[string]string{
+		"SGD": "1.35",
+		"JPY": "110",
+		"KWD": "0.3",
+	})
+	assert.NoError(t, err)
+
+	cases := map[string]struct {
+		Amount         money.Money
+		To             string
+		MarkupBps      uint64
+		Rounding       money.RoundingMode
+		ExpectedAmount string
+	}{
+		"SuccessSameCurrency": {
+			Amount:         money.Money{Amount: money.NewDecimal(1000, 2), Currency: "SGD"},
+			To:             "SGD",
+			ExpectedAmount: "10.00",
+		},
+		"SuccessWithoutMarkup": {
+			Amount:         money.Money{Amount: money.NewDecimal(1000, 2), Currency: "USD"},
+			To:             "SGD",
+			Rounding:       money.RoundHalfUp,
+			ExpectedAmount: "13.50",
+		},
+		"SuccessWithMarkup": {
+			Amount:         money.Money{Amount: money.NewDecimal(1000, 2), Currency: "USD"},
+			To:             "SGD",
+			MarkupBps:      250,
+			Rounding:       money.RoundHalfUp,
+			ExpectedAmount: "13.84",
+		},
+		"SuccessToZeroMinorUnits": {
+			Amount:         money.Money{Amount: money.NewDecimal(1050, 2), Currency: "USD"},
+			To:             "JPY",
+			Rounding:       money.RoundHalfUp,
+			ExpectedAmount: "1155",
+		},
+		"SuccessToThreeMinorUnits": {
+			Amount:         money.Money{Amount: money.NewDecimal(1000, 2), Currency: "USD"},
+			To:             "KWD",
+			Rounding:       money.RoundHalfUp,
+			ExpectedAmount: "3.000",
+		},
+		"SuccessRoundDown": {
+			Amount:         money.Money{Amount: money.NewDecimal(1001, 2), Currency: "USD"},
+			To:             "SGD",
+			MarkupBps:      250,
+			Rounding:       money.RoundDown,
+			ExpectedAmount: "13.85",
+		},
+		"SuccessRoundUp": {
+			Amount:         money.Money{Amount: money.NewDecimal(1001, 2), Currency: "USD"},
+			To:             "SGD",
+			MarkupBps:      250,
+			Rounding:       money.RoundUp,
+			ExpectedAmount: "13.86",
+		},
+	}
+
+	for v, tc := range cases {
+		t.Run(v, func(t *testing.T) {
+			converter := NewConverter(provider, tc.MarkupBps, tc.Rounding)
+
+			conversion, err := converter.Convert(context.Background(), tc.Amount, tc.To)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ExpectedAmount, conversion.Result.Amount.String())
+			assert.Equal(t, tc.To, conversion.Result.Currency)
+		})
+	}
+}
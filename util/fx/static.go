@@ -0,0 +1,42 @@
+package fx
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// StaticRateProvider serves rates from an in-memory table, e.g. the
+	// [fx.static] section of the configuration.
+	StaticRateProvider struct {
+		Table *Table
+	}
+)
+
+// NewStaticRateProvider ...
+func NewStaticRateProvider(base string, rates map[string]string) (RateProvider, error) {
+	table, err := NewTable(base, rates, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	return &StaticRateProvider{
+		Table: table,
+	}, nil
+}
+
+// GetRate ...
+func (p *StaticRateProvider) GetRate(ctx context.Context, from string, to string) (*Rate, error) {
+	value, err := p.Table.Rate(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Rate{
+		From:   from,
+		To:     to,
+		Value:  value,
+		Source: "static",
+		AsOf:   p.Table.AsOf,
+	}, nil
+}
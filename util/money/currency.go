@@ -0,0 +1,98 @@
+package money
+
+import (
+	"strings"
+
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+)
+
+type (
+	// Currency describes an ISO 4217 currency and the number of digits after
+	// the decimal separator used by its minor unit.
+	Currency struct {
+		Code       string `json:"code"`
+		Numeric    string `json:"numeric"`
+		MinorUnits int    `json:"minorUnits"`
+	}
+)
+
+// DefaultCurrency is used when a wallet is created without a currency
+const DefaultCurrency = "SGD"
+
+var currencies = map[string]Currency{
+	"AED": {Code: "AED", Numeric: "784", MinorUnits: 2},
+	"ARS": {Code: "ARS", Numeric: "032", MinorUnits: 2},
+	"AUD": {Code: "AUD", Numeric: "036", MinorUnits: 2},
+	"BDT": {Code: "BDT", Numeric: "050", MinorUnits: 2},
+	"BHD": {Code: "BHD", Numeric: "048", MinorUnits: 3},
+	"BND": {Code: "BND", Numeric: "096", MinorUnits: 2},
+	"BRL": {Code: "BRL", Numeric: "986", MinorUnits: 2},
+	"CAD": {Code: "CAD", Numeric: "124", MinorUnits: 2},
+	"CHF": {Code: "CHF", Numeric: "756", MinorUnits: 2},
+	"CLP": {Code: "CLP", Numeric: "152", MinorUnits: 0},
+	"CNY": {Code: "CNY", Numeric: "156", MinorUnits: 2},
+	"COP": {Code: "COP", Numeric: "170", MinorUnits: 2},
+	"CZK": {Code: "CZK", Numeric: "203", MinorUnits: 2},
+	"DKK": {Code: "DKK", Numeric: "208", MinorUnits: 2},
+	"EGP": {Code: "EGP", Numeric: "818", MinorUnits: 2},
+	"EUR": {Code: "EUR", Numeric: "978", MinorUnits: 2},
+	"GBP": {Code: "GBP", Numeric: "826", MinorUnits: 2},
+	"HKD": {Code: "HKD", Numeric: "344", MinorUnits: 2},
+	"HUF": {Code: "HUF", Numeric: "348", MinorUnits: 2},
+	"IDR": {Code: "IDR", Numeric: "360", MinorUnits: 2},
+	"ILS": {Code: "ILS", Numeric: "376", MinorUnits: 2},
+	"INR": {Code: "INR", Numeric: "356", MinorUnits: 2},
+	"ISK": {Code: "ISK", Numeric: "352", MinorUnits: 0},
+	"JOD": {Code: "JOD", Numeric: "400", MinorUnits: 3},
+	"JPY": {Code: "JPY", Numeric: "392", MinorUnits: 0},
+	"KES": {Code: "KES", Numeric: "404", MinorUnits: 2},
+	"KHR": {Code: "KHR", Numeric: "116", MinorUnits: 2},
+	"KRW": {Code: "KRW", Numeric: "410", MinorUnits: 0},
+	"KWD": {Code: "KWD", Numeric: "414", MinorUnits: 3},
+	"LKR": {Code: "LKR", Numeric: "144", MinorUnits: 2},
+	"MMK": {Code: "MMK", Numeric: "104", MinorUnits: 2},
+	"MXN": {Code: "MXN", Numeric: "484", MinorUnits: 2},
+	"MYR": {Code: "MYR", Numeric: "458", MinorUnits: 2},
+	"NGN": {Code: "NGN", Numeric: "566", MinorUnits: 2},
+	"NOK": {Code: "NOK", Numeric: "578", MinorUnits: 2},
+	"NZD": {Code: "NZD", Numeric: "554", MinorUnits: 2},
+	"OMR": {Code: "OMR", Numeric: "512", MinorUnits: 3},
+	"PHP": {Code: "PHP", Numeric: "608", MinorUnits: 2},
+	"PKR": {Code: "PKR", Numeric: "586", MinorUnits: 2},
+	"PLN": {Code: "PLN", Numeric: "985", MinorUnits: 2},
+	"QAR": {Code: "QAR", Numeric: "634", MinorUnits: 2},
+	"RUB": {Code: "RUB", Numeric: "643", MinorUnits: 2},
+	"SAR": {Code: "SAR", Numeric: "682", MinorUnits: 2},
+	"SEK": {Code: "SEK", Numeric: "752", MinorUnits: 2},
+	"SGD": {Code: "SGD", Numeric: "702", MinorUnits: 2},
+	"THB": {Code: "THB", Numeric: "764", MinorUnits: 2},
+	"TRY": {Code: "TRY", Numeric: "949", MinorUnits: 2},
+	"TWD": {Code: "TWD", Numeric: "901", MinorUnits: 2},
+	"UAH": {Code: "UAH", Numeric: "980", MinorUnits: 2},
+	"USD": {Code: "USD", Numeric: "840", MinorUnits: 2},
+	"VND": {Code: "VND", Numeric: "704", MinorUnits: 0},
+	"ZAR": {Code: "ZAR", Numeric: "710", MinorUnits: 2},
+}
+
+// GetCurrency returns the ISO 4217 currency for the given alphabetic code.
+// The lookup is case insensitive.
+func GetCurrency(code string) (Currency, error) {
+	currency, ok := currencies[strings.ToUpper(strings.TrimSpace(code))]
+	if !ok {
+		return Currency{}, errors.InvalidCurrency
+	}
+
+	return currency, nil
+}
+
+// FromMinorUnits returns an amount given in the minor unit of the currency,
+// e.g. 1050 is 10.50 SGD and 1050 JPY
+func (c Currency) FromMinorUnits(amount int64) Decimal {
+	return NewDecimal(amount, int32(c.MinorUnits))
+}
+
+// IsCurrencyValid ...
+func IsCurrencyValid(code string) error {
+	_, err := GetCurrency(code)
+	return err
+}
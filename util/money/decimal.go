@@ -0,0 +1,281 @@
+package money
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/go-pg/pg/v10/types"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+)
+
+type (
+	// Decimal is an exact fixed-point number, value × 10^-scale. The zero
+	// value is 0 with a scale of 0.
+	Decimal struct {
+		value *big.Int
+		scale int32
+	}
+)
+
+// MaxPrecision is the maximum number of significant digits a Decimal may
+// hold, arithmetic exceeding it fails with errors.AmountOverflow.
+const MaxPrecision = 38
+
+var (
+	_ types.ValueAppender = Decimal{}
+	_ types.ValueScanner  = (*Decimal)(nil)
+)
+
+// NewDecimal returns value × 10^-scale, e.g. NewDecimal(1050, 2) is 10.50
+func NewDecimal(value int64, scale int32) Decimal {
+	return Decimal{value: big.NewInt(value), scale: scale}
+}
+
+// NewDecimalFromRat rounds r to the given scale
+func NewDecimalFromRat(r *big.Rat, scale int32, mode RoundingMode) (Decimal, error) {
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(pow10(scale)))
+
+	d := Decimal{value: roundRat(scaled, mode), scale: scale}
+	if err := d.checkPrecision(); err != nil {
+		return Decimal{}, err
+	}
+
+	return d, nil
+}
+
+// ParseDecimal parses a plain decimal string such as "-1234.50". Exponents,
+// thousand separators and empty fractions are rejected.
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+
+	unsigned := strings.TrimLeft(s, "+-")
+	if len(s)-len(unsigned) > 1 || unsigned == "" {
+		return Decimal{}, errors.InvalidDecimal
+	}
+
+	integer, fraction := unsigned, ""
+	if i := strings.IndexByte(unsigned, '.'); i >= 0 {
+		integer, fraction = unsigned[:i], unsigned[i+1:]
+		if fraction == "" {
+			return Decimal{}, errors.InvalidDecimal
+		}
+	}
+
+	if integer == "" {
+		integer = "0"
+	}
+
+	digits := integer + fraction
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return Decimal{}, errors.InvalidDecimal
+		}
+	}
+
+	value, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, errors.InvalidDecimal
+	}
+
+	if strings.HasPrefix(s, "-") {
+		value.Neg(value)
+	}
+
+	d := Decimal{value: value, scale: int32(len(fraction))}
+	if err := d.checkPrecision(); err != nil {
+		return Decimal{}, err
+	}
+
+	return d, nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics on invalid input, it is
+// meant for constants and tests.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+// Scale returns the number of digits after the decimal point
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Sign returns -1, 0 or 1
+func (d Decimal) Sign() int {
+	if d.value == nil {
+		return 0
+	}
+
+	return d.value.Sign()
+}
+
+// IsZero also lets go-pg insert DEFAULT or NULL for zero amounts
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp returns -1, 0 or 1 if d is less than, equal to or greater than o
+func (d Decimal) Cmp(o Decimal) int {
+	scale := maxScale(d, o)
+	return d.rescaled(scale).Cmp(o.rescaled(scale))
+}
+
+// Add returns d + o with the larger of both scales
+func (d Decimal) Add(o Decimal) (Decimal, error) {
+	scale := maxScale(d, o)
+
+	sum := Decimal{value: new(big.Int).Add(d.rescaled(scale), o.rescaled(scale)), scale: scale}
+	if err := sum.checkPrecision(); err != nil {
+		return Decimal{}, err
+	}
+
+	return sum, nil
+}
+
+// Sub returns d - o with the larger of both scales
+func (d Decimal) Sub(o Decimal) (Decimal, error) {
+	return d.Add(o.Neg())
+}
+
+// Neg returns -d
+func (d Decimal) Neg() Decimal {
+	return Decimal{value: new(big.Int).Neg(d.unscaled()), scale: d.scale}
+}
+
+// Round returns d with the given scale, rounding when digits are dropped.
+// Adding digits can exceed MaxPrecision and fail with errors.AmountOverflow.
+func (d Decimal) Round(scale int32, mode RoundingMode) (Decimal, error) {
+	if scale >= d.scale {
+		rounded := Decimal{value: d.rescaled(scale), scale: scale}
+		if err := rounded.checkPrecision(); err != nil {
+			return Decimal{}, err
+		}
+
+		return rounded, nil
+	}
+
+	return NewDecimalFromRat(d.Rat(), scale, mode)
+}
+
+// Rat returns d as an exact rational number
+func (d Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.unscaled(), pow10(d.scale))
+}
+
+// String formats d without exponent, keeping trailing zeros of the scale
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.unscaled()).String()
+
+	sign := ""
+	if d.Sign() < 0 {
+		sign = "-"
+	}
+
+	if d.scale <= 0 {
+		if d.Sign() == 0 {
+			return "0"
+		}
+		return sign + digits + strings.Repeat("0", int(-d.scale))
+	}
+
+	if len(digits) <= int(d.scale) {
+		digits = strings.Repeat("0", int(d.scale)-len(digits)+1) + digits
+	}
+
+	point := len(digits) - int(d.scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// MarshalJSON encodes d as a string so no precision is lost in clients
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON accepts both "10.50" and 10.50
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		*d = Decimal{}
+		return nil
+	}
+
+	if strings.HasPrefix(s, `"`) || strings.HasSuffix(s, `"`) {
+		if len(s) < 2 || !strings.HasPrefix(s, `"`) || !strings.HasSuffix(s, `"`) {
+			return errors.InvalidDecimal
+		}
+
+		s = s[1 : len(s)-1]
+	}
+
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+	return nil
+}
+
+// AppendValue implements types.ValueAppender
+func (d Decimal) AppendValue(b []byte, flags int) ([]byte, error) {
+	return append(b, d.String()...), nil
+}
+
+// ScanValue implements types.ValueScanner
+func (d *Decimal) ScanValue(rd types.Reader, n int) error {
+	if n <= 0 {
+		*d = Decimal{}
+		return nil
+	}
+
+	s, err := types.ScanString(rd, n)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+	return nil
+}
+
+func (d Decimal) unscaled() *big.Int {
+	if d.value == nil {
+		return new(big.Int)
+	}
+
+	return d.value
+}
+
+// rescaled returns the unscaled value at a scale greater or equal to d.scale
+func (d Decimal) rescaled(scale int32) *big.Int {
+	return new(big.Int).Mul(d.unscaled(), pow10(scale-d.scale))
+}
+
+func (d Decimal) checkPrecision() error {
+	if len(new(big.Int).Abs(d.unscaled()).String()) > MaxPrecision {
+		return errors.AmountOverflow
+	}
+
+	return nil
+}
+
+func maxScale(a Decimal, b Decimal) int32 {
+	if a.scale > b.scale {
+		return a.scale
+	}
+
+	return b.scale
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,156 @@
+package money
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/go-pg/pg/v10/types"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+func TestParseDecimal(t *testing.T) {
+	cases := map[string]struct {
+		Value         string
+		Expected      string
+		ExpectedScale int32
+		ExpectedError error
+	}{
+		"SuccessInteger":          {Value: "1050", Expected: "1050", ExpectedScale: 0},
+		"SuccessFraction":         {Value: "10.50", Expected: "10.50", ExpectedScale: 2},
+		"SuccessNegative":         {Value: "-0.05", Expected: "-0.05", ExpectedScale: 2},
+		"SuccessLeadingPoint":     {Value: ".5", Expected: "0.5", ExpectedScale: 1},
+		"SuccessHugeValue":        {Value: "12345678901234567890123456.123456789", Expected: "12345678901234567890123456.123456789", ExpectedScale: 9},
+		"FailedEmpty":             {Value: "", ExpectedError: errors.InvalidDecimal},
+		"FailedTrailingPoint":     {Value: "10.", ExpectedError: errors.InvalidDecimal},
+		"FailedExponent":          {Value: "1e3", ExpectedError: errors.InvalidDecimal},
+		"FailedThousandSeparator": {Value: "1,000", ExpectedError: errors.InvalidDecimal},
+		"FailedDoubleSign":        {Value: "--1", ExpectedError: errors.InvalidDecimal},
+		"FailedPrecision":         {Value: strings.Repeat("9", MaxPrecision+1), ExpectedError: errors.AmountOverflow},
+	}
+
+	for v, tc := range cases {
+		t.Run(v, func(t *testing.T) {
+			d, err := ParseDecimal(tc.Value)
+
+			if tc.ExpectedError != nil {
+				assert.Equal(t, tc.ExpectedError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.Expected, d.String())
+				assert.Equal(t, tc.ExpectedScale, d.Scale())
+			}
+		})
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	sum, err := MustParseDecimal("0.1").Add(MustParseDecimal("0.2"))
+	assert.NoError(t, err)
+	assert.Equal(t, "0.3", sum.String())
+
+	diff, err := MustParseDecimal("1").Sub(MustParseDecimal("1.25"))
+	assert.NoError(t, err)
+	assert.Equal(t, "-0.25", diff.String())
+
+	assert.Equal(t, 0, MustParseDecimal("10.50").Cmp(MustParseDecimal("10.5")))
+	assert.Equal(t, 1, MustParseDecimal("10.51").Cmp(MustParseDecimal("10.5")))
+	assert.True(t, Decimal{}.IsZero())
+	assert.Equal(t, "0", Decimal{}.String())
+
+	round := func(s string, scale int32, mode RoundingMode) string {
+		rounded, err := MustParseDecimal(s).Round(scale, mode)
+		assert.NoError(t, err)
+		return rounded.String()
+	}
+	assert.Equal(t, "10.50", round("10.5", 2, RoundDown))
+	assert.Equal(t, "10.51", round("10.505", 2, RoundHalfUp))
+	assert.Equal(t, "-10.51", round("-10.505", 2, RoundHalfUp))
+	assert.Equal(t, "10.50", round("10.505", 2, RoundHalfEven))
+
+	// NOTE: Adding digits must not exceed the precision either
+	_, err = MustParseDecimal("12345678901234567890123456789012345678").Round(2, RoundDown)
+	assert.Equal(t, errors.AmountOverflow, err)
+}
+
+func TestDecimalJSON(t *testing.T) {
+	b, err := json.Marshal(MustParseDecimal("10.50"))
+	assert.NoError(t, err)
+	assert.Equal(t, `"10.50"`, string(b))
+
+	var payload struct {
+		Quoted Decimal `json:"quoted"`
+		Number Decimal `json:"number"`
+		Null   Decimal `json:"null"`
+		Huge   Decimal `json:"huge"`
+	}
+	err = json.Unmarshal([]byte(`{"quoted": "0.10", "number": 10.5, "null": null, "huge": 123456789012345678901234567890}`), &payload)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.10", payload.Quoted.String())
+	assert.Equal(t, "10.5", payload.Number.String())
+	assert.True(t, payload.Null.IsZero())
+	assert.Equal(t, "123456789012345678901234567890", payload.Huge.String())
+
+	err = json.Unmarshal([]byte(`{"quoted": "1e3"}`), &payload)
+	assert.Error(t, err)
+
+	// NOTE: Quotes must be balanced
+	for _, data := range []string{`"10.50`, `10.50"`, `"`, `""10.50""`} {
+		var d Decimal
+		assert.Equal(t, errors.InvalidDecimal, d.UnmarshalJSON([]byte(data)), data)
+	}
+}
+
+func TestDecimalPostgres(t *testing.T) {
+	b, err := MustParseDecimal("-10.50").AppendValue(nil, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "-10.50", string(b))
+
+	assert.Equal(t, "1234.5678", string(types.Append(nil, MustParseDecimal("1234.5678"), 1)))
+
+	var d Decimal
+	err = d.ScanValue(nil, -1)
+	assert.NoError(t, err)
+	assert.True(t, d.IsZero())
+}
+
+func TestRoundRat(t *testing.T) {
+	cases := map[string]struct {
+		Value    *big.Rat
+		Mode     RoundingMode
+		Expected int64
+	}{
+		"HalfUpRoundsHalfAwayFromZero": {Value: big.NewRat(5, 2), Mode: RoundHalfUp, Expected: 3},
+		"HalfUpRoundsNegativeHalfDown": {Value: big.NewRat(-5, 2), Mode: RoundHalfUp, Expected: -3},
+		"HalfEvenRoundsHalfToEven":     {Value: big.NewRat(5, 2), Mode: RoundHalfEven, Expected: 2},
+		"HalfEvenRoundsOddHalfUp":      {Value: big.NewRat(7, 2), Mode: RoundHalfEven, Expected: 4},
+		"HalfEvenRoundsAboveHalfUp":    {Value: big.NewRat(13, 5), Mode: RoundHalfEven, Expected: 3},
+		"DownTruncates":                {Value: big.NewRat(29, 10), Mode: RoundDown, Expected: 2},
+		"DownTruncatesTowardsZero":     {Value: big.NewRat(-29, 10), Mode: RoundDown, Expected: -2},
+		"UpCeils":                      {Value: big.NewRat(21, 10), Mode: RoundUp, Expected: 3},
+		"ExactValueIsUnchanged":        {Value: big.NewRat(4, 1), Mode: RoundUp, Expected: 4},
+	}
+
+	for v, tc := range cases {
+		t.Run(v, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, roundRat(tc.Value, tc.Mode).Int64())
+		})
+	}
+}
+
+func TestParseRoundingMode(t *testing.T) {
+	mode, err := ParseRoundingMode("HALF_EVEN")
+	assert.NoError(t, err)
+	assert.Equal(t, RoundHalfEven, mode)
+
+	mode, err = ParseRoundingMode("")
+	assert.NoError(t, err)
+	assert.Equal(t, RoundHalfUp, mode)
+
+	_, err = ParseRoundingMode("bankers")
+	assert.Equal(t, errors.InvalidRoundingMode, err)
+}
@@ -0,0 +1,109 @@
+package money
+
+import (
+	"fmt"
+	"strings"
+
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+)
+
+type (
+	// Money is an exact amount in the major unit of its currency, scaled to
+	// the currency minor units, e.g. 10.50 SGD or 1050 JPY.
+	Money struct {
+		Amount   Decimal `json:"amount"`
+		Currency string  `json:"currency"`
+	}
+)
+
+// New validates the currency and returns a Money in its canonical form. An
+// amount with more decimal places than the currency allows is rejected
+// rather than silently rounded.
+func New(amount Decimal, currency string) (Money, error) {
+	c, err := GetCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	rounded, err := amount.Round(int32(c.MinorUnits), RoundDown)
+	if err != nil {
+		return Money{}, err
+	}
+
+	if rounded.Cmp(amount) != 0 {
+		return Money{}, errors.InvalidAmountScale
+	}
+
+	return Money{
+		Amount:   rounded,
+		Currency: c.Code,
+	}, nil
+}
+
+// IsZero ...
+func (m Money) IsZero() bool {
+	return m.Amount.IsZero()
+}
+
+// SameCurrency reports whether both values are expressed in the same currency
+func (m Money) SameCurrency(o Money) bool {
+	return strings.EqualFold(m.Currency, o.Currency)
+}
+
+// Add returns m + o. Cross-currency additions are rejected, the caller must
+// convert the amount explicitly beforehand.
+func (m Money) Add(o Money) (Money, error) {
+	if !m.SameCurrency(o) {
+		return Money{}, errors.CurrencyMismatch
+	}
+
+	amount, err := m.Amount.Add(o.Amount)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: amount, Currency: m.Currency}, nil
+}
+
+// Sub returns m - o. Cross-currency subtractions are rejected and the result
+// can never be negative.
+func (m Money) Sub(o Money) (Money, error) {
+	if !m.SameCurrency(o) {
+		return Money{}, errors.CurrencyMismatch
+	}
+
+	amount, err := m.Amount.Sub(o.Amount)
+	if err != nil {
+		return Money{}, err
+	}
+
+	if amount.Sign() < 0 {
+		return Money{}, errors.InsufficientAmount
+	}
+
+	return Money{Amount: amount, Currency: m.Currency}, nil
+}
+
+// Cmp returns -1, 0 or 1 if m is less than, equal to or greater than o
+func (m Money) Cmp(o Money) (int, error) {
+	if !m.SameCurrency(o) {
+		return 0, errors.CurrencyMismatch
+	}
+
+	return m.Amount.Cmp(o.Amount), nil
+}
+
+// String formats the amount with its currency, e.g. "10.50 SGD"
+func (m Money) String() string {
+	c, err := GetCurrency(m.Currency)
+	if err != nil {
+		return fmt.Sprintf("%s %s", m.Amount, m.Currency)
+	}
+
+	amount, err := m.Amount.Round(int32(c.MinorUnits), RoundHalfUp)
+	if err != nil {
+		return fmt.Sprintf("%s %s", m.Amount, c.Code)
+	}
+
+	return fmt.Sprintf("%s %s", amount, c.Code)
+}
@@ -0,0 +1,114 @@
+package money
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+)
+
+func TestNew(t *testing.T) {
+	cases := map[string]struct {
+		Amount           string
+		Currency         string
+		ExpectedAmount   string
+		ExpectedCurrency string
+		ExpectedError    error
+	}{
+		"SuccessUppercase": {
+			Amount:           "10",
+			Currency:         "SGD",
+			ExpectedAmount:   "10.00",
+			ExpectedCurrency: "SGD",
+		},
+		"SuccessLowercase": {
+			Amount:           "10.5",
+			Currency:         "usd",
+			ExpectedAmount:   "10.50",
+			ExpectedCurrency: "USD",
+		},
+		"SuccessTrailingZeros": {
+			Amount:           "1050.00",
+			Currency:         "JPY",
+			ExpectedAmount:   "1050",
+			ExpectedCurrency: "JPY",
+		},
+		"FailedTooManyDecimalPlaces": {
+			Amount:        "10.505",
+			Currency:      "SGD",
+			ExpectedError: errors.InvalidAmountScale,
+		},
+		"FailedUnknownCurrency": {
+			Amount:        "10",
+			Currency:      "XYZ",
+			ExpectedError: errors.InvalidCurrency,
+		},
+		"FailedEmptyCurrency": {
+			Amount:        "10",
+			Currency:      "",
+			ExpectedError: errors.InvalidCurrency,
+		},
+	}
+
+	for v, tc := range cases {
+		t.Run(v, func(t *testing.T) {
+			m, err := New(MustParseDecimal(tc.Amount), tc.Currency)
+
+			if tc.ExpectedError != nil {
+				assert.Equal(t, tc.ExpectedError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.ExpectedAmount, m.Amount.String())
+				assert.Equal(t, tc.ExpectedCurrency, m.Currency)
+			}
+		})
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	sgd := func(amount string) Money { return Money{Amount: MustParseDecimal(amount), Currency: "SGD"} }
+	usd := func(amount string) Money { return Money{Amount: MustParseDecimal(amount), Currency: "USD"} }
+
+	sum, err := sgd("10.50").Add(sgd("0.50"))
+	assert.NoError(t, err)
+	assert.Equal(t, "11.00", sum.Amount.String())
+
+	diff, err := sgd("10.50").Sub(sgd("0.50"))
+	assert.NoError(t, err)
+	assert.Equal(t, "10.00", diff.Amount.String())
+
+	_, err = sgd("10.50").Add(usd("0.50"))
+	assert.Equal(t, errors.CurrencyMismatch, err)
+
+	_, err = sgd("10.50").Sub(usd("0.50"))
+	assert.Equal(t, errors.CurrencyMismatch, err)
+
+	_, err = sgd("0.50").Sub(sgd("10.50"))
+	assert.Equal(t, errors.InsufficientAmount, err)
+
+	_, err = sgd(strings.Repeat("9", MaxPrecision)).Add(sgd("1"))
+	assert.Equal(t, errors.AmountOverflow, err)
+
+	cmp, err := sgd("0.01").Cmp(sgd("0.02"))
+	assert.NoError(t, err)
+	assert.Equal(t, -1, cmp)
+
+	_, err = sgd("1").Cmp(usd("1"))
+	assert.Equal(t, errors.CurrencyMismatch, err)
+}
+
+func TestString(t *testing.T) {
+	assert.Equal(t, "10.50 SGD", Money{Amount: MustParseDecimal("10.5"), Currency: "SGD"}.String())
+	assert.Equal(t, "0.05 USD", Money{Amount: NewDecimal(5, 2), Currency: "USD"}.String())
+	assert.Equal(t, "1050 JPY", Money{Amount: NewDecimal(1050, 0), Currency: "JPY"}.String())
+	assert.Equal(t, "1.050 KWD", Money{Amount: NewDecimal(1050, 3), Currency: "KWD"}.String())
+}
+
+func TestFromMinorUnits(t *testing.T) {
+	for code, expected := range map[string]string{"SGD": "5000.00", "JPY": "500000", "KWD": "500.000"} {
+		currency, err := GetCurrency(code)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, currency.FromMinorUnits(500000).String())
+	}
+}
@@ -0,0 +1,70 @@
+package money
+
+import (
+	"math/big"
+	"strings"
+
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+)
+
+type (
+	// RoundingMode decides how a value is rounded when digits are dropped
+	RoundingMode string
+)
+
+const (
+	// RoundHalfUp rounds half away from zero
+	RoundHalfUp RoundingMode = "half_up"
+	// RoundHalfEven rounds half to the nearest even digit (banker's rounding)
+	RoundHalfEven RoundingMode = "half_even"
+	// RoundDown truncates towards zero
+	RoundDown RoundingMode = "down"
+	// RoundUp rounds away from zero
+	RoundUp RoundingMode = "up"
+)
+
+// ParseRoundingMode ...
+func ParseRoundingMode(mode string) (RoundingMode, error) {
+	switch RoundingMode(strings.ToLower(mode)) {
+	case RoundHalfUp, "":
+		return RoundHalfUp, nil
+	case RoundHalfEven:
+		return RoundHalfEven, nil
+	case RoundDown:
+		return RoundDown, nil
+	case RoundUp:
+		return RoundUp, nil
+	default:
+		return "", errors.InvalidRoundingMode
+	}
+}
+
+// roundRat rounds a rational number to an integer
+func roundRat(r *big.Rat, mode RoundingMode) *big.Int {
+	num := new(big.Int).Abs(r.Num())
+	quo, rem := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+
+	if rem.Sign() != 0 {
+		half := new(big.Int).Mul(rem, big.NewInt(2)).Cmp(r.Denom())
+
+		switch mode {
+		case RoundDown:
+		case RoundUp:
+			quo.Add(quo, big.NewInt(1))
+		case RoundHalfEven:
+			if half > 0 || half == 0 && quo.Bit(0) == 1 {
+				quo.Add(quo, big.NewInt(1))
+			}
+		default:
+			if half >= 0 {
+				quo.Add(quo, big.NewInt(1))
+			}
+		}
+	}
+
+	if r.Sign() < 0 {
+		quo.Neg(quo)
+	}
+
+	return quo
+}
@@ -0,0 +1,23 @@
+package logging
+
+import (
+	"context"
+
+	"gitlab.com/renodesper/spenmo-test/util/logger"
+	"gitlab.com/renodesper/spenmo-test/util/notifier"
+)
+
+type Logging struct {
+	Log logger.Logger
+}
+
+// CreateNotifier creates notifier that writes every event to the log
+func CreateNotifier(log logger.Logger) notifier.Notifier {
+	return &Logging{Log: log}
+}
+
+// Notify implements Notifier.Notify
+func (n *Logging) Notify(ctx context.Context, event notifier.Event) error {
+	n.Log.Infow(event.Message, "type", event.Type, "data", event.Data, "occurredAt", event.OccurredAt)
+	return nil
+}
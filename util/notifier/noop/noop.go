@@ -0,0 +1,20 @@
+package noop
+
+import (
+	"context"
+
+	"gitlab.com/renodesper/spenmo-test/util/notifier"
+)
+
+type Noop struct {
+}
+
+// CreateNotifier creates notifier that does nothing
+func CreateNotifier() notifier.Notifier {
+	return &Noop{}
+}
+
+// Notify implements Notifier.Notify
+func (n *Noop) Notify(ctx context.Context, event notifier.Event) error {
+	return nil
+}
@@ -0,0 +1,44 @@
+package notifier
+
+import (
+	"context"
+	"time"
+)
+
+// Notifier delivers events to the people or systems watching them
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+// Event is a single notification. Data carries the entity the event is about
+// and is serialized as is by the notifiers.
+type Event struct {
+	Type       string      `json:"type"`
+	Message    string      `json:"message"`
+	Data       interface{} `json:"data"`
+	OccurredAt time.Time   `json:"occurredAt"`
+}
+
+// Notifiers contains one or more notifier
+type Notifiers struct {
+	Ns []Notifier
+}
+
+// Notify implements Notifier.Notify, every notifier is called even when one
+// fails and the first error is returned
+func (ns *Notifiers) Notify(ctx context.Context, event Event) error {
+	var err error
+
+	for _, n := range ns.Ns {
+		if e := n.Notify(ctx, event); e != nil && err == nil {
+			err = e
+		}
+	}
+
+	return err
+}
+
+// New notifiers
+func New(ns ...Notifier) *Notifiers {
+	return &Notifiers{ns}
+}
@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gitlab.com/renodesper/spenmo-test/util/ratelimit"
+)
+
+type (
+	Memory struct {
+		mu        sync.Mutex
+		windows   map[string]window
+		lastSweep time.Time
+	}
+
+	window struct {
+		start   time.Time
+		count   int
+		expires time.Time
+	}
+)
+
+// CreateStore creates rate limit store kept in the memory of the process, the
+// counters are neither shared between instances nor kept across restarts
+func CreateStore() ratelimit.Store {
+	return &Memory{
+		windows: map[string]window{},
+	}
+}
+
+// Increment implements Store.Increment
+func (m *Memory) Increment(ctx context.Context, key string, start time.Time, ttl time.Duration) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// NOTE: Only the current window of a key is kept, a newer window replaces it
+	w, ok := m.windows[key]
+	if !ok || w.start.Before(start) {
+		w = window{start: start}
+	}
+	if w.start.After(start) {
+		return 0, nil
+	}
+
+	w.count++
+	w.expires = start.Add(ttl)
+	m.windows[key] = w
+
+	m.sweep(start)
+
+	return w.count, nil
+}
+
+// sweep drops the windows of the clients gone quiet, at most once a minute
+func (m *Memory) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < time.Minute {
+		return
+	}
+	m.lastSweep = now
+
+	for key, w := range m.windows {
+		if !w.expires.After(now) {
+			delete(m.windows, key)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncrement(t *testing.T) {
+	ctx := context.Background()
+	store := CreateStore()
+	start := time.Date(2021, time.August, 17, 10, 0, 0, 0, time.UTC)
+
+	for i := 1; i <= 3; i++ {
+		count, err := store.Increment(ctx, "default:ip:10.0.0.1", start, time.Second)
+		assert.NoError(t, err)
+		assert.Equal(t, i, count)
+	}
+
+	count, err := store.Increment(ctx, "default:ip:10.0.0.2", start, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// NOTE: A new window starts over
+	count, err = store.Increment(ctx, "default:ip:10.0.0.1", start.Add(time.Second), time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestSweep(t *testing.T) {
+	ctx := context.Background()
+	store := CreateStore().(*Memory)
+	start := time.Date(2021, time.August, 17, 10, 0, 0, 0, time.UTC)
+
+	_, _ = store.Increment(ctx, "default:ip:10.0.0.1", start, time.Second)
+	_, _ = store.Increment(ctx, "reports:ip:10.0.0.1", start, time.Hour)
+	assert.Len(t, store.windows, 2)
+
+	// NOTE: The window of the default group expired, the one of reports did not
+	_, _ = store.Increment(ctx, "default:ip:10.0.0.2", start.Add(2*time.Minute), time.Second)
+	assert.Len(t, store.windows, 2)
+	assert.Contains(t, store.windows, "reports:ip:10.0.0.1")
+	assert.Contains(t, store.windows, "default:ip:10.0.0.2")
+}
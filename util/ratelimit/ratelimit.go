@@ -0,0 +1,187 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// Store counts the requests of a key per window. Windows are kept for at
+	// least their ttl, so replicas sharing a store share the counters.
+	Store interface {
+		Increment(ctx context.Context, key string, start time.Time, ttl time.Duration) (int, error)
+	}
+
+	// Limit allows Requests per Period to every client
+	Limit struct {
+		Requests int           `mapstructure:"requests" json:"requests"`
+		Period   time.Duration `mapstructure:"period" json:"period"`
+	}
+
+	// Group applies its own limit to the routes starting with one of its
+	// prefixes, HTTP paths such as /graphql or gRPC methods such as
+	// /spenmo.v1.WalletService/. A :param segment matches any segment, as in
+	// /wallets/:id/statements.
+	Group struct {
+		Name     string   `mapstructure:"name" json:"name"`
+		Prefixes []string `mapstructure:"prefixes" json:"prefixes"`
+		Limit    `mapstructure:",squash"`
+	}
+
+	// Result is the state of the window of a client once the request is
+	// counted
+	Result struct {
+		Allowed   bool
+		Limit     int
+		Remaining int
+		Reset     time.Time
+		// RetryAfter is the number of seconds until Reset, rounded up
+		RetryAfter int
+	}
+
+	// Limiter counts the requests of every client per group with a fixed
+	// window, the routes outside every group share the default limit
+	Limiter struct {
+		store  Store
+		limit  Limit
+		groups []Group
+		now    func() time.Time
+	}
+)
+
+// DefaultGroup names the routes outside every group
+const DefaultGroup = "default"
+
+// NewLimiter validates the limits and creates the limiter
+func NewLimiter(store Store, limit Limit, groups []Group) (*Limiter, error) {
+	if err := limit.validate(DefaultGroup); err != nil {
+		return nil, err
+	}
+
+	for _, group := range groups {
+		if group.Name == "" || group.Name == DefaultGroup {
+			return nil, fmt.Errorf("rate limit group name %q is invalid", group.Name)
+		}
+		if len(group.Prefixes) == 0 {
+			return nil, fmt.Errorf("rate limit group %s has no prefix", group.Name)
+		}
+		if err := group.Limit.validate(group.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Limiter{
+		store:  store,
+		limit:  limit,
+		groups: groups,
+		now:    time.Now,
+	}, nil
+}
+
+// Allow counts a request of client on route. The request is allowed when the
+// store fails, the error is returned so it can be logged.
+func (l *Limiter) Allow(ctx context.Context, route string, client string) (Result, error) {
+	name, limit := l.match(route)
+
+	now := l.now()
+	start := now.Truncate(limit.Period)
+
+	res := Result{
+		Allowed:    true,
+		Limit:      limit.Requests,
+		Remaining:  limit.Requests,
+		Reset:      start.Add(limit.Period),
+		RetryAfter: int((start.Add(limit.Period).Sub(now) + time.Second - 1) / time.Second),
+	}
+
+	count, err := l.store.Increment(ctx, fmt.Sprintf("%s:%s", name, client), start, limit.Period)
+	if err != nil {
+		return res, err
+	}
+
+	res.Allowed = count <= limit.Requests
+	if count < limit.Requests {
+		res.Remaining = limit.Requests - count
+	} else {
+		res.Remaining = 0
+	}
+
+	return res, nil
+}
+
+// match returns the group with the longest prefix of route
+func (l *Limiter) match(route string) (string, Limit) {
+	name, limit, longest := DefaultGroup, l.limit, -1
+
+	for _, group := range l.groups {
+		for _, prefix := range group.Prefixes {
+			if hasPrefix(route, prefix) && len(prefix) > longest {
+				name, limit, longest = group.Name, group.Limit, len(prefix)
+			}
+		}
+	}
+
+	return name, limit
+}
+
+// hasPrefix compares route and prefix segment by segment, the last segment of
+// prefix only has to start the segment of route
+func hasPrefix(route string, prefix string) bool {
+	if !strings.Contains(prefix, ":") {
+		return strings.HasPrefix(route, prefix)
+	}
+
+	routeSegments := strings.Split(route, "/")
+	prefixSegments := strings.Split(prefix, "/")
+	if len(routeSegments) < len(prefixSegments) {
+		return false
+	}
+
+	last := len(prefixSegments) - 1
+	for i, segment := range prefixSegments {
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			if routeSegments[i] == "" {
+				return false
+			}
+		case i == last:
+			if !strings.HasPrefix(routeSegments[i], segment) {
+				return false
+			}
+		case routeSegments[i] != segment:
+			return false
+		}
+	}
+
+	return true
+}
+
+func (l Limit) validate(name string) error {
+	if l.Requests <= 0 || l.Period <= 0 {
+		return fmt.Errorf("rate limit of %s needs positive requests and period", name)
+	}
+
+	return nil
+}
+
+// ClientKey identifies the caller by API key, then by user and then by IP.
+// The user is the one set by the gateway, the same the services authorize.
+// API keys are hashed so they are not kept by the store.
+func ClientKey(apiKey string, userID uuid.UUID, ip string) string {
+	if apiKey != "" {
+		sum := sha256.Sum256([]byte(apiKey))
+		return "key:" + hex.EncodeToString(sum[:8])
+	}
+
+	if userID != uuid.Nil {
+		return "user:" + userID.String()
+	}
+
+	return "ip:" + ip
+}
@@ -0,0 +1,137 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStore struct {
+	counts map[string]int
+	err    error
+}
+
+func (s *fakeStore) Increment(ctx context.Context, key string, start time.Time, ttl time.Duration) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	key = fmt.Sprintf("%s@%d", key, start.Unix())
+	s.counts[key]++
+	return s.counts[key], nil
+}
+
+func TestLimiter(t *testing.T) {
+	store := &fakeStore{counts: map[string]int{}}
+	limiter, err := NewLimiter(store, Limit{Requests: 2, Period: time.Second}, []Group{
+		{Name: "graphql", Prefixes: []string{"/graphql"}, Limit: Limit{Requests: 1, Period: time.Second}},
+		{Name: "reports", Prefixes: []string{"/wallets/:id/statements"}, Limit: Limit{Requests: 1, Period: time.Minute}},
+		{Name: "grpc-wallets", Prefixes: []string{"/spenmo.v1.WalletService/"}, Limit: Limit{Requests: 3, Period: time.Second}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2021, time.August, 17, 10, 0, 0, 250*int(time.Millisecond), time.UTC)
+	limiter.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	t.Run("SuccessWithinLimit", func(t *testing.T) {
+		res, err := limiter.Allow(ctx, "/users", "ip:10.0.0.1")
+		assert.NoError(t, err)
+		assert.Equal(t, Result{Allowed: true, Limit: 2, Remaining: 1, Reset: now.Truncate(time.Second).Add(time.Second), RetryAfter: 1}, res)
+
+		res, err = limiter.Allow(ctx, "/teams", "ip:10.0.0.1")
+		assert.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 0, res.Remaining)
+	})
+
+	t.Run("FailedLimitExceeded", func(t *testing.T) {
+		res, err := limiter.Allow(ctx, "/users", "ip:10.0.0.1")
+		assert.NoError(t, err)
+		assert.False(t, res.Allowed)
+		assert.Equal(t, 0, res.Remaining)
+
+		// NOTE: Other clients keep their own window
+		res, err = limiter.Allow(ctx, "/users", "ip:10.0.0.2")
+		assert.NoError(t, err)
+		assert.True(t, res.Allowed)
+	})
+
+	t.Run("SuccessNextWindow", func(t *testing.T) {
+		now = now.Add(time.Second)
+		defer func() { now = now.Add(-time.Second) }()
+
+		res, err := limiter.Allow(ctx, "/users", "ip:10.0.0.1")
+		assert.NoError(t, err)
+		assert.True(t, res.Allowed)
+	})
+
+	t.Run("SuccessGroups", func(t *testing.T) {
+		cases := map[string]struct {
+			Route    string
+			Expected string
+		}{
+			"Default":          {Route: "/wallets/d4a6607a-1af7-4571-bdff-2672be72ba0e", Expected: DefaultGroup},
+			"Prefix":           {Route: "/graphql", Expected: "graphql"},
+			"Param":            {Route: "/wallets/d4a6607a-1af7-4571-bdff-2672be72ba0e/statements", Expected: "reports"},
+			"ParamMissing":     {Route: "/wallets//statements", Expected: DefaultGroup},
+			"ParamTooShort":    {Route: "/wallets/d4a6607a-1af7-4571-bdff-2672be72ba0e", Expected: DefaultGroup},
+			"GRPCMethod":       {Route: "/spenmo.v1.WalletService/GetWallet", Expected: "grpc-wallets"},
+			"GRPCOtherService": {Route: "/spenmo.v1.CardService/GetCard", Expected: DefaultGroup},
+		}
+
+		for name, tc := range cases {
+			t.Run(name, func(t *testing.T) {
+				group, _ := limiter.match(tc.Route)
+				assert.Equal(t, tc.Expected, group)
+			})
+		}
+	})
+
+	t.Run("SuccessStoreFailed", func(t *testing.T) {
+		failing, _ := NewLimiter(&fakeStore{err: fmt.Errorf("connection refused")}, Limit{Requests: 2, Period: time.Second}, nil)
+
+		res, err := failing.Allow(ctx, "/users", "ip:10.0.0.1")
+		assert.Error(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 2, res.Remaining)
+	})
+}
+
+func TestNewLimiter(t *testing.T) {
+	limit := Limit{Requests: 5, Period: time.Second}
+
+	cases := map[string]struct {
+		Limit  Limit
+		Groups []Group
+	}{
+		"FailedDefaultLimit":  {Limit: Limit{Requests: 5}},
+		"FailedGroupName":     {Limit: limit, Groups: []Group{{Name: DefaultGroup, Prefixes: []string{"/users"}, Limit: limit}}},
+		"FailedGroupPrefixes": {Limit: limit, Groups: []Group{{Name: "users", Limit: limit}}},
+		"FailedGroupLimit":    {Limit: limit, Groups: []Group{{Name: "users", Prefixes: []string{"/users"}}}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewLimiter(&fakeStore{}, tc.Limit, tc.Groups)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestClientKey(t *testing.T) {
+	userID := uuid.MustParse("8e159833-5078-4b0a-80a0-363d82bafd60")
+
+	// NOTE: The API key wins over the user and the IP, and is not kept as is
+	key := ClientKey("secret", userID, "10.0.0.1")
+	assert.Equal(t, ClientKey("secret", uuid.Nil, ""), key)
+	assert.Regexp(t, "^key:[0-9a-f]{16}$", key)
+	assert.Equal(t, "user:8e159833-5078-4b0a-80a0-363d82bafd60", ClientKey("", userID, "10.0.0.1"))
+	assert.Equal(t, "ip:10.0.0.1", ClientKey("", uuid.Nil, "10.0.0.1"))
+}
@@ -1,6 +1,7 @@
 package response
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,6 +20,27 @@ type (
 		Data interface{} `json:"data"`
 		Meta e.Meta      `json:"meta"`
 	}
+
+	// ProblemResponse is an RFC 7807 problem details document, the code,
+	// trace and details of the error are kept as extension members
+	ProblemResponse struct {
+		Type      string     `json:"type"`
+		Title     string     `json:"title"`
+		Status    int        `json:"status"`
+		Detail    string     `json:"detail,omitempty"`
+		Instance  string     `json:"instance,omitempty"`
+		Code      string     `json:"code,omitempty"`
+		RequestID string     `json:"requestId,omitempty"`
+		Trace     string     `json:"trace,omitempty"`
+		Details   []e.Detail `json:"details,omitempty"`
+	}
+)
+
+const (
+	// ProblemContentType is the media type of a ProblemResponse
+	ProblemContentType = "application/problem+json"
+	// ProblemTypePrefix prefixes the code of an error to build its problem type
+	ProblemTypePrefix = "urn:spenmo:error:"
 )
 
 // PopulateMeta will return current timestamp and requestId as meta
@@ -32,3 +54,24 @@ func PopulateMeta(requestID string) e.Meta {
 		"requestId": requestID,
 	}
 }
+
+// NewProblemResponse describes er as a problem, the type is derived from its
+// code so every occurrence of an error shares the same type
+func NewProblemResponse(er e.Error, instance string, requestID string) *ProblemResponse {
+	problemType := "about:blank"
+	if er.Code != "" {
+		problemType = ProblemTypePrefix + er.Code
+	}
+
+	return &ProblemResponse{
+		Type:      problemType,
+		Title:     http.StatusText(er.Status),
+		Status:    er.Status,
+		Detail:    er.Message,
+		Instance:  instance,
+		Code:      er.Code,
+		RequestID: requestID,
+		Trace:     er.Trace,
+		Details:   er.Details,
+	}
+}
@@ -0,0 +1,216 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+)
+
+type (
+	// Schedule returns the first run strictly after a point in time, in the
+	// location of that time
+	Schedule interface {
+		Next(after time.Time) time.Time
+	}
+
+	// Cron is a standard 5 field cron expression: minute, hour, day of month,
+	// month and day of week. Fields accept *, lists, ranges and steps. When
+	// both days are restricted a day matching either of them runs.
+	Cron struct {
+		minute uint64
+		hour   uint64
+		dom    uint64
+		month  uint64
+		dow    uint64
+		anyDom bool
+		anyDow bool
+	}
+
+	// Monthly runs at midnight on Day of every month, on the last day of the
+	// months shorter than Day
+	Monthly struct {
+		Day int
+	}
+
+	field struct {
+		min int
+		max int
+	}
+)
+
+var (
+	minuteField = field{min: 0, max: 59}
+	hourField   = field{min: 0, max: 23}
+	domField    = field{min: 1, max: 31}
+	monthField  = field{min: 1, max: 12}
+	// NOTE: 7 is accepted for Sunday as in most cron implementations
+	dowField = field{min: 0, max: 7}
+)
+
+// cronSearchLimit bounds the search for expressions that never match, such as 0 0 31 2 *
+const cronSearchLimit = 5 * 366 * 24 * 60
+
+// ParseCron ...
+func ParseCron(expr string) (*Cron, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, invalid(expr, "expected 5 fields")
+	}
+
+	c := &Cron{
+		anyDom: fields[2] == "*",
+		anyDow: fields[4] == "*",
+	}
+
+	var err error
+	if c.minute, err = minuteField.parse(fields[0]); err != nil {
+		return nil, invalid(expr, err.Error())
+	}
+	if c.hour, err = hourField.parse(fields[1]); err != nil {
+		return nil, invalid(expr, err.Error())
+	}
+	if c.dom, err = domField.parse(fields[2]); err != nil {
+		return nil, invalid(expr, err.Error())
+	}
+	if c.month, err = monthField.parse(fields[3]); err != nil {
+		return nil, invalid(expr, err.Error())
+	}
+	if c.dow, err = dowField.parse(fields[4]); err != nil {
+		return nil, invalid(expr, err.Error())
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow = c.dow&^(1<<7) | 1
+	}
+
+	return c, nil
+}
+
+// Next implements Schedule.Next. The zero time is returned when the
+// expression never matches.
+func (c *Cron) Next(after time.Time) time.Time {
+	loc := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+
+	for i := 0; i < cronSearchLimit; i++ {
+		switch {
+		case !has(c.month, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !c.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !has(c.hour, t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !has(c.minute, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (c *Cron) matchDay(t time.Time) bool {
+	dom := has(c.dom, t.Day())
+	dow := has(c.dow, int(t.Weekday()))
+
+	if c.anyDom || c.anyDow {
+		return dom && dow
+	}
+
+	return dom || dow
+}
+
+// NewMonthly ...
+func NewMonthly(day int) (Monthly, error) {
+	if day < 1 || day > 31 {
+		return Monthly{}, errors.InvalidDayOfMonth
+	}
+
+	return Monthly{Day: day}, nil
+}
+
+// Next implements Schedule.Next
+func (m Monthly) Next(after time.Time) time.Time {
+	year, month, _ := after.Date()
+
+	for {
+		run := time.Date(year, month, clampDay(year, month, m.Day), 0, 0, 0, 0, after.Location())
+		if run.After(after) {
+			return run
+		}
+
+		month++
+		if month > time.December {
+			month = time.January
+			year++
+		}
+	}
+}
+
+func clampDay(year int, month time.Month, day int) int {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if day > last {
+		return last
+	}
+
+	return day
+}
+
+// parse turns a field into a bitset of the values it matches
+func (f field) parse(value string) (uint64, error) {
+	var set uint64
+
+	for _, part := range strings.Split(value, ",") {
+		rangePart, step := part, 1
+
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part)
+			}
+
+			rangePart, step = part[:i], n
+		}
+
+		from, to := f.min, f.max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+
+			n, err := strconv.Atoi(bounds[0])
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+			from, to = n, n
+
+			if len(bounds) == 2 {
+				if to, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("invalid value %q", part)
+				}
+			} else if step > 1 {
+				// NOTE: "5/15" means every 15 starting at 5
+				to = f.max
+			}
+		}
+
+		if from < f.min || to > f.max || from > to {
+			return 0, fmt.Errorf("value out of range %q", part)
+		}
+
+		for v := from; v <= to; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+
+	return set, nil
+}
+
+func has(set uint64, v int) bool {
+	return set&(1<<uint(v)) != 0
+}
+
+func invalid(expr string, reason string) error {
+	return errors.InvalidCronExpression.AppendError(fmt.Errorf("%q: %s", expr, reason))
+}
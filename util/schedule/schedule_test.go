@@ -0,0 +1,141 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCron(t *testing.T) {
+	singapore, err := time.LoadLocation("Asia/Singapore")
+	assert.NoError(t, err)
+
+	// NOTE: Tuesday 17 August 2021
+	after := time.Date(2021, time.August, 17, 10, 30, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		Expr          string
+		After         time.Time
+		ExpectedNext  time.Time
+		ExpectedError bool
+	}{
+		"SuccessEveryMinute": {
+			Expr:         "* * * * *",
+			After:        after,
+			ExpectedNext: time.Date(2021, time.August, 17, 10, 31, 0, 0, time.UTC),
+		},
+		"SuccessFirstOfMonth": {
+			Expr:         "0 9 1 * *",
+			After:        after,
+			ExpectedNext: time.Date(2021, time.September, 1, 9, 0, 0, 0, time.UTC),
+		},
+		"SuccessStepAndRange": {
+			Expr:         "*/15 9-17 * * 1-5",
+			After:        time.Date(2021, time.August, 20, 17, 50, 0, 0, time.UTC),
+			ExpectedNext: time.Date(2021, time.August, 23, 9, 0, 0, 0, time.UTC),
+		},
+		"SuccessSundayAsSeven": {
+			Expr:         "0 0 * * 7",
+			After:        after,
+			ExpectedNext: time.Date(2021, time.August, 22, 0, 0, 0, 0, time.UTC),
+		},
+		"SuccessDayOfMonthOrWeek": {
+			Expr:         "0 0 20 * 5",
+			After:        after,
+			ExpectedNext: time.Date(2021, time.August, 20, 0, 0, 0, 0, time.UTC),
+		},
+		"SuccessInLocation": {
+			Expr:         "0 0 * * *",
+			After:        after.In(singapore),
+			ExpectedNext: time.Date(2021, time.August, 18, 0, 0, 0, 0, singapore),
+		},
+		"SuccessNeverMatches": {
+			Expr:         "0 0 30 2 *",
+			After:        after,
+			ExpectedNext: time.Time{},
+		},
+		"FailedFieldCount": {
+			Expr:          "0 9 1 *",
+			ExpectedError: true,
+		},
+		"FailedOutOfRange": {
+			Expr:          "60 9 1 * *",
+			ExpectedError: true,
+		},
+		"FailedInvalidStep": {
+			Expr:          "*/0 9 1 * *",
+			ExpectedError: true,
+		},
+		"FailedReversedRange": {
+			Expr:          "0 17-9 * * *",
+			ExpectedError: true,
+		},
+	}
+
+	for v, tc := range cases {
+		t.Run(v, func(t *testing.T) {
+			c, err := ParseCron(tc.Expr)
+
+			if tc.ExpectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				next := c.Next(tc.After)
+				assert.True(t, tc.ExpectedNext.Equal(next), "expected %s, got %s", tc.ExpectedNext, next)
+			}
+		})
+	}
+}
+
+func TestMonthly(t *testing.T) {
+	cases := map[string]struct {
+		Day           int
+		After         time.Time
+		ExpectedNext  time.Time
+		ExpectedError bool
+	}{
+		"SuccessSameMonth": {
+			Day:          20,
+			After:        time.Date(2021, time.August, 17, 10, 30, 0, 0, time.UTC),
+			ExpectedNext: time.Date(2021, time.August, 20, 0, 0, 0, 0, time.UTC),
+		},
+		"SuccessNextMonth": {
+			Day:          1,
+			After:        time.Date(2021, time.August, 1, 0, 0, 0, 0, time.UTC),
+			ExpectedNext: time.Date(2021, time.September, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"SuccessClampedToMonthEnd": {
+			Day:          31,
+			After:        time.Date(2021, time.January, 31, 0, 0, 0, 0, time.UTC),
+			ExpectedNext: time.Date(2021, time.February, 28, 0, 0, 0, 0, time.UTC),
+		},
+		"SuccessNextYear": {
+			Day:          15,
+			After:        time.Date(2021, time.December, 20, 0, 0, 0, 0, time.UTC),
+			ExpectedNext: time.Date(2022, time.January, 15, 0, 0, 0, 0, time.UTC),
+		},
+		"FailedDayZero": {
+			Day:           0,
+			ExpectedError: true,
+		},
+		"FailedDayOutOfRange": {
+			Day:           32,
+			ExpectedError: true,
+		},
+	}
+
+	for v, tc := range cases {
+		t.Run(v, func(t *testing.T) {
+			m, err := NewMonthly(tc.Day)
+
+			if tc.ExpectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				next := m.Next(tc.After)
+				assert.True(t, tc.ExpectedNext.Equal(next), "expected %s, got %s", tc.ExpectedNext, next)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"time"
+)
+
+type (
+	// CSVWriter renders a statement as a single CSV table. The opening and
+	// closing balances are rows of the same table so the file stays parsable.
+	CSVWriter struct {
+		w        *csv.Writer
+		currency string
+		to       string
+	}
+)
+
+var csvHeader = []string{"Date", "Reference", "Type", "Description", "Debit", "Credit", "Balance", "Currency"}
+
+// NewCSVWriter ...
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w)}
+}
+
+// Begin ...
+func (c *CSVWriter) Begin(s *Statement) error {
+	from, to := s.Period()
+	c.currency = s.Currency
+	c.to = to
+
+	if err := c.w.Write(csvHeader); err != nil {
+		return err
+	}
+
+	return c.w.Write([]string{from, "", "", "Opening balance", "", "", s.OpeningBalance.String(), c.currency})
+}
+
+// Entry ...
+func (c *CSVWriter) Entry(entry Entry) error {
+	debit, credit := "", ""
+	if entry.Amount.Sign() < 0 {
+		debit = entry.Amount.Neg().String()
+	} else {
+		credit = entry.Amount.String()
+	}
+
+	return c.w.Write([]string{
+		entry.Date.UTC().Format(time.RFC3339),
+		escapeCell(entry.Reference),
+		escapeCell(entry.Type),
+		escapeCell(entry.Description),
+		debit,
+		credit,
+		entry.Balance.String(),
+		c.currency,
+	})
+}
+
+// End ...
+func (c *CSVWriter) End(summary Summary) error {
+	err := c.w.Write([]string{c.to, "", "", "Closing balance", summary.TotalDebit.String(), summary.TotalCredit.String(), summary.ClosingBalance.String(), c.currency})
+	if err != nil {
+		return err
+	}
+
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// escapeCell prefixes the text that a spreadsheet would run as a formula with
+// a quote, the amounts are written by the statement and are left as they are
+func escapeCell(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+
+	return cell
+}
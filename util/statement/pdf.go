@@ -0,0 +1,243 @@
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type (
+	// PDFWriter renders a statement as a plain PDF document using the built-in
+	// Courier font. Each page is written out as soon as it is full, so memory
+	// use does not grow with the number of entries.
+	PDFWriter struct {
+		w       *countingWriter
+		offsets map[int]int64
+		objects int
+		pages   []int
+		page    *bytes.Buffer
+		y       int
+	}
+
+	countingWriter struct {
+		w io.Writer
+		n int64
+	}
+)
+
+const (
+	pdfCatalogObject = 1
+	pdfPagesObject   = 2
+	pdfFontObject    = 3
+
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 40
+	pdfFontSize   = 8
+	pdfLineHeight = 11
+
+	pdfDescriptionWidth = 30
+)
+
+const pdfRowFormat = "%-20s %-10s %-30s %14s %14s %14s"
+
+// NewPDFWriter ...
+func NewPDFWriter(w io.Writer) *PDFWriter {
+	return &PDFWriter{
+		w:       &countingWriter{w: w},
+		offsets: map[int]int64{},
+		objects: pdfFontObject,
+	}
+}
+
+// Begin ...
+func (p *PDFWriter) Begin(s *Statement) error {
+	if _, err := io.WriteString(p.w, "%PDF-1.4\n"); err != nil {
+		return err
+	}
+
+	if err := p.writeObject(pdfFontObject, "<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>"); err != nil {
+		return err
+	}
+
+	from, to := s.Period()
+
+	p.newPage()
+	for _, line := range []string{
+		"Wallet statement",
+		"",
+		"Wallet:          " + s.WalletID,
+		"Currency:        " + s.Currency,
+		"Period:          " + from + " to " + to,
+		"Opening balance: " + s.OpeningBalance.String(),
+		"",
+	} {
+		if err := p.line(line); err != nil {
+			return err
+		}
+	}
+
+	return p.columns()
+}
+
+// Entry ...
+func (p *PDFWriter) Entry(entry Entry) error {
+	debit, credit := "", ""
+	if entry.Amount.Sign() < 0 {
+		debit = entry.Amount.Neg().String()
+	} else {
+		credit = entry.Amount.String()
+	}
+
+	description := entry.Description
+	if len(description) > pdfDescriptionWidth {
+		description = description[:pdfDescriptionWidth-3] + "..."
+	}
+
+	row := fmt.Sprintf(pdfRowFormat, entry.Date.UTC().Format("2006-01-02 15:04:05"), entry.Type, description, debit, credit, entry.Balance.String())
+	return p.line(row)
+}
+
+// End ...
+func (p *PDFWriter) End(summary Summary) error {
+	for _, line := range []string{
+		"",
+		fmt.Sprintf("Transactions:    %d", summary.Count),
+		"Total debit:     " + summary.TotalDebit.String(),
+		"Total credit:    " + summary.TotalCredit.String(),
+		"Closing balance: " + summary.ClosingBalance.String(),
+	} {
+		if err := p.line(line); err != nil {
+			return err
+		}
+	}
+
+	if err := p.flushPage(); err != nil {
+		return err
+	}
+
+	kids := make([]string, 0, len(p.pages))
+	for _, page := range p.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", page))
+	}
+
+	err := p.writeObject(pdfPagesObject, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pages)))
+	if err != nil {
+		return err
+	}
+
+	err = p.writeObject(pdfCatalogObject, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pdfPagesObject))
+	if err != nil {
+		return err
+	}
+
+	return p.writeTrailer()
+}
+
+// columns writes the table header, repeated at the top of every page
+func (p *PDFWriter) columns() error {
+	header := fmt.Sprintf(pdfRowFormat, "Date", "Type", "Description", "Debit", "Credit", "Balance")
+
+	if err := p.line(header); err != nil {
+		return err
+	}
+
+	return p.line(strings.Repeat("-", len(header)))
+}
+
+func (p *PDFWriter) line(text string) error {
+	if p.y < pdfMargin {
+		if err := p.flushPage(); err != nil {
+			return err
+		}
+
+		p.newPage()
+		if err := p.columns(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(p.page, "BT /F1 %d Tf %d %d Td (%s) Tj ET\n", pdfFontSize, pdfMargin, p.y, escapePDF(text))
+	p.y -= pdfLineHeight
+
+	return nil
+}
+
+func (p *PDFWriter) newPage() {
+	p.page = &bytes.Buffer{}
+	p.y = pdfPageHeight - pdfMargin
+}
+
+func (p *PDFWriter) flushPage() error {
+	content := p.nextObject()
+	err := p.writeObject(content, fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", p.page.Len(), p.page.String()))
+	if err != nil {
+		return err
+	}
+
+	page := p.nextObject()
+	err = p.writeObject(page, fmt.Sprintf(
+		"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 %d 0 R >> >> /Contents %d 0 R >>",
+		pdfPagesObject, pdfPageWidth, pdfPageHeight, pdfFontObject, content,
+	))
+	if err != nil {
+		return err
+	}
+
+	p.pages = append(p.pages, page)
+	p.page.Reset()
+
+	return nil
+}
+
+func (p *PDFWriter) nextObject() int {
+	p.objects++
+	return p.objects
+}
+
+func (p *PDFWriter) writeObject(n int, body string) error {
+	p.offsets[n] = p.w.n
+	_, err := fmt.Fprintf(p.w, "%d 0 obj\n%s\nendobj\n", n, body)
+	return err
+}
+
+func (p *PDFWriter) writeTrailer() error {
+	xref := p.w.n
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", p.objects+1)
+	for n := 1; n <= p.objects; n++ {
+		fmt.Fprintf(&b, "%010d 00000 n \n", p.offsets[n])
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", p.objects+1, pdfCatalogObject, xref)
+
+	_, err := io.WriteString(p.w, b.String())
+	return err
+}
+
+// escapePDF escapes a PDF literal string, characters outside printable ASCII
+// are not available in the base font and are replaced
+func escapePDF(text string) string {
+	var b strings.Builder
+
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < ' ' || r > '~':
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+func (c *countingWriter) Write(b []byte) (int, error) {
+	n, err := c.w.Write(b)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,155 @@
+package statement
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+type (
+	// Statement describes the activity of a wallet over [From, To). Entries are
+	// pulled lazily so large periods are streamed rather than loaded at once.
+	Statement struct {
+		WalletID       string
+		Currency       string
+		From           time.Time
+		To             time.Time
+		OpeningBalance money.Decimal
+		Entries        func(fn func(Entry) error) error
+	}
+
+	// Entry is a single transaction on a statement. Amount is signed, credits
+	// are positive and debits negative. Balance is the running balance after
+	// the entry and is filled in by Statement.Write.
+	Entry struct {
+		Date        time.Time
+		Reference   string
+		Type        string
+		Description string
+		Amount      money.Decimal
+		Balance     money.Decimal
+	}
+
+	// Summary closes a statement
+	Summary struct {
+		Count          int
+		TotalDebit     money.Decimal
+		TotalCredit    money.Decimal
+		ClosingBalance money.Decimal
+	}
+
+	// Writer renders a statement in a specific format
+	Writer interface {
+		Begin(s *Statement) error
+		Entry(entry Entry) error
+		End(summary Summary) error
+	}
+)
+
+const (
+	FormatCSV = "csv"
+	FormatPDF = "pdf"
+)
+
+const dateLayout = "2006-01-02"
+
+// NewWriter returns the writer for format, defaulting to CSV
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch strings.ToLower(format) {
+	case FormatCSV, "":
+		return NewCSVWriter(w), nil
+	case FormatPDF:
+		return NewPDFWriter(w), nil
+	default:
+		return nil, errors.InvalidStatementFormat
+	}
+}
+
+// IsFormatValid ...
+func IsFormatValid(format string) error {
+	switch strings.ToLower(format) {
+	case FormatCSV, FormatPDF, "":
+		return nil
+	default:
+		return errors.InvalidStatementFormat
+	}
+}
+
+// ContentType returns the MIME type of format
+func ContentType(format string) string {
+	if strings.ToLower(format) == FormatPDF {
+		return "application/pdf"
+	}
+
+	return "text/csv"
+}
+
+// Filename returns a download name such as statement-<wallet>-2021-01-01-2021-01-31.csv
+func (s *Statement) Filename(format string) string {
+	if format == "" {
+		format = FormatCSV
+	}
+
+	from, to := s.Period()
+	return "statement-" + s.WalletID + "-" + from + "-" + to + "." + strings.ToLower(format)
+}
+
+// Period returns the first and last day covered by the statement
+func (s *Statement) Period() (string, string) {
+	return s.From.Format(dateLayout), s.To.Add(-time.Nanosecond).Format(dateLayout)
+}
+
+// Write renders the statement, computing the running balance as entries are
+// streamed to w
+func (s *Statement) Write(w Writer) error {
+	if err := w.Begin(s); err != nil {
+		return wrap(err)
+	}
+
+	summary := Summary{ClosingBalance: s.OpeningBalance}
+
+	err := s.Entries(func(entry Entry) error {
+		var err error
+
+		if entry.Amount.Sign() < 0 {
+			summary.TotalDebit, err = summary.TotalDebit.Add(entry.Amount.Neg())
+		} else {
+			summary.TotalCredit, err = summary.TotalCredit.Add(entry.Amount)
+		}
+		if err != nil {
+			return err
+		}
+
+		summary.ClosingBalance, err = summary.ClosingBalance.Add(entry.Amount)
+		if err != nil {
+			return err
+		}
+
+		summary.Count++
+		entry.Balance = summary.ClosingBalance
+
+		return wrap(w.Entry(entry))
+	})
+	if err != nil {
+		return err
+	}
+
+	return wrap(w.End(summary))
+}
+
+// wrap keeps application errors and flags anything else as a write failure
+func wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := err.(e.Error); ok {
+		return err
+	}
+
+	return errors.FailedStatementWrite.AppendError(err)
+}
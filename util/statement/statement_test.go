@@ -0,0 +1,127 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+func newTestStatement(entries []Entry) *Statement {
+	return &Statement{
+		WalletID:       "d4a6607a-1af7-4571-bdff-2672be72ba0e",
+		Currency:       "SGD",
+		From:           time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:             time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+		OpeningBalance: money.MustParseDecimal("100.00"),
+		Entries: func(fn func(Entry) error) error {
+			for _, entry := range entries {
+				if err := fn(entry); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	s := newTestStatement([]Entry{
+		{Date: time.Date(2021, 1, 5, 10, 0, 0, 0, time.UTC), Reference: "ref-1", Type: "adjustment", Description: "Top up", Amount: money.MustParseDecimal("50.00")},
+		{Date: time.Date(2021, 1, 6, 10, 0, 0, 0, time.UTC), Reference: "ref-2", Type: "card", Description: "ACME, Inc.", Amount: money.MustParseDecimal("-20.50")},
+	})
+
+	var b bytes.Buffer
+	err := s.Write(NewCSVWriter(&b))
+	assert.NoError(t, err)
+
+	expected := strings.Join([]string{
+		"Date,Reference,Type,Description,Debit,Credit,Balance,Currency",
+		"2021-01-01,,,Opening balance,,,100.00,SGD",
+		"2021-01-05T10:00:00Z,ref-1,adjustment,Top up,,50.00,150.00,SGD",
+		`2021-01-06T10:00:00Z,ref-2,card,"ACME, Inc.",20.50,,129.50,SGD`,
+		"2021-01-31,,,Closing balance,20.50,50.00,129.50,SGD",
+		"",
+	}, "\n")
+	assert.Equal(t, expected, b.String())
+	assert.Equal(t, "statement-d4a6607a-1af7-4571-bdff-2672be72ba0e-2021-01-01-2021-01-31.csv", s.Filename(""))
+}
+
+func TestWriteCSVEscapesFormulas(t *testing.T) {
+	s := newTestStatement([]Entry{
+		{Date: time.Date(2021, 1, 5, 10, 0, 0, 0, time.UTC), Reference: "@ref", Type: "card", Description: "=HYPERLINK(\"http://example.com\")", Amount: money.MustParseDecimal("-1.00")},
+		{Date: time.Date(2021, 1, 6, 10, 0, 0, 0, time.UTC), Type: "card", Description: "+1 Coffee", Amount: money.MustParseDecimal("-1.00")},
+		{Date: time.Date(2021, 1, 7, 10, 0, 0, 0, time.UTC), Type: "card", Description: "-Refund", Amount: money.MustParseDecimal("-1.00")},
+		{Date: time.Date(2021, 1, 8, 10, 0, 0, 0, time.UTC), Type: "card", Description: "\tTab", Amount: money.MustParseDecimal("-1.00")},
+		{Date: time.Date(2021, 1, 9, 10, 0, 0, 0, time.UTC), Type: "card", Description: "\rReturn", Amount: money.MustParseDecimal("-1.00")},
+	})
+
+	var b bytes.Buffer
+	err := s.Write(NewCSVWriter(&b))
+	assert.NoError(t, err)
+
+	records, err := csv.NewReader(&b).ReadAll()
+	assert.NoError(t, err)
+
+	descriptions := []string{}
+	for _, record := range records[2:7] {
+		descriptions = append(descriptions, record[3])
+	}
+	assert.Equal(t, []string{`'=HYPERLINK("http://example.com")`, "'+1 Coffee", "'-Refund", "'\tTab", "'\rReturn"}, descriptions)
+	assert.Equal(t, "'@ref", records[2][1])
+
+	// NOTE: The balances are numbers and are not escaped
+	assert.Equal(t, "95.00", records[6][6])
+}
+
+func TestWritePDF(t *testing.T) {
+	entries := make([]Entry, 0, 200)
+	for i := 0; i < 200; i++ {
+		entries = append(entries, Entry{
+			Date:        time.Date(2021, 1, 5, 10, 0, i, 0, time.UTC),
+			Type:        "card",
+			Description: fmt.Sprintf("Merchant (%d) \\ café", i),
+			Amount:      money.MustParseDecimal("-0.10"),
+		})
+	}
+
+	var b bytes.Buffer
+	err := newTestStatement(entries).Write(NewPDFWriter(&b))
+	assert.NoError(t, err)
+
+	pdf := b.String()
+	assert.True(t, strings.HasPrefix(pdf, "%PDF-1.4\n"))
+	assert.True(t, strings.HasSuffix(pdf, "%%EOF\n"))
+	assert.True(t, strings.Contains(pdf, `Merchant \(0\) \\ caf?`))
+	assert.True(t, strings.Contains(pdf, "Closing balance: 80.00"))
+	assert.True(t, strings.Contains(pdf, "/Count 4"))
+
+	// NOTE: Every xref offset must point at the start of its object
+	xref := pdf[strings.LastIndex(pdf, "\nxref\n")+1:]
+	lines := strings.Split(xref, "\n")
+	for n := 1; n < 12; n++ {
+		var offset int
+		_, err := fmt.Sscanf(lines[2+n], "%010d", &offset)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(pdf[offset:], fmt.Sprintf("%d 0 obj\n", n)))
+	}
+}
+
+func TestNewWriter(t *testing.T) {
+	_, err := NewWriter("PDF", &bytes.Buffer{})
+	assert.NoError(t, err)
+
+	_, err = NewWriter("", &bytes.Buffer{})
+	assert.NoError(t, err)
+
+	_, err = NewWriter("xlsx", &bytes.Buffer{})
+	assert.Equal(t, errors.InvalidStatementFormat, err)
+	assert.Equal(t, errors.InvalidStatementFormat, IsFormatValid("xlsx"))
+	assert.Equal(t, "application/pdf", ContentType("pdf"))
+}
@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName names the tracer of the spans started by the service
+const TracerName = "gitlab.com/renodesper/spenmo-test"
+
+// ErrorCodeKey is the code of the e.Error a span failed with
+const ErrorCodeKey = attribute.Key("error.code")
+
+// NewProvider batches the spans to exporter, ratio is the share of the
+// traces started by the service that are sampled. The traces started by a
+// caller keep the decision of the caller.
+func NewProvider(exporter sdktrace.SpanExporter, serviceName string, env string, version string, ratio float64) *sdktrace.TracerProvider {
+	res := resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceNameKey.String(serviceName),
+		semconv.ServiceVersionKey.String(version),
+		semconv.DeploymentEnvironmentKey.String(env),
+	)
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+}
+
+// Install makes provider the global tracer provider. The W3C traceparent and
+// baggage headers are propagated even without a provider, so the traces of
+// the callers are not cut.
+func Install(provider trace.TracerProvider) {
+	if provider != nil {
+		otel.SetTracerProvider(provider)
+	}
+
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+// Start starts a span of the service as a child of the span of ctx, the span
+// is dropped when no provider is installed
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, opts...)
+}
+
+// RecordError marks span as failed by err, with the code of err when it is an
+// e.Error
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+
+	if er, ok := err.(e.Error); ok && er.Code != "" {
+		span.SetAttributes(ErrorCodeKey.String(er.Code))
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
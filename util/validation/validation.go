@@ -0,0 +1,111 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator"
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+)
+
+var (
+	expiryMonthRegex = regexp.MustCompile(`^(0?[1-9]|1[0-2])$`)
+	expiryYearRegex  = regexp.MustCompile(`^\d{4}$`)
+)
+
+// New creates a validator that names the fields by their JSON key and knows
+// the card expiry rules
+func New() *validator.Validate {
+	validate := validator.New()
+
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	_ = validate.RegisterValidation("expiry_month", func(fl validator.FieldLevel) bool {
+		return expiryMonthRegex.MatchString(fl.Field().String())
+	})
+	_ = validate.RegisterValidation("expiry_year", func(fl validator.FieldLevel) bool {
+		return expiryYearRegex.MatchString(fl.Field().String())
+	})
+
+	return validate
+}
+
+// Error turns the error of validate.Struct into errors.InvalidRequest with
+// one detail per field that failed
+func Error(err error) e.Error {
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return errors.InvalidRequest
+	}
+
+	return errors.InvalidRequest.WithDetails(Details(validationErrors)...)
+}
+
+// Details describes the failed rules, the field is the path of its JSON key
+// within the request
+func Details(validationErrors validator.ValidationErrors) []e.Detail {
+	details := make([]e.Detail, 0, len(validationErrors))
+	for _, fe := range validationErrors {
+		field := fe.Namespace()
+		if i := strings.Index(field, "."); i >= 0 {
+			field = field[i+1:]
+		}
+
+		details = append(details, e.Detail{
+			Field:   field,
+			Rule:    fe.Tag(),
+			Message: fmt.Sprintf("%s %s", field, message(fe)),
+		})
+	}
+
+	return details
+}
+
+func message(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "oneof":
+		return fmt.Sprintf("must be one of %s", strings.Join(strings.Fields(fe.Param()), ", "))
+	case "numeric":
+		return "must only contain digits"
+	case "min", "gte":
+		return fmt.Sprintf("must be at least %s%s", fe.Param(), unit(fe.Kind()))
+	case "max", "lte":
+		return fmt.Sprintf("must be at most %s%s", fe.Param(), unit(fe.Kind()))
+	case "len":
+		return fmt.Sprintf("must be exactly %s%s", fe.Param(), unit(fe.Kind()))
+	case "expiry_month":
+		return "must be a month between 01 and 12"
+	case "expiry_year":
+		return "must be a four digit year"
+	default:
+		return fmt.Sprintf("failed on the %s rule", fe.Tag())
+	}
+}
+
+// unit tells what the bound of a min, max or len rule counts
+func unit(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return " characters long"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " items"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,111 @@
+package validation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/endpoint"
+	e "gitlab.com/renodesper/spenmo-test/util/error"
+	"gitlab.com/renodesper/spenmo-test/util/errors"
+)
+
+func TestError(t *testing.T) {
+	validCard := endpoint.CreateCardRequest{
+		CardNo:      "4111111111111111",
+		ExpiryMonth: "08",
+		ExpiryYear:  "2030",
+		CVV:         "123",
+		WalletID:    uuid.New(),
+	}
+
+	tests := map[string]struct {
+		req     interface{}
+		details []e.Detail
+	}{
+		"SuccessCard": {
+			req: validCard,
+		},
+		"SuccessPagination": {
+			req: endpoint.GetAllUsersRequest{Skip: 0, Limit: 100},
+		},
+		"FailedRequired": {
+			req: endpoint.CreateUserRequest{Email: "admin@spenmo.com"},
+			details: []e.Detail{
+				{Field: "name", Rule: "required", Message: "name is required"},
+			},
+		},
+		"FailedEmail": {
+			req: endpoint.CreateUserRequest{Email: "admin", Name: "Admin"},
+			details: []e.Detail{
+				{Field: "email", Rule: "email", Message: "email must be a valid email address"},
+			},
+		},
+		"FailedUpdateEmail": {
+			req: endpoint.UpdateUserRequest{ID: uuid.New(), Email: "admin"},
+			details: []e.Detail{
+				{Field: "email", Rule: "email", Message: "email must be a valid email address"},
+			},
+		},
+		"FailedLimitRange": {
+			req: endpoint.GetAllUsersRequest{Skip: -1, Limit: 500},
+			details: []e.Detail{
+				{Field: "skip", Rule: "min", Message: "skip must be at least 0"},
+				{Field: "limit", Rule: "max", Message: "limit must be at most 100"},
+			},
+		},
+		"FailedExpiry": {
+			req: func() endpoint.CreateCardRequest {
+				req := validCard
+				req.ExpiryMonth = "13"
+				req.ExpiryYear = "30"
+				return req
+			}(),
+			details: []e.Detail{
+				{Field: "expiryMonth", Rule: "expiry_month", Message: "expiryMonth must be a month between 01 and 12"},
+				{Field: "expiryYear", Rule: "expiry_year", Message: "expiryYear must be a four digit year"},
+			},
+		},
+		"FailedCardNumber": {
+			req: func() endpoint.CreateCardRequest {
+				req := validCard
+				req.CardNo = "4111"
+				req.CVV = "12a"
+				return req
+			}(),
+			details: []e.Detail{
+				{Field: "cardNo", Rule: "min", Message: "cardNo must be at least 13 characters long"},
+				{Field: "cvv", Rule: "numeric", Message: "cvv must only contain digits"},
+			},
+		},
+		"FailedOneOf": {
+			req: endpoint.CreateTeamMemberRequest{TeamID: uuid.New(), UserID: uuid.New(), Role: "owner"},
+			details: []e.Detail{
+				{Field: "role", Rule: "oneof", Message: "role must be one of member, admin"},
+			},
+		},
+	}
+
+	validate := New()
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validate.Struct(tt.req)
+			if tt.details == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			er := Error(err)
+			assert.Equal(t, errors.InvalidRequest.Code, er.Code)
+			assert.Equal(t, errors.InvalidRequest.Status, er.Status)
+			assert.Equal(t, tt.details, er.Details)
+		})
+	}
+
+	t.Run("FailedNotValidationErrors", func(t *testing.T) {
+		er := Error(fmt.Errorf("validator: (nil *endpoint.CreateUserRequest)"))
+		assert.Equal(t, errors.InvalidRequest.Code, er.Code)
+		assert.Empty(t, er.Details)
+	})
+}
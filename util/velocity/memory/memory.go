@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gitlab.com/renodesper/spenmo-test/util/velocity"
+)
+
+type Memory struct {
+	mu      sync.Mutex
+	events  map[string][]velocity.Event
+	members map[string]map[string]bool
+}
+
+// CreateStore creates velocity store kept in the memory of the process, the
+// counters are neither shared between instances nor kept across restarts
+func CreateStore() velocity.Store {
+	return &Memory{
+		events:  map[string][]velocity.Event{},
+		members: map[string]map[string]bool{},
+	}
+}
+
+// Record implements Store.Record
+func (m *Memory) Record(ctx context.Context, key string, event velocity.Event, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// NOTE: Events are appended in order, so the expired ones are a prefix
+	events := m.events[key]
+	expiry := event.At.Add(-ttl)
+	i := 0
+	for i < len(events) && events[i].At.Before(expiry) {
+		i++
+	}
+
+	m.events[key] = append(events[i:], event)
+	return nil
+}
+
+// Window implements Store.Window
+func (m *Memory) Window(ctx context.Context, key string, since time.Time) (velocity.Window, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	window := velocity.Window{}
+	for _, event := range m.events[key] {
+		if event.At.Before(since) {
+			continue
+		}
+
+		amount, err := window.Amount.Add(event.Amount)
+		if err != nil {
+			return velocity.Window{}, err
+		}
+
+		window.Count++
+		window.Amount = amount
+	}
+
+	return window, nil
+}
+
+// HasMember implements Store.HasMember
+func (m *Memory) HasMember(ctx context.Context, key string, member string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.members[key][member], nil
+}
+
+// AddMember implements Store.AddMember
+func (m *Memory) AddMember(ctx context.Context, key string, member string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.members[key] == nil {
+		m.members[key] = map[string]bool{}
+	}
+
+	m.members[key][member] = true
+	return nil
+}
@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/renodesper/spenmo-test/util/money"
+	"gitlab.com/renodesper/spenmo-test/util/velocity"
+)
+
+func TestWindow(t *testing.T) {
+	ctx := context.Background()
+	store := CreateStore()
+	now := time.Date(2021, time.August, 17, 10, 0, 0, 0, time.UTC)
+
+	for _, event := range []velocity.Event{
+		{At: now.Add(-2 * time.Hour), Amount: money.MustParseDecimal("100.00")},
+		{At: now.Add(-30 * time.Minute), Amount: money.MustParseDecimal("10.00")},
+		{At: now, Amount: money.MustParseDecimal("5.50")},
+	} {
+		assert.NoError(t, store.Record(ctx, "card", event, 90*time.Minute))
+	}
+
+	window, err := store.Window(ctx, "card", now.Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, window.Count)
+	assert.Zero(t, money.MustParseDecimal("15.50").Cmp(window.Amount))
+
+	// NOTE: The first event outlived its ttl and was dropped
+	window, err = store.Window(ctx, "card", now.Add(-3*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, window.Count)
+
+	window, err = store.Window(ctx, "other", now.Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, window.Count)
+}
+
+func TestMembers(t *testing.T) {
+	ctx := context.Background()
+	store := CreateStore()
+
+	ok, err := store.HasMember(ctx, "merchants", "acme")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.NoError(t, store.AddMember(ctx, "merchants", "acme"))
+	assert.NoError(t, store.AddMember(ctx, "merchants", "acme"))
+
+	ok, err = store.HasMember(ctx, "merchants", "acme")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = store.HasMember(ctx, "other", "acme")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
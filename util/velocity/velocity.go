@@ -0,0 +1,31 @@
+package velocity
+
+import (
+	"context"
+	"time"
+
+	"gitlab.com/renodesper/spenmo-test/util/money"
+)
+
+type (
+	// Store keeps the counters velocity rules are evaluated against. Events
+	// are kept per key for at least their ttl, members form a set per key.
+	Store interface {
+		Record(ctx context.Context, key string, event Event, ttl time.Duration) error
+		Window(ctx context.Context, key string, since time.Time) (Window, error)
+		HasMember(ctx context.Context, key string, member string) (bool, error)
+		AddMember(ctx context.Context, key string, member string) error
+	}
+
+	// Event ...
+	Event struct {
+		At     time.Time
+		Amount money.Decimal
+	}
+
+	// Window aggregates the events of a key recorded since a point in time
+	Window struct {
+		Count  int
+		Amount money.Decimal
+	}
+)